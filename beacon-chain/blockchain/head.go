@@ -8,6 +8,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -115,17 +116,25 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 		return errors.New("cannot save nil head state")
 	}
 
+	oldHeadRoot := s.headRoot()
+	oldHeadSlot := s.headSlot()
+
 	// A chain re-org occurred, so we fire an event notifying the rest of the services.
-	if bytesutil.ToBytes32(newHeadBlock.Block.ParentRoot) != s.headRoot() {
+	if bytesutil.ToBytes32(newHeadBlock.Block.ParentRoot) != oldHeadRoot {
+		depth := s.reorgDepth(ctx, oldHeadRoot, oldHeadSlot, headRoot)
 		log.WithFields(logrus.Fields{
 			"newSlot": fmt.Sprintf("%d", newHeadBlock.Block.Slot),
-			"oldSlot": fmt.Sprintf("%d", s.headSlot()),
+			"oldSlot": fmt.Sprintf("%d", oldHeadSlot),
+			"depth":   depth,
 		}).Debug("Chain reorg occurred")
 		s.stateNotifier.StateFeed().Send(&feed.Event{
 			Type: statefeed.Reorg,
 			Data: &statefeed.ReorgData{
-				NewSlot: newHeadBlock.Block.Slot,
-				OldSlot: s.headSlot(),
+				NewSlot:          newHeadBlock.Block.Slot,
+				OldSlot:          oldHeadSlot,
+				NewHeadBlockRoot: headRoot,
+				OldHeadBlockRoot: oldHeadRoot,
+				Depth:            depth,
 			},
 		})
 
@@ -135,6 +144,16 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 	// Cache the new head info.
 	s.setHead(headRoot, newHeadBlock, newHeadState)
 
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.NewHead,
+		Data: &statefeed.NewHeadData{
+			Slot:            newHeadBlock.Block.Slot,
+			BlockRoot:       headRoot,
+			StateRoot:       bytesutil.ToBytes32(newHeadBlock.Block.StateRoot),
+			EpochTransition: helpers.SlotToEpoch(newHeadBlock.Block.Slot) > helpers.SlotToEpoch(oldHeadSlot),
+		},
+	})
+
 	// Save the new head root to DB.
 	if err := s.beaconDB.SaveHeadBlockRoot(ctx, headRoot); err != nil {
 		return errors.Wrap(err, "could not save head root in DB")
@@ -143,6 +162,21 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 	return nil
 }
 
+// This computes the reorg depth as the number of slots between the old head and the
+// closest block in fork choice shared by the old and new heads. If fork choice no longer
+// knows the old head, the whole old head slot is reported as the depth.
+func (s *Service) reorgDepth(ctx context.Context, oldHeadRoot [32]byte, oldHeadSlot uint64, newHeadRoot [32]byte) uint64 {
+	_, ancestorSlot, err := s.forkChoiceStore.CommonAncestor(ctx, oldHeadRoot, newHeadRoot)
+	if err != nil {
+		log.WithError(err).Debug("Could not determine common ancestor of reorged heads")
+		return oldHeadSlot
+	}
+	if ancestorSlot > oldHeadSlot {
+		return 0
+	}
+	return oldHeadSlot - ancestorSlot
+}
+
 // This gets called to update canonical root mapping. It does not save head block
 // root in DB. With the inception of inital-sync-cache-state flag, it uses finalized
 // check point as anchors to resume sync therefore head is no longer needed to be saved on per slot basis.
@@ -198,3 +198,29 @@ func TestUpdateRecentCanonicalBlocks_CanUpdateWithParent(t *testing.T) {
 		t.Error("Block should not be canonical")
 	}
 }
+
+func TestReorgDepth(t *testing.T) {
+	db := testDB.SetupDB(t)
+	service := setupBeaconChain(t, db)
+	ctx := context.Background()
+	common := [32]byte{'a'}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 1, common, [32]byte{'g'}, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	oldHead := [32]byte{'b'}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 4, oldHead, common, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	newHead := [32]byte{'c'}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 3, newHead, common, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if depth := service.reorgDepth(ctx, oldHead, 4, newHead); depth != 3 {
+		t.Errorf("Expected reorg depth 3, received %d", depth)
+	}
+	// The old head is unknown to fork choice, so its whole slot is reported.
+	if depth := service.reorgDepth(ctx, [32]byte{'z'}, 4, newHead); depth != 4 {
+		t.Errorf("Expected reorg depth 4, received %d", depth)
+	}
+}
@@ -144,6 +144,10 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock,
 			return nil, errors.Wrap(err, "could not save new justified")
 		}
 
+		if err := s.notifyFinalizedCheckpoint(ctx, s.finalizedCheckpt); err != nil {
+			return nil, err
+		}
+
 		if featureconfig.Get().NewStateMgmt {
 			fRoot := bytesutil.ToBytes32(postState.FinalizedCheckpoint().Root)
 			fBlock, err := s.beaconDB.Block(ctx, fRoot)
@@ -300,6 +304,10 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 			return errors.Wrap(err, "could not save new justified")
 		}
 
+		if err := s.notifyFinalizedCheckpoint(ctx, s.finalizedCheckpt); err != nil {
+			return err
+		}
+
 		if featureconfig.Get().NewStateMgmt {
 			fRoot := bytesutil.ToBytes32(postState.FinalizedCheckpoint().Root)
 			fBlock, err := s.beaconDB.Block(ctx, fRoot)
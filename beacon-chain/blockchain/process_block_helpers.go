@@ -7,6 +7,8 @@ import (
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -453,6 +455,29 @@ func (s *Service) finalizedImpliesNewJustified(ctx context.Context, state *state
 	return nil
 }
 
+// This notifies the state feed subscribers of a newly finalized checkpoint. The state root
+// is left empty if the finalized block can not be found in the DB.
+func (s *Service) notifyFinalizedCheckpoint(ctx context.Context, cp *ethpb.Checkpoint) error {
+	root := bytesutil.ToBytes32(cp.Root)
+	b, err := s.beaconDB.Block(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+	var stateRoot [32]byte
+	if b != nil && b.Block != nil {
+		stateRoot = bytesutil.ToBytes32(b.Block.StateRoot)
+	}
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.FinalizedCheckpoint,
+		Data: &statefeed.FinalizedCheckpointData{
+			Epoch:     cp.Epoch,
+			BlockRoot: root,
+			StateRoot: stateRoot,
+		},
+	})
+	return nil
+}
+
 // This retrieves missing blocks from DB (ie. the blocks that couldn't received over sync) and inserts them to fork choice store.
 // This is useful for block tree visualizer and additional vote accounting.
 func (s *Service) fillInForkChoiceMissingBlocks(ctx context.Context, blk *ethpb.BeaconBlock, state *stateTrie.BeaconState) error {
@@ -383,14 +383,24 @@ func (s *Service) initializeChainInfo(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "could not get genesis block from db")
 	}
-	if genesisBlock == nil {
-		return errors.New("no genesis block in db")
-	}
-	genesisBlkRoot, err := stateutil.BlockRoot(genesisBlock.Block)
-	if err != nil {
-		return errors.Wrap(err, "could not get signing root of genesis block")
+	if genesisBlock != nil {
+		genesisBlkRoot, err := stateutil.BlockRoot(genesisBlock.Block)
+		if err != nil {
+			return errors.Wrap(err, "could not get signing root of genesis block")
+		}
+		s.genesisRoot = genesisBlkRoot
+	} else {
+		// A node started from a weak subjectivity checkpoint has no genesis block, so
+		// the checkpoint block stands in as the root of its chain.
+		originRoot, err := s.beaconDB.OriginBlockRoot(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get origin block root from db")
+		}
+		if originRoot == params.BeaconConfig().ZeroHash {
+			return errors.New("no genesis block in db")
+		}
+		s.genesisRoot = originRoot
 	}
-	s.genesisRoot = genesisBlkRoot
 
 	if flags.Get().UnsafeSync {
 		headBlock, err := s.beaconDB.HeadBlock(ctx)
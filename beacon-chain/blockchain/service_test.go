@@ -359,6 +359,51 @@ func TestChainService_InitializeChainInfo(t *testing.T) {
 	}
 }
 
+func TestChainService_InitializeChainInfo_FromOrigin(t *testing.T) {
+	db := testDB.SetupDB(t)
+	ctx := context.Background()
+
+	originSlot := params.BeaconConfig().SlotsPerEpoch * 4
+	origin := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: originSlot, ParentRoot: []byte{'p'}}}
+	originRoot, err := stateutil.BlockRoot(origin.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originState := testutil.NewBeaconState()
+	if err := originState.SetSlot(originSlot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveBlock(ctx, origin); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, originState, originRoot); err != nil {
+		t.Fatal(err)
+	}
+	c := &Service{beaconDB: db, stateGen: stategen.New(db, cache.NewStateSummaryCache())}
+	if err := c.initializeChainInfo(ctx); err == nil {
+		t.Fatal("Expected an error without a genesis or origin block")
+	}
+
+	if err := db.SaveOriginBlockRoot(ctx, originRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{
+		Epoch: helpers.SlotToEpoch(originSlot),
+		Root:  originRoot[:],
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.initializeChainInfo(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if c.genesisRoot != originRoot {
+		t.Errorf("Expected origin root %#x as the chain root, received %#x", originRoot, c.genesisRoot)
+	}
+	if c.HeadSlot() != originSlot {
+		t.Errorf("Expected head slot %d, received %d", originSlot, c.HeadSlot())
+	}
+}
+
 func TestChainService_SaveHeadNoDB(t *testing.T) {
 	db := testDB.SetupDB(t)
 	ctx := context.Background()
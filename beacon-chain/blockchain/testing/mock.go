@@ -43,6 +43,7 @@ type ChainService struct {
 	opNotifier                  opfeed.Notifier
 	ValidAttestation            bool
 	ForkChoiceStore             *protoarray.Store
+	CanonicalRoots              map[[32]byte]bool
 }
 
 // StateNotifier mocks the same method in the chain service.
@@ -264,3 +265,12 @@ func (ms *ChainService) HasInitSyncBlock(root [32]byte) bool {
 func (ms *ChainService) HeadGenesisValidatorRoot() [32]byte {
 	return [32]byte{}
 }
+
+// IsCanonical returns whether the block root is in CanonicalRoots, treating every
+// root as canonical if CanonicalRoots is not set.
+func (ms *ChainService) IsCanonical(_ context.Context, r [32]byte) (bool, error) {
+	if ms.CanonicalRoots != nil {
+		return ms.CanonicalRoots[r], nil
+	}
+	return true, nil
+}
@@ -132,3 +132,19 @@ func (c *committeeIDs) AddPersistentCommittee(pubkey []byte, comIndex []uint64,
 
 	c.persistentSubnets.Set(string(pubkey), comIndex, duration)
 }
+
+// EmptyAllCaches empties out all the committee IDs of the cache. This should only ever be used
+// for testing, the committee IDs of each role otherwise expire on their own.
+func (c *committeeIDs) EmptyAllCaches() {
+	c.attesterLock.Lock()
+	c.attester.Purge()
+	c.attesterLock.Unlock()
+
+	c.aggregatorLock.Lock()
+	c.aggregator.Purge()
+	c.aggregatorLock.Unlock()
+
+	c.subnetsLock.Lock()
+	c.persistentSubnets.Flush()
+	c.subnetsLock.Unlock()
+}
@@ -82,3 +82,21 @@ func TestCommitteeIDs_PersistentCommitteeRoundtrip(t *testing.T) {
 		t.Errorf("Number of committees is not %d but is %d", 20, len(coms))
 	}
 }
+
+func TestCommitteeIDs_EmptyAllCaches(t *testing.T) {
+	c := newCommitteeIDs()
+	c.AddAttesterCommiteeID(1, 2)
+	c.AddAggregatorCommiteeID(1, 3)
+	c.AddPersistentCommittee([]byte{'a'}, []uint64{4}, 0)
+
+	c.EmptyAllCaches()
+	if ids := c.GetAttesterCommitteeIDs(1); len(ids) != 0 {
+		t.Errorf("Expected no attester committee IDs, received %v", ids)
+	}
+	if ids := c.GetAggregatorCommitteeIDs(1); len(ids) != 0 {
+		t.Errorf("Expected no aggregator committee IDs, received %v", ids)
+	}
+	if ids := c.GetAllCommittees(); len(ids) != 0 {
+		t.Errorf("Expected no persistent committees, received %v", ids)
+	}
+}
@@ -81,6 +81,16 @@ func verifySignature(signedData []byte, pub []byte, signature []byte, domain []b
 	return nil
 }
 
+// batchSignature adds the signature of the object by the public key to the batch, to be
+// verified later along with the other signatures of the batch.
+func batchSignature(batch *bls.SignatureBatch, obj interface{}, pub []byte, signature []byte, domain []byte, description string) error {
+	root, err := helpers.ComputeSigningRoot(obj, domain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	return batch.AddBytes(signature, pub, root, description)
+}
+
 // ProcessEth1DataInBlock is an operation performed on each
 // beacon block to ensure the ETH1 data votes are processed
 // into the beacon state.
@@ -184,6 +194,15 @@ func ProcessBlockHeader(
 
 // VerifyBlockSignature verifies the proposer signature of a beacon block.
 func VerifyBlockSignature(beaconState *stateTrie.BeaconState, block *ethpb.SignedBeaconBlock) error {
+	return verifyBlockSignature(beaconState, block, nil)
+}
+
+// AddBlockSignature adds the proposer signature of a beacon block to the batch instead of verifying it.
+func AddBlockSignature(batch *bls.SignatureBatch, beaconState *stateTrie.BeaconState, block *ethpb.SignedBeaconBlock) error {
+	return verifyBlockSignature(beaconState, block, batch)
+}
+
+func verifyBlockSignature(beaconState *stateTrie.BeaconState, block *ethpb.SignedBeaconBlock, batch *bls.SignatureBatch) error {
 	proposer, err := beaconState.ValidatorAtIndex(block.Block.ProposerIndex)
 	if err != nil {
 		return err
@@ -195,6 +214,9 @@ func VerifyBlockSignature(beaconState *stateTrie.BeaconState, block *ethpb.Signe
 		return err
 	}
 	proposerPubKey := proposer.PublicKey
+	if batch != nil {
+		return batchSignature(batch, block.Block, proposerPubKey[:], block.Signature, domain, "block proposer")
+	}
 	return helpers.VerifyBlockSigningRoot(block.Block, proposerPubKey[:], block.Signature, domain)
 }
 
@@ -302,9 +324,26 @@ func ProcessRandao(
 	beaconState *stateTrie.BeaconState,
 	body *ethpb.BeaconBlockBody,
 ) (*stateTrie.BeaconState, error) {
+	if err := verifyRandao(beaconState, body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not verify block randao")
+	}
+
+	beaconState, err := ProcessRandaoNoVerify(beaconState, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process randao")
+	}
+	return beaconState, nil
+}
+
+// AddRandaoSignature adds the randao reveal of the block proposer to the batch instead of verifying it.
+func AddRandaoSignature(batch *bls.SignatureBatch, beaconState *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) error {
+	return verifyRandao(beaconState, body, batch)
+}
+
+func verifyRandao(beaconState *stateTrie.BeaconState, body *ethpb.BeaconBlockBody, batch *bls.SignatureBatch) error {
 	proposerIdx, err := helpers.BeaconProposerIndex(beaconState)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get beacon proposer index")
+		return errors.Wrap(err, "could not get beacon proposer index")
 	}
 	proposerPub := beaconState.PubkeyAtIndex(proposerIdx)
 
@@ -314,17 +353,16 @@ func ProcessRandao(
 
 	domain, err := helpers.Domain(beaconState.Fork(), currentEpoch, params.BeaconConfig().DomainRandao, beaconState.GenesisValidatorRoot())
 	if err != nil {
-		return nil, err
-	}
-	if err := verifySignature(buf, proposerPub[:], body.RandaoReveal, domain); err != nil {
-		return nil, errors.Wrap(err, "could not verify block randao")
+		return err
 	}
-
-	beaconState, err = ProcessRandaoNoVerify(beaconState, body)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not process randao")
+	if batch != nil {
+		root, err := ssz.HashTreeRoot(&pb.SigningRoot{ObjectRoot: buf, Domain: domain})
+		if err != nil {
+			return errors.Wrap(err, "could not hash container")
+		}
+		return batch.AddBytes(body.RandaoReveal, proposerPub[:], root, "randao reveal")
 	}
-	return beaconState, nil
+	return verifySignature(buf, proposerPub[:], body.RandaoReveal, domain)
 }
 
 // ProcessRandaoNoVerify generates a new randao mix to update
@@ -387,13 +425,34 @@ func ProcessProposerSlashings(
 	ctx context.Context,
 	beaconState *stateTrie.BeaconState,
 	body *ethpb.BeaconBlockBody,
+) (*stateTrie.BeaconState, error) {
+	return processProposerSlashings(ctx, beaconState, body, nil)
+}
+
+// ProcessProposerSlashingsBatched processes the proposer slashings of the block body like
+// ProcessProposerSlashings, but adds the signatures of the headers to the batch instead of
+// verifying them.
+func ProcessProposerSlashingsBatched(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
+) (*stateTrie.BeaconState, error) {
+	return processProposerSlashings(ctx, beaconState, body, batch)
+}
+
+func processProposerSlashings(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
 ) (*stateTrie.BeaconState, error) {
 	var err error
 	for idx, slashing := range body.ProposerSlashings {
 		if slashing == nil {
 			return nil, errors.New("nil proposer slashings in block body")
 		}
-		if err = VerifyProposerSlashing(beaconState, slashing); err != nil {
+		if err = verifyProposerSlashing(beaconState, slashing, batch, fmt.Sprintf("proposer slashing %d", idx)); err != nil {
 			return nil, errors.Wrapf(err, "could not verify proposer slashing %d", idx)
 		}
 		beaconState, err = v.SlashValidator(
@@ -410,6 +469,15 @@ func ProcessProposerSlashings(
 func VerifyProposerSlashing(
 	beaconState *stateTrie.BeaconState,
 	slashing *ethpb.ProposerSlashing,
+) error {
+	return verifyProposerSlashing(beaconState, slashing, nil, "")
+}
+
+func verifyProposerSlashing(
+	beaconState *stateTrie.BeaconState,
+	slashing *ethpb.ProposerSlashing,
+	batch *bls.SignatureBatch,
+	description string,
 ) error {
 	if slashing.Header_1 == nil || slashing.Header_1.Header == nil || slashing.Header_2 == nil || slashing.Header_2.Header == nil {
 		return errors.New("nil header cannot be verified")
@@ -436,8 +504,15 @@ func VerifyProposerSlashing(
 		return err
 	}
 	headers := []*ethpb.SignedBeaconBlockHeader{slashing.Header_1, slashing.Header_2}
-	for _, header := range headers {
+	for i, header := range headers {
 		proposerPubKey := proposer.PublicKey()
+		if batch != nil {
+			desc := fmt.Sprintf("%s header %d", description, i+1)
+			if err := batchSignature(batch, header.Header, proposerPubKey[:], header.Signature, domain, desc); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := helpers.VerifySigningRoot(header.Header, proposerPubKey[:], header.Signature, domain); err != nil {
 			return errors.Wrap(err, "could not verify beacon block header")
 		}
@@ -468,9 +543,30 @@ func ProcessAttesterSlashings(
 	ctx context.Context,
 	beaconState *stateTrie.BeaconState,
 	body *ethpb.BeaconBlockBody,
+) (*stateTrie.BeaconState, error) {
+	return processAttesterSlashings(ctx, beaconState, body, nil)
+}
+
+// ProcessAttesterSlashingsBatched processes the attester slashings of the block body like
+// ProcessAttesterSlashings, but adds the signatures of the attestations to the batch instead
+// of verifying them.
+func ProcessAttesterSlashingsBatched(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
+) (*stateTrie.BeaconState, error) {
+	return processAttesterSlashings(ctx, beaconState, body, batch)
+}
+
+func processAttesterSlashings(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
 ) (*stateTrie.BeaconState, error) {
 	for idx, slashing := range body.AttesterSlashings {
-		if err := VerifyAttesterSlashing(ctx, beaconState, slashing); err != nil {
+		if err := verifyAttesterSlashing(ctx, beaconState, slashing, batch, fmt.Sprintf("attester slashing %d", idx)); err != nil {
 			return nil, errors.Wrapf(err, "could not verify attester slashing %d", idx)
 		}
 		slashableIndices := slashableAttesterIndices(slashing)
@@ -504,6 +600,16 @@ func ProcessAttesterSlashings(
 
 // VerifyAttesterSlashing validates the attestation data in both attestations in the slashing object.
 func VerifyAttesterSlashing(ctx context.Context, beaconState *stateTrie.BeaconState, slashing *ethpb.AttesterSlashing) error {
+	return verifyAttesterSlashing(ctx, beaconState, slashing, nil, "")
+}
+
+func verifyAttesterSlashing(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	slashing *ethpb.AttesterSlashing,
+	batch *bls.SignatureBatch,
+	description string,
+) error {
 	if slashing == nil {
 		return errors.New("nil slashing")
 	}
@@ -520,10 +626,10 @@ func VerifyAttesterSlashing(ctx context.Context, beaconState *stateTrie.BeaconSt
 	if !IsSlashableAttestationData(data1, data2) {
 		return errors.New("attestations are not slashable")
 	}
-	if err := VerifyIndexedAttestation(ctx, beaconState, att1); err != nil {
+	if err := verifyIndexedAttestation(ctx, beaconState, att1, batch, description+" attestation 1"); err != nil {
 		return errors.Wrap(err, "could not validate indexed attestation")
 	}
-	if err := VerifyIndexedAttestation(ctx, beaconState, att2); err != nil {
+	if err := verifyIndexedAttestation(ctx, beaconState, att2, batch, description+" attestation 2"); err != nil {
 		return errors.Wrap(err, "could not validate indexed attestation")
 	}
 	return nil
@@ -578,6 +684,28 @@ func ProcessAttestations(
 	return beaconState, nil
 }
 
+// ProcessAttestationsBatched applies processing operations to a block's inner attestation
+// records like ProcessAttestations, but adds the attestation signatures to the batch instead
+// of verifying them.
+func ProcessAttestationsBatched(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
+) (*stateTrie.BeaconState, error) {
+	var err error
+	for idx, attestation := range body.Attestations {
+		beaconState, err = ProcessAttestationNoVerify(ctx, beaconState, attestation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not verify attestation at index %d in block", idx)
+		}
+		if err := verifyAttestation(ctx, beaconState, attestation, batch, fmt.Sprintf("attestation %d", idx)); err != nil {
+			return nil, errors.Wrapf(err, "could not verify attestation at index %d in block", idx)
+		}
+	}
+	return beaconState, nil
+}
+
 // ProcessAttestationsNoVerify applies processing operations to a block's inner attestation
 // records. The only difference would be that the attestation signature would not be verified.
 func ProcessAttestationsNoVerify(
@@ -760,6 +888,16 @@ func ProcessAttestationNoVerify(
 //        return False
 //    return True
 func VerifyIndexedAttestation(ctx context.Context, beaconState *stateTrie.BeaconState, indexedAtt *ethpb.IndexedAttestation) error {
+	return verifyIndexedAttestation(ctx, beaconState, indexedAtt, nil, "")
+}
+
+func verifyIndexedAttestation(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	indexedAtt *ethpb.IndexedAttestation,
+	batch *bls.SignatureBatch,
+	description string,
+) error {
 	ctx, span := trace.StartSpan(ctx, "core.VerifyIndexedAttestation")
 	defer span.End()
 
@@ -782,13 +920,43 @@ func VerifyIndexedAttestation(ctx context.Context, beaconState *stateTrie.Beacon
 			pubkeys = append(pubkeys, pk)
 		}
 	}
+	if batch != nil {
+		root, err := helpers.ComputeSigningRoot(indexedAtt.Data, domain)
+		if err != nil {
+			return errors.Wrap(err, "could not get signing root of object")
+		}
+		sig, err := bls.SignatureFromBytes(indexedAtt.Signature)
+		if err != nil {
+			return errors.Wrap(err, "could not convert bytes to signature")
+		}
+		// As in VerifyIndexedAttestationSig, the signature must decode even when
+		// there are no attesters, but there is nothing to verify it against.
+		if len(pubkeys) > 0 {
+			batch.Add(sig, pubkeys, root, description)
+		}
+		return nil
+	}
 	return attestationutil.VerifyIndexedAttestationSig(ctx, indexedAtt, pubkeys, domain)
-
 }
 
 // VerifyAttestation converts and attestation into an indexed attestation and verifies
 // the signature in that attestation.
 func VerifyAttestation(ctx context.Context, beaconState *stateTrie.BeaconState, att *ethpb.Attestation) error {
+	return verifyAttestation(ctx, beaconState, att, nil, "")
+}
+
+// AddAttestationSignature adds the signature of an attestation to the batch instead of verifying it.
+func AddAttestationSignature(ctx context.Context, batch *bls.SignatureBatch, beaconState *stateTrie.BeaconState, att *ethpb.Attestation) error {
+	return verifyAttestation(ctx, beaconState, att, batch, "attestation")
+}
+
+func verifyAttestation(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	att *ethpb.Attestation,
+	batch *bls.SignatureBatch,
+	description string,
+) error {
 	if att == nil || att.Data == nil {
 		return fmt.Errorf("nil or missing attestation data: %v", att)
 	}
@@ -797,7 +965,7 @@ func VerifyAttestation(ctx context.Context, beaconState *stateTrie.BeaconState,
 		return err
 	}
 	indexedAtt := attestationutil.ConvertToIndexed(ctx, att, committee)
-	return VerifyIndexedAttestation(ctx, beaconState, indexedAtt)
+	return verifyIndexedAttestation(ctx, beaconState, indexedAtt, batch, description)
 }
 
 // ProcessDeposits is one of the operations performed on each processed
@@ -1016,6 +1184,26 @@ func ProcessVoluntaryExits(
 	ctx context.Context,
 	beaconState *stateTrie.BeaconState,
 	body *ethpb.BeaconBlockBody,
+) (*stateTrie.BeaconState, error) {
+	return processVoluntaryExits(ctx, beaconState, body, nil)
+}
+
+// ProcessVoluntaryExitsBatched processes all the voluntary exits in a block body like
+// ProcessVoluntaryExits, but adds their signatures to the batch instead of verifying them.
+func ProcessVoluntaryExitsBatched(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
+) (*stateTrie.BeaconState, error) {
+	return processVoluntaryExits(ctx, beaconState, body, batch)
+}
+
+func processVoluntaryExits(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
 ) (*stateTrie.BeaconState, error) {
 	exits := body.VoluntaryExits
 	for idx, exit := range exits {
@@ -1033,7 +1221,8 @@ func ProcessVoluntaryExits(
 		if err != nil {
 			return nil, err
 		}
-		if err := VerifyExit(val, beaconState.Slot(), beaconState.Fork(), exit, beaconState.GenesisValidatorRoot()); err != nil {
+		desc := fmt.Sprintf("voluntary exit %d", idx)
+		if err := verifyExit(val, beaconState.Slot(), beaconState.Fork(), exit, beaconState.GenesisValidatorRoot(), batch, desc); err != nil {
 			return nil, errors.Wrapf(err, "could not verify exit %d", idx)
 		}
 		beaconState, err = v.InitiateValidatorExit(beaconState, exit.Exit.ValidatorIndex)
@@ -1085,6 +1274,18 @@ func ProcessVoluntaryExitsNoVerify(
 //    domain = get_domain(state, DOMAIN_VOLUNTARY_EXIT, exit.epoch)
 //    assert bls_verify(validator.pubkey, signing_root(exit), exit.signature, domain)
 func VerifyExit(validator *stateTrie.ReadOnlyValidator, currentSlot uint64, fork *pb.Fork, signed *ethpb.SignedVoluntaryExit, genesisRoot []byte) error {
+	return verifyExit(validator, currentSlot, fork, signed, genesisRoot, nil, "")
+}
+
+func verifyExit(
+	validator *stateTrie.ReadOnlyValidator,
+	currentSlot uint64,
+	fork *pb.Fork,
+	signed *ethpb.SignedVoluntaryExit,
+	genesisRoot []byte,
+	batch *bls.SignatureBatch,
+	description string,
+) error {
 	if signed == nil || signed.Exit == nil {
 		return errors.New("nil exit")
 	}
@@ -1116,6 +1317,9 @@ func VerifyExit(validator *stateTrie.ReadOnlyValidator, currentSlot uint64, fork
 		return err
 	}
 	valPubKey := validator.PublicKey()
+	if batch != nil {
+		return batchSignature(batch, exit, valPubKey[:], signed.Signature, domain, description)
+	}
 	if err := helpers.VerifySigningRoot(exit, valPubKey[:], signed.Signature, domain); err != nil {
 		return helpers.ErrSigFailedToVerify
 	}
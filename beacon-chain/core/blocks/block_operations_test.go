@@ -1576,6 +1576,34 @@ func TestValidateIndexedAttestation_AboveMaxLength(t *testing.T) {
 	}
 }
 
+func TestAddAttestationSignature_MalformedSignatureWithoutAttesters(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 100)
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target: &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		AggregationBits: bitfield.NewBitlist(uint64(len(committee))),
+		Signature:       []byte{'a'},
+	}
+
+	want := "could not convert bytes to signature"
+	if err := blocks.VerifyAttestation(context.Background(), beaconState, att); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected %s, received %v", want, err)
+	}
+	batch := bls.NewSignatureBatch()
+	if err := blocks.AddAttestationSignature(context.Background(), batch, beaconState, att); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected %s in the batch path, received %v", want, err)
+	}
+	if batch.Len() != 0 {
+		t.Errorf("Expected no signature in the batch, received %d", batch.Len())
+	}
+}
+
 func TestProcessDeposits_SameValidatorMultipleDepositsSameBlock(t *testing.T) {
 	// Same validator created 3 valid deposits within the same block
 	testutil.ResetCache()
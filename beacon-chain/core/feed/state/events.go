@@ -17,6 +17,10 @@ const (
 	// Reorg is an event sent when the new head state's slot after a block
 	// transition is lower than its previous head state slot value.
 	Reorg
+	// NewHead is sent when the fork choice head of the chain changes.
+	NewHead
+	// FinalizedCheckpoint is sent when a newer finalized checkpoint has been processed.
+	FinalizedCheckpoint
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -55,4 +59,33 @@ type ReorgData struct {
 	NewSlot uint64
 	// OldSlot is the slot of the head state before the reorg.
 	OldSlot uint64
+	// NewHeadBlockRoot is the block root of the head after the reorg.
+	NewHeadBlockRoot [32]byte
+	// OldHeadBlockRoot is the block root of the head before the reorg.
+	OldHeadBlockRoot [32]byte
+	// Depth is the number of slots between the old head and the common
+	// ancestor of the old and new heads in fork choice.
+	Depth uint64
+}
+
+// NewHeadData is the data sent with NewHead events.
+type NewHeadData struct {
+	// Slot is the slot of the new head block.
+	Slot uint64
+	// BlockRoot of the new head block.
+	BlockRoot [32]byte
+	// StateRoot of the new head block.
+	StateRoot [32]byte
+	// EpochTransition is true if the new head is in a later epoch than the previous head.
+	EpochTransition bool
+}
+
+// FinalizedCheckpointData is the data sent with FinalizedCheckpoint events.
+type FinalizedCheckpointData struct {
+	// Epoch of the finalized checkpoint.
+	Epoch uint64
+	// BlockRoot of the finalized checkpoint.
+	BlockRoot [32]byte
+	// StateRoot of the finalized checkpoint block.
+	StateRoot [32]byte
 }
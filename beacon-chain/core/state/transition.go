@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state/interop"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -339,6 +340,10 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 // transformations as defined in the Ethereum Serenity specification, including processing proposer slashings,
 // processing block attestations, and more.
 //
+// The proposer, randao, slashing, attestation and exit signatures of the block are collected
+// while processing it and verified all at once at the end. Deposit signatures are still
+// verified one by one, as an invalid deposit signature does not invalidate the block.
+//
 // Spec pseudocode definition:
 //
 //  def process_block(state: BeaconState, block: BeaconBlock) -> None:
@@ -354,37 +359,16 @@ func ProcessBlock(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessBlock")
 	defer span.End()
 
-	state, err := b.ProcessBlockHeader(state, signed)
-	if err != nil {
-		traceutil.AnnotateError(span, err)
-		return nil, errors.Wrap(err, "could not process block header")
-	}
-
-	state, err = b.ProcessRandao(state, signed.Block.Body)
-	if err != nil {
-		traceutil.AnnotateError(span, err)
-		return nil, errors.Wrap(err, "could not verify and process randao")
-	}
-
-	state, err = b.ProcessEth1DataInBlock(state, signed.Block)
-	if err != nil {
-		traceutil.AnnotateError(span, err)
-		return nil, errors.Wrap(err, "could not process eth1 data")
-	}
-
-	state, err = ProcessOperations(ctx, state, signed.Block.Body)
-	if err != nil {
-		traceutil.AnnotateError(span, err)
-		return nil, errors.Wrap(err, "could not process block operation")
-	}
-
-	return state, nil
+	return processBlockBatched(ctx, state, signed, true /* verifyAttSigs */)
 }
 
 // ProcessBlockNoVerifyAttSigs creates a new, modified beacon state by applying block operation
 // transformations as defined in the Ethereum Serenity specification. It does not validate
 // block attestation signatures.
 //
+// The other signatures of the block are collected while processing it and verified all at once
+// at the end, as in ProcessBlock.
+//
 // Spec pseudocode definition:
 //
 //  def process_block(state: BeaconState, block: BeaconBlock) -> None:
@@ -400,13 +384,35 @@ func ProcessBlockNoVerifyAttSigs(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessBlock")
 	defer span.End()
 
-	state, err := b.ProcessBlockHeader(state, signed)
+	return processBlockBatched(ctx, state, signed, false /* verifyAttSigs */)
+}
+
+// processBlockBatched processes the block, adding its signatures to a batch which is verified
+// once the block is processed. Attestation signatures are only added if verifyAttSigs is true.
+func processBlockBatched(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	signed *ethpb.SignedBeaconBlock,
+	verifyAttSigs bool,
+) (*stateTrie.BeaconState, error) {
+	span := trace.FromContext(ctx)
+
+	batch := bls.NewSignatureBatch()
+	state, err := b.ProcessBlockHeaderNoVerify(state, signed.Block)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process block header")
 	}
+	if err := b.AddBlockSignature(batch, state, signed); err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, errors.Wrap(err, "could not process block header")
+	}
 
-	state, err = b.ProcessRandao(state, signed.Block.Body)
+	if err := b.AddRandaoSignature(batch, state, signed.Block.Body); err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, errors.Wrap(err, "could not verify and process randao")
+	}
+	state, err = b.ProcessRandaoNoVerify(state, signed.Block.Body)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not verify and process randao")
@@ -418,12 +424,17 @@ func ProcessBlockNoVerifyAttSigs(
 		return nil, errors.Wrap(err, "could not process eth1 data")
 	}
 
-	state, err = ProcessOperationsNoVerify(ctx, state, signed.Block.Body)
+	state, err = processOperationsBatched(ctx, state, signed.Block.Body, batch, verifyAttSigs)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process block operation")
 	}
 
+	if err := batch.Verify(); err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, errors.Wrap(err, "could not verify block signatures")
+	}
+
 	return state, nil
 }
 
@@ -484,6 +495,50 @@ func ProcessOperations(
 	return state, nil
 }
 
+// processOperationsBatched processes the operations in the beacon block like ProcessOperations,
+// but adds the signatures of the slashings, attestations and voluntary exits to the batch
+// instead of verifying them. Attestation signatures are left out unless verifyAttSigs is true.
+func processOperationsBatched(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody,
+	batch *bls.SignatureBatch,
+	verifyAttSigs bool) (*stateTrie.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessOperations")
+	defer span.End()
+
+	if err := verifyOperationLengths(state, body); err != nil {
+		return nil, errors.Wrap(err, "could not verify operation lengths")
+	}
+
+	state, err := b.ProcessProposerSlashingsBatched(ctx, state, body, batch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block proposer slashings")
+	}
+	state, err = b.ProcessAttesterSlashingsBatched(ctx, state, body, batch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block attester slashings")
+	}
+	if verifyAttSigs {
+		state, err = b.ProcessAttestationsBatched(ctx, state, body, batch)
+	} else {
+		state, err = b.ProcessAttestationsNoVerify(ctx, state, body)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block attestations")
+	}
+	state, err = b.ProcessDeposits(ctx, state, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block validator deposits")
+	}
+	state, err = b.ProcessVoluntaryExitsBatched(ctx, state, body, batch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process validator exits")
+	}
+
+	return state, nil
+}
+
 // ProcessOperationsNoVerify processes the operations in the beacon block and updates beacon state
 // with the operations in block. It does not verify attestation signatures or voluntary exit signatures.
 //
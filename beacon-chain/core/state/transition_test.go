@@ -229,6 +229,51 @@ func TestProcessBlock_IncorrectProcessBlockAttestations(t *testing.T) {
 	}
 }
 
+func TestProcessBlock_InvalidRandaoInSignatureBatch(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+
+	block, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.Block.Body.RandaoReveal = privKeys[0].Sign([]byte("not an epoch")).Marshal()
+	if err := beaconState.SetSlot(beaconState.Slot() + 1); err != nil {
+		t.Fatal(err)
+	}
+	proposerIdx, err := helpers.BeaconProposerIndex(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconState.SetSlot(beaconState.Slot() - 1); err != nil {
+		t.Fatal(err)
+	}
+	domain, err := helpers.Domain(beaconState.Fork(), helpers.CurrentEpoch(beaconState), params.BeaconConfig().DomainBeaconProposer, beaconState.GenesisValidatorRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := helpers.ComputeSigningRoot(block.Block, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.Signature = privKeys[proposerIdx].Sign(root[:]).Marshal()
+
+	beaconState, err = state.ProcessSlots(context.Background(), beaconState, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "invalid signature of randao reveal"
+	// Blocks processed without their attestation signatures still have their other signatures verified.
+	for name, process := range map[string]func(context.Context, *beaconstate.BeaconState, *ethpb.SignedBeaconBlock) (*beaconstate.BeaconState, error){
+		"ProcessBlock":                state.ProcessBlock,
+		"ProcessBlockNoVerifyAttSigs": state.ProcessBlockNoVerifyAttSigs,
+	} {
+		_, err = process(context.Background(), beaconState.Copy(), block)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected %s, received %v", name, want, err)
+		}
+	}
+}
+
 func TestProcessBlock_IncorrectProcessExits(t *testing.T) {
 	beaconState, _ := testutil.DeterministicGenesisState(t, 100)
 
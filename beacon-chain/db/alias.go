@@ -22,3 +22,16 @@ type HeadAccessDatabase = iface.HeadAccessDatabase
 // key-value or relational database in practice. This is the full database interface which should
 // not be used often. Prefer a more restrictive interface in this package.
 type Database = iface.Database
+
+// PruneStats counts the records deleted from the database by a prune.
+type PruneStats = iface.PruneStats
+
+// OperationPools is a snapshot of the pending operations held in memory by the node.
+type OperationPools = iface.OperationPools
+
+// IntegrityReport counts the records checked by a database integrity check and lists the
+// inconsistencies found.
+type IntegrityReport = iface.IntegrityReport
+
+// IntegrityIssue is an inconsistency of a record of the database.
+type IntegrityIssue = iface.IntegrityIssue
@@ -28,6 +28,7 @@ type ReadOnlyDatabase interface {
 	BlockRoots(ctx context.Context, f *filters.QueryFilter) ([][32]byte, error)
 	HasBlock(ctx context.Context, blockRoot [32]byte) bool
 	GenesisBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error)
+	OriginBlockRoot(ctx context.Context) ([32]byte, error)
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotBlocks(ctx context.Context) ([]*ethpb.SignedBeaconBlock, error)
 	HighestSlotBlocksBelow(ctx context.Context, slot uint64) ([]*ethpb.SignedBeaconBlock, error)
@@ -80,6 +81,7 @@ type NoHeadAccessDatabase interface {
 	SaveBlock(ctx context.Context, block *eth.SignedBeaconBlock) error
 	SaveBlocks(ctx context.Context, blocks []*eth.SignedBeaconBlock) error
 	SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error
 	// State related methods.
 	SaveState(ctx context.Context, state *state.BeaconState, blockRoot [32]byte) error
 	SaveStates(ctx context.Context, states []*state.BeaconState, blockRoots [][32]byte) error
@@ -118,6 +120,7 @@ type HeadAccessDatabase interface {
 	// Block related methods.
 	HeadBlock(ctx context.Context) (*eth.SignedBeaconBlock, error)
 	SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveOrigin(ctx context.Context, block *eth.SignedBeaconBlock, state *state.BeaconState, checkpoint *eth.Checkpoint) error
 	// State related methods.
 	HeadState(ctx context.Context) (*state.BeaconState, error)
 }
@@ -133,6 +136,59 @@ type Database interface {
 	// Backup and restore methods
 	Backup(ctx context.Context) error
 
+	// Pruning and compaction methods.
+	Prune(ctx context.Context, retentionEpochs uint64) (*PruneStats, error)
+	Compact(ctx context.Context) (int64, error)
+
+	// Operation pools snapshot methods.
+	SaveOperationPools(ctx context.Context, pools *OperationPools) error
+	OperationPools(ctx context.Context) (*OperationPools, error)
+
+	// Peer scores methods.
+	SavePeerScores(ctx context.Context, scores []*db.PeerScore) error
+	PeerScores(ctx context.Context) ([]*db.PeerScore, error)
+
+	// Known peers methods.
+	SavePeerRecords(ctx context.Context, records []*db.PeerRecord) error
+	PeerRecords(ctx context.Context) ([]*db.PeerRecord, error)
+
+	// Verify checks the consistency of the database.
+	Verify(ctx context.Context) (*IntegrityReport, error)
+
 	// HistoricalStatesDeleted verifies historical states exist in DB.
 	HistoricalStatesDeleted(ctx context.Context) error
 }
+
+// PruneStats counts the records deleted from the database by a prune.
+type PruneStats struct {
+	Blocks         int
+	Attestations   int
+	StateSummaries int
+	ArchivedPoints int
+}
+
+// OperationPools is a snapshot of the pending operations held in memory by the node.
+type OperationPools struct {
+	Attestations      []*eth.Attestation
+	VoluntaryExits    []*eth.SignedVoluntaryExit
+	ProposerSlashings []*eth.ProposerSlashing
+	AttesterSlashings []*eth.AttesterSlashing
+}
+
+// IntegrityReport counts the records checked by a database integrity check and lists the
+// inconsistencies found.
+type IntegrityReport struct {
+	Blocks         int               `json:"blocks"`
+	StateSummaries int               `json:"state_summaries"`
+	States         int               `json:"states"`
+	FinalizedRoots int               `json:"finalized_roots"`
+	ArchivedPoints int               `json:"archived_points"`
+	Issues         []*IntegrityIssue `json:"issues"`
+}
+
+// IntegrityIssue is an inconsistency of a record of the database.
+type IntegrityIssue struct {
+	Bucket  string `json:"bucket"`
+	Key     string `json:"key"`
+	Problem string `json:"problem"`
+}
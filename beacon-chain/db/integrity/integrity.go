@@ -0,0 +1,46 @@
+// Package integrity checks the consistency of the beacon chain database of a stopped node.
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "integrity")
+
+// VerifyDatabase opens the database at the given path, checks its consistency and writes the
+// report to w as JSON. An error is returned if the database is inconsistent.
+func VerifyDatabase(ctx context.Context, dbPath string, w io.Writer) (err error) {
+	d, err := db.NewDB(dbPath, cache.NewStateSummaryCache())
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if closeErr := d.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	log.WithField("database-path", dbPath).Info("Verifying database")
+	report, err := d.Verify(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not verify database")
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return errors.Wrap(err, "could not write report")
+	}
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("database has %d inconsistencies", len(report.Issues))
+	}
+	log.Info("Database is consistent")
+	return nil
+}
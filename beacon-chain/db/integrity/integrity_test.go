@@ -0,0 +1,34 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestVerifyDatabase(t *testing.T) {
+	dbPath := path.Join(testutil.TempDir(), "verifydb")
+	defer func() {
+		if err := os.RemoveAll(dbPath); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	buf := new(bytes.Buffer)
+	if err := VerifyDatabase(context.Background(), dbPath, buf); err != nil {
+		t.Fatal(err)
+	}
+	report := &db.IntegrityReport{}
+	if err := json.Unmarshal(buf.Bytes(), report); err != nil {
+		t.Fatalf("Could not decode report %q: %v", buf.String(), err)
+	}
+	if report.Issues == nil || len(report.Issues) != 0 {
+		t.Errorf("Expected an empty list of issues, received %v", report.Issues)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
 	ethereum_beacon_p2p_v1 "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -28,6 +29,51 @@ func (e Exporter) Backup(ctx context.Context) error {
 	return e.db.Backup(ctx)
 }
 
+// Prune -- passthrough.
+func (e Exporter) Prune(ctx context.Context, retentionEpochs uint64) (*iface.PruneStats, error) {
+	return e.db.Prune(ctx, retentionEpochs)
+}
+
+// Compact -- passthrough.
+func (e Exporter) Compact(ctx context.Context) (int64, error) {
+	return e.db.Compact(ctx)
+}
+
+// SaveOperationPools -- passthrough.
+func (e Exporter) SaveOperationPools(ctx context.Context, pools *iface.OperationPools) error {
+	return e.db.SaveOperationPools(ctx, pools)
+}
+
+// OperationPools -- passthrough.
+func (e Exporter) OperationPools(ctx context.Context) (*iface.OperationPools, error) {
+	return e.db.OperationPools(ctx)
+}
+
+// SavePeerScores -- passthrough.
+func (e Exporter) SavePeerScores(ctx context.Context, scores []*db.PeerScore) error {
+	return e.db.SavePeerScores(ctx, scores)
+}
+
+// PeerScores -- passthrough.
+func (e Exporter) PeerScores(ctx context.Context) ([]*db.PeerScore, error) {
+	return e.db.PeerScores(ctx)
+}
+
+// SavePeerRecords -- passthrough.
+func (e Exporter) SavePeerRecords(ctx context.Context, records []*db.PeerRecord) error {
+	return e.db.SavePeerRecords(ctx, records)
+}
+
+// PeerRecords -- passthrough.
+func (e Exporter) PeerRecords(ctx context.Context) ([]*db.PeerRecord, error) {
+	return e.db.PeerRecords(ctx)
+}
+
+// Verify -- passthrough.
+func (e Exporter) Verify(ctx context.Context) (*iface.IntegrityReport, error) {
+	return e.db.Verify(ctx)
+}
+
 // AttestationsByDataRoot -- passthrough.
 func (e Exporter) AttestationsByDataRoot(ctx context.Context, attDataRoot [32]byte) ([]*eth.Attestation, error) {
 	return e.db.AttestationsByDataRoot(ctx, attDataRoot)
@@ -203,6 +249,21 @@ func (e Exporter) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte)
 	return e.db.SaveGenesisBlockRoot(ctx, blockRoot)
 }
 
+// OriginBlockRoot -- passthrough.
+func (e Exporter) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.OriginBlockRoot(ctx)
+}
+
+// SaveOriginBlockRoot -- passthrough.
+func (e Exporter) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	return e.db.SaveOriginBlockRoot(ctx, blockRoot)
+}
+
+// SaveOrigin -- passthrough.
+func (e Exporter) SaveOrigin(ctx context.Context, block *eth.SignedBeaconBlock, state *state.BeaconState, checkpoint *eth.Checkpoint) error {
+	return e.db.SaveOrigin(ctx, block, state, checkpoint)
+}
+
 // SaveState -- passthrough.
 func (e Exporter) SaveState(ctx context.Context, state *state.BeaconState, blockRoot [32]byte) error {
 	return e.db.SaveState(ctx, state, blockRoot)
@@ -309,6 +309,30 @@ func (k *Store) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) er
 	})
 }
 
+// OriginBlockRoot returns the root of the block a node's chain was started from when it was
+// initialized from a weak subjectivity checkpoint instead of genesis. It returns a zero
+// root if the node was started from genesis.
+func (k *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.OriginBlockRoot")
+	defer span.End()
+	var root [32]byte
+	err := k.db.View(func(tx *bolt.Tx) error {
+		copy(root[:], tx.Bucket(blocksBucket).Get(originBlockRootKey))
+		return nil
+	})
+	return root, err
+}
+
+// SaveOriginBlockRoot to the db.
+func (k *Store) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOriginBlockRoot")
+	defer span.End()
+	return k.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(originBlockRootKey, blockRoot[:])
+	})
+}
+
 // HighestSlotBlocks returns the blocks with the highest slot from the db.
 func (k *Store) HighestSlotBlocks(ctx context.Context) ([]*ethpb.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestSlotBlocks")
@@ -174,6 +174,30 @@ func TestStore_GenesisBlock(t *testing.T) {
 	}
 }
 
+func TestStore_OriginBlockRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	root, err := db.OriginBlockRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != [32]byte{} {
+		t.Errorf("Expected zero origin root for a node started from genesis, received %#x", root)
+	}
+
+	want := [32]byte{'o', 'r', 'i', 'g', 'i', 'n'}
+	if err := db.SaveOriginBlockRoot(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+	root, err = db.OriginBlockRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("Wanted %#x, received %#x", want, root)
+	}
+}
+
 func TestStore_BlocksCRUD_NoCache(t *testing.T) {
 	db := setupDB(t)
 	ctx := context.Background()
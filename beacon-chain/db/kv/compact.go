@@ -0,0 +1,263 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+const (
+	compactFileSuffix = ".compact"
+	// compactTxMaxSize is the amount of data copied in a single write transaction while
+	// compacting, so a large bucket does not have to fit in memory.
+	compactTxMaxSize = 64 * 1024 * 1024
+)
+
+// renameFile replaces the database file with its compacted copy.
+var renameFile = os.Rename
+
+// swappableDB is the bolt database of the store, which is swapped for its compacted copy.
+// Every transaction holds swapLock for reading, and write transactions hold writeLock for
+// reading too, so nothing is written to the database while it is copied and nothing uses it
+// while it is swapped. Transactions must not be nested.
+type swappableDB struct {
+	swapLock  sync.RWMutex
+	writeLock sync.RWMutex
+	current   *bolt.DB
+	// swapErr is set if the database could not be reopened once swapped, and is returned by
+	// every later transaction.
+	swapErr error
+}
+
+// View runs a read-only transaction on the database.
+func (s *swappableDB) View(fn func(*bolt.Tx) error) error {
+	s.swapLock.RLock()
+	defer s.swapLock.RUnlock()
+	if s.swapErr != nil {
+		return s.swapErr
+	}
+	return s.current.View(fn)
+}
+
+// Update runs a read-write transaction on the database.
+func (s *swappableDB) Update(fn func(*bolt.Tx) error) error {
+	s.writeLock.RLock()
+	defer s.writeLock.RUnlock()
+	s.swapLock.RLock()
+	defer s.swapLock.RUnlock()
+	if s.swapErr != nil {
+		return s.swapErr
+	}
+	return s.current.Update(fn)
+}
+
+// Batch runs a read-write transaction on the database, batched with concurrent ones.
+func (s *swappableDB) Batch(fn func(*bolt.Tx) error) error {
+	s.writeLock.RLock()
+	defer s.writeLock.RUnlock()
+	s.swapLock.RLock()
+	defer s.swapLock.RUnlock()
+	if s.swapErr != nil {
+		return s.swapErr
+	}
+	return s.current.Batch(fn)
+}
+
+// Close closes the database.
+func (s *swappableDB) Close() error {
+	s.swapLock.Lock()
+	defer s.swapLock.Unlock()
+	if s.swapErr != nil {
+		return s.swapErr
+	}
+	return s.current.Close()
+}
+
+// Compact rewrites the database into a new file, leaving out the free pages left behind by
+// deleted records, and atomically replaces the database file with it. It returns the number
+// of bytes reclaimed.
+//
+// Copying the database takes minutes on a large database, and writes wait until the compacted
+// file is swapped in, so it must only be compacted while nothing writes to it: when the node
+// starts, before its services run, or with the node stopped.
+func (k *Store) Compact(ctx context.Context) (int64, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Compact")
+	defer span.End()
+
+	datafile := path.Join(k.databasePath, databaseFileName)
+	compactFile := datafile + compactFileSuffix
+	k.db.writeLock.Lock()
+	defer k.db.writeLock.Unlock()
+	// Remove the leftovers of an interrupted compaction.
+	if err := os.Remove(compactFile); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	before, err := fileSize(datafile)
+	if err != nil {
+		return 0, err
+	}
+	logrus.WithField("prefix", "db").WithField("size", before).Info("Compacting database")
+
+	if err := k.copyInto(ctx, compactFile); err != nil {
+		if rmErr := os.Remove(compactFile); rmErr != nil && !os.IsNotExist(rmErr) {
+			logrus.WithError(rmErr).Error("Failed to remove compacted database")
+		}
+		return 0, errors.Wrap(err, "could not copy database")
+	}
+
+	k.db.swapLock.Lock()
+	defer k.db.swapLock.Unlock()
+	prometheus.Unregister(createBoltCollector(k.db.current))
+	if err := k.db.current.Close(); err != nil {
+		return 0, err
+	}
+	// The original database file is reopened if the compacted one cannot replace it.
+	renameErr := renameFile(compactFile, datafile)
+	if renameErr != nil {
+		if err := os.Remove(compactFile); err != nil && !os.IsNotExist(err) {
+			logrus.WithError(err).Error("Failed to remove compacted database")
+		}
+	}
+	boltDB, err := openBoltDB(datafile)
+	if err != nil {
+		k.db.swapErr = errors.Wrap(err, "database could not be reopened after compaction, restart the node")
+		return 0, k.db.swapErr
+	}
+	k.db.current = boltDB
+	if err := prometheus.Register(createBoltCollector(k.db.current)); err != nil {
+		return 0, err
+	}
+	if renameErr != nil {
+		return 0, errors.Wrap(renameErr, "could not replace database file")
+	}
+
+	after, err := fileSize(datafile)
+	if err != nil {
+		return 0, err
+	}
+	return before - after, nil
+}
+
+// copyInto copies every bucket of the database into a new bolt file at the given path.
+func (k *Store) copyInto(ctx context.Context, dst string) error {
+	copyDB, err := bolt.Open(dst, 0600, nil)
+	if err != nil {
+		return err
+	}
+	copyDB.AllocSize = boltAllocSize
+	c := &bucketCopier{dst: copyDB}
+	err = k.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return c.copy([][]byte{name}, b)
+		})
+	})
+	if err == nil {
+		err = c.commit()
+	} else {
+		c.rollback()
+	}
+	if closeErr := copyDB.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// bucketCopier copies buckets into the destination database, committing every
+// compactTxMaxSize bytes.
+type bucketCopier struct {
+	dst  *bolt.DB
+	tx   *bolt.Tx
+	size int
+}
+
+// copy copies the records and the nested buckets of the bucket into the bucket at the same
+// path of the destination database.
+func (c *bucketCopier) copy(path [][]byte, b *bolt.Bucket) error {
+	b2, err := c.bucket(path)
+	if err != nil {
+		return err
+	}
+	if err := b2.SetSequence(b.Sequence()); err != nil {
+		return err
+	}
+	return b.ForEach(func(k []byte, v []byte) error {
+		if v == nil {
+			nested := append(append(make([][]byte, 0, len(path)+1), path...), k)
+			if err := c.copy(nested, b.Bucket(k)); err != nil {
+				return err
+			}
+			// The transaction may have been committed while copying the nested bucket.
+			b2, err = c.bucket(path)
+			return err
+		}
+		if c.size+len(k)+len(v) > compactTxMaxSize {
+			if err := c.commit(); err != nil {
+				return err
+			}
+			if b2, err = c.bucket(path); err != nil {
+				return err
+			}
+		}
+		c.size += len(k) + len(v)
+		// The source read transaction outlives the write, so its buffers can be used as is.
+		return b2.Put(k, v)
+	})
+}
+
+// bucket returns the bucket at the given path in the current write transaction, creating
+// the transaction and the buckets as needed.
+func (c *bucketCopier) bucket(path [][]byte) (*bolt.Bucket, error) {
+	if c.tx == nil {
+		tx, err := c.dst.Begin(true)
+		if err != nil {
+			return nil, err
+		}
+		c.tx = tx
+	}
+	b, err := c.tx.CreateBucketIfNotExists(path[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range path[1:] {
+		if b, err = b.CreateBucketIfNotExists(name); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (c *bucketCopier) commit() error {
+	if c.tx == nil {
+		return nil
+	}
+	err := c.tx.Commit()
+	c.tx = nil
+	c.size = 0
+	return err
+}
+
+func (c *bucketCopier) rollback() {
+	if c.tx != nil {
+		// Rollback only fails once the transaction is committed.
+		_ = c.tx.Rollback()
+		c.tx = nil
+	}
+}
+
+func fileSize(file string) (int64, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -46,6 +46,7 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	root := checkpoint.Root
 	var previousRoot []byte
 	genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+	originRoot := tx.Bucket(blocksBucket).Get(originBlockRootKey)
 
 	// De-index recent finalized block roots, to be re-indexed.
 	previousFinalizedCheckpoint := &ethpb.Checkpoint{}
@@ -56,7 +57,7 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 		}
 	}
 
-	blockRoots, err := k.BlockRoots(ctx, filters.NewFilter().
+	blockRoots, err := getBlockRootsByFilter(ctx, tx, filters.NewFilter().
 		SetStartEpoch(previousFinalizedCheckpoint.Epoch).
 		SetEndEpoch(checkpoint.Epoch+1),
 	)
@@ -65,26 +66,33 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 		return err
 	}
 	for _, root := range blockRoots {
-		if err := bkt.Delete(root[:]); err != nil {
+		if err := bkt.Delete(root); err != nil {
 			traceutil.AnnotateError(span, err)
 			return err
 		}
 	}
 
 	// Walk up the ancestry chain until we reach a block root present in the finalized block roots
-	// index bucket or genesis block root.
+	// index bucket, the genesis block root or the origin block root of a node started from a
+	// checkpoint, which has no ancestors in the database.
 	for {
 		if bytes.Equal(root, genesisRoot) {
 			break
 		}
 
-		signedBlock, err := k.Block(ctx, bytesutil.ToBytes32(root))
-		if err != nil {
+		encBlock := tx.Bucket(blocksBucket).Get(root)
+		if encBlock == nil {
+			err := fmt.Errorf("missing block in database: block root=%#x", root)
 			traceutil.AnnotateError(span, err)
 			return err
 		}
-		if signedBlock == nil || signedBlock.Block == nil {
-			err := fmt.Errorf("missing block in database: block root=%#x", root)
+		signedBlock := &ethpb.SignedBeaconBlock{}
+		if err := decode(encBlock, signedBlock); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if signedBlock.Block == nil {
+			err := fmt.Errorf("nil block in database: block root=%#x", root)
 			traceutil.AnnotateError(span, err)
 			return err
 		}
@@ -105,6 +113,10 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 			return err
 		}
 
+		if originRoot != nil && bytes.Equal(root, originRoot) {
+			break
+		}
+
 		// Found parent, loop exit condition.
 		if parentBytes := bkt.Get(block.ParentRoot); parentBytes != nil {
 			parent := &dbpb.FinalizedBlockRootContainer{}
@@ -129,13 +141,12 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	}
 
 	// Upsert blocks from the current finalized epoch.
-	roots, err := k.BlockRoots(ctx, filters.NewFilter().SetStartEpoch(checkpoint.Epoch).SetEndEpoch(checkpoint.Epoch+1))
+	roots, err := getBlockRootsByFilter(ctx, tx, filters.NewFilter().SetStartEpoch(checkpoint.Epoch).SetEndEpoch(checkpoint.Epoch+1))
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return err
 	}
 	for _, root := range roots {
-		root := root[:]
 		if bytes.Equal(root, checkpoint.Root) || bkt.Get(root) != nil {
 			continue
 		}
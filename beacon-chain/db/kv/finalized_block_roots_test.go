@@ -181,6 +181,46 @@ func sszRootOrDie(t *testing.T, block *ethpb.SignedBeaconBlock) []byte {
 	return root[:]
 }
 
+func TestStore_IsFinalizedBlock_FromOrigin(t *testing.T) {
+	slotsPerEpoch := int(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	// The origin block's parent is not in the database, as for a node started from a checkpoint.
+	blks := makeBlocks(t, slotsPerEpoch*10, slotsPerEpoch*2, [32]byte{'o'})
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	originRoot, err := stateutil.BlockRoot(blks[0].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveOriginBlockRoot(ctx, originRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := stateutil.BlockRoot(blks[slotsPerEpoch].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 11, Root: root[:]}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i <= slotsPerEpoch; i++ {
+		root, err := stateutil.BlockRoot(blks[i].Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !db.IsFinalizedBlock(ctx, root) {
+			t.Errorf("Block at index %d was not considered finalized in the index", i)
+		}
+	}
+}
+
 func makeBlocks(t *testing.T, i, n int, previousRoot [32]byte) []*ethpb.SignedBeaconBlock {
 	blocks := make([]*ethpb.SignedBeaconBlock, n)
 	for j := i; j < n+i; j++ {
@@ -35,7 +35,7 @@ var BlockCacheSize = int64(1 << 21)
 // Store defines an implementation of the Prysm Database interface
 // using BoltDB as the underlying persistent kv-store for eth2.
 type Store struct {
-	db                  *bolt.DB
+	db                  *swappableDB
 	databasePath        string
 	blockCache          *ristretto.Cache
 	validatorIndexCache *ristretto.Cache
@@ -51,15 +51,10 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 	if err := os.MkdirAll(dirPath, 0700); err != nil {
 		return nil, err
 	}
-	datafile := path.Join(dirPath, databaseFileName)
-	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, InitialMmapSize: 10e6})
+	boltDB, err := openBoltDB(path.Join(dirPath, databaseFileName))
 	if err != nil {
-		if err == bolt.ErrTimeout {
-			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
-		}
 		return nil, err
 	}
-	boltDB.AllocSize = boltAllocSize
 	blockCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,           // number of keys to track frequency of (1000).
 		MaxCost:     BlockCacheSize, // maximum cost of cache (1000 Blocks).
@@ -79,7 +74,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 	}
 
 	kv := &Store{
-		db:                  boltDB,
+		db:                  &swappableDB{current: boltDB},
 		databasePath:        dirPath,
 		blockCache:          blockCache,
 		validatorIndexCache: validatorCache,
@@ -105,6 +100,13 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			stateSummaryBucket,
 			archivedIndexRootBucket,
 			slotsHasObjectBucket,
+			// Operation pools snapshot buckets.
+			poolAttestationsBucket,
+			poolVoluntaryExitsBucket,
+			poolProposerSlashingsBucket,
+			poolAttesterSlashingsBucket,
+			peerScoresBucket,
+			peerRecordsBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -121,7 +123,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 		return nil, err
 	}
 
-	err = prometheus.Register(createBoltCollector(kv.db))
+	err = prometheus.Register(createBoltCollector(kv.db.current))
 
 	return kv, err
 }
@@ -131,13 +133,13 @@ func (k *Store) ClearDB() error {
 	if _, err := os.Stat(k.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(k.db))
+	prometheus.Unregister(createBoltCollector(k.db.current))
 	return os.Remove(path.Join(k.databasePath, databaseFileName))
 }
 
 // Close closes the underlying BoltDB database.
 func (k *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(k.db))
+	prometheus.Unregister(createBoltCollector(k.db.current))
 	return k.db.Close()
 }
 
@@ -146,6 +148,19 @@ func (k *Store) DatabasePath() string {
 	return k.databasePath
 }
 
+// openBoltDB opens the bolt database file with the options used by the beacon node.
+func openBoltDB(datafile string) (*bolt.DB, error) {
+	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, InitialMmapSize: 10e6})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	boltDB.AllocSize = boltAllocSize
+	return boltDB, nil
+}
+
 func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
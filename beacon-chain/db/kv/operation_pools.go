@@ -0,0 +1,124 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveOperationPools replaces the snapshot of the operation pools stored in the db. Each
+// operation is stored by its hash tree root in the bucket of its kind.
+func (k *Store) SaveOperationPools(ctx context.Context, pools *iface.OperationPools) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOperationPools")
+	defer span.End()
+
+	atts := make([]proto.Message, len(pools.Attestations))
+	for i, att := range pools.Attestations {
+		atts[i] = att
+	}
+	exits := make([]proto.Message, len(pools.VoluntaryExits))
+	for i, exit := range pools.VoluntaryExits {
+		exits[i] = exit
+	}
+	proposerSlashings := make([]proto.Message, len(pools.ProposerSlashings))
+	for i, slashing := range pools.ProposerSlashings {
+		proposerSlashings[i] = slashing
+	}
+	attesterSlashings := make([]proto.Message, len(pools.AttesterSlashings))
+	for i, slashing := range pools.AttesterSlashings {
+		attesterSlashings[i] = slashing
+	}
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		if err := replaceBucket(tx, poolAttestationsBucket, atts); err != nil {
+			return err
+		}
+		if err := replaceBucket(tx, poolVoluntaryExitsBucket, exits); err != nil {
+			return err
+		}
+		if err := replaceBucket(tx, poolProposerSlashingsBucket, proposerSlashings); err != nil {
+			return err
+		}
+		return replaceBucket(tx, poolAttesterSlashingsBucket, attesterSlashings)
+	})
+}
+
+// OperationPools retrieves the snapshot of the operation pools stored in the db. The snapshot
+// is empty if the pools were never saved.
+func (k *Store) OperationPools(ctx context.Context) (*iface.OperationPools, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.OperationPools")
+	defer span.End()
+
+	pools := &iface.OperationPools{}
+	err := k.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(poolAttestationsBucket).ForEach(func(_, enc []byte) error {
+			att := &ethpb.Attestation{}
+			if err := decode(enc, att); err != nil {
+				return err
+			}
+			pools.Attestations = append(pools.Attestations, att)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if err := tx.Bucket(poolVoluntaryExitsBucket).ForEach(func(_, enc []byte) error {
+			exit := &ethpb.SignedVoluntaryExit{}
+			if err := decode(enc, exit); err != nil {
+				return err
+			}
+			pools.VoluntaryExits = append(pools.VoluntaryExits, exit)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if err := tx.Bucket(poolProposerSlashingsBucket).ForEach(func(_, enc []byte) error {
+			slashing := &ethpb.ProposerSlashing{}
+			if err := decode(enc, slashing); err != nil {
+				return err
+			}
+			pools.ProposerSlashings = append(pools.ProposerSlashings, slashing)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(poolAttesterSlashingsBucket).ForEach(func(_, enc []byte) error {
+			slashing := &ethpb.AttesterSlashing{}
+			if err := decode(enc, slashing); err != nil {
+				return err
+			}
+			pools.AttesterSlashings = append(pools.AttesterSlashings, slashing)
+			return nil
+		})
+	})
+	return pools, err
+}
+
+// replaceBucket empties a bucket and stores the given messages by their hash tree root.
+func replaceBucket(tx *bolt.Tx, bucket []byte, msgs []proto.Message) error {
+	if err := tx.DeleteBucket(bucket); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	bkt, err := tx.CreateBucket(bucket)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		root, err := ssz.HashTreeRoot(msg)
+		if err != nil {
+			return err
+		}
+		enc, err := encode(msg)
+		if err != nil {
+			return err
+		}
+		if err := bkt.Put(root[:], enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+)
+
+func TestStore_OperationPools_SaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	pools, err := db.OperationPools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.Attestations) != 0 || len(pools.VoluntaryExits) != 0 ||
+		len(pools.ProposerSlashings) != 0 || len(pools.AttesterSlashings) != 0 {
+		t.Fatalf("Expected empty operation pools, received %v", pools)
+	}
+
+	header := func(slot uint64) *ethpb.SignedBeaconBlockHeader {
+		return &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: slot, ProposerIndex: 3},
+			Signature: make([]byte, 96),
+		}
+	}
+	indexed := func(epoch uint64) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1, 2},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: epoch},
+				Target: &ethpb.Checkpoint{Epoch: epoch + 1},
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+	saved := &iface.OperationPools{
+		Attestations: []*ethpb.Attestation{
+			{
+				Data:            &ethpb.AttestationData{Slot: 5, Source: &ethpb.Checkpoint{}, Target: &ethpb.Checkpoint{}},
+				AggregationBits: bitfield.Bitlist{0b1101},
+				Signature:       make([]byte, 96),
+			},
+		},
+		VoluntaryExits: []*ethpb.SignedVoluntaryExit{
+			{Exit: &ethpb.VoluntaryExit{Epoch: 2, ValidatorIndex: 4}, Signature: make([]byte, 96)},
+		},
+		ProposerSlashings: []*ethpb.ProposerSlashing{
+			{Header_1: header(1), Header_2: header(2)},
+		},
+		AttesterSlashings: []*ethpb.AttesterSlashing{
+			{Attestation_1: indexed(1), Attestation_2: indexed(2)},
+		},
+	}
+	if err := db.SaveOperationPools(ctx, saved); err != nil {
+		t.Fatal(err)
+	}
+	pools, err = db.OperationPools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.Attestations) != 1 || !proto.Equal(pools.Attestations[0], saved.Attestations[0]) {
+		t.Errorf("Wanted attestations %v, received %v", saved.Attestations, pools.Attestations)
+	}
+	if len(pools.VoluntaryExits) != 1 || !proto.Equal(pools.VoluntaryExits[0], saved.VoluntaryExits[0]) {
+		t.Errorf("Wanted voluntary exits %v, received %v", saved.VoluntaryExits, pools.VoluntaryExits)
+	}
+	if len(pools.ProposerSlashings) != 1 || !proto.Equal(pools.ProposerSlashings[0], saved.ProposerSlashings[0]) {
+		t.Errorf("Wanted proposer slashings %v, received %v", saved.ProposerSlashings, pools.ProposerSlashings)
+	}
+	if len(pools.AttesterSlashings) != 1 || !proto.Equal(pools.AttesterSlashings[0], saved.AttesterSlashings[0]) {
+		t.Errorf("Wanted attester slashings %v, received %v", saved.AttesterSlashings, pools.AttesterSlashings)
+	}
+
+	// A new snapshot replaces the previous one.
+	if err := db.SaveOperationPools(ctx, &iface.OperationPools{VoluntaryExits: saved.VoluntaryExits}); err != nil {
+		t.Fatal(err)
+	}
+	pools, err = db.OperationPools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.Attestations) != 0 || len(pools.VoluntaryExits) != 1 ||
+		len(pools.ProposerSlashings) != 0 || len(pools.AttesterSlashings) != 0 {
+		t.Errorf("Expected only the voluntary exit to be kept, received %v", pools)
+	}
+}
@@ -0,0 +1,97 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveOrigin saves the block and state a node started from a checkpoint begins its chain with,
+// in a single transaction. The block is recorded as the origin block root, the head, the last
+// archived point, and the root of the given justified and finalized checkpoint.
+func (k *Store) SaveOrigin(ctx context.Context, signed *ethpb.SignedBeaconBlock, st *state.BeaconState, checkpoint *ethpb.Checkpoint) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOrigin")
+	defer span.End()
+	if signed == nil || signed.Block == nil {
+		return errors.New("nil origin block")
+	}
+	if st == nil {
+		return errors.New("nil origin state")
+	}
+	blockRoot, err := stateutil.BlockRoot(signed.Block)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil || bytesutil.ToBytes32(checkpoint.Root) != blockRoot {
+		return errors.New("checkpoint root is not the origin block root")
+	}
+	encBlock, err := encode(signed)
+	if err != nil {
+		return err
+	}
+	encState, err := encode(st.InnerStateUnsafe())
+	if err != nil {
+		return err
+	}
+	slot := signed.Block.Slot
+	encSummary, err := encode(&pb.StateSummary{Slot: slot, Root: blockRoot[:]})
+	if err != nil {
+		return err
+	}
+	encCheckpoint, err := encode(checkpoint)
+	if err != nil {
+		return err
+	}
+	archivedIndex := bytesutil.Uint64ToBytes(slot / params.BeaconConfig().SlotsPerArchivedPoint)
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		if err := k.setBlockSlotBitField(ctx, tx, slot); err != nil {
+			return err
+		}
+		if err := updateValueForIndices(createBlockIndicesFromBlock(signed.Block), blockRoot[:], tx); err != nil {
+			return errors.Wrap(err, "could not update DB indices")
+		}
+		blocks := tx.Bucket(blocksBucket)
+		if err := blocks.Put(blockRoot[:], encBlock); err != nil {
+			return err
+		}
+		if err := blocks.Put(originBlockRootKey, blockRoot[:]); err != nil {
+			return err
+		}
+		if err := blocks.Put(headBlockRootKey, blockRoot[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSummaryBucket).Put(blockRoot[:], encSummary); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateBucket).Put(blockRoot[:], encState); err != nil {
+			return err
+		}
+		if err := k.setStateSlotBitField(ctx, tx, st.Slot()); err != nil {
+			return err
+		}
+		archived := tx.Bucket(archivedIndexRootBucket)
+		if err := archived.Put(archivedIndex, blockRoot[:]); err != nil {
+			return err
+		}
+		if err := archived.Put(lastArchivedIndexKey, archivedIndex); err != nil {
+			return err
+		}
+		checkpoints := tx.Bucket(checkpointBucket)
+		if err := checkpoints.Put(justifiedCheckpointKey, encCheckpoint); err != nil {
+			return err
+		}
+		if err := checkpoints.Put(finalizedCheckpointKey, encCheckpoint); err != nil {
+			return err
+		}
+		return k.updateFinalizedBlockRoots(ctx, tx, checkpoint)
+	})
+}
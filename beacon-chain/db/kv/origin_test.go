@@ -0,0 +1,87 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStore_SaveOrigin(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	slot := 2*params.BeaconConfig().SlotsPerEpoch - 1
+	st := testutil.NewBeaconState()
+	if err := st.SetSlot(slot); err != nil {
+		t.Fatal(err)
+	}
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	root, err := stateutil.BlockRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := &ethpb.Checkpoint{Epoch: 2, Root: root[:]}
+
+	if err := db.SaveOrigin(ctx, blk, st, &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)}); err == nil {
+		t.Error("Expected an error for a checkpoint of another block")
+	}
+	if db.HasBlock(ctx, root) {
+		t.Error("Expected nothing to be saved for a rejected origin")
+	}
+	if err := db.SaveOrigin(ctx, blk, st, cp); err != nil {
+		t.Fatal(err)
+	}
+
+	originRoot, err := db.OriginBlockRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if originRoot != root {
+		t.Errorf("Expected origin root %#x, received %#x", root, originRoot)
+	}
+	head, err := db.HeadBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head == nil || !proto.Equal(head, blk) {
+		t.Errorf("Expected origin block as head, received %v", head)
+	}
+	headState, err := db.HeadState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headState == nil || headState.Slot() != slot {
+		t.Errorf("Expected origin state as head state, received %v", headState)
+	}
+	if !db.HasStateSummary(ctx, root) {
+		t.Error("Expected origin state summary to be saved")
+	}
+	blocks, err := db.HighestSlotBlocks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || blocks[0].Block.Slot != slot {
+		t.Errorf("Expected origin block to be the highest slot block, received %v", blocks)
+	}
+	for _, get := range []func(context.Context) (*ethpb.Checkpoint, error){db.JustifiedCheckpoint, db.FinalizedCheckpoint} {
+		saved, err := get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(saved, cp) {
+			t.Errorf("Expected checkpoint %v, received %v", cp, saved)
+		}
+	}
+	if !db.IsFinalizedBlock(ctx, root) {
+		t.Error("Expected origin block to be finalized")
+	}
+	if db.LastArchivedIndexRoot(ctx) != root {
+		t.Error("Expected origin block to be the last archived point")
+	}
+}
@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"context"
+
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SavePeerRecords replaces the records of the known peers stored in the db. Each record is
+// stored by the ID of its peer.
+func (k *Store) SavePeerRecords(ctx context.Context, records []*dbpb.PeerRecord) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePeerRecords")
+	defer span.End()
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(peerRecordsBucket); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		bkt, err := tx.CreateBucket(peerRecordsBucket)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			enc, err := encode(record)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put([]byte(record.PeerId), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PeerRecords retrieves the records of the known peers stored in the db.
+func (k *Store) PeerRecords(ctx context.Context) ([]*dbpb.PeerRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PeerRecords")
+	defer span.End()
+
+	var records []*dbpb.PeerRecord
+	err := k.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(peerRecordsBucket).ForEach(func(_, enc []byte) error {
+			record := &dbpb.PeerRecord{}
+			if err := decode(enc, record); err != nil {
+				return err
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	return records, err
+}
@@ -0,0 +1,64 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestStore_PeerRecords_SaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	records, err := db.PeerRecords(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("Expected no peer records, received %v", records)
+	}
+
+	saved := []*dbpb.PeerRecord{
+		{
+			PeerId:     "peer1",
+			Address:    "/ip4/127.0.0.1/tcp/13000",
+			ChainState: &pb.Status{HeadSlot: 64, FinalizedEpoch: 1},
+			LastSeen:   1000,
+		},
+		{
+			PeerId:   "peer2",
+			Enr:      []byte{'e', 'n', 'r'},
+			LastSeen: 2000,
+		},
+	}
+	if err := db.SavePeerRecords(ctx, saved); err != nil {
+		t.Fatal(err)
+	}
+	records, err = db.PeerRecords(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(saved) {
+		t.Fatalf("Expected %d peer records, received %d", len(saved), len(records))
+	}
+	for i := range saved {
+		if !proto.Equal(records[i], saved[i]) {
+			t.Errorf("Wanted %v, received %v", saved[i], records[i])
+		}
+	}
+
+	// Saving replaces the previous records.
+	if err := db.SavePeerRecords(ctx, saved[1:]); err != nil {
+		t.Fatal(err)
+	}
+	records, err = db.PeerRecords(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || !proto.Equal(records[0], saved[1]) {
+		t.Errorf("Expected only %v, received %v", saved[1], records)
+	}
+}
@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"context"
+
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SavePeerScores replaces the scores of the peers stored in the db. Each score is stored
+// by the ID of its peer.
+func (k *Store) SavePeerScores(ctx context.Context, scores []*dbpb.PeerScore) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePeerScores")
+	defer span.End()
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(peerScoresBucket); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		bkt, err := tx.CreateBucket(peerScoresBucket)
+		if err != nil {
+			return err
+		}
+		for _, score := range scores {
+			enc, err := encode(score)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put([]byte(score.PeerId), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PeerScores retrieves the scores of the peers stored in the db.
+func (k *Store) PeerScores(ctx context.Context) ([]*dbpb.PeerScore, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PeerScores")
+	defer span.End()
+
+	var scores []*dbpb.PeerScore
+	err := k.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(peerScoresBucket).ForEach(func(_, enc []byte) error {
+			score := &dbpb.PeerScore{}
+			if err := decode(enc, score); err != nil {
+				return err
+			}
+			scores = append(scores, score)
+			return nil
+		})
+	})
+	return scores, err
+}
@@ -0,0 +1,54 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+)
+
+func TestStore_PeerScores_SaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	scores, err := db.PeerScores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 0 {
+		t.Fatalf("Expected no peer scores, received %v", scores)
+	}
+
+	saved := []*dbpb.PeerScore{
+		{PeerId: "peer1", BadResponses: 2.5, Uptime: 3600},
+		{PeerId: "peer2", InvalidGossip: 4, BannedUntil: 1000, DropReason: "invalid gossip"},
+	}
+	if err := db.SavePeerScores(ctx, saved); err != nil {
+		t.Fatal(err)
+	}
+	scores, err = db.PeerScores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != len(saved) {
+		t.Fatalf("Expected %d peer scores, received %d", len(saved), len(scores))
+	}
+	for i := range saved {
+		if !proto.Equal(scores[i], saved[i]) {
+			t.Errorf("Wanted %v, received %v", saved[i], scores[i])
+		}
+	}
+
+	// Saving replaces the previous scores.
+	if err := db.SavePeerScores(ctx, saved[1:]); err != nil {
+		t.Fatal(err)
+	}
+	scores, err = db.PeerScores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 1 || !proto.Equal(scores[0], saved[1]) {
+		t.Errorf("Expected only %v, received %v", saved[1], scores)
+	}
+}
@@ -0,0 +1,265 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Prune deletes the data below finalization the node does not need to follow the chain:
+// blocks that are not part of the finalized chain along with their states, attestations
+// and archived points older than the retention period, and state summaries that no
+// longer reference a block. The retention period is a number of epochs before the
+// finalized epoch. The genesis, origin, finalized and head data are always kept. Only the
+// blocks from the retention bound of the previous prune on are looked at.
+//
+// Pruning only deletes records, the freed pages are reused by bolt but the database file
+// does not shrink until it is compacted.
+func (k *Store) Prune(ctx context.Context, retentionEpochs uint64) (*iface.PruneStats, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Prune")
+	defer span.End()
+
+	stats := &iface.PruneStats{}
+	finalized, err := k.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	finalizedRoot := bytesutil.ToBytes32(finalized.Root)
+	finalizedBlock, err := k.Block(ctx, finalizedRoot)
+	if err != nil {
+		return nil, err
+	}
+	if finalized.Epoch == 0 || finalizedBlock == nil {
+		return stats, nil
+	}
+	retentionEpoch := uint64(0)
+	if finalized.Epoch > retentionEpochs {
+		retentionEpoch = finalized.Epoch - retentionEpochs
+	}
+
+	keep, err := k.protectedRoots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keep[finalizedRoot] = true
+	originSlot, err := k.originSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks below the finalized block which were not finalized are from forks that can
+	// never become canonical. Blocks below the origin were linked to it by backfill.
+	startSlot, err := k.lastPrunedSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if startSlot < originSlot {
+		startSlot = originSlot
+	}
+	endSlot := helpers.StartSlot(retentionEpoch)
+	if endSlot > finalizedBlock.Block.Slot {
+		endSlot = finalizedBlock.Block.Slot
+	}
+	var blockRoots [][32]byte
+	// An end slot of 0 does not bound the slot filter, and the genesis block is kept anyway.
+	if startSlot < endSlot && endSlot > 1 {
+		roots, err := k.BlockRoots(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot-1))
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range roots {
+			if keep[root] || k.IsFinalizedBlock(ctx, root) {
+				continue
+			}
+			blockRoots = append(blockRoots, root)
+		}
+	}
+
+	archivedRoots, archivedIndices, err := k.expiredArchivedPoints(ctx, helpers.StartSlot(retentionEpoch), keep)
+	if err != nil {
+		return nil, err
+	}
+
+	var stateRoots [][32]byte
+	for _, root := range append(blockRoots, archivedRoots...) {
+		if k.HasState(ctx, root) {
+			stateRoots = append(stateRoots, root)
+		}
+	}
+	if err := k.DeleteStates(ctx, stateRoots); err != nil {
+		return nil, errors.Wrap(err, "could not delete states")
+	}
+	if err := k.DeleteBlocks(ctx, blockRoots); err != nil {
+		return nil, errors.Wrap(err, "could not delete blocks")
+	}
+	stats.Blocks = len(blockRoots)
+
+	if err := k.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(archivedIndexRootBucket)
+		for _, index := range archivedIndices {
+			if err := bkt.Delete(bytesutil.Uint64ToBytes(index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not delete archived points")
+	}
+	stats.ArchivedPoints = len(archivedIndices)
+
+	if stats.StateSummaries, err = k.pruneOrphanedStateSummaries(ctx, finalizedBlock.Block.Slot, keep); err != nil {
+		return nil, errors.Wrap(err, "could not delete state summaries")
+	}
+	if stats.Attestations, err = k.pruneAttestations(ctx, retentionEpoch); err != nil {
+		return nil, errors.Wrap(err, "could not delete attestations")
+	}
+	if endSlot > startSlot {
+		if err := k.saveLastPrunedSlot(ctx, endSlot); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// lastPrunedSlot returns the slot up to which the blocks were pruned, 0 if they never were.
+func (k *Store) lastPrunedSlot(ctx context.Context) (uint64, error) {
+	var slot uint64
+	err := k.db.View(func(tx *bolt.Tx) error {
+		if enc := tx.Bucket(chainMetadataBucket).Get(lastPrunedSlotKey); enc != nil {
+			slot = binary.LittleEndian.Uint64(enc)
+		}
+		return nil
+	})
+	return slot, err
+}
+
+// saveLastPrunedSlot saves the slot up to which the blocks were pruned.
+func (k *Store) saveLastPrunedSlot(ctx context.Context, slot uint64) error {
+	return k.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(lastPrunedSlotKey, bytesutil.Uint64ToBytes(slot))
+	})
+}
+
+// protectedRoots returns the genesis, origin and head block roots, whose data must never be pruned.
+func (k *Store) protectedRoots(ctx context.Context) (map[[32]byte]bool, error) {
+	keep := make(map[[32]byte]bool)
+	err := k.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		for _, key := range [][]byte{genesisBlockRootKey, originBlockRootKey, headBlockRootKey} {
+			if root := bkt.Get(key); root != nil {
+				keep[bytesutil.ToBytes32(root)] = true
+			}
+		}
+		return nil
+	})
+	return keep, err
+}
+
+// originSlot returns the slot of the origin block of a node started from a checkpoint, 0 otherwise.
+func (k *Store) originSlot(ctx context.Context) (uint64, error) {
+	originRoot, err := k.OriginBlockRoot(ctx)
+	if err != nil || originRoot == params.BeaconConfig().ZeroHash {
+		return 0, err
+	}
+	origin, err := k.Block(ctx, originRoot)
+	if err != nil || origin == nil {
+		return 0, err
+	}
+	return origin.Block.Slot, nil
+}
+
+// expiredArchivedPoints returns the roots and indices of the archived points below the given
+// slot. The genesis archived point and the last archived point are never expired.
+func (k *Store) expiredArchivedPoints(ctx context.Context, slot uint64, keep map[[32]byte]bool) ([][32]byte, []uint64, error) {
+	var roots [][32]byte
+	var indices []uint64
+	err := k.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(archivedIndexRootBucket)
+		lastRoot := []byte{}
+		if lastIndex := bkt.Get(lastArchivedIndexKey); lastIndex != nil {
+			lastRoot = bkt.Get(lastIndex)
+		}
+		return bkt.ForEach(func(key []byte, root []byte) error {
+			if len(key) != 8 {
+				// Skip the last archived index key.
+				return nil
+			}
+			index := binary.LittleEndian.Uint64(key)
+			if index == 0 || index*params.BeaconConfig().SlotsPerArchivedPoint >= slot || bytes.Equal(root, lastRoot) {
+				return nil
+			}
+			indices = append(indices, index)
+			if r := bytesutil.ToBytes32(root); !keep[r] {
+				roots = append(roots, r)
+			}
+			return nil
+		})
+	})
+	return roots, indices, err
+}
+
+// pruneOrphanedStateSummaries deletes the state summaries below the given slot of blocks which
+// are not in the database.
+func (k *Store) pruneOrphanedStateSummaries(ctx context.Context, slot uint64, keep map[[32]byte]bool) (int, error) {
+	deleted := 0
+	err := k.db.Update(func(tx *bolt.Tx) error {
+		blocks := tx.Bucket(blocksBucket)
+		bkt := tx.Bucket(stateSummaryBucket)
+		var orphaned [][32]byte
+		if err := bkt.ForEach(func(root []byte, enc []byte) error {
+			r := bytesutil.ToBytes32(root)
+			if keep[r] || blocks.Get(root) != nil {
+				return nil
+			}
+			summary := &pb.StateSummary{}
+			if err := decode(enc, summary); err != nil {
+				return err
+			}
+			if summary.Slot < slot {
+				orphaned = append(orphaned, r)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, root := range orphaned {
+			if err := bkt.Delete(root[:]); err != nil {
+				return err
+			}
+		}
+		deleted = len(orphaned)
+		return nil
+	})
+	return deleted, err
+}
+
+// pruneAttestations deletes the attestations targeting an epoch before the given epoch.
+func (k *Store) pruneAttestations(ctx context.Context, epoch uint64) (int, error) {
+	var roots [][32]byte
+	if err := k.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(attestationsBucket).ForEach(func(root []byte, enc []byte) error {
+			ac := &dbpb.AttestationContainer{}
+			if err := decode(enc, ac); err != nil {
+				return err
+			}
+			if ac.Data != nil && ac.Data.Target != nil && ac.Data.Target.Epoch < epoch {
+				roots = append(roots, bytesutil.ToBytes32(root))
+			}
+			return nil
+		})
+	}); err != nil {
+		return 0, err
+	}
+	return len(roots), k.DeleteAttestations(ctx, roots)
+}
@@ -0,0 +1,304 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_Prune(t *testing.T) {
+	slotsPerEpoch := int(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	blks := makeBlocks(t, 0, slotsPerEpoch*2, genesisBlockRoot)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	finalizedRoot, err := stateutil.BlockRoot(blks[slotsPerEpoch].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := testutil.NewBeaconState()
+	if err := db.SaveState(ctx, st, finalizedRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A block of a fork below the finalized block, with its state.
+	parentRoot, err := stateutil.BlockRoot(blks[0].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 3, ParentRoot: parentRoot[:], ProposerIndex: 1}}
+	if err := db.SaveBlock(ctx, fork); err != nil {
+		t.Fatal(err)
+	}
+	forkRoot, err := stateutil.BlockRoot(fork.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, st, forkRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	// Summaries of blocks which are not in the database, below and above the finalized block.
+	orphaned := &pb.StateSummary{Slot: 2, Root: []byte{'a'}}
+	recent := &pb.StateSummary{Slot: uint64(slotsPerEpoch * 3), Root: []byte{'b'}}
+	if err := db.SaveStateSummaries(ctx, []*pb.StateSummary{orphaned, recent}); err != nil {
+		t.Fatal(err)
+	}
+
+	oldAtt := &ethpb.Attestation{
+		Data:            &ethpb.AttestationData{Slot: 1, Target: &ethpb.Checkpoint{Epoch: 0}},
+		AggregationBits: bitfield.Bitlist{0b00000001, 0b1},
+	}
+	newAtt := &ethpb.Attestation{
+		Data:            &ethpb.AttestationData{Slot: uint64(slotsPerEpoch), Target: &ethpb.Checkpoint{Epoch: 1}},
+		AggregationBits: bitfield.Bitlist{0b00000001, 0b1},
+	}
+	if err := db.SaveAttestations(ctx, []*ethpb.Attestation{oldAtt, newAtt}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := db.Prune(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Blocks != 1 || stats.StateSummaries != 1 || stats.Attestations != 1 {
+		t.Errorf("Unexpected prune stats %+v", stats)
+	}
+
+	if db.HasBlock(ctx, forkRoot) || db.HasState(ctx, forkRoot) {
+		t.Error("Expected the fork block and its state to be pruned")
+	}
+	for i, b := range blks {
+		root, err := stateutil.BlockRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !db.HasBlock(ctx, root) {
+			t.Errorf("Expected canonical block %d to be kept", i)
+		}
+	}
+	if !db.HasState(ctx, finalizedRoot) {
+		t.Error("Expected the finalized state to be kept")
+	}
+	if db.HasStateSummary(ctx, [32]byte{'a'}) {
+		t.Error("Expected the orphaned state summary to be pruned")
+	}
+	if !db.HasStateSummary(ctx, [32]byte{'b'}) {
+		t.Error("Expected the state summary above the finalized block to be kept")
+	}
+	oldRoot, err := ssz.HashTreeRoot(oldAtt.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRoot, err := ssz.HashTreeRoot(newAtt.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.HasAttestation(ctx, oldRoot) {
+		t.Error("Expected the attestation older than the retention to be pruned")
+	}
+	if !db.HasAttestation(ctx, newRoot) {
+		t.Error("Expected the attestation within the retention to be kept")
+	}
+
+	lastPruned, err := db.lastPrunedSlot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastPruned != uint64(slotsPerEpoch) {
+		t.Errorf("Expected the blocks to be pruned up to slot %d, received %d", slotsPerEpoch, lastPruned)
+	}
+	// The blocks below the last pruned slot are not looked at again.
+	fork.Block.ProposerIndex = 2
+	if err := db.SaveBlock(ctx, fork); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = db.Prune(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Blocks != 0 {
+		t.Errorf("Expected no block to be pruned, received %d", stats.Blocks)
+	}
+}
+
+func TestStore_PruneBeforeFinalization(t *testing.T) {
+	db := setupDB(t)
+	stats, err := db.Prune(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *stats != (iface.PruneStats{}) {
+		t.Errorf("Expected nothing to be pruned, received %+v", stats)
+	}
+}
+
+func TestStore_ExpiredArchivedPoints(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	for i := uint64(0); i < 4; i++ {
+		if err := db.SaveArchivedPointRoot(ctx, [32]byte{byte(i + 1)}, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.SaveLastArchivedIndex(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	keep := map[[32]byte]bool{{2}: true}
+	roots, indices, err := db.expiredArchivedPoints(ctx, 4*params.BeaconConfig().SlotsPerArchivedPoint, keep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The genesis and last archived points are never expired, and the state of a
+	// protected root is kept.
+	if len(indices) != 2 || indices[0] != 1 || indices[1] != 2 {
+		t.Errorf("Unexpected expired indices %v", indices)
+	}
+	if len(roots) != 1 || roots[0] != [32]byte{3} {
+		t.Errorf("Unexpected expired roots %v", roots)
+	}
+}
+
+func TestStore_Compact(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	blks := makeBlocks(t, 0, 64, genesisBlockRoot)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	st := testutil.NewBeaconState()
+	roots := make([][32]byte, len(blks))
+	var err error
+	for i, b := range blks {
+		roots[i], err = stateutil.BlockRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveState(ctx, st, roots[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.DeleteStates(ctx, roots[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.db.Update(func(tx *bolt.Tx) error {
+		nested, err := tx.Bucket(chainMetadataBucket).CreateBucket([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		return nested.Put([]byte("key"), []byte("value"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes made while the database is copied wait for the swap, so none of them is lost.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				db.HasBlock(ctx, roots[0])
+				if err := db.SaveHeadBlockRoot(ctx, roots[0]); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}
+	}()
+	reclaimed, err := db.Compact(ctx)
+	close(done)
+	wg.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reclaimed <= 0 {
+		t.Errorf("Expected bytes to be reclaimed, received %d", reclaimed)
+	}
+	if _, err := os.Stat(path.Join(db.databasePath, databaseFileName+compactFileSuffix)); !os.IsNotExist(err) {
+		t.Error("Expected the compacted file to be renamed")
+	}
+	for i, root := range roots {
+		if !db.HasBlock(ctx, root) {
+			t.Errorf("Expected block %d to be kept", i)
+		}
+	}
+	if err := db.db.View(func(tx *bolt.Tx) error {
+		nested := tx.Bucket(chainMetadataBucket).Bucket([]byte("nested"))
+		if nested == nil || !bytes.Equal(nested.Get([]byte("key")), []byte("value")) {
+			t.Error("Expected the nested bucket to be copied")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !db.HasState(ctx, roots[0]) {
+		t.Error("Expected the remaining state to be kept")
+	}
+	// The database is usable after the swap.
+	if err := db.SaveHeadBlockRoot(ctx, roots[0]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_Compact_RenameFailure(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	defer func(rename func(string, string) error) {
+		renameFile = rename
+	}(renameFile)
+	renameFile = func(string, string) error {
+		return errors.New("rename failed")
+	}
+
+	blks := makeBlocks(t, 0, 1, genesisBlockRoot)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateutil.BlockRoot(blks[0].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Compact(ctx); err == nil {
+		t.Fatal("Expected compaction to fail")
+	}
+	if _, err := os.Stat(path.Join(db.databasePath, databaseFileName+compactFileSuffix)); !os.IsNotExist(err) {
+		t.Error("Expected the compacted file to be removed")
+	}
+	// The original database is reopened.
+	if !db.HasBlock(ctx, root) {
+		t.Error("Expected the block to be kept")
+	}
+	if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+}
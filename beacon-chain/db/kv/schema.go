@@ -24,6 +24,18 @@ var (
 	archivedIndexRootBucket              = []byte("archived-index-root")
 	slotsHasObjectBucket                 = []byte("slots-has-objects")
 
+	// Operation pools snapshot buckets.
+	poolAttestationsBucket      = []byte("pool-attestations")
+	poolVoluntaryExitsBucket    = []byte("pool-voluntary-exits")
+	poolProposerSlashingsBucket = []byte("pool-proposer-slashings")
+	poolAttesterSlashingsBucket = []byte("pool-attester-slashings")
+
+	// Peer scores bucket.
+	peerScoresBucket = []byte("peer-scores")
+
+	// Known peers bucket.
+	peerRecordsBucket = []byte("peer-records")
+
 	// Key indices buckets.
 	blockParentRootIndicesBucket        = []byte("block-parent-root-indices")
 	blockSlotIndicesBucket              = []byte("block-slot-indices")
@@ -37,6 +49,7 @@ var (
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
 	genesisBlockRootKey       = []byte("genesis-root")
+	originBlockRootKey        = []byte("origin-root")
 	depositContractAddressKey = []byte("deposit-contract")
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
@@ -44,6 +57,7 @@ var (
 	lastArchivedIndexKey      = []byte("last-archived")
 	savedBlockSlotsKey        = []byte("saved-block-slots")
 	savedStateSlotsKey        = []byte("saved-state-slots")
+	lastPrunedSlotKey         = []byte("last-pruned-slot")
 
 	// New state management service compatibility bucket.
 	newStateServiceCompatibleBucket = []byte("new-state-compatible")
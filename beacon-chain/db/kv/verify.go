@@ -0,0 +1,363 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Verify walks the blocks, state summaries, states, finalized block roots index, archived
+// points and checkpoints of the database and reports the records which are inconsistent
+// with each other. Every state is hashed, so verifying a large database takes a while.
+func (k *Store) Verify(ctx context.Context) (*iface.IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Verify")
+	defer span.End()
+
+	report := &iface.IntegrityReport{Issues: []*iface.IntegrityIssue{}}
+	err := k.db.View(func(tx *bolt.Tx) error {
+		v := &verifier{
+			tx:     tx,
+			report: report,
+			blocks: make(map[[32]byte]*blockInfo),
+		}
+		checks := []func() error{
+			v.verifyBlocks,
+			v.verifyStateSummaries,
+			v.verifyStates,
+			v.verifyFinalizedBlockRoots,
+			v.verifyArchivedPoints,
+			v.verifyCheckpoints,
+		}
+		for _, check := range checks {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := check(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].Bucket != report.Issues[j].Bucket {
+			return report.Issues[i].Bucket < report.Issues[j].Bucket
+		}
+		return report.Issues[i].Key < report.Issues[j].Key
+	})
+	return report, nil
+}
+
+// blockInfo is the part of a block the integrity checks need, to avoid keeping every block
+// in memory.
+type blockInfo struct {
+	slot       uint64
+	parentRoot [32]byte
+	stateRoot  [32]byte
+}
+
+type verifier struct {
+	tx          *bolt.Tx
+	report      *iface.IntegrityReport
+	blocks      map[[32]byte]*blockInfo
+	genesisRoot []byte
+	originRoot  []byte
+}
+
+func (v *verifier) issue(bucket []byte, key string, format string, args ...interface{}) {
+	v.report.Issues = append(v.report.Issues, &iface.IntegrityIssue{
+		Bucket:  string(bucket),
+		Key:     key,
+		Problem: fmt.Sprintf(format, args...),
+	})
+}
+
+// verifyBlocks checks that every block is stored under its root and that its parent is in
+// the database, and that the genesis, origin and head roots reference a block.
+func (v *verifier) verifyBlocks() error {
+	bkt := v.tx.Bucket(blocksBucket)
+	v.genesisRoot = bkt.Get(genesisBlockRootKey)
+	v.originRoot = bkt.Get(originBlockRootKey)
+
+	if err := bkt.ForEach(func(key []byte, enc []byte) error {
+		if len(key) != 32 {
+			// Skip the genesis, origin and head root keys.
+			return nil
+		}
+		v.report.Blocks++
+		signed := &ethpb.SignedBeaconBlock{}
+		if err := decode(enc, signed); err != nil {
+			v.issue(blocksBucket, fmt.Sprintf("%#x", key), "could not decode block: %v", err)
+			return nil
+		}
+		if signed.Block == nil {
+			v.issue(blocksBucket, fmt.Sprintf("%#x", key), "block is empty")
+			return nil
+		}
+		root, err := stateutil.BlockRoot(signed.Block)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(root[:], key) {
+			v.issue(blocksBucket, fmt.Sprintf("%#x", key), "block hashes to %#x", root)
+		}
+		v.blocks[bytesutil.ToBytes32(key)] = &blockInfo{
+			slot:       signed.Block.Slot,
+			parentRoot: bytesutil.ToBytes32(signed.Block.ParentRoot),
+			stateRoot:  bytesutil.ToBytes32(signed.Block.StateRoot),
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// The lowest block of a node started from a checkpoint which did not backfill all the
+	// way to genesis yet has no parent in the database.
+	hasGenesis := v.genesisRoot != nil && v.blocks[bytesutil.ToBytes32(v.genesisRoot)] != nil
+	lowestSlot := ^uint64(0)
+	for _, info := range v.blocks {
+		if info.slot < lowestSlot {
+			lowestSlot = info.slot
+		}
+	}
+	for root, info := range v.blocks {
+		if bytes.Equal(root[:], v.genesisRoot) || bytes.Equal(root[:], v.originRoot) {
+			continue
+		}
+		parent, ok := v.blocks[info.parentRoot]
+		if !ok {
+			if hasGenesis || info.slot != lowestSlot {
+				v.issue(blocksBucket, fmt.Sprintf("%#x", root), "parent block %#x is missing", info.parentRoot)
+			}
+			continue
+		}
+		if parent.slot >= info.slot {
+			v.issue(blocksBucket, fmt.Sprintf("%#x", root), "parent block %#x is at slot %d, not below slot %d",
+				info.parentRoot, parent.slot, info.slot)
+		}
+	}
+
+	for _, key := range [][]byte{genesisBlockRootKey, originBlockRootKey, headBlockRootKey} {
+		root := bkt.Get(key)
+		if root == nil {
+			continue
+		}
+		if _, ok := v.blocks[bytesutil.ToBytes32(root)]; !ok {
+			v.issue(blocksBucket, string(key), "block %#x is missing", root)
+		}
+	}
+	return nil
+}
+
+// verifyStateSummaries checks that every state summary references a block of the same slot.
+func (v *verifier) verifyStateSummaries() error {
+	return v.tx.Bucket(stateSummaryBucket).ForEach(func(key []byte, enc []byte) error {
+		v.report.StateSummaries++
+		summary := &pb.StateSummary{}
+		if err := decode(enc, summary); err != nil {
+			v.issue(stateSummaryBucket, fmt.Sprintf("%#x", key), "could not decode state summary: %v", err)
+			return nil
+		}
+		if !bytes.Equal(summary.Root, key) {
+			v.issue(stateSummaryBucket, fmt.Sprintf("%#x", key), "state summary is of root %#x", summary.Root)
+		}
+		info, ok := v.blocks[bytesutil.ToBytes32(key)]
+		if !ok {
+			v.issue(stateSummaryBucket, fmt.Sprintf("%#x", key), "block is missing")
+			return nil
+		}
+		if info.slot != summary.Slot {
+			v.issue(stateSummaryBucket, fmt.Sprintf("%#x", key), "state summary slot %d differs from block slot %d",
+				summary.Slot, info.slot)
+		}
+		return nil
+	})
+}
+
+// verifyStates checks that every state is stored under the root of a block, and that the
+// states at the slot of their block hash to the state root of the block. The states of
+// blocks followed by empty slots may have been advanced past the block, and cannot be checked.
+func (v *verifier) verifyStates() error {
+	return v.tx.Bucket(stateBucket).ForEach(func(key []byte, enc []byte) error {
+		v.report.States++
+		st, err := createState(enc)
+		if err != nil {
+			v.issue(stateBucket, fmt.Sprintf("%#x", key), "could not decode state: %v", err)
+			return nil
+		}
+		info, ok := v.blocks[bytesutil.ToBytes32(key)]
+		if !ok {
+			v.issue(stateBucket, fmt.Sprintf("%#x", key), "block is missing")
+			return nil
+		}
+		if st.Slot < info.slot {
+			v.issue(stateBucket, fmt.Sprintf("%#x", key), "state slot %d is below block slot %d", st.Slot, info.slot)
+			return nil
+		}
+		if st.Slot != info.slot {
+			return nil
+		}
+		root, err := stateutil.HashTreeRootState(st)
+		if err != nil {
+			return err
+		}
+		if root != info.stateRoot {
+			v.issue(stateBucket, fmt.Sprintf("%#x", key), "state hashes to %#x, block state root is %#x", root, info.stateRoot)
+		}
+		return nil
+	})
+}
+
+// verifyFinalizedBlockRoots checks that the finalized block roots index forms a chain from
+// the finalized checkpoint down to genesis, or to the origin of a node started from a
+// checkpoint, and holds nothing else but the blocks of the finalized epoch.
+func (v *verifier) verifyFinalizedBlockRoots() error {
+	bkt := v.tx.Bucket(finalizedBlockRootsIndexBucket)
+	index := make(map[[32]byte]*dbpb.FinalizedBlockRootContainer)
+	if err := bkt.ForEach(func(key []byte, enc []byte) error {
+		if len(key) != 32 {
+			// Skip the previous finalized checkpoint key.
+			return nil
+		}
+		v.report.FinalizedRoots++
+		if bytes.Equal(enc, containerFinalizedButNotCanonical) {
+			// Blocks of the finalized epoch are indexed without a container.
+			if _, ok := v.blocks[bytesutil.ToBytes32(key)]; !ok {
+				v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", key), "block is missing")
+			}
+			return nil
+		}
+		container := &dbpb.FinalizedBlockRootContainer{}
+		if err := decode(enc, container); err != nil {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", key), "could not decode container: %v", err)
+			return nil
+		}
+		root := bytesutil.ToBytes32(key)
+		index[root] = container
+		info, ok := v.blocks[root]
+		if !ok {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", key), "block is missing")
+		} else if info.parentRoot != bytesutil.ToBytes32(container.ParentRoot) {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", key), "parent root %#x differs from block parent root %#x",
+				container.ParentRoot, info.parentRoot)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	finalized := &ethpb.Checkpoint{}
+	if enc := v.tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey); enc != nil {
+		if err := decode(enc, finalized); err != nil {
+			// Reported by the checkpoints check.
+			return nil
+		}
+	}
+	visited := make(map[[32]byte]bool)
+	root := bytesutil.ToBytes32(finalized.Root)
+	for root != params.BeaconConfig().ZeroHash && !bytes.Equal(root[:], v.genesisRoot) {
+		container, ok := index[root]
+		if !ok {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", root), "finalized chain is broken, root is not indexed")
+			break
+		}
+		visited[root] = true
+		if bytes.Equal(root[:], v.originRoot) {
+			break
+		}
+		parentRoot := bytesutil.ToBytes32(container.ParentRoot)
+		if parent, ok := index[parentRoot]; ok && len(parent.ChildRoot) > 0 && !bytes.Equal(parent.ChildRoot, root[:]) {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", parentRoot), "child root %#x differs from finalized child %#x",
+				parent.ChildRoot, root)
+		}
+		if visited[parentRoot] {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", parentRoot), "finalized chain has a cycle")
+			break
+		}
+		root = parentRoot
+	}
+	for root := range index {
+		if !visited[root] {
+			v.issue(finalizedBlockRootsIndexBucket, fmt.Sprintf("%#x", root), "root is not in the finalized chain")
+		}
+	}
+	return nil
+}
+
+// verifyArchivedPoints checks that the block and state of every archived point are in the
+// database, and that the last archived index references an archived point.
+func (v *verifier) verifyArchivedPoints() error {
+	bkt := v.tx.Bucket(archivedIndexRootBucket)
+	states := v.tx.Bucket(stateBucket)
+	if err := bkt.ForEach(func(key []byte, root []byte) error {
+		if len(key) != 8 {
+			// Skip the last archived index key.
+			return nil
+		}
+		v.report.ArchivedPoints++
+		index := binary.LittleEndian.Uint64(key)
+		if _, ok := v.blocks[bytesutil.ToBytes32(root)]; !ok {
+			v.issue(archivedIndexRootBucket, fmt.Sprintf("%d", index), "block %#x is missing", root)
+		}
+		if states.Get(root) == nil {
+			v.issue(archivedIndexRootBucket, fmt.Sprintf("%d", index), "state %#x is missing", root)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if lastIndex := bkt.Get(lastArchivedIndexKey); lastIndex != nil && bkt.Get(lastIndex) == nil {
+		v.issue(archivedIndexRootBucket, string(lastArchivedIndexKey), "archived point %d is missing",
+			binary.LittleEndian.Uint64(lastIndex))
+	}
+	return nil
+}
+
+// verifyCheckpoints checks that the justified and finalized checkpoints reference a block
+// with a state or state summary, and that finalization is not ahead of justification.
+func (v *verifier) verifyCheckpoints() error {
+	bkt := v.tx.Bucket(checkpointBucket)
+	checkpoints := make(map[string]*ethpb.Checkpoint)
+	for _, key := range [][]byte{justifiedCheckpointKey, finalizedCheckpointKey} {
+		enc := bkt.Get(key)
+		if enc == nil {
+			continue
+		}
+		cp := &ethpb.Checkpoint{}
+		if err := decode(enc, cp); err != nil {
+			v.issue(checkpointBucket, string(key), "could not decode checkpoint: %v", err)
+			continue
+		}
+		checkpoints[string(key)] = cp
+		root := bytesutil.ToBytes32(cp.Root)
+		if root == params.BeaconConfig().ZeroHash || bytes.Equal(cp.Root, v.genesisRoot) {
+			continue
+		}
+		if _, ok := v.blocks[root]; !ok {
+			v.issue(checkpointBucket, string(key), "block %#x is missing", cp.Root)
+		}
+		if v.tx.Bucket(stateBucket).Get(cp.Root) == nil && v.tx.Bucket(stateSummaryBucket).Get(cp.Root) == nil {
+			v.issue(checkpointBucket, string(key), "state and state summary %#x are missing", cp.Root)
+		}
+	}
+	justified, finalized := checkpoints[string(justifiedCheckpointKey)], checkpoints[string(finalizedCheckpointKey)]
+	if justified != nil && finalized != nil && finalized.Epoch > justified.Epoch {
+		v.issue(checkpointBucket, string(finalizedCheckpointKey), "finalized epoch %d is after justified epoch %d",
+			finalized.Epoch, justified.Epoch)
+	}
+	return nil
+}
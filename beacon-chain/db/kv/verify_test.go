@@ -0,0 +1,154 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+// setupConsistentDB saves a chain of blocks from genesis, finalized at the block of slot
+// slotsPerEpoch, with the states of the genesis and finalized blocks. It returns the block
+// roots, indexed by slot.
+func setupConsistentDB(t *testing.T, db *Store) [][32]byte {
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	st := testutil.NewBeaconState()
+
+	roots := make([][32]byte, 2*slotsPerEpoch+1)
+	parentRoot := params.BeaconConfig().ZeroHash
+	for slot := uint64(0); slot <= 2*slotsPerEpoch; slot++ {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		var blkState *state.BeaconState
+		if slot == 0 || slot == slotsPerEpoch {
+			if err := st.SetSlot(slot); err != nil {
+				t.Fatal(err)
+			}
+			stateRoot, err := st.HashTreeRoot(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			blk.Block.StateRoot = stateRoot[:]
+			blkState = st.Copy()
+		}
+		root, err := stateutil.BlockRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		if blkState != nil {
+			if err := db.SaveState(ctx, blkState, root); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: slot, Root: root[:]}); err != nil {
+			t.Fatal(err)
+		}
+		roots[slot] = root
+		parentRoot = root
+	}
+
+	if err := db.SaveGenesisBlockRoot(ctx, roots[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveHeadBlockRoot(ctx, roots[2*slotsPerEpoch]); err != nil {
+		t.Fatal(err)
+	}
+	cp := &ethpb.Checkpoint{Epoch: 1, Root: roots[slotsPerEpoch][:]}
+	if err := db.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveArchivedPointRoot(ctx, roots[0], 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveLastArchivedIndex(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	return roots
+}
+
+func TestStore_VerifyConsistentDatabase(t *testing.T) {
+	db := setupDB(t)
+	roots := setupConsistentDB(t, db)
+
+	report, err := db.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range report.Issues {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if report.Blocks != len(roots) || report.StateSummaries != len(roots) || report.States != 2 {
+		t.Errorf("Unexpected counts %+v", report)
+	}
+	if report.FinalizedRoots == 0 || report.ArchivedPoints != 1 {
+		t.Errorf("Unexpected counts %+v", report)
+	}
+}
+
+func TestStore_VerifyReportsIssues(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	roots := setupConsistentDB(t, db)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	// A block whose parent is missing.
+	orphan := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 3, ParentRoot: []byte{'a'}}}
+	if err := db.SaveBlock(ctx, orphan); err != nil {
+		t.Fatal(err)
+	}
+	// A state summary of a missing block.
+	if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 3, Root: []byte{'b'}}); err != nil {
+		t.Fatal(err)
+	}
+	// A state not matching the state root of its block.
+	st := testutil.NewBeaconState()
+	if err := st.SetSlot(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, st, roots[2]); err != nil {
+		t.Fatal(err)
+	}
+	// An archived point without a state.
+	if err := db.SaveArchivedPointRoot(ctx, roots[3], 1); err != nil {
+		t.Fatal(err)
+	}
+	// A finalized chain missing a block root.
+	if err := db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(finalizedBlockRootsIndexBucket).Delete(roots[slotsPerEpoch-1][:])
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := db.Verify(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems := make(map[string]int)
+	for _, issue := range report.Issues {
+		problems[issue.Bucket]++
+	}
+	wanted := map[string]int{
+		string(blocksBucket):                   1,
+		string(stateSummaryBucket):             1,
+		string(stateBucket):                    1,
+		string(archivedIndexRootBucket):        1,
+		string(finalizedBlockRootsIndexBucket): 1,
+	}
+	for bucket, count := range wanted {
+		if problems[bucket] < count {
+			t.Errorf("Expected an issue in bucket %s, received %+v", bucket, report.Issues)
+		}
+	}
+}
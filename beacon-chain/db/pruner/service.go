@@ -0,0 +1,161 @@
+// Package pruner deletes the data of the beacon chain database the node no longer
+// needs once it is finalized, and compacts the database file when the node starts.
+package pruner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/sirupsen/logrus"
+)
+
+// pruneInterval is the number of finalized epochs between two prunes of the database.
+const pruneInterval = 32
+
+// Service prunes the database as the chain is finalized.
+type Service struct {
+	ctx             context.Context
+	cancel          context.CancelFunc
+	beaconDB        db.Database
+	stateNotifier   statefeed.Notifier
+	retentionEpochs uint64
+	lastPrunedEpoch uint64
+}
+
+// Config options for the pruner service.
+type Config struct {
+	BeaconDB        db.Database
+	StateNotifier   statefeed.Notifier
+	RetentionEpochs uint64
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:             ctx,
+		cancel:          cancel,
+		beaconDB:        cfg.BeaconDB,
+		stateNotifier:   cfg.StateNotifier,
+		retentionEpochs: cfg.RetentionEpochs,
+	}
+}
+
+// Start the pruner service event loop.
+func (s *Service) Start() {
+	s.initLastPrunedEpoch()
+	go s.run()
+}
+
+// Stop the pruner service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the pruner. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.FinalizedCheckpoint {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.FinalizedCheckpointData)
+			if !ok {
+				log.Error("Event feed data is not type *statefeed.FinalizedCheckpointData")
+				continue
+			}
+			if err := s.onFinalized(data.Epoch); err != nil {
+				log.WithError(err).Error("Could not prune database")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state feed notifier failed")
+			return
+		}
+	}
+}
+
+// initLastPrunedEpoch starts counting the epochs to the next prune from the finalized epoch, as
+// the database was pruned up to it when the node started.
+func (s *Service) initLastPrunedEpoch() {
+	checkpoint, err := s.beaconDB.FinalizedCheckpoint(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve finalized checkpoint")
+		return
+	}
+	if checkpoint != nil {
+		s.lastPrunedEpoch = checkpoint.Epoch
+	}
+}
+
+// onFinalized prunes the database once pruneInterval epochs were finalized since the last
+// prune. The database is not compacted, bolt reuses the pages freed by pruning.
+func (s *Service) onFinalized(epoch uint64) error {
+	if epoch < s.lastPrunedEpoch+pruneInterval {
+		return nil
+	}
+	log.WithField("finalizedEpoch", epoch).Debug("Pruning database")
+	stats, err := s.beaconDB.Prune(s.ctx, s.retentionEpochs)
+	if err != nil {
+		return errors.Wrap(err, "could not prune database")
+	}
+	logStats(stats).Info("Pruned database")
+	s.lastPrunedEpoch = epoch
+	return nil
+}
+
+// PruneAndCompact prunes the database and compacts it. Nothing may write to the database while
+// it is compacted.
+func PruneAndCompact(ctx context.Context, d db.Database, retentionEpochs uint64) error {
+	stats, err := d.Prune(ctx, retentionEpochs)
+	if err != nil {
+		return errors.Wrap(err, "could not prune database")
+	}
+	logStats(stats).Info("Pruned database")
+	reclaimed, err := d.Compact(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not compact database")
+	}
+	log.WithField("bytesReclaimed", reclaimed).Info("Compacted database")
+	return nil
+}
+
+// PruneDatabase opens the database at the given path, prunes and compacts it.
+func PruneDatabase(ctx context.Context, dbPath string, retentionEpochs uint64) (err error) {
+	d, err := db.NewDB(dbPath, cache.NewStateSummaryCache())
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if closeErr := d.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	log.WithField("database-path", dbPath).Info("Pruning database")
+	return PruneAndCompact(ctx, d, retentionEpochs)
+}
+
+func logStats(stats *db.PruneStats) *logrus.Entry {
+	return log.WithFields(logrus.Fields{
+		"blocks":         stats.Blocks,
+		"attestations":   stats.Attestations,
+		"stateSummaries": stats.StateSummaries,
+		"archivedPoints": stats.ArchivedPoints,
+	})
+}
@@ -0,0 +1,71 @@
+package pruner
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestService_PrunesEveryInterval(t *testing.T) {
+	s := NewService(context.Background(), &Config{BeaconDB: dbtest.SetupDB(t)})
+
+	if err := s.onFinalized(pruneInterval - 1); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != 0 {
+		t.Errorf("Expected no prune before the interval, last pruned epoch %d", s.lastPrunedEpoch)
+	}
+	if err := s.onFinalized(pruneInterval); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != pruneInterval {
+		t.Errorf("Expected last pruned epoch %d, received %d", pruneInterval, s.lastPrunedEpoch)
+	}
+	if err := s.onFinalized(pruneInterval + 1); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != pruneInterval {
+		t.Errorf("Expected no prune before the next interval, last pruned epoch %d", s.lastPrunedEpoch)
+	}
+}
+
+func TestService_InitLastPrunedEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	if err := beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 40, Root: make([]byte, 32)}); err != nil {
+		t.Fatal(err)
+	}
+	s := NewService(ctx, &Config{BeaconDB: beaconDB})
+	s.initLastPrunedEpoch()
+	if s.lastPrunedEpoch != 40 {
+		t.Errorf("Expected last pruned epoch 40, received %d", s.lastPrunedEpoch)
+	}
+	// The database pruned on start is not pruned again before the interval.
+	if err := s.onFinalized(40 + pruneInterval - 1); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != 40 {
+		t.Errorf("Expected no prune before the interval, last pruned epoch %d", s.lastPrunedEpoch)
+	}
+}
+
+func TestPruneDatabase(t *testing.T) {
+	dbPath := path.Join(testutil.TempDir(), "prunedb")
+	defer func() {
+		if err := os.RemoveAll(dbPath); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := PruneDatabase(context.Background(), dbPath, 0); err != nil {
+		t.Fatal(err)
+	}
+	// The database is closed once pruned.
+	if err := PruneDatabase(context.Background(), dbPath, 0); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -71,6 +71,17 @@ var (
 		Usage: "Comma separated list of domains from which to accept cross origin requests " +
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 	}
+	// DisableBeaconAPI for the standard Eth2 Beacon REST API served by the beacon node.
+	DisableBeaconAPI = &cli.BoolFlag{
+		Name:  "disable-beacon-api",
+		Usage: "Disable the standard Eth2 Beacon REST API (/eth/v1 routes)",
+	}
+	// BeaconAPIPort defines the port of the standard Eth2 Beacon REST API.
+	BeaconAPIPort = &cli.IntFlag{
+		Name:  "beacon-api-port",
+		Usage: "Port exposing the standard Eth2 Beacon REST API (/eth/v1 routes)",
+		Value: 3500,
+	}
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -113,11 +124,45 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the DB.",
 		Value: 2048,
 	}
+	// CheckpointStateFlag defines a flag for the beacon node to start from a trusted finalized state file.
+	CheckpointStateFlag = &cli.StringFlag{
+		Name:  "checkpoint-state",
+		Usage: "The trusted finalized beacon state file (.SSZ) to start syncing from instead of genesis. Requires --checkpoint-block.",
+	}
+	// CheckpointBlockFlag defines a flag for the block of the trusted finalized state.
+	CheckpointBlockFlag = &cli.StringFlag{
+		Name:  "checkpoint-block",
+		Usage: "The signed beacon block file (.SSZ) matching the state given by --checkpoint-state.",
+	}
+	// CheckpointSyncNodeFlag defines a flag for fetching the trusted finalized state from another beacon node.
+	CheckpointSyncNodeFlag = &cli.StringFlag{
+		Name: "checkpoint-sync-node",
+		Usage: "The gRPC endpoint of a trusted beacon node with debug endpoints enabled, " +
+			"to fetch its finalized state and block from and start syncing from instead of genesis.",
+	}
+	// PruneDBFlag enables pruning the beacon chain database.
+	PruneDBFlag = &cli.BoolFlag{
+		Name: "prune-db",
+		Usage: "Deletes blocks of forks, attestations, orphaned state summaries and archived points below finalization " +
+			"and compacts the database on start, then prunes it as the chain is finalized.",
+	}
+	// PruneRetentionEpochsFlag defines the number of epochs before finalization to keep blocks of forks, attestations and archived points of.
+	PruneRetentionEpochsFlag = &cli.Uint64Flag{
+		Name:  "prune-retention-epochs",
+		Usage: "The number of epochs before the finalized epoch to keep blocks of forks, attestations and archived points of when pruning the database.",
+		Value: 256,
+	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
 		Usage: "Does not run the discoveryV5 dht.",
 	}
+	// MinPeersPerSubnet sets the minimum number of peers to maintain on each attestation subnet required by the duties of our validators.
+	MinPeersPerSubnet = &cli.Uint64Flag{
+		Name:  "minimum-peers-per-subnet",
+		Usage: "Sets the minimum number of peers that a node will attempt to maintain on each attestation subnet it needs for its validator duties.",
+		Value: 4,
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
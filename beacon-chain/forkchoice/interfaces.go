@@ -41,4 +41,5 @@ type Getter interface {
 	Node([32]byte) *protoarray.Node
 	HasNode([32]byte) bool
 	Store() *protoarray.Store
+	CommonAncestor(context.Context, [32]byte, [32]byte) ([32]byte, uint64, error)
 }
@@ -273,6 +273,47 @@ func (s *Store) updateBestChildAndDescendant(parentIndex uint64, childIndex uint
 	return nil
 }
 
+// commonAncestor returns the root and slot of the closest block which both input roots
+// descend from. Parent nodes are always stored at lower indices than their children, so
+// the walk steps back from whichever node has the higher index until the two meet.
+func (s *Store) commonAncestor(ctx context.Context, r1 [32]byte, r2 [32]byte) ([32]byte, uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.commonAncestor")
+	defer span.End()
+
+	s.nodeIndicesLock.RLock()
+	defer s.nodeIndicesLock.RUnlock()
+
+	i, ok := s.NodeIndices[r1]
+	if !ok {
+		return [32]byte{}, 0, errUnknownCommonAncestor
+	}
+	j, ok := s.NodeIndices[r2]
+	if !ok {
+		return [32]byte{}, 0, errUnknownCommonAncestor
+	}
+	for i != j {
+		if ctx.Err() != nil {
+			return [32]byte{}, 0, ctx.Err()
+		}
+		if i >= uint64(len(s.Nodes)) || j >= uint64(len(s.Nodes)) {
+			return [32]byte{}, 0, errInvalidNodeIndex
+		}
+		if i > j {
+			i = s.Nodes[i].Parent
+		} else {
+			j = s.Nodes[j].Parent
+		}
+		// One of the branches reached the pruned part of the tree.
+		if i == NonExistentNode || j == NonExistentNode {
+			return [32]byte{}, 0, errUnknownCommonAncestor
+		}
+	}
+	if i >= uint64(len(s.Nodes)) {
+		return [32]byte{}, 0, errInvalidNodeIndex
+	}
+	return s.Nodes[i].Root, s.Nodes[i].Slot, nil
+}
+
 // prune prunes the store with the new finalized root. The tree is only
 // pruned if the input finalized root are different than the one in stored and
 // the number of the Nodes in store has met prune threshold.
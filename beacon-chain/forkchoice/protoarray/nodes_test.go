@@ -529,3 +529,42 @@ func TestStore_ViableForHead(t *testing.T) {
 		}
 	}
 }
+
+func TestStore_CommonAncestor(t *testing.T) {
+	// Tree: A <- B <- C
+	//            \
+	//             D <- E
+	roots := [][32]byte{{'A'}, {'B'}, {'C'}, {'D'}, {'E'}}
+	parents := []uint64{NonExistentNode, 0, 1, 1, 3}
+	s := &Store{NodeIndices: make(map[[32]byte]uint64)}
+	for i, r := range roots {
+		s.Nodes = append(s.Nodes, &Node{Root: r, Slot: uint64(i), Parent: parents[i]})
+		s.NodeIndices[r] = uint64(i)
+	}
+
+	tests := []struct {
+		r1       [32]byte
+		r2       [32]byte
+		wantRoot [32]byte
+		wantSlot uint64
+	}{
+		{roots[2], roots[4], roots[1], 1},
+		{roots[4], roots[2], roots[1], 1},
+		{roots[2], roots[2], roots[2], 2},
+		{roots[0], roots[4], roots[0], 0},
+		{roots[3], roots[4], roots[3], 3},
+	}
+	for _, tc := range tests {
+		root, slot, err := s.commonAncestor(context.Background(), tc.r1, tc.r2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if root != tc.wantRoot || slot != tc.wantSlot {
+			t.Errorf("commonAncestor(%#x, %#x) = %#x, %d, want %#x, %d", tc.r1[:1], tc.r2[:1], root[:1], slot, tc.wantRoot[:1], tc.wantSlot)
+		}
+	}
+
+	if _, _, err := s.commonAncestor(context.Background(), roots[2], [32]byte{'Z'}); err != errUnknownCommonAncestor {
+		t.Errorf("Expected %v, received %v", errUnknownCommonAncestor, err)
+	}
+}
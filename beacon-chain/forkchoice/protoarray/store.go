@@ -135,3 +135,9 @@ func (f *ForkChoice) HasNode(root [32]byte) bool {
 	_, ok := f.store.NodeIndices[root]
 	return ok
 }
+
+// CommonAncestor returns the root and slot of the closest block in the fork choice store
+// which both input roots descend from.
+func (f *ForkChoice) CommonAncestor(ctx context.Context, r1 [32]byte, r2 [32]byte) ([32]byte, uint64, error) {
+	return f.store.commonAncestor(ctx, r1, r2)
+}
@@ -2,14 +2,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path"
 	"runtime"
 	runtimeDebug "runtime/debug"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	golog "github.com/ipfs/go-log/v2"
 	joonix "github.com/joonix/log"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/integrity"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -33,6 +37,8 @@ var appFlags = []cli.Flag{
 	flags.KeyFlag,
 	flags.DisableGRPCGateway,
 	flags.GRPCGatewayPort,
+	flags.DisableBeaconAPI,
+	flags.BeaconAPIPort,
 	flags.MinSyncPeers,
 	flags.RPCMaxPageSize,
 	flags.ContractDeploymentBlock,
@@ -41,6 +47,7 @@ var appFlags = []cli.Flag{
 	flags.SlasherCertFlag,
 	flags.SlasherProviderFlag,
 	flags.DisableDiscv5,
+	flags.MinPeersPerSubnet,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
 	flags.InteropMockEth1DataVotesFlag,
@@ -52,6 +59,11 @@ var appFlags = []cli.Flag{
 	flags.ArchiveBlocksFlag,
 	flags.ArchiveAttestationsFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.CheckpointStateFlag,
+	flags.CheckpointBlockFlag,
+	flags.CheckpointSyncNodeFlag,
+	flags.PruneDBFlag,
+	flags.PruneRetentionEpochsFlag,
 	flags.EnableDebugRPCEndpoints,
 	cmd.BootstrapNode,
 	cmd.NoDiscovery,
@@ -69,6 +81,7 @@ var appFlags = []cli.Flag{
 	cmd.P2PBlacklist,
 	cmd.P2PEncoding,
 	cmd.P2PPubsub,
+	cmd.P2PPeerStoreExpiry,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
 	cmd.EnableTracingFlag,
@@ -105,6 +118,52 @@ func main() {
 	app.Usage = "this is a beacon chain implementation for Ethereum 2.0"
 	app.Action = startNode
 	app.Version = version.GetVersion()
+	app.Commands = []*cli.Command{
+		{
+			Name:     "db",
+			Category: "db",
+			Usage:    "defines useful functions for maintaining the beacon chain database",
+			Subcommands: []*cli.Command{
+				{
+					Name: "prune",
+					Description: `deletes the blocks of forks, attestations, orphaned state summaries and archived points
+below finalization from the beacon chain database, then compacts it. The beacon node must be stopped`,
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.PruneRetentionEpochsFlag,
+					},
+					Action: func(cliCtx *cli.Context) error {
+						dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), node.BeaconChainDBName)
+						retention := cliCtx.Uint64(flags.PruneRetentionEpochsFlag.Name)
+
+						if err := pruner.PruneDatabase(context.Background(), dbPath, retention); err != nil {
+							log.WithError(err).Error("Pruning database failed")
+							return err
+						}
+						return nil
+					},
+				},
+				{
+					Name: "verify",
+					Description: `checks that the blocks, state summaries, states, finalized block roots, archived points and
+checkpoints of the beacon chain database are consistent, and writes a JSON report of the inconsistencies
+to the standard output. The beacon node must be stopped`,
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+					},
+					Action: func(cliCtx *cli.Context) error {
+						dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), node.BeaconChainDBName)
+
+						if err := integrity.VerifyDatabase(context.Background(), dbPath, os.Stdout); err != nil {
+							log.WithError(err).Error("Verifying database failed")
+							return err
+						}
+						return nil
+					},
+				},
+			},
+		},
+	}
 
 	app.Flags = appFlags
 
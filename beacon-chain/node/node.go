@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
@@ -30,12 +31,15 @@ import (
 	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/interop-cold-start"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/snapshot"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/checkpoint"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -49,11 +53,13 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
 )
 
 var log = logrus.WithField("prefix", "node")
 
-const beaconChainDBName = "beaconchaindata"
+// BeaconChainDBName is the directory of the beacon chain database in the data directory.
+const BeaconChainDBName = "beaconchaindata"
 const testSkipPowFlag = "test-skip-pow"
 
 // BeaconNode defines a struct that handles the services running a random beacon chain
@@ -127,6 +133,10 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.startFromCheckpoint(cliCtx); err != nil {
+		return nil, err
+	}
+
 	beacon.startStateGen()
 
 	if err := beacon.registerP2P(cliCtx); err != nil {
@@ -159,6 +169,10 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerBackfillService(); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
 	}
@@ -171,6 +185,14 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerPrunerService(); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerSnapshotService(); err != nil {
+		return nil, err
+	}
+
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		if err := beacon.registerPrometheusService(); err != nil {
 			return nil, err
@@ -250,7 +272,7 @@ func (b *BeaconNode) startForkChoice() {
 
 func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
-	dbPath := path.Join(baseDir, beaconChainDBName)
+	dbPath := path.Join(baseDir, BeaconChainDBName)
 	clearDB := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearDB := cliCtx.Bool(cmd.ForceClearDB.Name)
 
@@ -287,12 +309,66 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 		}
 	}
 
+	if cliCtx.Bool(flags.PruneDBFlag.Name) {
+		if err := pruner.PruneAndCompact(b.ctx, d, cliCtx.Uint64(flags.PruneRetentionEpochsFlag.Name)); err != nil {
+			return err
+		}
+	}
+
 	log.WithField("database-path", dbPath).Info("Checking DB")
 	b.db = d
 	b.depositCache = depositcache.NewDepositCache()
 	return nil
 }
 
+// startFromCheckpoint seeds an empty database with a trusted finalized state and block,
+// read from files or fetched from another beacon node, so the node syncs from there
+// instead of genesis.
+func (b *BeaconNode) startFromCheckpoint(cliCtx *cli.Context) error {
+	statePath := cliCtx.String(flags.CheckpointStateFlag.Name)
+	blockPath := cliCtx.String(flags.CheckpointBlockFlag.Name)
+	syncNode := cliCtx.String(flags.CheckpointSyncNodeFlag.Name)
+	if statePath == "" && blockPath == "" && syncNode == "" {
+		return nil
+	}
+	if (statePath == "") != (blockPath == "") {
+		return errors.New("--checkpoint-state and --checkpoint-block must be used together")
+	}
+	head, err := b.db.HeadBlock(b.ctx)
+	if err != nil {
+		return err
+	}
+	if head != nil {
+		log.Info("Database already contains a chain, ignoring the weak subjectivity checkpoint")
+		return nil
+	}
+
+	var origin *checkpoint.Origin
+	if statePath != "" {
+		origin, err = checkpoint.LoadFiles(statePath, blockPath)
+	} else {
+		var conn *grpc.ClientConn
+		conn, err = grpc.DialContext(b.ctx, syncNode,
+			grpc.WithInsecure(),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name))),
+		)
+		if err != nil {
+			return errors.Wrapf(err, "could not dial checkpoint sync node %s", syncNode)
+		}
+		defer func() {
+			if err := conn.Close(); err != nil {
+				log.WithError(err).Error("Could not close connection to checkpoint sync node")
+			}
+		}()
+		origin, err = checkpoint.Fetch(b.ctx, conn)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not load weak subjectivity checkpoint")
+	}
+
+	return checkpoint.Save(b.ctx, b.db, origin)
+}
+
 func (b *BeaconNode) startStateGen() {
 	b.stateGen = stategen.New(b.db, b.stateSummaryCache)
 }
@@ -336,6 +412,9 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		Encoding:          cliCtx.String(cmd.P2PEncoding.Name),
 		StateNotifier:     b,
 		PubSub:            cliCtx.String(cmd.P2PPubsub.Name),
+		BeaconDB:          b.db,
+		PeerStoreExpiry:   cliCtx.Duration(cmd.P2PPeerStoreExpiry.Name),
+		MinPeersPerSubnet: cliCtx.Uint64(flags.MinPeersPerSubnet.Name),
 	})
 	if err != nil {
 		return err
@@ -482,6 +561,14 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService() error {
+	bs := backfill.NewService(b.ctx, &backfill.Config{
+		DB:  b.db,
+		P2P: b.fetchP2P(),
+	})
+	return b.services.RegisterService(bs)
+}
+
 func (b *BeaconNode) registerRPCService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -522,6 +609,10 @@ func (b *BeaconNode) registerRPCService() error {
 	slasherProvider := b.cliCtx.String(flags.SlasherProviderFlag.Name)
 	mockEth1DataVotes := b.cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
+	var beaconAPIPort string
+	if !b.cliCtx.Bool(flags.DisableBeaconAPI.Name) {
+		beaconAPIPort = b.cliCtx.String(flags.BeaconAPIPort.Name)
+	}
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		Host:                    host,
@@ -531,9 +622,12 @@ func (b *BeaconNode) registerRPCService() error {
 		BeaconDB:                b.db,
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
+		PeerManager:             p2pService,
+		MetadataProvider:        p2pService,
 		HeadFetcher:             chainService,
 		ForkFetcher:             chainService,
 		FinalizationFetcher:     chainService,
+		CanonicalFetcher:        chainService,
 		ParticipationFetcher:    chainService,
 		BlockReceiver:           chainService,
 		AttestationReceiver:     chainService,
@@ -555,6 +649,7 @@ func (b *BeaconNode) registerRPCService() error {
 		SlasherProvider:         slasherProvider,
 		StateGen:                b.stateGen,
 		EnableDebugRPCEndpoints: enableDebugRPCEndpoints,
+		BeaconAPIPort:           beaconAPIPort,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -646,3 +741,30 @@ func (b *BeaconNode) registerArchiverService() error {
 	})
 	return b.services.RegisterService(svc)
 }
+
+func (b *BeaconNode) registerPrunerService() error {
+	if !b.cliCtx.Bool(flags.PruneDBFlag.Name) {
+		return nil
+	}
+	svc := pruner.NewService(b.ctx, &pruner.Config{
+		BeaconDB:        b.db,
+		StateNotifier:   b,
+		RetentionEpochs: b.cliCtx.Uint64(flags.PruneRetentionEpochsFlag.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerSnapshotService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := snapshot.NewService(b.ctx, &snapshot.Config{
+		BeaconDB:      b.db,
+		HeadFetcher:   chainService,
+		AttPool:       b.attestationPool,
+		ExitPool:      b.exitPool,
+		SlashingsPool: b.slashingsPool,
+	})
+	return b.services.RegisterService(svc)
+}
@@ -1,8 +1,11 @@
 package kv
 
 import (
+	"sort"
+
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -65,6 +68,99 @@ func (p *AttCaches) AggregateUnaggregatedAttestations() error {
 	return nil
 }
 
+// ReaggregateAttestations merges the aggregated attestations of the pool which share the
+// same data whenever their aggregation bits are disjoint, so each aggregate covers as many
+// votes as possible. Aggregates with overlapping bits cannot be merged, they are kept unless
+// all their votes are covered by the other aggregates of the same data.
+// It returns the number of aggregated attestations in the pool before and after the pass.
+func (p *AttCaches) ReaggregateAttestations() (int, int, error) {
+	p.aggregatedAttLock.Lock()
+	defer p.aggregatedAttLock.Unlock()
+
+	var before, after int
+	for r, atts := range p.aggregatedAtt {
+		before += len(atts)
+		reaggregated, err := reaggregate(atts)
+		if err != nil {
+			return 0, 0, err
+		}
+		p.aggregatedAtt[r] = reaggregated
+		after += len(reaggregated)
+	}
+	return before, after, nil
+}
+
+// reaggregate merges attestations of the same data. Every attestation, starting from the
+// largest ones, is merged into each aggregate it does not overlap with, or becomes a new
+// aggregate if it is not contained in any of them. Aggregates covered by the union of the
+// others are then dropped, starting from the smallest ones.
+func reaggregate(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
+	if len(atts) <= 1 {
+		return atts, nil
+	}
+	sorted := make([]*ethpb.Attestation, len(atts))
+	copy(sorted, atts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AggregationBits.Count() > sorted[j].AggregationBits.Count()
+	})
+
+	merged := make([]*ethpb.Attestation, 0, len(sorted))
+	for _, att := range sorted {
+		absorbed := false
+		for i, m := range merged {
+			if m.AggregationBits.Len() != att.AggregationBits.Len() {
+				continue
+			}
+			if m.AggregationBits.Contains(att.AggregationBits) {
+				absorbed = true
+				continue
+			}
+			if m.AggregationBits.Overlaps(att.AggregationBits) {
+				continue
+			}
+			aggregated, err := helpers.AggregateAttestation(m, att)
+			if err != nil {
+				return nil, err
+			}
+			merged[i] = aggregated
+			absorbed = true
+		}
+		if !absorbed {
+			merged = append(merged, att)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].AggregationBits.Count() < merged[j].AggregationBits.Count()
+	})
+	kept := merged
+	for i := 0; i < len(kept); i++ {
+		if isCoveredByOthers(kept, i) {
+			kept = append(kept[:i], kept[i+1:]...)
+			i--
+		}
+	}
+	return kept, nil
+}
+
+// isCoveredByOthers returns true if every vote of the attestation at the given index
+// is held by at least one of the other attestations.
+func isCoveredByOthers(atts []*ethpb.Attestation, index int) bool {
+	bits := atts[index].AggregationBits
+	var union bitfield.Bitlist
+	for i, att := range atts {
+		if i == index || att.AggregationBits.Len() != bits.Len() {
+			continue
+		}
+		if union == nil {
+			union = bitfield.Bitlist(append([]byte{}, att.AggregationBits...))
+			continue
+		}
+		union = union.Or(att.AggregationBits)
+	}
+	return union != nil && union.Contains(bits)
+}
+
 // SaveAggregatedAttestation saves an aggregated attestation in cache.
 func (p *AttCaches) SaveAggregatedAttestation(att *ethpb.Attestation) error {
 	if att == nil || att.Data == nil {
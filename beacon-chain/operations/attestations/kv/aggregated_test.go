@@ -287,3 +287,60 @@ func TestKV_Aggregated_AggregatesAttestations(t *testing.T) {
 		t.Error("Did not receive correct aggregated atts")
 	}
 }
+
+func TestKV_ReaggregateAttestations(t *testing.T) {
+	cache := NewAttCaches()
+	sig := bls.RandKey().Sign([]byte{'a'}).Marshal()
+
+	data1 := &ethpb.AttestationData{Slot: 1}
+	data2 := &ethpb.AttestationData{Slot: 2}
+	r1, err := hashFn(data1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := hashFn(data2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Overlapping aggregates of the first data, which single votes can complete.
+	cache.aggregatedAtt[r1] = []*ethpb.Attestation{
+		{Data: data1, AggregationBits: bitfield.Bitlist{0b10011}, Signature: sig},
+		{Data: data1, AggregationBits: bitfield.Bitlist{0b10110}, Signature: sig},
+		{Data: data1, AggregationBits: bitfield.Bitlist{0b11000}, Signature: sig},
+		{Data: data1, AggregationBits: bitfield.Bitlist{0b10010}, Signature: sig},
+	}
+	// Aggregates of the second data, all covered once the first two are merged.
+	cache.aggregatedAtt[r2] = []*ethpb.Attestation{
+		{Data: data2, AggregationBits: bitfield.Bitlist{0b10011}, Signature: sig},
+		{Data: data2, AggregationBits: bitfield.Bitlist{0b10110}, Signature: sig},
+		{Data: data2, AggregationBits: bitfield.Bitlist{0b11100}, Signature: sig},
+		{Data: data2, AggregationBits: bitfield.Bitlist{0b11001}, Signature: sig},
+	}
+
+	before, after, err := cache.ReaggregateAttestations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before != 8 || after != 3 {
+		t.Errorf("Wanted 8 attestations before and 3 after, received %d and %d", before, after)
+	}
+
+	wanted := []bitfield.Bitlist{{0b11011}, {0b11110}}
+	atts := cache.AggregatedAttestationsBySlotIndex(1, 0)
+	sort.Slice(atts, func(i, j int) bool {
+		return atts[i].AggregationBits[0] < atts[j].AggregationBits[0]
+	})
+	if len(atts) != len(wanted) {
+		t.Fatalf("Wanted %d attestations, received %d", len(wanted), len(atts))
+	}
+	for i, att := range atts {
+		if !reflect.DeepEqual(att.AggregationBits, wanted[i]) {
+			t.Errorf("Wanted aggregation bits %#b, received %#b", wanted[i], att.AggregationBits)
+		}
+	}
+
+	atts = cache.AggregatedAttestationsBySlotIndex(2, 0)
+	if len(atts) != 1 || !reflect.DeepEqual(atts[0].AggregationBits, bitfield.Bitlist{0b11111}) {
+		t.Errorf("Wanted a single attestation with every vote, received %v", atts)
+	}
+}
@@ -30,6 +30,14 @@ var (
 		Name: "expired_block_atts_total",
 		Help: "The number of expired and deleted block attestations in the pool.",
 	})
+	reaggregatedAttsRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reaggregated_atts_removed_total",
+		Help: "The number of aggregated attestations merged or dropped from the pool by re-aggregation.",
+	})
+	reaggregationShrinkRatio = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "reaggregation_pool_shrink_ratio",
+		Help: "The ratio of aggregated attestations removed from the pool by the last re-aggregation.",
+	})
 )
 
 func (s *Service) updateMetrics() {
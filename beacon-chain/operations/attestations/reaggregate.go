@@ -0,0 +1,50 @@
+package attestations
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+)
+
+// Re-aggregate the aggregated attestations of the pool three times per slot.
+var reaggregateAttsPeriod = slotutil.DivideSlotBy(3 /* times-per-slot */)
+
+// This re-aggregates the attestations pool by running reaggregateAtts
+// at every reaggregateAttsPeriod.
+func (s *Service) reaggregateAttsPool() {
+	ticker := time.NewTicker(reaggregateAttsPeriod)
+	for {
+		select {
+		case <-ticker.C:
+			s.reaggregateAtts()
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// This merges the aggregated attestations of the pool sharing the same data and drops
+// the ones covered by others, then records how much the pool shrank.
+func (s *Service) reaggregateAtts() {
+	before, after, err := s.pool.ReaggregateAttestations()
+	if err != nil {
+		log.WithError(err).Error("Could not re-aggregate attestations")
+		return
+	}
+	s.updateMetrics()
+	if before == 0 {
+		reaggregationShrinkRatio.Set(0)
+		return
+	}
+	removed := before - after
+	reaggregatedAttsRemoved.Add(float64(removed))
+	reaggregationShrinkRatio.Set(float64(removed) / float64(before))
+	if removed > 0 {
+		log.WithFields(logrus.Fields{
+			"before": before,
+			"after":  after,
+		}).Debug("Re-aggregated attestations in the pool")
+	}
+}
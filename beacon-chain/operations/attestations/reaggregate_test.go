@@ -0,0 +1,42 @@
+package attestations
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+func TestReaggregateAtts_DropsAttsCoveredByOthers(t *testing.T) {
+	s, err := NewService(context.Background(), &Config{Pool: NewPool()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig := bls.RandKey().Sign([]byte("foo")).Marshal()
+	data := &ethpb.AttestationData{Slot: 1}
+	att1 := &ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b100111}, Signature: sig}
+	att2 := &ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b111100}, Signature: sig}
+	// The votes of att3 overlap with both att1 and att2, and are all held by one of them.
+	att3 := &ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b101110}, Signature: sig}
+	if err := s.pool.SaveAggregatedAttestations([]*ethpb.Attestation{att1, att2, att3}); err != nil {
+		t.Fatal(err)
+	}
+	if count := s.pool.AggregatedAttestationCount(); count != 3 {
+		t.Fatalf("Wanted 3 aggregated attestations before re-aggregation, received %d", count)
+	}
+
+	s.reaggregateAtts()
+
+	atts := s.pool.AggregatedAttestations()
+	if len(atts) != 2 {
+		t.Fatalf("Wanted 2 aggregated attestations after re-aggregation, received %d", len(atts))
+	}
+	for _, att := range atts {
+		if att.AggregationBits.Contains(att3.AggregationBits) {
+			t.Errorf("Attestation %v should have been dropped", att3)
+		}
+	}
+}
@@ -47,6 +47,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 func (s *Service) Start() {
 	go s.prepareForkChoiceAtts()
 	go s.pruneAttsPool()
+	go s.reaggregateAttsPool()
 }
 
 // Stop the beacon block attestation pool service's main event loop
@@ -97,6 +97,34 @@ func (p *Pool) PendingProposerSlashings(ctx context.Context, state *beaconstate.
 	return pending
 }
 
+// AllPendingAttesterSlashings returns every attester slashing of the pool, without checking
+// whether they can be included into a block.
+func (p *Pool) AllPendingAttesterSlashings() []*ethpb.AttesterSlashing {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	seen := make(map[*ethpb.AttesterSlashing]bool)
+	pending := make([]*ethpb.AttesterSlashing, 0, len(p.pendingAttesterSlashing))
+	for _, slashing := range p.pendingAttesterSlashing {
+		// A slashing of several validators is pending once per slashed validator.
+		if seen[slashing.attesterSlashing] {
+			continue
+		}
+		seen[slashing.attesterSlashing] = true
+		pending = append(pending, slashing.attesterSlashing)
+	}
+	return pending
+}
+
+// AllPendingProposerSlashings returns every proposer slashing of the pool, without checking
+// whether they can be included into a block.
+func (p *Pool) AllPendingProposerSlashings() []*ethpb.ProposerSlashing {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	pending := make([]*ethpb.ProposerSlashing, len(p.pendingProposerSlashing))
+	copy(pending, p.pendingProposerSlashing)
+	return pending
+}
+
 // InsertAttesterSlashing into the pool. This method is a no-op if the attester slashing already exists in the pool,
 // has been included into a block recently, or the validator is already exited.
 func (p *Pool) InsertAttesterSlashing(
@@ -694,3 +694,19 @@ func TestPool_PendingAttesterSlashings_NoDuplicates(t *testing.T) {
 		t.Errorf("Unexpected return from PendingAttesterSlashings, wanted %v, received %v", want, got)
 	}
 }
+
+func TestPool_AllPendingAttesterSlashings(t *testing.T) {
+	slashing1 := &ethpb.AttesterSlashing{Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}}}
+	slashing2 := &ethpb.AttesterSlashing{Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{3}}}
+	p := &Pool{
+		pendingAttesterSlashing: []*PendingAttesterSlashing{
+			{attesterSlashing: slashing1, validatorToSlash: 1},
+			{attesterSlashing: slashing1, validatorToSlash: 2},
+			{attesterSlashing: slashing2, validatorToSlash: 3},
+		},
+	}
+	want := []*ethpb.AttesterSlashing{slashing1, slashing2}
+	if got := p.AllPendingAttesterSlashings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllPendingAttesterSlashings() = %v, want %v", got, want)
+	}
+}
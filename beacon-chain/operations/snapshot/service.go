@@ -0,0 +1,209 @@
+// Package snapshot saves the operation pools of the beacon node to the database, so pending
+// attestations, voluntary exits and slashings survive a restart of the node.
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// Save the operation pools to the database every epoch.
+var snapshotPeriod = time.Duration(params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second
+
+// Service restores the operation pools from the database on start, then saves them
+// to the database periodically and on stop.
+type Service struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	beaconDB      db.Database
+	headFetcher   blockchain.HeadFetcher
+	attPool       attestations.Pool
+	exitPool      *voluntaryexits.Pool
+	slashingsPool *slashings.Pool
+	restored      chan struct{}
+}
+
+// Config options for the snapshot service.
+type Config struct {
+	BeaconDB      db.Database
+	HeadFetcher   blockchain.HeadFetcher
+	AttPool       attestations.Pool
+	ExitPool      *voluntaryexits.Pool
+	SlashingsPool *slashings.Pool
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		beaconDB:      cfg.BeaconDB,
+		headFetcher:   cfg.HeadFetcher,
+		attPool:       cfg.AttPool,
+		exitPool:      cfg.ExitPool,
+		slashingsPool: cfg.SlashingsPool,
+		restored:      make(chan struct{}),
+	}
+}
+
+// Start restores the operation pools and starts the snapshot event loop.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the snapshot event loop, and save the operation pools one last time.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	select {
+	case <-s.restored:
+	default:
+		// Do not overwrite the previous snapshot with pools which were not restored yet.
+		return nil
+	}
+	return s.save(context.Background())
+}
+
+// Status reports the healthy status of the snapshot service. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	if err := s.restore(s.ctx); err != nil {
+		log.WithError(err).Error("Could not restore operation pools")
+	}
+	close(s.restored)
+
+	ticker := time.NewTicker(snapshotPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.save(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save operation pools")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// save writes the pending operations of the pools to the database.
+func (s *Service) save(ctx context.Context) error {
+	pools := &db.OperationPools{
+		Attestations:      append(s.attPool.AggregatedAttestations(), s.attPool.UnaggregatedAttestations()...),
+		VoluntaryExits:    s.exitPool.AllPendingExits(),
+		ProposerSlashings: s.slashingsPool.AllPendingProposerSlashings(),
+		AttesterSlashings: s.slashingsPool.AllPendingAttesterSlashings(),
+	}
+	if err := s.beaconDB.SaveOperationPools(ctx, pools); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"attestations":      len(pools.Attestations),
+		"voluntaryExits":    len(pools.VoluntaryExits),
+		"proposerSlashings": len(pools.ProposerSlashings),
+		"attesterSlashings": len(pools.AttesterSlashings),
+	}).Debug("Saved operation pools")
+	return nil
+}
+
+// restore inserts the operations saved in the database back into the pools. The operations
+// are validated against the head state, the ones no longer valid are dropped.
+func (s *Service) restore(ctx context.Context) error {
+	pools, err := s.beaconDB.OperationPools(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve operation pools")
+	}
+	headState, err := s.headFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve head state")
+	}
+	if headState == nil {
+		log.Debug("No head state to validate operation pools against, skipping restore")
+		return nil
+	}
+
+	var restored, dropped int
+	count := func(ok bool) {
+		if ok {
+			restored++
+		} else {
+			dropped++
+		}
+	}
+	for _, att := range pools.Attestations {
+		ok, err := s.restoreAttestation(ctx, headState, att)
+		if err != nil {
+			return err
+		}
+		count(ok)
+	}
+	for _, exit := range pools.VoluntaryExits {
+		count(s.restoreVoluntaryExit(ctx, headState, exit))
+	}
+	for _, slashing := range pools.ProposerSlashings {
+		count(s.slashingsPool.InsertProposerSlashing(ctx, headState, slashing) == nil)
+	}
+	for _, slashing := range pools.AttesterSlashings {
+		count(s.slashingsPool.InsertAttesterSlashing(ctx, headState, slashing) == nil)
+	}
+	log.WithFields(logrus.Fields{
+		"restored": restored,
+		"dropped":  dropped,
+	}).Info("Restored operation pools")
+	return nil
+}
+
+// restoreAttestation inserts an attestation back into the attestation pool, unless it
+// expired or its signature is not valid against the head state.
+func (s *Service) restoreAttestation(ctx context.Context, headState *stateTrie.BeaconState, att *ethpb.Attestation) (bool, error) {
+	if att.Data == nil {
+		return false, nil
+	}
+	genesisTime := time.Unix(int64(headState.GenesisTime()), 0)
+	if att.Data.Slot+params.BeaconConfig().SlotsPerEpoch <= helpers.SlotsSince(genesisTime) {
+		return false, nil
+	}
+	if err := blocks.VerifyAttestation(ctx, headState, att); err != nil {
+		return false, nil
+	}
+	if helpers.IsAggregated(att) {
+		return true, s.attPool.SaveAggregatedAttestation(att)
+	}
+	return true, s.attPool.SaveUnaggregatedAttestation(att)
+}
+
+// restoreVoluntaryExit inserts an exit back into the exit pool if it is still valid
+// against the head state.
+func (s *Service) restoreVoluntaryExit(ctx context.Context, headState *stateTrie.BeaconState, exit *ethpb.SignedVoluntaryExit) bool {
+	if exit.Exit == nil || int(exit.Exit.ValidatorIndex) >= headState.NumValidators() {
+		return false
+	}
+	val, err := headState.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
+	if err != nil {
+		return false
+	}
+	exitedEpochSlot := exit.Exit.Epoch * params.BeaconConfig().SlotsPerEpoch
+	if err := blocks.VerifyExit(val, exitedEpochSlot, headState.Fork(), exit, headState.GenesisValidatorRoot()); err != nil {
+		return false
+	}
+	s.exitPool.InsertVoluntaryExit(ctx, headState, exit)
+	return true
+}
@@ -0,0 +1,110 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestService_RestoresValidOperations(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	headState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	if err := headState.SetGenesisTime(uint64(roughtime.Now().Unix())); err != nil {
+		t.Fatal(err)
+	}
+
+	atts, err := testutil.GenerateAttestations(headState, privKeys, 1, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	badAtt := &ethpb.Attestation{
+		Data:            atts[0].Data,
+		AggregationBits: atts[0].AggregationBits,
+		Signature:       make([]byte, 96),
+	}
+	proposerSlashing, err := testutil.GenerateProposerSlashingForValidator(headState, privKeys[1], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknownValidatorExit := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{ValidatorIndex: 1000},
+		Signature: make([]byte, 96),
+	}
+	if err := beaconDB.SaveOperationPools(ctx, &db.OperationPools{
+		Attestations:      []*ethpb.Attestation{atts[0], badAtt},
+		VoluntaryExits:    []*ethpb.SignedVoluntaryExit{unknownValidatorExit},
+		ProposerSlashings: []*ethpb.ProposerSlashing{proposerSlashing},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(ctx, &Config{
+		BeaconDB:      beaconDB,
+		HeadFetcher:   &mock.ChainService{State: headState},
+		AttPool:       attestations.NewPool(),
+		ExitPool:      voluntaryexits.NewPool(),
+		SlashingsPool: slashings.NewPool(),
+	})
+	if err := s.restore(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	restoredAtts := append(s.attPool.AggregatedAttestations(), s.attPool.UnaggregatedAttestations()...)
+	if len(restoredAtts) != 1 {
+		t.Errorf("Expected the valid attestation to be restored, received %d attestations", len(restoredAtts))
+	}
+	if exits := s.exitPool.AllPendingExits(); len(exits) != 0 {
+		t.Errorf("Expected the invalid exit to be dropped, received %v", exits)
+	}
+	if pending := s.slashingsPool.PendingProposerSlashings(ctx, headState); len(pending) != 1 {
+		t.Errorf("Expected the proposer slashing to be pending inclusion, received %d slashings", len(pending))
+	}
+
+	if err := s.save(ctx); err != nil {
+		t.Fatal(err)
+	}
+	pools, err := beaconDB.OperationPools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.Attestations) != 1 || len(pools.VoluntaryExits) != 0 ||
+		len(pools.ProposerSlashings) != 1 || len(pools.AttesterSlashings) != 0 {
+		t.Errorf("Unexpected operation pools saved %v", pools)
+	}
+}
+
+func TestService_StopBeforeRestoreKeepsSnapshot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	exit := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1}, Signature: make([]byte, 96)}
+	if err := beaconDB.SaveOperationPools(ctx, &db.OperationPools{VoluntaryExits: []*ethpb.SignedVoluntaryExit{exit}}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(ctx, &Config{
+		BeaconDB:      beaconDB,
+		AttPool:       attestations.NewPool(),
+		ExitPool:      voluntaryexits.NewPool(),
+		SlashingsPool: slashings.NewPool(),
+	})
+	if err := s.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	pools, err := beaconDB.OperationPools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.VoluntaryExits) != 1 {
+		t.Errorf("Expected the snapshot to be kept, received %v", pools)
+	}
+}
@@ -96,3 +96,12 @@ func (p *Pool) MarkIncluded(exit *ethpb.SignedVoluntaryExit) {
 	}
 	p.included[exit.Exit.ValidatorIndex] = true
 }
+
+// AllPendingExits returns every exit of the pool, including the ones not ready for inclusion yet.
+func (p *Pool) AllPendingExits() []*ethpb.SignedVoluntaryExit {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	pending := make([]*ethpb.SignedVoluntaryExit, len(p.pending))
+	copy(pending, p.pending)
+	return pending
+}
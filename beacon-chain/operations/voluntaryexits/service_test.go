@@ -460,3 +460,14 @@ func TestPool_PendingExits(t *testing.T) {
 		})
 	}
 }
+
+func TestPool_AllPendingExits(t *testing.T) {
+	pending := []*ethpb.SignedVoluntaryExit{
+		{Exit: &ethpb.VoluntaryExit{Epoch: 0, ValidatorIndex: 1}},
+		{Exit: &ethpb.VoluntaryExit{Epoch: 100, ValidatorIndex: 2}},
+	}
+	p := &Pool{pending: pending}
+	if got := p.AllPendingExits(); !reflect.DeepEqual(got, pending) {
+		t.Errorf("AllPendingExits() = %v, want %v", got, pending)
+	}
+}
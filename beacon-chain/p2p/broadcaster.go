@@ -18,6 +18,9 @@ import (
 // GossipTypeMapping.
 var ErrMessageNotMapped = errors.New("message type is not mapped to a PubSub topic")
 
+// ErrPubSubNotCreated occurs on a Broadcast attempt before the pubsub router is created.
+var ErrPubSubNotCreated = errors.New("pubsub router is not created")
+
 // Broadcast a message to the p2p network.
 func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 	ctx, span := trace.StartSpan(ctx, "p2p.Broadcast")
@@ -56,7 +59,12 @@ func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 		span.AddMessageSendEvent(int64(id), messageLen /*uncompressed*/, messageLen /*compressed*/)
 	}
 
-	if err := s.pubsub.Publish(topic+s.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
+	ps := s.PubSub()
+	if ps == nil {
+		traceutil.AnnotateError(span, ErrPubSubNotCreated)
+		return ErrPubSubNotCreated
+	}
+	if err := ps.Publish(topic+s.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
 		err := errors.Wrap(err, "could not publish message")
 		traceutil.AnnotateError(span, err)
 		return err
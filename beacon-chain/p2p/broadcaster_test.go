@@ -86,6 +86,23 @@ func TestService_Broadcast(t *testing.T) {
 	}
 }
 
+func TestService_Broadcast_NoPubSub(t *testing.T) {
+	p := &Service{
+		cfg: &Config{
+			Encoding: "ssz",
+		},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: []byte{'A'},
+	}
+	msg := &testpb.TestSimpleMessage{
+		Bar: 55,
+	}
+	GossipTypeMapping[reflect.TypeOf(msg)] = "/eth2/%x/testing"
+	if err := p.Broadcast(context.Background(), msg); err != ErrPubSubNotCreated {
+		t.Errorf("Expected %v, received %v", ErrPubSubNotCreated, err)
+	}
+}
+
 func TestService_Broadcast_ReturnsErr_TopicNotMapped(t *testing.T) {
 	p := Service{
 		genesisTime:           time.Now(),
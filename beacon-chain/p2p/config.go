@@ -1,7 +1,10 @@
 package p2p
 
 import (
+	"time"
+
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 )
 
 // Config for the p2p service. These parameters are set from application level flags
@@ -29,4 +32,7 @@ type Config struct {
 	Encoding              string
 	StateNotifier         statefeed.Notifier
 	PubSub                string
+	BeaconDB              db.Database
+	PeerStoreExpiry       time.Duration
+	MinPeersPerSubnet     uint64
 }
@@ -0,0 +1,188 @@
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+const (
+	// decayToZero is the value below which a decayed counter is reset to zero.
+	decayToZero = 0.01
+	// gossipScoreInspectPeriod is the interval at which the gossip scores of the peers are snapshotted.
+	gossipScoreInspectPeriod = time.Minute
+
+	// The maximum score a peer may get from its time in the mesh of a topic, and from the first
+	// deliveries of messages on a topic, before the weight of the topic applies.
+	maxInMeshScore        = 10
+	maxFirstMessageScore  = 40
+	maxPositiveTopicScore = maxInMeshScore + maxFirstMessageScore
+	// The number of slots in the mesh for which a peer gets the maximum time in mesh score.
+	timeInMeshCapSlots = 300
+
+	// The weights of the topics, the attestation subnets sharing the weight of their topic.
+	beaconBlockWeight       = 0.5
+	aggregateWeight         = 0.5
+	attestationSubnetWeight = 1
+	voluntaryExitWeight     = 0.05
+	proposerSlashingWeight  = 0.05
+	attesterSlashingWeight  = 0.05
+	totalTopicWeight        = beaconBlockWeight + aggregateWeight + attestationSubnetWeight +
+		voluntaryExitWeight + proposerSlashingWeight + attesterSlashingWeight
+
+	// The thresholds below which gossip is not emitted to, not published to, and ignored from a peer.
+	gossipThreshold   = -4000
+	publishThreshold  = -8000
+	graylistThreshold = -16000
+)
+
+// peerScoringParams returns the gossipsub peer score parameters and thresholds of the node. The
+// topics map holds the scoring parameters of the eth2 topics of every fork of the chain, so the
+// genesis validators root must be known. The application specific score of a peer is the score
+// it has in the peers status.
+func (s *Service) peerScoringParams() (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds, error) {
+	topics, err := s.scoredTopics()
+	if err != nil {
+		return nil, nil, err
+	}
+	epoch := slotDuration() * time.Duration(params.BeaconConfig().SlotsPerEpoch)
+	scoreParams := &pubsub.PeerScoreParams{
+		Topics:        topics,
+		TopicScoreCap: maxPositiveTopicScore * totalTopicWeight,
+		AppSpecificScore: func(pid peer.ID) float64 {
+			score, err := s.peers.Score(pid)
+			if err != nil {
+				return 0
+			}
+			return score
+		},
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    -maxPositiveTopicScore * totalTopicWeight,
+		IPColocationFactorThreshold: 10,
+		BehaviourPenaltyWeight:      -16,
+		BehaviourPenaltyDecay:       scoreDecay(10 * epoch),
+		DecayInterval:               slotDuration(),
+		DecayToZero:                 decayToZero,
+		RetainScore:                 100 * epoch,
+	}
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             gossipThreshold,
+		PublishThreshold:            publishThreshold,
+		GraylistThreshold:           graylistThreshold,
+		AcceptPXThreshold:           100,
+		OpportunisticGraftThreshold: 5,
+	}
+	return scoreParams, thresholds, nil
+}
+
+// scoredTopics returns the scoring parameters of the eth2 topics for the fork digests of the
+// genesis fork and of every scheduled fork. The parameters can not be updated once the router
+// is created, so the topics of the forks to come are scored from the start.
+func (s *Service) scoredTopics() (map[string]*pubsub.TopicScoreParams, error) {
+	versions := [][]byte{params.BeaconConfig().GenesisForkVersion}
+	for _, version := range params.BeaconConfig().ForkVersionSchedule {
+		versions = append(versions, version)
+	}
+	suffix := s.Encoding().ProtocolSuffix()
+	topics := make(map[string]*pubsub.TopicScoreParams)
+	for _, version := range versions {
+		forkDigest, err := helpers.ComputeForkDigest(version, s.genesisValidatorsRoot)
+		if err != nil {
+			return nil, err
+		}
+		for format := range GossipTopicMappings {
+			topicParams, err := eth2TopicScoreParams(format)
+			if err != nil {
+				return nil, err
+			}
+			if !strings.Contains(format, "%d") {
+				topics[fmt.Sprintf(format, forkDigest)+suffix] = topicParams
+				continue
+			}
+			for i := uint64(0); i < params.BeaconNetworkConfig().AttestationSubnetCount; i++ {
+				topics[fmt.Sprintf(format, forkDigest, i)+suffix] = topicParams
+			}
+		}
+	}
+	return topics, nil
+}
+
+// eth2TopicScoreParams returns the scoring parameters of the given topic format, derived from the
+// number of messages expected on the topic every slot.
+func eth2TopicScoreParams(format string) (*pubsub.TopicScoreParams, error) {
+	cfg := params.BeaconConfig()
+	subnetCount := float64(params.BeaconNetworkConfig().AttestationSubnetCount)
+	committeesPerSlot := float64(cfg.MaxCommitteesPerSlot)
+	switch format {
+	case "/eth2/%x/beacon_block":
+		return topicScoreParams(beaconBlockWeight, 1, true), nil
+	case "/eth2/%x/beacon_aggregate_and_proof":
+		rate := committeesPerSlot * float64(cfg.TargetAggregatorsPerCommittee)
+		return topicScoreParams(aggregateWeight, rate, true), nil
+	case "/eth2/%x/committee_index%d_beacon_attestation":
+		rate := committeesPerSlot * float64(cfg.TargetCommitteeSize) / subnetCount
+		return topicScoreParams(attestationSubnetWeight/subnetCount, rate, true), nil
+	// Operations are only gossiped when they occur, so their topics are not expected to have a
+	// steady rate of messages, and the rates are bounded by the number of operations in a block.
+	case "/eth2/%x/voluntary_exit":
+		return topicScoreParams(voluntaryExitWeight, float64(cfg.MaxVoluntaryExits), false), nil
+	case "/eth2/%x/proposer_slashing":
+		return topicScoreParams(proposerSlashingWeight, float64(cfg.MaxProposerSlashings), false), nil
+	case "/eth2/%x/attester_slashing":
+		return topicScoreParams(attesterSlashingWeight, float64(cfg.MaxAttesterSlashings), false), nil
+	default:
+		return nil, fmt.Errorf("unknown topic %s", format)
+	}
+}
+
+// topicScoreParams returns the scoring parameters of a topic with the given weight, on which
+// expectedRate messages are expected every slot. Peers in the mesh of a topic with a steady rate
+// of messages are penalized if they deliver less than expected.
+func topicScoreParams(weight float64, expectedRate float64, steadyRate bool) *pubsub.TopicScoreParams {
+	slotsPerEpoch := float64(params.BeaconConfig().SlotsPerEpoch)
+	epoch := slotDuration() * time.Duration(params.BeaconConfig().SlotsPerEpoch)
+	// A peer in the mesh is expected to be the first to deliver its share of the messages.
+	firstMessagesCap := math.Max(1, expectedRate*slotsPerEpoch/float64(pubsub.GossipSubD))
+	meshMessagesThreshold := math.Max(1, firstMessagesCap/10)
+	topicParams := &pubsub.TopicScoreParams{
+		TopicWeight:                     weight,
+		TimeInMeshWeight:                maxInMeshScore / float64(timeInMeshCapSlots),
+		TimeInMeshQuantum:               slotDuration(),
+		TimeInMeshCap:                   timeInMeshCapSlots,
+		FirstMessageDeliveriesWeight:    maxFirstMessageScore / firstMessagesCap,
+		FirstMessageDeliveriesDecay:     scoreDecay(20 * epoch),
+		FirstMessageDeliveriesCap:       firstMessagesCap,
+		MeshMessageDeliveriesDecay:      scoreDecay(5 * epoch),
+		MeshMessageDeliveriesCap:        firstMessagesCap,
+		MeshMessageDeliveriesThreshold:  meshMessagesThreshold,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 4 * epoch,
+		MeshFailurePenaltyDecay:         scoreDecay(5 * epoch),
+		// Rejected messages cost a peer more than it can ever get from the topic.
+		InvalidMessageDeliveriesWeight: -maxPositiveTopicScore / weight,
+		InvalidMessageDeliveriesDecay:  scoreDecay(50 * epoch),
+	}
+	if steadyRate {
+		// The penalty is the square of the deficit, so a peer delivering no message is penalized
+		// as much as the score it could get from the topic.
+		topicParams.MeshMessageDeliveriesWeight = -maxPositiveTopicScore / (meshMessagesThreshold * meshMessagesThreshold)
+		topicParams.MeshFailurePenaltyWeight = topicParams.MeshMessageDeliveriesWeight
+	}
+	return topicParams
+}
+
+// scoreDecay returns the decay factor of a counter decaying to zero over the given duration.
+func scoreDecay(d time.Duration) float64 {
+	ticks := float64(d / slotDuration())
+	return math.Pow(decayToZero, 1/ticks)
+}
+
+func slotDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+}
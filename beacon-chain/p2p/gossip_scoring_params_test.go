@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestScoredTopics(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	c := params.BeaconConfig()
+	c.ForkVersionSchedule = map[uint64][]byte{10: {0x01, 0x00, 0x00, 0x00}}
+	params.OverrideBeaconConfig(c)
+	root := make([]byte, 32)
+	s := &Service{cfg: &Config{Encoding: encoder.SSZSnappy}, genesisValidatorsRoot: root}
+
+	topics, err := s.scoredTopics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subnetCount := int(params.BeaconNetworkConfig().AttestationSubnetCount)
+	if len(topics) != 2*(len(GossipTopicMappings)-1+subnetCount) {
+		t.Errorf("Unexpected number of scored topics: %d", len(topics))
+	}
+	digest, err := helpers.ComputeForkDigest(c.GenesisForkVersion, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	suffix := s.Encoding().ProtocolSuffix()
+	block := topics[fmt.Sprintf("/eth2/%x/beacon_block", digest)+suffix]
+	if block == nil {
+		t.Fatal("Expected the block topic to be scored")
+	}
+	if block.MeshMessageDeliveriesWeight >= 0 {
+		t.Error("Expected peers not delivering blocks to be penalized")
+	}
+	subnet := topics[fmt.Sprintf("/eth2/%x/committee_index%d_beacon_attestation", digest, subnetCount-1)+suffix]
+	if subnet == nil {
+		t.Fatal("Expected the attestation subnets to be scored")
+	}
+	exit := topics[fmt.Sprintf("/eth2/%x/voluntary_exit", digest)+suffix]
+	if exit == nil {
+		t.Fatal("Expected the voluntary exit topic to be scored")
+	}
+	if exit.MeshMessageDeliveriesWeight != 0 {
+		t.Error("Expected peers not to be penalized for the rare voluntary exits")
+	}
+
+	// The topics of the scheduled fork are scored from the start.
+	nextDigest, err := helpers.ComputeForkDigest([]byte{0x01, 0x00, 0x00, 0x00}, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topics[fmt.Sprintf("/eth2/%x/beacon_block", nextDigest)+suffix] == nil {
+		t.Error("Expected the block topic of the next fork to be scored")
+	}
+}
+
+func TestTopicScoreParams_Valid(t *testing.T) {
+	for format := range GossipTopicMappings {
+		topicParams, err := eth2TopicScoreParams(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if topicParams.TopicWeight <= 0 {
+			t.Errorf("Expected a positive weight for topic %s", format)
+		}
+		if math.Abs(topicParams.InvalidMessageDeliveriesWeight*topicParams.TopicWeight+maxPositiveTopicScore) > 1e-9 {
+			t.Errorf("Unexpected invalid message penalty for topic %s", format)
+		}
+		for _, decay := range []float64{
+			topicParams.FirstMessageDeliveriesDecay,
+			topicParams.MeshMessageDeliveriesDecay,
+			topicParams.MeshFailurePenaltyDecay,
+			topicParams.InvalidMessageDeliveriesDecay,
+		} {
+			if decay <= 0 || decay >= 1 {
+				t.Errorf("Invalid decay %v for topic %s", decay, format)
+			}
+		}
+	}
+}
@@ -49,6 +49,7 @@ type EncodingProvider interface {
 // PubSubProvider provides the p2p pubsub protocol.
 type PubSubProvider interface {
 	PubSub() *pubsub.PubSub
+	WaitForPubSub(ctx context.Context) error
 }
 
 // PeerManager abstracts some peer management methods from libp2p.
@@ -56,7 +57,6 @@ type PeerManager interface {
 	Disconnect(peer.ID) error
 	PeerID() peer.ID
 	RefreshENR()
-	FindPeersWithSubnet(index uint64) (bool, error)
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
@@ -15,6 +15,24 @@ var (
 		Name: "p2p_repeat_attempts",
 		Help: "The number of repeat attempts the connection handler is triggered for a peer.",
 	})
+	subnetPeerCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_subnet_peer_count",
+		Help: "The number of peers on each attestation subnet required by our validators.",
+	},
+		[]string{"subnet"})
+	requiredSubnetCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "p2p_required_subnets",
+		Help: "The number of attestation subnets required by our validators.",
+	})
+	subnetsBelowMinPeers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "p2p_subnets_below_minimum_peers",
+		Help: "The number of required attestation subnets with less than the minimum number of peers.",
+	})
+	subnetPeersDialed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_subnet_peers_dialed_total",
+		Help: "The number of peers dialed by the search for peers on an attestation subnet.",
+	},
+		[]string{"subnet"})
 )
 
 func (s *Service) updateMetrics() {
@@ -0,0 +1,104 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/sirupsen/logrus"
+)
+
+// scoreDecayInterval is the interval at which the scored behaviours of the peers decay.
+const scoreDecayInterval = 10 * time.Minute
+
+// disconnectBadPeers disconnects the connected peers whose score fell to the disconnect threshold.
+func (s *Service) disconnectBadPeers() {
+	for _, pid := range s.peers.Connected() {
+		if !s.peers.IsBad(pid) {
+			continue
+		}
+		info, err := s.peers.ScoreInfo(pid)
+		if err != nil {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"peer":   pid,
+			"score":  info.Score,
+			"reason": info.DropReason,
+		}).Debug("Disconnecting bad peer")
+		if err := s.Disconnect(pid); err != nil {
+			log.WithError(err).Error("Unable to disconnect from peer")
+		}
+	}
+}
+
+// restorePeerScores restores the peer scores saved in the db.
+func (s *Service) restorePeerScores(ctx context.Context) error {
+	if s.cfg.BeaconDB == nil {
+		return nil
+	}
+	scores, err := s.cfg.BeaconDB.PeerScores(ctx)
+	if err != nil {
+		return err
+	}
+	for _, score := range scores {
+		pid, err := peer.IDB58Decode(score.PeerId)
+		if err != nil {
+			log.WithError(err).WithField("peer", score.PeerId).Debug("Could not decode peer ID of saved score")
+			continue
+		}
+		info := &peers.ScoreInfo{
+			InvalidGossip: score.InvalidGossip,
+			BadResponses:  score.BadResponses,
+			Timeouts:      score.Timeouts,
+			UsefulBlocks:  score.UsefulBlocks,
+			Uptime:        time.Duration(score.Uptime) * time.Second,
+			DropReason:    score.DropReason,
+		}
+		if score.BannedUntil != 0 {
+			info.BannedUntil = time.Unix(score.BannedUntil, 0)
+		}
+		s.peers.SetScoreInfo(pid, info)
+	}
+	log.WithField("peers", len(scores)).Debug("Restored peer scores")
+	return nil
+}
+
+// savePeerScores saves the scores of the peers which have any scored behaviour to the db.
+func (s *Service) savePeerScores(ctx context.Context) error {
+	if s.cfg.BeaconDB == nil {
+		return nil
+	}
+	pids := s.peers.All()
+	scores := make([]*dbpb.PeerScore, 0, len(pids))
+	for _, pid := range pids {
+		info, err := s.peers.ScoreInfo(pid)
+		if err != nil {
+			continue
+		}
+		score := &dbpb.PeerScore{
+			PeerId:        pid.Pretty(),
+			InvalidGossip: info.InvalidGossip,
+			BadResponses:  info.BadResponses,
+			Timeouts:      info.Timeouts,
+			UsefulBlocks:  info.UsefulBlocks,
+			Uptime:        uint64(info.Uptime.Seconds()),
+			DropReason:    info.DropReason,
+		}
+		if s.peers.IsBanned(pid) {
+			score.BannedUntil = info.BannedUntil.Unix()
+		}
+		if isZeroScore(score) {
+			continue
+		}
+		scores = append(scores, score)
+	}
+	return s.cfg.BeaconDB.SavePeerScores(ctx, scores)
+}
+
+func isZeroScore(score *dbpb.PeerScore) bool {
+	return score.InvalidGossip == 0 && score.BadResponses == 0 && score.Timeouts == 0 &&
+		score.UsefulBlocks == 0 && score.Uptime == 0 && score.BannedUntil == 0
+}
@@ -0,0 +1,61 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+func TestService_SaveRestorePeerScores(t *testing.T) {
+	ctx := context.Background()
+	cfg := &Config{BeaconDB: dbtest.SetupDB(t)}
+	s := &Service{cfg: cfg, peers: peers.NewStatus(2)}
+
+	bad, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	good, err := peer.IDB58Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.peers.Add(nil, bad, nil, network.DirInbound)
+	s.peers.Add(nil, good, nil, network.DirInbound)
+	s.peers.IncrementInvalidGossip(bad)
+	s.peers.IncrementInvalidGossip(bad)
+	if !s.peers.IsBanned(bad) {
+		t.Fatal("Expected peer to be banned")
+	}
+	if err := s.savePeerScores(ctx); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := cfg.BeaconDB.PeerScores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("Expected only the scored peer to be saved, received %d scores", len(saved))
+	}
+
+	restored := &Service{cfg: cfg, peers: peers.NewStatus(2)}
+	if err := restored.restorePeerScores(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !restored.peers.IsBad(bad) || !restored.peers.IsBanned(bad) {
+		t.Error("Expected restored peer to be bad and banned")
+	}
+	info, err := restored.peers.ScoreInfo(bad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.InvalidGossip != 2 || info.DropReason != peers.ReasonInvalidGossip {
+		t.Errorf("Unexpected restored score %+v", info)
+	}
+	if _, err := restored.peers.ScoreInfo(good); err != peers.ErrPeerUnknown {
+		t.Errorf("Expected unscored peer not to be restored, received %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+// savePeersInterval is the interval at which the known peers are saved to the db.
+const savePeersInterval = 5 * time.Minute
+
+// restorePeers restores the scores and the known peers saved in the db. Peers which have not been
+// seen for longer than the peer store expiry are not restored, though their score is.
+func (s *Service) restorePeers(ctx context.Context) error {
+	if s.cfg.BeaconDB == nil {
+		return nil
+	}
+	if err := s.restorePeerScores(ctx); err != nil {
+		return err
+	}
+	records, err := s.cfg.BeaconDB.PeerRecords(ctx)
+	if err != nil {
+		return err
+	}
+	restored := 0
+	for _, record := range records {
+		var lastSeen time.Time
+		if record.LastSeen != 0 {
+			lastSeen = time.Unix(record.LastSeen, 0)
+		}
+		if s.isExpiredPeer(lastSeen) {
+			continue
+		}
+		pid, err := peer.IDB58Decode(record.PeerId)
+		if err != nil {
+			log.WithError(err).WithField("peer", record.PeerId).Debug("Could not decode peer ID of saved peer")
+			continue
+		}
+		var enrRecord *enr.Record
+		if len(record.Enr) > 0 {
+			enrRecord = &enr.Record{}
+			if err := rlp.DecodeBytes(record.Enr, enrRecord); err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not decode ENR of saved peer")
+				enrRecord = nil
+			}
+		}
+		var addr ma.Multiaddr
+		if record.Address != "" {
+			addr, err = ma.NewMultiaddr(record.Address)
+			if err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not decode address of saved peer")
+			}
+		}
+		s.peers.Add(enrRecord, pid, addr, network.DirUnknown)
+		s.peers.SetLastSeen(pid, lastSeen)
+		if record.ChainState != nil {
+			s.peers.SetChainState(pid, record.ChainState)
+		}
+		restored++
+	}
+	log.WithField("peers", restored).Debug("Restored known peers")
+	return nil
+}
+
+// savePeers saves the scores and the records of the known peers, along with their chain state, to
+// the db. Peers which have not been seen for longer than the peer store expiry are dropped.
+func (s *Service) savePeers(ctx context.Context) error {
+	if s.cfg.BeaconDB == nil {
+		return nil
+	}
+	if err := s.savePeerScores(ctx); err != nil {
+		return err
+	}
+	pids := s.peers.All()
+	records := make([]*dbpb.PeerRecord, 0, len(pids))
+	for _, pid := range pids {
+		lastSeen, err := s.peers.LastSeen(pid)
+		if err != nil || s.isExpiredPeer(lastSeen) {
+			continue
+		}
+		record := &dbpb.PeerRecord{PeerId: pid.Pretty()}
+		if !lastSeen.IsZero() {
+			record.LastSeen = lastSeen.Unix()
+		}
+		if enrRecord, err := s.peers.ENR(pid); err == nil && enrRecord != nil {
+			buf := bytes.NewBuffer([]byte{})
+			if err := enrRecord.EncodeRLP(buf); err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not encode ENR of peer")
+			} else {
+				record.Enr = buf.Bytes()
+			}
+		}
+		if addr, err := s.peers.Address(pid); err == nil && addr != nil {
+			record.Address = addr.String()
+		}
+		if chainState, err := s.peers.ChainState(pid); err == nil {
+			record.ChainState = chainState
+		}
+		records = append(records, record)
+	}
+	return s.cfg.BeaconDB.SavePeerRecords(ctx, records)
+}
+
+// connectToKnownPeers dials the best of the known peers restored from the db, up to the maximum
+// number of peers.
+func (s *Service) connectToKnownPeers() {
+	pids := s.bestKnownPeers(int(s.cfg.MaxPeers))
+	log.WithField("peers", len(pids)).Debug("Connecting to known peers")
+	for _, pid := range pids {
+		addr, err := s.peers.Address(pid)
+		if err != nil {
+			continue
+		}
+		// make each dial non-blocking
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(info); err != nil {
+				log.WithError(err).Tracef("Could not connect with known peer %s", info.String())
+			}
+		}(peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{addr}})
+	}
+}
+
+// bestKnownPeers returns at most maxPeers of the disconnected peers with a known address which are
+// not bad, the best scored first and then those with the most advanced chain.
+func (s *Service) bestKnownPeers(maxPeers int) []peer.ID {
+	candidates := make([]peer.ID, 0)
+	scores := make(map[peer.ID]float64)
+	finalizedEpochs := make(map[peer.ID]uint64)
+	for _, pid := range s.peers.Disconnected() {
+		if s.peers.IsBad(pid) {
+			continue
+		}
+		if addr, err := s.peers.Address(pid); err != nil || addr == nil {
+			continue
+		}
+		if score, err := s.peers.Score(pid); err == nil {
+			scores[pid] = score
+		}
+		if chainState, err := s.peers.ChainState(pid); err == nil && chainState != nil {
+			finalizedEpochs[pid] = chainState.FinalizedEpoch
+		}
+		candidates = append(candidates, pid)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+		return finalizedEpochs[candidates[i]] > finalizedEpochs[candidates[j]]
+	})
+	if len(candidates) > maxPeers {
+		candidates = candidates[:maxPeers]
+	}
+	return candidates
+}
+
+// isExpiredPeer returns whether a peer last seen at the given time is to be forgotten.
+func (s *Service) isExpiredPeer(lastSeen time.Time) bool {
+	return s.cfg.PeerStoreExpiry > 0 && roughtime.Since(lastSeen) > s.cfg.PeerStoreExpiry
+}
@@ -0,0 +1,177 @@
+package p2p
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestService_SaveRestorePeers(t *testing.T) {
+	ctx := context.Background()
+	cfg := &Config{BeaconDB: dbtest.SetupDB(t), PeerStoreExpiry: 24 * time.Hour}
+	s := &Service{cfg: cfg, peers: peers.NewStatus(2)}
+
+	good := decodePeerID(t, "16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	bad := decodePeerID(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	unseen := decodePeerID(t, "16Uiu2HAmFhtF2qMahmtWA8GvQpT6e3ZaGfUyEiJJXfKKjPDNVWtz")
+	expired := decodePeerID(t, "16Uiu2HAmEZDMHSiQKvHVbjwRqGZFpCdD2Jt6EHPBQq4hCv6FJYYJ")
+	connected := decodePeerID(t, "QmUn6ycS8Fu6L462uZvuEfDoSgYX6kqP4aSZWMa7z1tWAX")
+
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := gethCrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := &enr.Record{}
+	record.Set(enr.WithEntry("test", []byte{'a'}))
+	if err := enode.SignV4(record, key); err != nil {
+		t.Fatal(err)
+	}
+	chainState := &pb.Status{FinalizedEpoch: 5, HeadSlot: 200}
+	for _, pid := range []peer.ID{good, bad, unseen, expired, connected} {
+		s.peers.Add(record, pid, addr, network.DirOutbound)
+	}
+	for _, pid := range []peer.ID{good, bad, expired} {
+		s.peers.SetConnectionState(pid, peers.PeerConnected)
+		s.peers.SetConnectionState(pid, peers.PeerDisconnected)
+	}
+	s.peers.SetConnectionState(connected, peers.PeerConnected)
+	// A peer penalized before its handshake completes is never seen, but its ban is kept.
+	s.peers.IncrementInvalidGossip(unseen)
+	s.peers.IncrementInvalidGossip(unseen)
+	s.peers.SetChainState(good, chainState)
+	s.peers.IncrementUsefulBlocks(good, 64)
+	s.peers.IncrementInvalidGossip(bad)
+	s.peers.IncrementInvalidGossip(bad)
+	if !s.peers.IsBanned(bad) {
+		t.Fatal("Expected peer to be banned")
+	}
+	s.peers.SetLastSeen(expired, time.Now().Add(-48*time.Hour))
+
+	if err := s.savePeers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := cfg.BeaconDB.PeerRecords(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 3 {
+		t.Fatalf("Expected only the seen peers to be saved, received %d records", len(saved))
+	}
+	scores, err := cfg.BeaconDB.PeerScores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 3 {
+		t.Fatalf("Expected only the scored peers to be saved, received %d scores", len(scores))
+	}
+
+	restored := &Service{cfg: cfg, peers: peers.NewStatus(2)}
+	if err := restored.restorePeers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	restoredAddr, err := restored.peers.Address(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restoredAddr.Equal(addr) {
+		t.Errorf("Unexpected restored address: expected %v, received %v", addr, restoredAddr)
+	}
+	restoredRecord, err := restored.peers.ENR(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry []byte
+	if err := restoredRecord.Load(enr.WithEntry("test", &entry)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(entry, []byte{'a'}) {
+		t.Errorf("Unexpected restored ENR entry %v", entry)
+	}
+	restoredState, err := restored.peers.ChainState(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restoredState.FinalizedEpoch != chainState.FinalizedEpoch || restoredState.HeadSlot != chainState.HeadSlot {
+		t.Errorf("Unexpected restored chain state %v", restoredState)
+	}
+	info, err := restored.peers.ScoreInfo(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.UsefulBlocks != 64 {
+		t.Errorf("Unexpected restored score %+v", info)
+	}
+	for _, pid := range []peer.ID{bad, unseen} {
+		if !restored.peers.IsBad(pid) || !restored.peers.IsBanned(pid) {
+			t.Errorf("Expected restored peer %s to be bad and banned", pid)
+		}
+	}
+	if addr, err := restored.peers.Address(unseen); err != nil || addr != nil {
+		t.Errorf("Expected only the score of the unseen peer to be restored, received address %v", addr)
+	}
+	if _, err := restored.peers.ConnectionState(expired); err != peers.ErrPeerUnknown {
+		t.Errorf("Expected expired peer not to be restored, received %v", err)
+	}
+	if _, err := restored.peers.Address(connected); err != nil {
+		t.Errorf("Expected connected peer to be restored, received %v", err)
+	}
+	lastSeen, err := restored.peers.LastSeen(connected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(lastSeen) > time.Minute {
+		t.Errorf("Expected connected peer to be seen when saved, received %v", lastSeen)
+	}
+}
+
+func TestService_BestKnownPeers(t *testing.T) {
+	s := &Service{cfg: &Config{}, peers: peers.NewStatus(2)}
+
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useful := decodePeerID(t, "16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	ahead := decodePeerID(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	behind := decodePeerID(t, "16Uiu2HAmFhtF2qMahmtWA8GvQpT6e3ZaGfUyEiJJXfKKjPDNVWtz")
+	bad := decodePeerID(t, "16Uiu2HAmEZDMHSiQKvHVbjwRqGZFpCdD2Jt6EHPBQq4hCv6FJYYJ")
+	for _, pid := range []peer.ID{useful, ahead, behind, bad} {
+		s.peers.Add(nil, pid, addr, network.DirOutbound)
+	}
+	s.peers.IncrementUsefulBlocks(useful, 64)
+	s.peers.SetChainState(ahead, &pb.Status{FinalizedEpoch: 10})
+	s.peers.SetChainState(behind, &pb.Status{FinalizedEpoch: 5})
+	s.peers.IncrementInvalidGossip(bad)
+	s.peers.IncrementInvalidGossip(bad)
+
+	best := s.bestKnownPeers(10)
+	if want := []peer.ID{useful, ahead, behind}; !reflect.DeepEqual(best, want) {
+		t.Errorf("Unexpected best known peers: expected %v, received %v", want, best)
+	}
+	if best := s.bestKnownPeers(1); len(best) != 1 || best[0] != useful {
+		t.Errorf("Expected only the best peer, received %v", best)
+	}
+}
+
+func decodePeerID(t *testing.T, id string) peer.ID {
+	pid, err := peer.IDB58Decode(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pid
+}
@@ -0,0 +1,264 @@
+package peers
+
+import (
+	"math"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+// Reasons recorded for the penalties which got a peer dropped.
+const (
+	ReasonInvalidGossip = "invalid gossip"
+	ReasonBadResponse   = "bad response"
+	ReasonTimeout       = "timeout"
+)
+
+// minScoreCount is the count below which a decayed behaviour count is reset to zero.
+const minScoreCount = 0.1
+
+// ScorerParams holds the weights, decay and thresholds of the peer scorer. The score of a peer is
+// the weighted sum of the counts of its behaviours, and the rewards for useful blocks and uptime
+// are capped so a well behaved peer can not build an unlimited credit.
+type ScorerParams struct {
+	InvalidGossipWeight float64
+	BadResponseWeight   float64
+	TimeoutWeight       float64
+	UsefulBlockWeight   float64
+	UsefulBlocksCap     float64
+	// UptimeWeight is the reward per full hour the peer was connected.
+	UptimeWeight float64
+	UptimeCap    float64
+	// DecayFactor multiplies the behaviour counts every time the scores decay.
+	DecayFactor float64
+	// Gossip from a peer scoring at or below the graylist threshold is ignored.
+	GraylistThreshold float64
+	// A peer scoring at or below the disconnect threshold is considered bad and disconnected.
+	DisconnectThreshold float64
+	// A peer scoring at or below the ban threshold is considered bad for the ban duration,
+	// whatever its score.
+	BanThreshold float64
+	BanDuration  time.Duration
+}
+
+// DefaultScorerParams returns the scorer parameters for which a peer with no reward is
+// disconnected after maxBadResponses bad responses.
+func DefaultScorerParams(maxBadResponses int) *ScorerParams {
+	disconnect := -float64(maxBadResponses)
+	return &ScorerParams{
+		InvalidGossipWeight: -2,
+		BadResponseWeight:   -1,
+		TimeoutWeight:       -0.5,
+		UsefulBlockWeight:   0.01,
+		UsefulBlocksCap:     1,
+		UptimeWeight:        0.1,
+		UptimeCap:           1,
+		DecayFactor:         0.8,
+		GraylistThreshold:   disconnect / 2,
+		DisconnectThreshold: disconnect,
+		BanThreshold:        2 * disconnect,
+		BanDuration:         time.Hour,
+	}
+}
+
+// peerScores holds the scored behaviours of a peer.
+type peerScores struct {
+	invalidGossip float64
+	badResponses  float64
+	timeouts      float64
+	usefulBlocks  float64
+	uptime        time.Duration
+	connectedAt   time.Time
+	bannedUntil   time.Time
+	dropReason    string
+	// gossipScore is the last score of the peer in the gossipsub router.
+	gossipScore float64
+}
+
+// ScoreInfo details the score of a peer.
+type ScoreInfo struct {
+	Score         float64
+	InvalidGossip float64
+	BadResponses  float64
+	Timeouts      float64
+	UsefulBlocks  float64
+	Uptime        time.Duration
+	BannedUntil   time.Time
+	DropReason    string
+	GossipScore   float64
+}
+
+// IncrementInvalidGossip increments the number of invalid gossip messages received from the given remote peer.
+func (p *Status) IncrementInvalidGossip(pid peer.ID) {
+	p.penalize(pid, ReasonInvalidGossip, func(scores *peerScores) {
+		scores.invalidGossip++
+	})
+}
+
+// IncrementTimeouts increments the number of requests to the given remote peer which timed out.
+func (p *Status) IncrementTimeouts(pid peer.ID) {
+	p.penalize(pid, ReasonTimeout, func(scores *peerScores) {
+		scores.timeouts++
+	})
+}
+
+// IncrementUsefulBlocks rewards the given remote peer for blocks it served us.
+func (p *Status) IncrementUsefulBlocks(pid peer.ID, count int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.fetch(pid)
+	status.scores.usefulBlocks += float64(count)
+}
+
+// Score returns the score of the given remote peer.
+// This will error if the peer does not exist.
+func (p *Status) Score(pid peer.ID) (float64, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return p.score(status), nil
+	}
+	return 0, ErrPeerUnknown
+}
+
+// ScoreInfo returns the details of the score of the given remote peer.
+// This will error if the peer does not exist.
+func (p *Status) ScoreInfo(pid peer.ID) (*ScoreInfo, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	status, ok := p.status[pid]
+	if !ok {
+		return nil, ErrPeerUnknown
+	}
+	return &ScoreInfo{
+		Score:         p.score(status),
+		InvalidGossip: status.scores.invalidGossip,
+		BadResponses:  status.scores.badResponses,
+		Timeouts:      status.scores.timeouts,
+		UsefulBlocks:  status.scores.usefulBlocks,
+		Uptime:        p.uptime(status),
+		BannedUntil:   status.scores.bannedUntil,
+		DropReason:    status.scores.dropReason,
+		GossipScore:   status.scores.gossipScore,
+	}, nil
+}
+
+// SetScoreInfo restores the score of the given remote peer, possibly creating it.
+// The score itself is computed from the restored behaviours.
+func (p *Status) SetScoreInfo(pid peer.ID, info *ScoreInfo) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.fetch(pid)
+	status.scores.invalidGossip = info.InvalidGossip
+	status.scores.badResponses = info.BadResponses
+	status.scores.timeouts = info.Timeouts
+	status.scores.usefulBlocks = info.UsefulBlocks
+	status.scores.uptime = info.Uptime
+	status.scores.bannedUntil = info.BannedUntil
+	status.scores.dropReason = info.DropReason
+}
+
+// SetGossipScores records a snapshot of the scores of the peers in the gossipsub router.
+// The gossip scores of unknown peers are not recorded.
+func (p *Status) SetGossipScores(scores map[peer.ID]float64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for pid, score := range scores {
+		if status, ok := p.status[pid]; ok {
+			status.scores.gossipScore = score
+		}
+	}
+}
+
+// IsGraylisted states if the gossip of the peer is to be ignored.
+// If the peer is unknown this will return `false`.
+func (p *Status) IsGraylisted(pid peer.ID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return p.score(status) <= p.params.GraylistThreshold
+	}
+	return false
+}
+
+// IsBanned states if the peer is temporarily banned.
+// If the peer is unknown this will return `false`.
+func (p *Status) IsBanned(pid peer.ID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return p.isBanned(status)
+	}
+	return false
+}
+
+// penalize applies a penalty to a peer, recording the reason of the penalty if it gets the
+// peer dropped and banning the peer if its score falls to the ban threshold.
+func (p *Status) penalize(pid peer.ID, reason string, apply func(scores *peerScores)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.fetch(pid)
+	apply(&status.scores)
+	score := p.score(status)
+	if score <= p.params.DisconnectThreshold {
+		status.scores.dropReason = reason
+	}
+	if score <= p.params.BanThreshold && !p.isBanned(status) {
+		status.scores.bannedUntil = roughtime.Now().Add(p.params.BanDuration)
+	}
+}
+
+// score computes the score of a peer. This must be called with the lock held.
+func (p *Status) score(status *peerStatus) float64 {
+	scores := status.scores
+	score := p.params.InvalidGossipWeight*scores.invalidGossip +
+		p.params.BadResponseWeight*scores.badResponses +
+		p.params.TimeoutWeight*scores.timeouts
+	score += math.Min(p.params.UsefulBlockWeight*scores.usefulBlocks, p.params.UsefulBlocksCap)
+	// Uptime is rewarded per full hour, so a newly connected peer gets no credit.
+	score += math.Min(p.params.UptimeWeight*math.Floor(p.uptime(status).Hours()), p.params.UptimeCap)
+	return score
+}
+
+// isBad states if a peer is to be considered bad. This must be called with the lock held.
+func (p *Status) isBad(status *peerStatus) bool {
+	return p.isBanned(status) || p.score(status) <= p.params.DisconnectThreshold
+}
+
+// isBanned states if a peer is banned. This must be called with the lock held.
+func (p *Status) isBanned(status *peerStatus) bool {
+	return roughtime.Now().Before(status.scores.bannedUntil)
+}
+
+// uptime returns the time a peer has been connected. This must be called with the lock held.
+func (p *Status) uptime(status *peerStatus) time.Duration {
+	uptime := status.scores.uptime
+	if status.peerState == PeerConnected && !status.scores.connectedAt.IsZero() {
+		uptime += roughtime.Since(status.scores.connectedAt)
+	}
+	return uptime
+}
+
+// decayScores decays the behaviour counts of a peer. This must be called with the lock held.
+func (p *Status) decayScores(status *peerStatus) {
+	decay := func(count float64) float64 {
+		count *= p.params.DecayFactor
+		if count < minScoreCount {
+			return 0
+		}
+		return count
+	}
+	status.scores.invalidGossip = decay(status.scores.invalidGossip)
+	status.scores.badResponses = decay(status.scores.badResponses)
+	status.scores.timeouts = decay(status.scores.timeouts)
+	status.scores.usefulBlocks = decay(status.scores.usefulBlocks)
+}
@@ -0,0 +1,172 @@
+package peers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+func TestScorer_InvalidGossipThresholds(t *testing.T) {
+	maxBadResponses := 4
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(nil, id, nil, network.DirUnknown)
+
+	p.IncrementInvalidGossip(id)
+	if !p.IsGraylisted(id) {
+		t.Error("Peer should be graylisted")
+	}
+	if p.IsBad(id) {
+		t.Error("Graylisted peer should not be bad yet")
+	}
+	p.IncrementInvalidGossip(id)
+	if !p.IsBad(id) {
+		t.Error("Peer should be bad")
+	}
+	p.IncrementInvalidGossip(id)
+	if p.IsBanned(id) {
+		t.Error("Peer should not be banned yet")
+	}
+	p.IncrementInvalidGossip(id)
+	if !p.IsBanned(id) {
+		t.Error("Peer should be banned")
+	}
+	info, err := p.ScoreInfo(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Score != -8 {
+		t.Errorf("Unexpected score: expected %v, received %v", -8, info.Score)
+	}
+	if info.DropReason != peers.ReasonInvalidGossip {
+		t.Errorf("Unexpected drop reason: expected %q, received %q", peers.ReasonInvalidGossip, info.DropReason)
+	}
+}
+
+func TestScorer_UsefulBlocksOffsetPenalties(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(nil, id, nil, network.DirUnknown)
+
+	p.IncrementUsefulBlocks(id, 1000)
+	for i := 0; i < maxBadResponses; i++ {
+		p.IncrementBadResponses(id)
+	}
+	if p.IsBad(id) {
+		t.Error("Peer serving useful blocks should not be bad")
+	}
+	score, err := p.Score(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The reward for useful blocks is capped.
+	if score != -1 {
+		t.Errorf("Unexpected score: expected %v, received %v", -1, score)
+	}
+	p.IncrementBadResponses(id)
+	if !p.IsBad(id) {
+		t.Error("Peer should be bad")
+	}
+}
+
+func TestScorer_TimeoutsDecay(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(nil, id, nil, network.DirUnknown)
+
+	for i := 0; i < 4; i++ {
+		p.IncrementTimeouts(id)
+	}
+	if !p.IsBad(id) {
+		t.Error("Peer should be bad")
+	}
+	p.Decay()
+	if p.IsBad(id) {
+		t.Error("Peer should no longer be bad after decay")
+	}
+	info, err := p.ScoreInfo(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Timeouts != 3.2 {
+		t.Errorf("Unexpected timeouts: expected %v, received %v", 3.2, info.Timeouts)
+	}
+	if info.DropReason != peers.ReasonTimeout {
+		t.Errorf("Unexpected drop reason: expected %q, received %q", peers.ReasonTimeout, info.DropReason)
+	}
+}
+
+func TestScorer_SetScoreInfo(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bannedUntil := time.Now().Add(time.Hour)
+	p.SetScoreInfo(id, &peers.ScoreInfo{
+		BadResponses: 1,
+		Uptime:       3 * time.Hour,
+		BannedUntil:  bannedUntil,
+		DropReason:   peers.ReasonBadResponse,
+	})
+	if !p.IsBanned(id) || !p.IsBad(id) {
+		t.Error("Restored peer should be banned and bad")
+	}
+	info, err := p.ScoreInfo(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The restored uptime is rewarded per full hour.
+	if info.Score < -0.7-1e-9 || info.Score > -0.7+1e-9 {
+		t.Errorf("Unexpected score: expected %v, received %v", -0.7, info.Score)
+	}
+	if !info.BannedUntil.Equal(bannedUntil) {
+		t.Errorf("Unexpected ban expiry: expected %v, received %v", bannedUntil, info.BannedUntil)
+	}
+}
+
+func TestScorer_SetGossipScores(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := peer.IDB58Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(nil, id, nil, network.DirUnknown)
+	p.SetGossipScores(map[peer.ID]float64{id: -42, unknown: 10})
+
+	info, err := p.ScoreInfo(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.GossipScore != -42 {
+		t.Errorf("Unexpected gossip score: expected %v, received %v", -42, info.GossipScore)
+	}
+	if _, err := p.ScoreInfo(unknown); err != peers.ErrPeerUnknown {
+		t.Errorf("Expected the gossip score of an unknown peer not to be recorded, received %v", err)
+	}
+}
@@ -17,6 +17,10 @@
 //
 // Peer information is persistent for the run of the service.  This allows for collection of useful long-term statistics such as
 // number of bad responses obtained from the peer, giving the basis for decisions to not talk to known-bad peers.
+//
+// Each peer is scored from its behaviour: invalid gossip, bad responses and timeouts are penalized, while blocks served and
+// uptime are rewarded.  The counts of the behaviours decay over time, and thresholds on the score decide whether the gossip
+// of a peer is ignored (graylisted), whether the peer is disconnected (bad) and whether it is temporarily banned.
 package peers
 
 import (
@@ -51,6 +55,20 @@ const (
 	PeerDisconnecting
 )
 
+// String returns the name of the connection state.
+func (s PeerConnectionState) String() string {
+	switch s {
+	case PeerConnecting:
+		return "connecting"
+	case PeerConnected:
+		return "connected"
+	case PeerDisconnecting:
+		return "disconnecting"
+	default:
+		return "disconnected"
+	}
+}
+
 var (
 	// ErrPeerUnknown is returned when there is an attempt to obtain data from a peer that is not known.
 	ErrPeerUnknown = errors.New("peer unknown")
@@ -60,6 +78,7 @@ var (
 type Status struct {
 	lock            sync.RWMutex
 	maxBadResponses int
+	params          *ScorerParams
 	status          map[peer.ID]*peerStatus
 }
 
@@ -72,13 +91,15 @@ type peerStatus struct {
 	enr                   *enr.Record
 	metaData              *pb.MetaData
 	chainStateLastUpdated time.Time
-	badResponses          int
+	lastSeen              time.Time
+	scores                peerScores
 }
 
 // NewStatus creates a new status entity.
 func NewStatus(maxBadResponses int) *Status {
 	return &Status{
 		maxBadResponses: maxBadResponses,
+		params:          DefaultScorerParams(maxBadResponses),
 		status:          make(map[peer.ID]*peerStatus),
 	}
 }
@@ -246,6 +267,14 @@ func (p *Status) SetConnectionState(pid peer.ID, state PeerConnectionState) {
 	defer p.lock.Unlock()
 
 	status := p.fetch(pid)
+	if state == PeerConnected && status.peerState != PeerConnected {
+		status.scores.connectedAt = roughtime.Now()
+		status.lastSeen = status.scores.connectedAt
+	}
+	if state != PeerConnected && status.peerState == PeerConnected {
+		status.scores.uptime += roughtime.Since(status.scores.connectedAt)
+		status.lastSeen = roughtime.Now()
+	}
 	status.peerState = state
 }
 
@@ -273,13 +302,33 @@ func (p *Status) ChainStateLastUpdated(pid peer.ID) (time.Time, error) {
 	return roughtime.Now(), ErrPeerUnknown
 }
 
-// IncrementBadResponses increments the number of bad responses we have received from the given remote peer.
-func (p *Status) IncrementBadResponses(pid peer.ID) {
+// LastSeen returns the last time we were connected to the peer, which is now if the peer is connected.
+func (p *Status) LastSeen(pid peer.ID) (time.Time, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		if status.peerState == PeerConnected {
+			return roughtime.Now(), nil
+		}
+		return status.lastSeen, nil
+	}
+	return time.Time{}, ErrPeerUnknown
+}
+
+// SetLastSeen sets the last time we were connected to the peer, for peers restored from the db.
+func (p *Status) SetLastSeen(pid peer.ID, lastSeen time.Time) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	status := p.fetch(pid)
-	status.badResponses++
+	p.fetch(pid).lastSeen = lastSeen
+}
+
+// IncrementBadResponses increments the number of bad responses we have received from the given remote peer.
+func (p *Status) IncrementBadResponses(pid peer.ID) {
+	p.penalize(pid, ReasonBadResponse, func(scores *peerScores) {
+		scores.badResponses++
+	})
 }
 
 // BadResponses obtains the number of bad responses we have received from the given remote peer.
@@ -289,19 +338,20 @@ func (p *Status) BadResponses(pid peer.ID) (int, error) {
 	defer p.lock.RUnlock()
 
 	if status, ok := p.status[pid]; ok {
-		return status.badResponses, nil
+		return int(status.scores.badResponses), nil
 	}
 	return -1, ErrPeerUnknown
 }
 
-// IsBad states if the peer is to be considered bad.
+// IsBad states if the peer is to be considered bad, either because its score fell to the disconnect threshold or
+// because it is banned.
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
 func (p *Status) IsBad(pid peer.ID) bool {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
 	if status, ok := p.status[pid]; ok {
-		return status.badResponses >= p.maxBadResponses
+		return p.isBad(status)
 	}
 	return false
 }
@@ -390,7 +440,7 @@ func (p *Status) Bad() []peer.ID {
 	defer p.lock.RUnlock()
 	peers := make([]peer.ID, 0)
 	for pid, status := range p.status {
-		if status.badResponses >= p.maxBadResponses {
+		if p.isBad(status) {
 			peers = append(peers, pid)
 		}
 	}
@@ -408,16 +458,14 @@ func (p *Status) All() []peer.ID {
 	return pids
 }
 
-// Decay reduces the bad responses of all peers, giving reformed peers a chance to join the network.
+// Decay reduces the scored behaviours of all peers, giving reformed peers a chance to join the network.
 // This can be run periodically, although note that each time it runs it does give all bad peers another chance as well to clog up
-// the network with bad responses, so should not be run too frequently; once an hour would be reasonable.
+// the network with bad responses, so should not be run too frequently. Banned peers stay bad until their ban expires.
 func (p *Status) Decay() {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	for _, status := range p.status {
-		if status.badResponses > 0 {
-			status.badResponses--
-		}
+		p.decayScores(status)
 	}
 }
 
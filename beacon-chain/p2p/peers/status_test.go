@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -286,6 +287,47 @@ func TestPeerImplicitAdd(t *testing.T) {
 	}
 }
 
+func TestPeerLastSeen(t *testing.T) {
+	p := peers.NewStatus(2)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.LastSeen(id); err != peers.ErrPeerUnknown {
+		t.Errorf("Unexpected error for unknown peer: %v", err)
+	}
+
+	p.Add(nil, id, nil, network.DirOutbound)
+	lastSeen, err := p.LastSeen(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lastSeen.IsZero() {
+		t.Errorf("Expected never connected peer not to be seen, received %v", lastSeen)
+	}
+
+	p.SetConnectionState(id, peers.PeerConnected)
+	p.SetConnectionState(id, peers.PeerDisconnected)
+	lastSeen, err = p.LastSeen(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSeen.IsZero() || time.Since(lastSeen) > time.Minute {
+		t.Errorf("Expected peer to be seen on disconnection, received %v", lastSeen)
+	}
+
+	restored := time.Now().Add(-time.Hour)
+	p.SetLastSeen(id, restored)
+	lastSeen, err = p.LastSeen(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lastSeen.Equal(restored) {
+		t.Errorf("Unexpected last seen: expected %v, received %v", restored, lastSeen)
+	}
+}
+
 func TestPeerChainState(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(maxBadResponses)
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
@@ -39,7 +40,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/runutil"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
-	"github.com/sirupsen/logrus"
 )
 
 var _ = shared.Service(&Service{})
@@ -61,6 +61,13 @@ const prysmProtocolPrefix = "/prysm/0.0.0"
 // maxBadResponses is the maximum number of bad responses from a peer before we stop talking to it.
 const maxBadResponses = 5
 
+// Errors returned by connectWithPeer when it does not dial a peer.
+var (
+	errAtPeerLimit = errors.New("at peer limit")
+	errDialSelf    = errors.New("cannot dial ourselves")
+	errBadPeer     = errors.New("peer is bad")
+)
+
 const (
 	pubsubFlood  = "flood"
 	pubsubGossip = "gossip"
@@ -80,6 +87,7 @@ type Service struct {
 	exclusionList         *ristretto.Cache
 	metaData              *pb.MetaData
 	pubsub                *pubsub.PubSub
+	pubsubLock            sync.RWMutex
 	dv5Listener           Listener
 	startupErr            error
 	stateNotifier         statefeed.Notifier
@@ -87,6 +95,7 @@ type Service struct {
 	host                  host.Host
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
+	pubsubCreated         chan struct{}
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -111,6 +120,7 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg:           cfg,
 		exclusionList: cache,
 		isPreGenesis:  true,
+		pubsubCreated: make(chan struct{}),
 	}
 
 	dv5Nodes, kadDHTNodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
@@ -155,37 +165,17 @@ func NewService(cfg *Config) (*Service, error) {
 	}
 	s.host = h
 
-	// TODO(3147): Add gossip sub options
-	// Gossipsub registration is done before we add in any new peers
-	// due to libp2p's gossipsub implementation not taking into
-	// account previously added peers when creating the gossipsub
-	// object.
-	psOpts := []pubsub.Option{
-		pubsub.WithMessageSigning(false),
-		pubsub.WithStrictSignatureVerification(false),
-		pubsub.WithMessageIdFn(msgIDFunction),
+	s.peers = peers.NewStatus(maxBadResponses)
+	if err := s.restorePeers(ctx); err != nil {
+		log.WithError(err).Error("Could not restore known peers")
 	}
 
-	var gs *pubsub.PubSub
 	if cfg.PubSub == "" {
 		cfg.PubSub = pubsubGossip
 	}
-	if cfg.PubSub == pubsubFlood {
-		gs, err = pubsub.NewFloodSub(s.ctx, s.host, psOpts...)
-	} else if cfg.PubSub == pubsubGossip {
-		gs, err = pubsub.NewGossipSub(s.ctx, s.host, psOpts...)
-	} else if cfg.PubSub == pubsubRandom {
-		gs, err = pubsub.NewRandomSub(s.ctx, s.host, int(cfg.MaxPeers), psOpts...)
-	} else {
+	if cfg.PubSub != pubsubFlood && cfg.PubSub != pubsubGossip && cfg.PubSub != pubsubRandom {
 		return nil, fmt.Errorf("unknown pubsub type %s", cfg.PubSub)
 	}
-	if err != nil {
-		log.WithError(err).Error("Failed to start pubsub")
-		return nil, err
-	}
-	s.pubsub = gs
-
-	s.peers = peers.NewStatus(maxBadResponses)
 
 	return s, nil
 }
@@ -202,6 +192,13 @@ func (s *Service) Start() {
 	s.awaitStateInitialized()
 	s.isPreGenesis = false
 
+	// The router is created before we add in any new peers, and once the state is initialized
+	// as the scoring parameters of the topics depend on the fork digests.
+	// The node cannot gossip without a router, so it does not run at all.
+	if err := s.createPubSub(); err != nil {
+		log.WithError(err).Fatal("Failed to start pubsub")
+	}
+
 	var peersToWatch []string
 	if s.cfg.RelayNodeAddr != "" {
 		peersToWatch = append(peersToWatch, s.cfg.RelayNodeAddr)
@@ -215,6 +212,9 @@ func (s *Service) Start() {
 		s.host.ConnManager().Protect(peer.ID, "relay")
 	}
 
+	// Reconnect to the best of the known peers before discovering new ones.
+	s.connectToKnownPeers()
+
 	if !s.cfg.NoDiscovery && !s.cfg.DisableDiscv5 {
 		ipAddr := ipAddr()
 		listener, err := s.startDiscoveryV5(
@@ -276,7 +276,14 @@ func (s *Service) Start() {
 	runutil.RunEvery(s.ctx, 5*time.Second, func() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
-	runutil.RunEvery(s.ctx, time.Hour, s.Peers().Decay)
+	runutil.RunEvery(s.ctx, scoreDecayInterval, s.Peers().Decay)
+	runutil.RunEvery(s.ctx, pollingPeriod, s.disconnectBadPeers)
+	runutil.RunEvery(s.ctx, savePeersInterval, func() {
+		if err := s.savePeers(s.ctx); err != nil {
+			log.WithError(err).Error("Could not save known peers")
+		}
+	})
+	runutil.RunEvery(s.ctx, subnetDiscoveryPeriod, s.maintainSubnetPeers)
 	runutil.RunEvery(s.ctx, 10*time.Second, s.updateMetrics)
 	runutil.RunEvery(s.ctx, refreshRate, func() {
 		s.RefreshENR()
@@ -301,6 +308,9 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if err := s.savePeers(context.Background()); err != nil {
+		log.WithError(err).Error("Could not save known peers")
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -341,11 +351,69 @@ func (s *Service) Encoding() encoder.NetworkEncoding {
 	}
 }
 
-// PubSub returns the p2p pubsub framework.
+// PubSub returns the p2p pubsub framework. The router is created once the state is initialized,
+// and nil is returned until then.
 func (s *Service) PubSub() *pubsub.PubSub {
+	s.pubsubLock.RLock()
+	defer s.pubsubLock.RUnlock()
 	return s.pubsub
 }
 
+// WaitForPubSub waits until the pubsub router is created. An error is returned if the
+// context is done or the service stops before then.
+func (s *Service) WaitForPubSub(ctx context.Context) error {
+	select {
+	case <-s.pubsubCreated:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ctx.Done():
+		return errors.New("p2p service stopped before the pubsub router was created")
+	}
+}
+
+// createPubSub creates the pubsub router of the configured type.
+func (s *Service) createPubSub() error {
+	// TODO(3147): Add gossip sub options
+	// Gossipsub registration is done before we add in any new peers
+	// due to libp2p's gossipsub implementation not taking into
+	// account previously added peers when creating the gossipsub
+	// object.
+	psOpts := []pubsub.Option{
+		pubsub.WithMessageSigning(false),
+		pubsub.WithStrictSignatureVerification(false),
+		pubsub.WithMessageIdFn(msgIDFunction),
+	}
+
+	var gs *pubsub.PubSub
+	var err error
+	if s.cfg.PubSub == pubsubFlood {
+		gs, err = pubsub.NewFloodSub(s.ctx, s.host, psOpts...)
+	} else if s.cfg.PubSub == pubsubGossip {
+		var scoreParams *pubsub.PeerScoreParams
+		var thresholds *pubsub.PeerScoreThresholds
+		scoreParams, thresholds, err = s.peerScoringParams()
+		if err != nil {
+			return err
+		}
+		psOpts = append(psOpts,
+			pubsub.WithPeerScore(scoreParams, thresholds),
+			pubsub.WithPeerScoreInspect(s.peers.SetGossipScores, gossipScoreInspectPeriod),
+		)
+		gs, err = pubsub.NewGossipSub(s.ctx, s.host, psOpts...)
+	} else {
+		gs, err = pubsub.NewRandomSub(s.ctx, s.host, int(s.cfg.MaxPeers), psOpts...)
+	}
+	if err != nil {
+		return err
+	}
+	s.pubsubLock.Lock()
+	s.pubsub = gs
+	s.pubsubLock.Unlock()
+	close(s.pubsubCreated)
+	return nil
+}
+
 // SetStreamHandler sets the protocol handler on the p2p host multiplexer.
 // This method is a pass through to libp2pcore.Host.SetStreamHandler.
 func (s *Service) SetStreamHandler(topic string, handler network.StreamHandler) {
@@ -409,63 +477,6 @@ func (s *Service) RefreshENR() {
 	s.pingPeers()
 }
 
-// FindPeersWithSubnet performs a network search for peers
-// subscribed to a particular subnet. Then we try to connect
-// with those peers.
-func (s *Service) FindPeersWithSubnet(index uint64) (bool, error) {
-	if s.dv5Listener == nil {
-		// return if discovery isn't set
-		return false, nil
-	}
-	iterator := s.dv5Listener.RandomNodes()
-	nodes := enode.ReadNodes(iterator, lookupLimit)
-	exists := false
-	for _, node := range nodes {
-		if node.IP() == nil {
-			continue
-		}
-		// do not look for nodes with no tcp port set
-		if err := node.Record().Load(enr.WithEntry("tcp", new(enr.TCP))); err != nil {
-			if !enr.IsNotFound(err) {
-				log.WithError(err).Debug("Could not retrieve tcp port")
-			}
-			continue
-		}
-		subnets, err := retrieveAttSubnets(node.Record())
-		if err != nil {
-			log.Debugf("could not retrieve subnets: %v", err)
-			continue
-		}
-		for _, comIdx := range subnets {
-			if comIdx == index {
-				multiAddr, err := convertToSingleMultiAddr(node)
-				if err != nil {
-					return false, err
-				}
-				info, err := peer.AddrInfoFromP2pAddr(multiAddr)
-				if err != nil {
-					return false, err
-				}
-				if s.peers.IsActive(info.ID) {
-					exists = true
-					continue
-				}
-				if s.host.Network().Connectedness(info.ID) == network.Connected {
-					exists = true
-					continue
-				}
-				s.peers.Add(node.Record(), info.ID, multiAddr, network.DirUnknown)
-				if err := s.connectWithPeer(*info); err != nil {
-					log.WithError(err).Tracef("Could not connect with peer %s", info.String())
-					continue
-				}
-				exists = true
-			}
-		}
-	}
-	return exists, nil
-}
-
 // AddPingMethod adds the metadata ping rpc method to the p2p service, so that it can
 // be used to refresh ENR.
 func (s *Service) AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error) {
@@ -539,17 +550,17 @@ func (s *Service) connectWithAllPeers(multiAddrs []ma.Multiaddr) {
 	}
 }
 
+// connectWithPeer dials the peer, unless we are at the peer limit, the peer is bad or it is
+// ourselves, in which case the reason it was not dialed is returned.
 func (s *Service) connectWithPeer(info peer.AddrInfo) error {
 	if len(s.Peers().Active()) >= int(s.cfg.MaxPeers) {
-		log.WithFields(logrus.Fields{"peer": info.ID.String(),
-			"reason": "at peer limit"}).Trace("Not dialing peer")
-		return nil
+		return errAtPeerLimit
 	}
 	if info.ID == s.host.ID() {
-		return nil
+		return errDialSelf
 	}
 	if s.Peers().IsBad(info.ID) {
-		return nil
+		return errBadPeer
 	}
 	if err := s.host.Connect(s.ctx, info); err != nil {
 		s.Peers().IncrementBadResponses(info.ID)
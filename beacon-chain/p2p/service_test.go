@@ -132,6 +132,9 @@ func TestService_Start_OnlyStartsOnce(t *testing.T) {
 	if s.started != true {
 		t.Error("Expected service to be started")
 	}
+	if s.PubSub() == nil {
+		t.Error("Expected the pubsub router to be created")
+	}
 	s.Start()
 	testutil.AssertLogsContain(t, hook, "Attempted to start p2p service when it was already started")
 	if err := s.Stop(); err != nil {
@@ -140,6 +143,23 @@ func TestService_Start_OnlyStartsOnce(t *testing.T) {
 	exitRoutine <- true
 }
 
+func TestService_WaitForPubSub(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{ctx: ctx, cancel: cancel, pubsubCreated: make(chan struct{})}
+	if s.PubSub() != nil {
+		t.Error("Expected no pubsub router before it is created")
+	}
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer waitCancel()
+	if err := s.WaitForPubSub(waitCtx); err != context.DeadlineExceeded {
+		t.Errorf("Expected %v, received %v", context.DeadlineExceeded, err)
+	}
+	cancel()
+	if err := s.WaitForPubSub(context.Background()); err == nil {
+		t.Error("Expected an error when the service stops before creating the router")
+	}
+}
+
 func TestService_Status_NotRunning(t *testing.T) {
 	s := &Service{started: false}
 	s.dv5Listener = &mockListener{}
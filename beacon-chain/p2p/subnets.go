@@ -1,16 +1,34 @@
 package p2p
 
 import (
+	"math"
+	"strconv"
+	"time"
+
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 )
 
 var attestationSubnetCount = params.BeaconNetworkConfig().AttestationSubnetCount
 
 var attSubnetEnrKey = params.BeaconNetworkConfig().AttSubnetKey
 
+// subnetDiscoveryPeriod is the interval at which we search for peers on the attestation subnets
+// required by our validators.
+var subnetDiscoveryPeriod = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+
+// subnetLookaheadSlots is the number of slots ahead of the duties of our validators for which we
+// search for peers on their subnets, so that the peers are connected by the duty slot.
+var subnetLookaheadSlots = params.BeaconConfig().SlotsPerEpoch
+
 func intializeAttSubnets(node *enode.LocalNode) *enode.LocalNode {
 	bitV := bitfield.NewBitvector64()
 	entry := enr.WithEntry(attSubnetEnrKey, bitV.Bytes())
@@ -41,3 +59,159 @@ func retrieveBitvector(record *enr.Record) (bitfield.Bitvector64, error) {
 	}
 	return bitV, nil
 }
+
+// maintainSubnetPeers searches the network for peers on the attestation subnets required by our
+// validators on which we have less than the minimum number of peers.
+func (s *Service) maintainSubnetPeers() {
+	if s.genesisTime.IsZero() {
+		return
+	}
+	subnets := requiredSubnets(slotutil.SlotsSinceGenesis(s.genesisTime))
+	minPeers := int(s.cfg.MinPeersPerSubnet)
+	required := make(map[uint64]bool, len(subnets))
+	missing := make(map[uint64]int)
+	subnetPeerCount.Reset()
+	for _, idx := range subnets {
+		required[idx] = true
+		count := len(s.peers.SubscribedToSubnet(idx))
+		subnetPeerCount.WithLabelValues(strconv.FormatUint(idx, 10)).Set(float64(count))
+		if count < minPeers {
+			missing[idx] = minPeers - count
+		}
+	}
+	requiredSubnetCount.Set(float64(len(subnets)))
+	subnetsBelowMinPeers.Set(float64(len(missing)))
+	if len(missing) == 0 {
+		return
+	}
+	log.WithField("subnets", len(missing)).Debug("Searching network for peers on attestation subnets")
+	s.findPeersWithSubnets(missing, required)
+}
+
+// requiredSubnets returns the attestation subnets of the persistent subscriptions of our
+// validators, and of their attester and aggregator duties up to the lookahead from the current slot.
+func requiredSubnets(currentSlot uint64) []uint64 {
+	subnets := cache.CommitteeIDs.GetAllCommittees()
+	for slot := currentSlot; slot <= currentSlot+subnetLookaheadSlots; slot++ {
+		subnets = append(subnets, cache.CommitteeIDs.GetAttesterCommitteeIDs(slot)...)
+		subnets = append(subnets, cache.CommitteeIDs.GetAggregatorCommitteeIDs(slot)...)
+	}
+	return sliceutil.SetUint64(subnets)
+}
+
+// findPeersWithSubnets dials the nodes of a random lookup of the network which advertise the
+// subnets we lack peers on, until we have dialed the missing number of peers of each subnet. At
+// the peer limit, a peer on none of the required subnets is disconnected to make room for each.
+func (s *Service) findPeersWithSubnets(missing map[uint64]int, required map[uint64]bool) {
+	if s.dv5Listener == nil {
+		// return if discovery isn't set
+		return
+	}
+	iterator := s.dv5Listener.RandomNodes()
+	defer iterator.Close()
+	nodes := enode.ReadNodes(iterator, lookupLimit)
+	for _, node := range nodes {
+		subnets, ok := dialableSubnets(node)
+		if !ok {
+			continue
+		}
+		wanted := false
+		for _, idx := range subnets {
+			if missing[idx] > 0 {
+				wanted = true
+				break
+			}
+		}
+		if !wanted {
+			continue
+		}
+		multiAddr, err := convertToSingleMultiAddr(node)
+		if err != nil {
+			log.WithError(err).Debug("Could not convert node to multiaddress")
+			continue
+		}
+		info, err := peer.AddrInfoFromP2pAddr(multiAddr)
+		if err != nil {
+			log.WithError(err).Debug("Could not convert multiaddress to peer address info")
+			continue
+		}
+		if s.peers.IsActive(info.ID) || s.host.Network().Connectedness(info.ID) == network.Connected {
+			continue
+		}
+		if len(s.peers.Active()) >= int(s.cfg.MaxPeers) && !s.disconnectPeerOffSubnets(required) {
+			log.Debug("At peer limit with every peer on a required subnet")
+			return
+		}
+		s.peers.Add(node.Record(), info.ID, multiAddr, network.DirUnknown)
+		if err := s.connectWithPeer(*info); err != nil {
+			log.WithError(err).Tracef("Could not connect with peer %s", info.String())
+			continue
+		}
+		for _, idx := range subnets {
+			if missing[idx] > 0 {
+				missing[idx]--
+				subnetPeersDialed.WithLabelValues(strconv.FormatUint(idx, 10)).Inc()
+			}
+		}
+	}
+}
+
+// disconnectPeerOffSubnets disconnects the lowest scored of the connected peers which are on
+// none of the required subnets. It returns false if there is no such peer.
+func (s *Service) disconnectPeerOffSubnets(required map[uint64]bool) bool {
+	var worst peer.ID
+	worstScore := math.Inf(1)
+	for _, pid := range s.peers.Connected() {
+		subnets, err := s.peers.CommitteeIndices(pid)
+		if err != nil {
+			continue
+		}
+		onRequired := false
+		for _, idx := range subnets {
+			if required[idx] {
+				onRequired = true
+				break
+			}
+		}
+		if onRequired {
+			continue
+		}
+		score, err := s.peers.Score(pid)
+		if err != nil {
+			continue
+		}
+		if score < worstScore {
+			worst, worstScore = pid, score
+		}
+	}
+	if worst == "" {
+		return false
+	}
+	log.WithField("peer", worst).Debug("Disconnecting peer to make room for a peer on a required subnet")
+	s.peers.SetConnectionState(worst, peers.PeerDisconnecting)
+	if err := s.Disconnect(worst); err != nil {
+		log.WithError(err).Error("Unable to disconnect from peer")
+	}
+	return true
+}
+
+// dialableSubnets returns the attestation subnets advertised by a node, and whether the node can
+// be dialed.
+func dialableSubnets(node *enode.Node) ([]uint64, bool) {
+	if node.IP() == nil {
+		return nil, false
+	}
+	// do not look for nodes with no tcp port set
+	if err := node.Record().Load(enr.WithEntry("tcp", new(enr.TCP))); err != nil {
+		if !enr.IsNotFound(err) {
+			log.WithError(err).Debug("Could not retrieve tcp port")
+		}
+		return nil, false
+	}
+	subnets, err := retrieveAttSubnets(node.Record())
+	if err != nil {
+		log.Debugf("could not retrieve subnets: %v", err)
+		return nil, false
+	}
+	return subnets, true
+}
@@ -1,20 +1,28 @@
 package p2p
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
 func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
+	cache.CommitteeIDs.EmptyAllCaches()
+	defer cache.CommitteeIDs.EmptyAllCaches()
 	port := 2000
 	ipAddr, pkey := createAddrAndPrivKey(t)
 	genesisTime := time.Now()
@@ -103,19 +111,7 @@ func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
 	time.Sleep(6 * discoveryWaitTime)
 
 	// look up 3 different subnets
-	exists, err := s.FindPeersWithSubnet(1)
-	if err != nil {
-		t.Fatal(err)
-	}
-	exists2, err := s.FindPeersWithSubnet(2)
-	if err != nil {
-		t.Fatal(err)
-	}
-	exists3, err := s.FindPeersWithSubnet(3)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if !exists || !exists2 || !exists3 {
+	if !nodeWithSubnetExists(s, 1) || !nodeWithSubnetExists(s, 2) || !nodeWithSubnetExists(s, 3) {
 		t.Fatal("Peer with subnet doesn't exist")
 	}
 
@@ -128,12 +124,7 @@ func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
 	testService.RefreshENR()
 	time.Sleep(2 * time.Second)
 
-	exists, err = s.FindPeersWithSubnet(2)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	if !exists {
+	if !nodeWithSubnetExists(s, 2) {
 		t.Fatal("Peer with subnet doesn't exist")
 	}
 	if err := s.Stop(); err != nil {
@@ -141,3 +132,170 @@ func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
 	}
 	exitRoutine <- true
 }
+
+func TestRequiredSubnets(t *testing.T) {
+	cache.CommitteeIDs.EmptyAllCaches()
+	defer cache.CommitteeIDs.EmptyAllCaches()
+	currentSlot := uint64(10000)
+	cache.CommitteeIDs.AddAttesterCommiteeID(currentSlot, 5)
+	cache.CommitteeIDs.AddAggregatorCommiteeID(currentSlot+1, 6)
+	cache.CommitteeIDs.AddAttesterCommiteeID(currentSlot+subnetLookaheadSlots, 7)
+	cache.CommitteeIDs.AddAttesterCommiteeID(currentSlot+subnetLookaheadSlots+1, 8)
+	cache.CommitteeIDs.AddAttesterCommiteeID(currentSlot-1, 9)
+
+	subnets := make(map[uint64]bool)
+	for _, idx := range requiredSubnets(currentSlot) {
+		subnets[idx] = true
+	}
+	for _, idx := range []uint64{5, 6, 7} {
+		if !subnets[idx] {
+			t.Errorf("Expected subnet %d of an upcoming duty to be required", idx)
+		}
+	}
+	for _, idx := range []uint64{8, 9} {
+		if subnets[idx] {
+			t.Errorf("Expected subnet %d of a duty outside of the lookahead not to be required", idx)
+		}
+	}
+}
+
+type subnetNodesListener struct {
+	mockListener
+	nodes []*enode.Node
+}
+
+func (l *subnetNodesListener) RandomNodes() enode.Iterator {
+	return enode.IterNodes(l.nodes)
+}
+
+func TestService_MaintainSubnetPeers(t *testing.T) {
+	cache.CommitteeIDs.EmptyAllCaches()
+	defer cache.CommitteeIDs.EmptyAllCaches()
+
+	h, _, _ := createHost(t, 5000)
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	wantedHost, wantedNode := createSubnetNode(t, 5001, 3)
+	unwantedHost, unwantedNode := createSubnetNode(t, 5002, 4)
+	otherHost, _ := createSubnetNode(t, 5003, 5)
+	for _, remote := range []host.Host{wantedHost, unwantedHost, otherHost} {
+		defer func(remote host.Host) {
+			if err := remote.Close(); err != nil {
+				t.Error(err)
+			}
+		}(remote)
+	}
+
+	s := &Service{
+		ctx:         context.Background(),
+		cfg:         &Config{MaxPeers: 1, MinPeersPerSubnet: 1},
+		host:        h,
+		peers:       peers.NewStatus(maxBadResponses),
+		genesisTime: time.Now(),
+		dv5Listener: &subnetNodesListener{nodes: []*enode.Node{unwantedNode, wantedNode}},
+	}
+	// Fill the peer limit with a peer on none of the required subnets.
+	if err := h.Connect(context.Background(), peer.AddrInfo{ID: otherHost.ID(), Addrs: otherHost.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+	s.peers.Add(nil, otherHost.ID(), otherHost.Addrs()[0], network.DirOutbound)
+	s.peers.SetConnectionState(otherHost.ID(), peers.PeerConnected)
+	cache.CommitteeIDs.AddAttesterCommiteeID(0, 3)
+
+	s.maintainSubnetPeers()
+
+	if h.Network().Connectedness(wantedHost.ID()) != network.Connected {
+		t.Error("Expected peer on the required subnet to be dialed")
+	}
+	if h.Network().Connectedness(unwantedHost.ID()) == network.Connected {
+		t.Error("Expected peer on no required subnet not to be dialed")
+	}
+	if h.Network().Connectedness(otherHost.ID()) == network.Connected {
+		t.Error("Expected peer on no required subnet to be disconnected at the peer limit")
+	}
+}
+
+func TestService_FindPeersWithSubnets_AtPeerLimit(t *testing.T) {
+	h, _, _ := createHost(t, 5010)
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	wantedHost, wantedNode := createSubnetNode(t, 5011, 3)
+	defer func() {
+		if err := wantedHost.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	s := &Service{
+		ctx:         context.Background(),
+		cfg:         &Config{MaxPeers: 1},
+		host:        h,
+		peers:       peers.NewStatus(maxBadResponses),
+		dv5Listener: &subnetNodesListener{nodes: []*enode.Node{wantedNode}},
+	}
+	// The only connected peer is on a required subnet, so there is no room for another.
+	connected := decodePeerID(t, "16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	record := &enr.Record{}
+	s.peers.Add(record, connected, nil, network.DirOutbound)
+	s.peers.SetConnectionState(connected, peers.PeerConnected)
+	bitV := bitfield.NewBitvector64()
+	bitV.SetBitAt(3, true)
+	s.peers.SetMetadata(connected, &pb.MetaData{Attnets: bitV})
+
+	missing := map[uint64]int{3: 1}
+	s.findPeersWithSubnets(missing, map[uint64]bool{3: true})
+
+	if h.Network().Connectedness(wantedHost.ID()) == network.Connected {
+		t.Error("Expected no peer to be dialed at the peer limit")
+	}
+	if missing[3] != 1 {
+		t.Errorf("Expected no peer to be counted as dialed, %d peers missing", missing[3])
+	}
+	if state, err := s.peers.ConnectionState(connected); err != nil || state != peers.PeerConnected {
+		t.Errorf("Expected peer on a required subnet to stay connected, received %v", state)
+	}
+}
+
+// nodeWithSubnetExists returns whether a lookup of the network finds a node advertising the subnet.
+func nodeWithSubnetExists(s *Service, idx uint64) bool {
+	iterator := s.dv5Listener.RandomNodes()
+	defer iterator.Close()
+	for _, node := range enode.ReadNodes(iterator, lookupLimit) {
+		subnets, err := retrieveAttSubnets(node.Record())
+		if err != nil {
+			continue
+		}
+		for _, subnet := range subnets {
+			if subnet == idx {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// createSubnetNode creates a host listening on the given port and a node record for it which
+// advertises the given attestation subnet.
+func createSubnetNode(t *testing.T, port int, subnet uint64) (host.Host, *enode.Node) {
+	h, pkey, ipAddr := createHost(t, port)
+	bitV := bitfield.NewBitvector64()
+	bitV.SetBitAt(subnet, true)
+	record := &enr.Record{}
+	record.Set(enr.IPv4(ipAddr))
+	record.Set(enr.TCP(port))
+	record.Set(enr.WithEntry(attSubnetEnrKey, &bitV))
+	if err := enode.SignV4(record, pkey); err != nil {
+		t.Fatal(err)
+	}
+	node, err := enode.New(enode.ValidSchemes, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h, node
+}
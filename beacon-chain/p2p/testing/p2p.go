@@ -149,6 +149,11 @@ func (p *TestP2P) PubSub() *pubsub.PubSub {
 	return p.pubsub
 }
 
+// WaitForPubSub returns immediately as the floodsub router is created with the test p2p.
+func (p *TestP2P) WaitForPubSub(_ context.Context) error {
+	return nil
+}
+
 // Disconnect from a peer.
 func (p *TestP2P) Disconnect(pid peer.ID) error {
 	return p.Host.Network().ClosePeer(pid)
@@ -239,11 +244,6 @@ func (p *TestP2P) Peers() *peers.Status {
 	return p.peers
 }
 
-// FindPeersWithSubnet mocks the p2p func.
-func (p *TestP2P) FindPeersWithSubnet(index uint64) (bool, error) {
-	return false, nil
-}
-
 // RefreshENR mocks the p2p func.
 func (p *TestP2P) RefreshENR() {
 	return
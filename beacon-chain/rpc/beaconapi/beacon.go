@@ -0,0 +1,605 @@
+package beaconapi
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// getGenesis serves GET /eth/v1/beacon/genesis.
+func (s *Server) getGenesis(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	genesisTime := s.GenesisTimeFetcher.GenesisTime()
+	if genesisTime.IsZero() {
+		writeError(w, http.StatusNotFound, "Chain genesis info is not yet known")
+		return
+	}
+	root := s.GenesisFetcher.GenesisValidatorRoot()
+	writeData(w, &genesisJSON{
+		GenesisTime:           strconv.FormatInt(genesisTime.Unix(), 10),
+		GenesisValidatorsRoot: hexString(root[:]),
+		GenesisForkVersion:    hexString(params.BeaconConfig().GenesisForkVersion),
+	})
+}
+
+// getStateRoot serves GET /eth/v1/beacon/states/{state_id}/root.
+func (s *Server) getStateRoot(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	root, err := st.HashTreeRoot(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not compute state root: "+err.Error())
+		return
+	}
+	writeData(w, &rootJSON{Root: hexString(root[:])})
+}
+
+// getStateFork serves GET /eth/v1/beacon/states/{state_id}/fork.
+func (s *Server) getStateFork(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeData(w, forkToJSON(st.Fork()))
+}
+
+// getFinalityCheckpoints serves GET /eth/v1/beacon/states/{state_id}/finality_checkpoints.
+func (s *Server) getFinalityCheckpoints(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeData(w, &finalityCheckpointsJSON{
+		PreviousJustified: checkpointToJSON(st.PreviousJustifiedCheckpoint()),
+		CurrentJustified:  checkpointToJSON(st.CurrentJustifiedCheckpoint()),
+		Finalized:         checkpointToJSON(st.FinalizedCheckpoint()),
+	})
+}
+
+// listValidators serves GET /eth/v1/beacon/states/{state_id}/validators, optionally
+// filtered by the id (index or public key) and status query parameters.
+func (s *Server) listValidators(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	indices, err := validatorIndices(st, queryValues(r, "id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	statuses := queryValues(r, "status")
+	epoch := helpers.CurrentEpoch(st)
+	res := make([]*validatorContainerJSON, 0, len(indices))
+	for _, idx := range indices {
+		container, err := validatorContainer(st, idx, epoch)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if !matchesStatus(container.Status, statuses) {
+			continue
+		}
+		res = append(res, container)
+	}
+	writeData(w, res)
+}
+
+// getValidator serves GET /eth/v1/beacon/states/{state_id}/validators/{validator_id}.
+func (s *Server) getValidator(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	indices, err := validatorIndices(st, []string{p["validator_id"]})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	container, err := validatorContainer(st, indices[0], helpers.CurrentEpoch(st))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeData(w, container)
+}
+
+// listValidatorBalances serves GET /eth/v1/beacon/states/{state_id}/validator_balances.
+func (s *Server) listValidatorBalances(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	indices, err := validatorIndices(st, queryValues(r, "id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	res := make([]*validatorBalanceJSON, 0, len(indices))
+	for _, idx := range indices {
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Could not get balance: "+err.Error())
+			return
+		}
+		res = append(res, &validatorBalanceJSON{Index: uintString(idx), Balance: uintString(balance)})
+	}
+	writeData(w, res)
+}
+
+// listCommittees serves GET /eth/v1/beacon/states/{state_id}/committees, optionally
+// filtered by the epoch, index and slot query parameters.
+func (s *Server) listCommittees(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, err := s.stateByID(r.Context(), p["state_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	epoch := helpers.CurrentEpoch(st)
+	if e := r.URL.Query().Get("epoch"); e != "" {
+		if epoch, err = parseUint(e, "epoch"); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+	}
+	if epoch > helpers.NextEpoch(st) || epoch+1 < helpers.CurrentEpoch(st) {
+		writeError(w, http.StatusBadRequest, "Epoch is not within the state's shuffling lookahead")
+		return
+	}
+	slotFilter, hasSlotFilter, err := optionalUintQuery(r, "slot")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	indexFilter, hasIndexFilter, err := optionalUintQuery(r, "index")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	activeCount, err := helpers.ActiveValidatorCount(st, epoch)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not get active validator count: "+err.Error())
+		return
+	}
+	committeesPerSlot := helpers.SlotCommitteeCount(activeCount)
+
+	res := make([]*committeeJSON, 0)
+	startSlot := helpers.StartSlot(epoch)
+	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch; slot++ {
+		if hasSlotFilter && slot != slotFilter {
+			continue
+		}
+		for idx := uint64(0); idx < committeesPerSlot; idx++ {
+			if hasIndexFilter && idx != indexFilter {
+				continue
+			}
+			committee, err := helpers.BeaconCommitteeFromState(st, slot, idx)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "Could not get committee: "+err.Error())
+				return
+			}
+			res = append(res, &committeeJSON{
+				Index:      uintString(idx),
+				Slot:       uintString(slot),
+				Validators: uintStrings(committee),
+			})
+		}
+	}
+	writeData(w, res)
+}
+
+// listBlockHeaders serves GET /eth/v1/beacon/headers. Without query parameters it
+// returns the head block header, otherwise the headers at the given slot or with
+// the given parent root.
+func (s *Server) listBlockHeaders(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := r.Context()
+	slot, hasSlot, err := optionalUintQuery(r, "slot")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	parentRoot := r.URL.Query().Get("parent_root")
+
+	var blks []*ethpb.SignedBeaconBlock
+	switch {
+	case hasSlot || parentRoot != "":
+		f := filters.NewFilter()
+		if hasSlot {
+			f = f.SetStartSlot(slot).SetEndSlot(slot)
+		}
+		if parentRoot != "" {
+			root, ok, err := parseRoot(parentRoot)
+			if err != nil || !ok {
+				writeError(w, http.StatusBadRequest, "Invalid parent_root "+parentRoot)
+				return
+			}
+			f = f.SetParentRoot(root[:])
+		}
+		if blks, err = s.BeaconDB.Blocks(ctx, f); err != nil {
+			writeError(w, http.StatusInternalServerError, "Could not retrieve blocks: "+err.Error())
+			return
+		}
+	default:
+		blk, _, err := s.blockByID(ctx, idHead)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		blks = []*ethpb.SignedBeaconBlock{blk}
+	}
+
+	res := make([]*blockHeaderContainerJSON, 0, len(blks))
+	for _, blk := range blks {
+		container, err := s.blockHeaderContainer(ctx, blk)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Could not get block header: "+err.Error())
+			return
+		}
+		res = append(res, container)
+	}
+	writeData(w, res)
+}
+
+// getBlockHeader serves GET /eth/v1/beacon/headers/{block_id}.
+func (s *Server) getBlockHeader(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	blk, _, err := s.blockByID(r.Context(), p["block_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	container, err := s.blockHeaderContainer(r.Context(), blk)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not get block header: "+err.Error())
+		return
+	}
+	writeData(w, container)
+}
+
+// getBlock serves GET /eth/v1/beacon/blocks/{block_id}.
+func (s *Server) getBlock(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	blk, _, err := s.blockByID(r.Context(), p["block_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeData(w, signedBlockToJSON(blk))
+}
+
+// getBlockRoot serves GET /eth/v1/beacon/blocks/{block_id}/root.
+func (s *Server) getBlockRoot(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	_, root, err := s.blockByID(r.Context(), p["block_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeData(w, &rootJSON{Root: hexString(root[:])})
+}
+
+// listBlockAttestations serves GET /eth/v1/beacon/blocks/{block_id}/attestations.
+func (s *Server) listBlockAttestations(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	blk, _, err := s.blockByID(r.Context(), p["block_id"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var atts []*ethpb.Attestation
+	if blk.Block.Body != nil {
+		atts = blk.Block.Body.Attestations
+	}
+	writeData(w, attestationsToJSON(atts))
+}
+
+// listPoolAttestations serves GET /eth/v1/beacon/pool/attestations, optionally
+// filtered by the slot and committee_index query parameters.
+func (s *Server) listPoolAttestations(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	slot, hasSlot, err := optionalUintQuery(r, "slot")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	committeeIndex, hasCommitteeIndex, err := optionalUintQuery(r, "committee_index")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	atts := append(s.AttestationsPool.AggregatedAttestations(), s.AttestationsPool.UnaggregatedAttestations()...)
+	filtered := make([]*ethpb.Attestation, 0, len(atts))
+	for _, att := range atts {
+		if hasSlot && att.Data.Slot != slot {
+			continue
+		}
+		if hasCommitteeIndex && att.Data.CommitteeIndex != committeeIndex {
+			continue
+		}
+		filtered = append(filtered, att)
+	}
+	writeData(w, attestationsToJSON(filtered))
+}
+
+// listPoolAttesterSlashings serves GET /eth/v1/beacon/pool/attester_slashings.
+func (s *Server) listPoolAttesterSlashings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	headState, err := s.HeadFetcher.HeadState(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not get head state: "+err.Error())
+		return
+	}
+	writeData(w, attesterSlashingsToJSON(s.SlashingsPool.PendingAttesterSlashings(r.Context(), headState)))
+}
+
+// listPoolProposerSlashings serves GET /eth/v1/beacon/pool/proposer_slashings.
+func (s *Server) listPoolProposerSlashings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	headState, err := s.HeadFetcher.HeadState(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not get head state: "+err.Error())
+		return
+	}
+	writeData(w, proposerSlashingsToJSON(s.SlashingsPool.PendingProposerSlashings(r.Context(), headState)))
+}
+
+// listPoolVoluntaryExits serves GET /eth/v1/beacon/pool/voluntary_exits.
+func (s *Server) listPoolVoluntaryExits(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	headState, err := s.HeadFetcher.HeadState(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not get head state: "+err.Error())
+		return
+	}
+	writeData(w, voluntaryExitsToJSON(s.ExitPool.PendingExits(headState, headState.Slot())))
+}
+
+// submitPoolAttestations serves POST /eth/v1/beacon/pool/attestations. Every attestation is
+// submitted even if others are rejected, and the rejected ones are reported by their index.
+func (s *Server) submitPoolAttestations(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var req []*attestationJSON
+	if err := decodeBody(r, &req); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var failures []*indexedFailureJSON
+	for i, a := range req {
+		d := &jsonDecoder{}
+		att := d.attestation(a)
+		err := d.err
+		if err == nil {
+			_, err = s.ValidatorServer.ProposeAttestation(r.Context(), att)
+			if err != nil {
+				err = grpcToAPIError(err)
+			}
+		}
+		if err != nil {
+			failures = append(failures, &indexedFailureJSON{Index: i, Message: err.Error()})
+		}
+	}
+	writeFailures(w, "Some attestations could not be submitted", failures)
+}
+
+// submitPoolAttesterSlashing serves POST /eth/v1/beacon/pool/attester_slashings.
+func (s *Server) submitPoolAttesterSlashing(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	req := &attesterSlashingJSON{}
+	if err := decodeBody(r, req); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	d := &jsonDecoder{}
+	slashing := d.attesterSlashing(req)
+	if d.err != nil {
+		writeAPIError(w, d.err)
+		return
+	}
+	if _, err := s.BeaconChainServer.SubmitAttesterSlashing(r.Context(), slashing); err != nil {
+		writeAPIError(w, grpcToAPIError(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// submitPoolProposerSlashing serves POST /eth/v1/beacon/pool/proposer_slashings.
+func (s *Server) submitPoolProposerSlashing(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	req := &proposerSlashingJSON{}
+	if err := decodeBody(r, req); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	d := &jsonDecoder{}
+	slashing := d.proposerSlashing(req)
+	if d.err != nil {
+		writeAPIError(w, d.err)
+		return
+	}
+	if _, err := s.BeaconChainServer.SubmitProposerSlashing(r.Context(), slashing); err != nil {
+		writeAPIError(w, grpcToAPIError(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// submitPoolVoluntaryExit serves POST /eth/v1/beacon/pool/voluntary_exits.
+func (s *Server) submitPoolVoluntaryExit(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	req := &signedVoluntaryExitJSON{}
+	if err := decodeBody(r, req); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	d := &jsonDecoder{}
+	exit := d.signedVoluntaryExit(req)
+	if d.err != nil {
+		writeAPIError(w, d.err)
+		return
+	}
+	if _, err := s.ValidatorServer.ProposeExit(r.Context(), exit); err != nil {
+		writeAPIError(w, grpcToAPIError(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) blockHeaderContainer(ctx context.Context, blk *ethpb.SignedBeaconBlock) (*blockHeaderContainerJSON, error) {
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		return nil, err
+	}
+	bodyRoot, err := ssz.HashTreeRoot(blk.Block.Body)
+	if err != nil {
+		return nil, err
+	}
+	canonical, err := s.CanonicalFetcher.IsCanonical(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return &blockHeaderContainerJSON{
+		Root:      hexString(root[:]),
+		Canonical: canonical,
+		Header: &signedBlockHeaderJSON{
+			Message: blockHeaderToJSON(&ethpb.BeaconBlockHeader{
+				Slot:          blk.Block.Slot,
+				ProposerIndex: blk.Block.ProposerIndex,
+				ParentRoot:    blk.Block.ParentRoot,
+				StateRoot:     blk.Block.StateRoot,
+				BodyRoot:      bodyRoot[:],
+			}),
+			Signature: hexString(blk.Signature),
+		},
+	}, nil
+}
+
+// validatorIndices resolves validator ids, given as indices or hex encoded public keys,
+// to indices in st. No ids selects every validator.
+func validatorIndices(st *stateTrie.BeaconState, ids []string) ([]uint64, error) {
+	if len(ids) == 0 {
+		indices := make([]uint64, st.NumValidators())
+		for i := range indices {
+			indices[i] = uint64(i)
+		}
+		return indices, nil
+	}
+	indices := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if strings.HasPrefix(id, "0x") {
+			pubKey, err := hex.DecodeString(id[2:])
+			if err != nil || len(pubKey) != params.BeaconConfig().BLSPubkeyLength {
+				return nil, newAPIError(http.StatusBadRequest, "Invalid validator public key %s", id)
+			}
+			idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
+			if !ok {
+				return nil, newAPIError(http.StatusNotFound, "Validator %s not found", id)
+			}
+			indices = append(indices, idx)
+			continue
+		}
+		idx, err := parseUint(id, "validator id")
+		if err != nil {
+			return nil, err
+		}
+		if idx >= uint64(st.NumValidators()) {
+			return nil, newAPIError(http.StatusNotFound, "Validator %d not found", idx)
+		}
+		indices = append(indices, idx)
+	}
+	return indices, nil
+}
+
+func validatorContainer(st *stateTrie.BeaconState, idx uint64, epoch uint64) (*validatorContainerJSON, error) {
+	v, err := st.ValidatorAtIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := st.BalanceAtIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	return &validatorContainerJSON{
+		Index:     uintString(idx),
+		Balance:   uintString(balance),
+		Status:    validatorStatus(v, epoch),
+		Validator: validatorToJSON(v),
+	}, nil
+}
+
+// validatorStatus returns the Beacon API status of a validator at epoch.
+func validatorStatus(v *ethpb.Validator, epoch uint64) string {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	switch {
+	case v.ActivationEpoch > epoch:
+		if v.ActivationEligibilityEpoch == farFutureEpoch {
+			return "pending_initialized"
+		}
+		return "pending_queued"
+	case epoch < v.ExitEpoch:
+		if v.ExitEpoch == farFutureEpoch {
+			return "active_ongoing"
+		}
+		if v.Slashed {
+			return "active_slashed"
+		}
+		return "active_exiting"
+	case epoch < v.WithdrawableEpoch:
+		if v.Slashed {
+			return "exited_slashed"
+		}
+		return "exited_unslashed"
+	case v.EffectiveBalance != 0:
+		return "withdrawal_possible"
+	default:
+		return "withdrawal_done"
+	}
+}
+
+// matchesStatus reports whether status matches any of the requested statuses, where a
+// requested status may also be a category such as "active" or "pending".
+func matchesStatus(status string, requested []string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	for _, r := range requested {
+		if status == r || strings.HasPrefix(status, r+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// queryValues returns the values of a query parameter, which may be repeated or comma separated.
+func queryValues(r *http.Request, name string) []string {
+	var res []string
+	for _, v := range r.URL.Query()[name] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+	return res
+}
+
+func requiredUintQuery(r *http.Request, name string) (uint64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, newAPIError(http.StatusBadRequest, "Missing %s", name)
+	}
+	return parseUint(v, name)
+}
+
+func optionalUintQuery(r *http.Request, name string) (uint64, bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, false, nil
+	}
+	i, err := parseUint(v, name)
+	return i, err == nil, err
+}
@@ -0,0 +1,347 @@
+package beaconapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serve(t *testing.T, s *Server, method string, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func post(t *testing.T, s *Server, path string, body interface{}) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(b))
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func get(t *testing.T, s *Server, path string, data interface{}) {
+	rec := serve(t, s, http.MethodGet, path)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: expected status 200, received %d: %s", path, rec.Code, rec.Body.String())
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&dataResponse{Data: data}); err != nil {
+		t.Fatalf("GET %s: could not decode response: %v", path, err)
+	}
+}
+
+func expectStatus(t *testing.T, s *Server, path string, code int) {
+	rec := serve(t, s, http.MethodGet, path)
+	if rec.Code != code {
+		t.Errorf("GET %s: expected status %d, received %d: %s", path, code, rec.Code, rec.Body.String())
+	}
+	resp := &errorResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(resp); err != nil || resp.Code != code {
+		t.Errorf("GET %s: expected error body with code %d, received %v (%v)", path, code, resp, err)
+	}
+}
+
+func TestRouter_UnknownRoutes(t *testing.T) {
+	s := &Server{}
+	expectStatus(t, s, "/eth/v1/beacon/unknown", http.StatusNotFound)
+	rec := serve(t, s, http.MethodPost, "/eth/v1/node/version")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, received %d", rec.Code)
+	}
+}
+
+func TestGetGenesis(t *testing.T) {
+	s := &Server{
+		GenesisTimeFetcher: &mock.ChainService{},
+		GenesisFetcher:     &mock.ChainService{},
+	}
+	expectStatus(t, s, "/eth/v1/beacon/genesis", http.StatusNotFound)
+
+	genesis := time.Unix(1590832934, 0)
+	root := [32]byte{'r'}
+	s.GenesisTimeFetcher = &mock.ChainService{Genesis: genesis}
+	s.GenesisFetcher = &mock.ChainService{ValidatorsRoot: root}
+	res := &genesisJSON{}
+	get(t, s, "/eth/v1/beacon/genesis", res)
+	want := &genesisJSON{
+		GenesisTime:           "1590832934",
+		GenesisValidatorsRoot: hexString(root[:]),
+		GenesisForkVersion:    hexString(params.BeaconConfig().GenesisForkVersion),
+	}
+	if *res != *want {
+		t.Errorf("Expected %v, received %v", want, res)
+	}
+}
+
+func TestGetStateRoot(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 16)
+	s := &Server{HeadFetcher: &mock.ChainService{State: st}}
+	root, err := st.HashTreeRoot(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := &rootJSON{}
+	get(t, s, "/eth/v1/beacon/states/head/root", res)
+	if res.Root != hexString(root[:]) {
+		t.Errorf("Expected state root %s, received %s", hexString(root[:]), res.Root)
+	}
+	get(t, s, "/eth/v1/beacon/states/"+hexString(root[:])+"/root", res)
+	if res.Root != hexString(root[:]) {
+		t.Errorf("Expected state root %s, received %s", hexString(root[:]), res.Root)
+	}
+
+	expectStatus(t, s, "/eth/v1/beacon/states/"+hexString(make([]byte, 32))+"/root", http.StatusNotFound)
+	expectStatus(t, s, "/eth/v1/beacon/states/0x1234/root", http.StatusBadRequest)
+	expectStatus(t, s, "/eth/v1/beacon/states/latest/root", http.StatusBadRequest)
+}
+
+func TestListValidators(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 16)
+	vals := st.Validators()
+	vals[3].ActivationEpoch = 10
+	if err := st.SetValidators(vals); err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{HeadFetcher: &mock.ChainService{State: st}}
+
+	var res []*validatorContainerJSON
+	get(t, s, "/eth/v1/beacon/states/head/validators", &res)
+	if len(res) != 16 {
+		t.Fatalf("Expected 16 validators, received %d", len(res))
+	}
+
+	get(t, s, fmt.Sprintf("/eth/v1/beacon/states/head/validators?id=1,%#x&id=5", vals[2].PublicKey), &res)
+	if len(res) != 3 || res[0].Index != "1" || res[1].Index != "2" || res[2].Index != "5" {
+		t.Errorf("Unexpected validators %v", res)
+	}
+
+	get(t, s, "/eth/v1/beacon/states/head/validators?status=pending", &res)
+	if len(res) != 1 || res[0].Index != "3" || res[0].Status != "pending_queued" {
+		t.Errorf("Unexpected pending validators %v", res)
+	}
+
+	container := &validatorContainerJSON{}
+	get(t, s, "/eth/v1/beacon/states/head/validators/4", container)
+	if container.Status != "active_ongoing" || container.Balance != uintString(params.BeaconConfig().MaxEffectiveBalance) {
+		t.Errorf("Unexpected validator %v", container)
+	}
+	expectStatus(t, s, "/eth/v1/beacon/states/head/validators/16", http.StatusNotFound)
+}
+
+func TestValidatorStatus(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	tests := []struct {
+		validator *ethpb.Validator
+		status    string
+	}{
+		{&ethpb.Validator{ActivationEligibilityEpoch: farFuture, ActivationEpoch: farFuture}, "pending_initialized"},
+		{&ethpb.Validator{ActivationEligibilityEpoch: 1, ActivationEpoch: farFuture}, "pending_queued"},
+		{&ethpb.Validator{ExitEpoch: farFuture, WithdrawableEpoch: farFuture}, "active_ongoing"},
+		{&ethpb.Validator{ExitEpoch: 10, WithdrawableEpoch: 20}, "active_exiting"},
+		{&ethpb.Validator{ExitEpoch: 10, WithdrawableEpoch: 20, Slashed: true}, "active_slashed"},
+		{&ethpb.Validator{ExitEpoch: 2, WithdrawableEpoch: 20}, "exited_unslashed"},
+		{&ethpb.Validator{ExitEpoch: 2, WithdrawableEpoch: 20, Slashed: true}, "exited_slashed"},
+		{&ethpb.Validator{ExitEpoch: 2, WithdrawableEpoch: 3, EffectiveBalance: 1}, "withdrawal_possible"},
+		{&ethpb.Validator{ExitEpoch: 2, WithdrawableEpoch: 3}, "withdrawal_done"},
+	}
+	for _, tt := range tests {
+		if status := validatorStatus(tt.validator, 5); status != tt.status {
+			t.Errorf("Expected status %s, received %s", tt.status, status)
+		}
+	}
+}
+
+func TestGetBlock(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	ctx := context.Background()
+
+	genesis := testutil.NewBeaconBlock()
+	canonical := testutil.NewBeaconBlock()
+	canonical.Block.Slot = 2
+	canonical.Block.Body.Attestations = []*ethpb.Attestation{{
+		AggregationBits: []byte{0x03},
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}}
+	orphaned := testutil.NewBeaconBlock()
+	orphaned.Block.Slot = 2
+	orphaned.Block.ProposerIndex = 1
+	canonicalRoots := make(map[[32]byte]bool)
+	roots := make([][32]byte, 0, 3)
+	for _, blk := range []*ethpb.SignedBeaconBlock{genesis, canonical, orphaned} {
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, root)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, roots[0]); err != nil {
+		t.Fatal(err)
+	}
+	canonicalRoots[roots[0]] = true
+	canonicalRoots[roots[1]] = true
+	chain := &mock.ChainService{Block: canonical, CanonicalRoots: canonicalRoots}
+	s := &Server{BeaconDB: db, HeadFetcher: chain, CanonicalFetcher: chain}
+
+	res := &signedBlockJSON{}
+	get(t, s, "/eth/v1/beacon/blocks/2", res)
+	if res.Message.ProposerIndex != "0" || len(res.Message.Body.Attestations) != 1 {
+		t.Errorf("Expected canonical block at slot 2, received %v", res.Message)
+	}
+
+	root := &rootJSON{}
+	get(t, s, "/eth/v1/beacon/blocks/genesis/root", root)
+	if root.Root != hexString(roots[0][:]) {
+		t.Errorf("Expected genesis root %s, received %s", hexString(roots[0][:]), root.Root)
+	}
+	get(t, s, "/eth/v1/beacon/blocks/head/root", root)
+	if root.Root != hexString(roots[1][:]) {
+		t.Errorf("Expected head root %s, received %s", hexString(roots[1][:]), root.Root)
+	}
+
+	var atts []*attestationJSON
+	get(t, s, "/eth/v1/beacon/blocks/"+hexString(roots[1][:])+"/attestations", &atts)
+	if len(atts) != 1 || atts[0].AggregationBits != "0x03" || atts[0].Data.Slot != "1" {
+		t.Errorf("Unexpected block attestations %v", atts)
+	}
+
+	var headers []*blockHeaderContainerJSON
+	get(t, s, "/eth/v1/beacon/headers?slot=2", &headers)
+	if len(headers) != 2 {
+		t.Fatalf("Expected 2 headers at slot 2, received %d", len(headers))
+	}
+	for _, h := range headers {
+		wantCanonical := h.Root == hexString(roots[1][:])
+		if h.Canonical != wantCanonical {
+			t.Errorf("Expected header %s canonical=%v", h.Root, wantCanonical)
+		}
+	}
+
+	expectStatus(t, s, "/eth/v1/beacon/blocks/1", http.StatusNotFound)
+	expectStatus(t, s, "/eth/v1/beacon/blocks/"+hexString(make([]byte, 32)), http.StatusNotFound)
+}
+
+type mockBeaconChainServer struct {
+	ethpb.BeaconChainServer
+	proposerSlashings []*ethpb.ProposerSlashing
+}
+
+func (m *mockBeaconChainServer) SubmitProposerSlashing(_ context.Context, req *ethpb.ProposerSlashing) (*ethpb.SubmitSlashingResponse, error) {
+	m.proposerSlashings = append(m.proposerSlashings, req)
+	return &ethpb.SubmitSlashingResponse{}, nil
+}
+
+func testAttestationJSON() *attestationJSON {
+	return &attestationJSON{
+		AggregationBits: "0x03",
+		Data: &attestationDataJSON{
+			Slot:            "1",
+			Index:           "2",
+			BeaconBlockRoot: hexString(make([]byte, 32)),
+			Source:          &checkpointJSON{Epoch: "0", Root: hexString(make([]byte, 32))},
+			Target:          &checkpointJSON{Epoch: "0", Root: hexString(make([]byte, 32))},
+		},
+		Signature: hexString(make([]byte, 96)),
+	}
+}
+
+func TestSubmitPoolAttestations(t *testing.T) {
+	vs := &mockValidatorServer{}
+	s := &Server{ValidatorServer: vs}
+
+	invalid := testAttestationJSON()
+	invalid.Data.Slot = "one"
+	rec := post(t, s, "/eth/v1/beacon/pool/attestations", []*attestationJSON{testAttestationJSON(), invalid})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, received %d", rec.Code)
+	}
+	resp := &indexedErrorResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Failures) != 1 || resp.Failures[0].Index != 1 {
+		t.Errorf("Expected only the second attestation to fail, received %v", resp.Failures)
+	}
+	if len(vs.attestations) != 1 || vs.attestations[0].Data.Slot != 1 || vs.attestations[0].Data.CommitteeIndex != 2 {
+		t.Errorf("Expected the valid attestation to be submitted, received %v", vs.attestations)
+	}
+
+	if rec := post(t, s, "/eth/v1/beacon/pool/attestations", []*attestationJSON{testAttestationJSON()}); rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, received %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSubmitPoolProposerSlashing(t *testing.T) {
+	bs := &mockBeaconChainServer{}
+	s := &Server{BeaconChainServer: bs}
+
+	header := &signedBlockHeaderJSON{
+		Message: &blockHeaderJSON{
+			Slot:          "1",
+			ProposerIndex: "3",
+			ParentRoot:    hexString(make([]byte, 32)),
+			StateRoot:     hexString(make([]byte, 32)),
+			BodyRoot:      hexString(make([]byte, 32)),
+		},
+		Signature: hexString(make([]byte, 96)),
+	}
+	slashing := &proposerSlashingJSON{SignedHeader1: header, SignedHeader2: header}
+	if rec := post(t, s, "/eth/v1/beacon/pool/proposer_slashings", slashing); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, received %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(bs.proposerSlashings) != 1 || bs.proposerSlashings[0].Header_1.Header.ProposerIndex != 3 {
+		t.Errorf("Expected the proposer slashing to be submitted, received %v", bs.proposerSlashings)
+	}
+
+	if rec := post(t, s, "/eth/v1/beacon/pool/proposer_slashings", &proposerSlashingJSON{SignedHeader1: header}); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a slashing missing a header, received %d", rec.Code)
+	}
+}
+
+func TestSubmitPoolVoluntaryExit(t *testing.T) {
+	vs := &mockValidatorServer{}
+	s := &Server{ValidatorServer: vs}
+
+	exit := &signedVoluntaryExitJSON{
+		Message:   &voluntaryExitJSON{Epoch: "1", ValidatorIndex: "5"},
+		Signature: hexString(make([]byte, 96)),
+	}
+	if rec := post(t, s, "/eth/v1/beacon/pool/voluntary_exits", exit); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, received %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(vs.exits) != 1 || vs.exits[0].Exit.ValidatorIndex != 5 {
+		t.Errorf("Expected the voluntary exit to be submitted, received %v", vs.exits)
+	}
+
+	vs.exitErr = status.Error(codes.InvalidArgument, "invalid signature provided")
+	if rec := post(t, s, "/eth/v1/beacon/pool/voluntary_exits", exit); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a rejected exit, received %d", rec.Code)
+	}
+}
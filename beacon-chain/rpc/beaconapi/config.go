@@ -0,0 +1,83 @@
+package beaconapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+type depositContractJSON struct {
+	Address string `json:"address"`
+}
+
+// getSpec serves GET /eth/v1/config/spec. Every beacon config value with a YAML
+// name in the spec config files is returned under that name.
+func (s *Server) getSpec(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	val := reflect.ValueOf(params.BeaconConfig()).Elem()
+	res := make(map[string]string, val.NumField())
+	for i := 0; i < val.NumField(); i++ {
+		name := val.Type().Field(i).Tag.Get("yaml")
+		if name == "" {
+			continue
+		}
+		res[name] = specValue(val.Field(i))
+	}
+	writeData(w, res)
+}
+
+// getForkSchedule serves GET /eth/v1/config/fork_schedule.
+func (s *Server) getForkSchedule(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	schedule := params.BeaconConfig().ForkVersionSchedule
+	epochs := make([]uint64, 0, len(schedule))
+	for epoch := range schedule {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	res := make([]*forkJSON, 0, len(epochs))
+	previous := params.BeaconConfig().GenesisForkVersion
+	for _, epoch := range epochs {
+		res = append(res, forkToJSON(&pbp2p.Fork{
+			PreviousVersion: previous,
+			CurrentVersion:  schedule[epoch],
+			Epoch:           epoch,
+		}))
+		previous = schedule[epoch]
+	}
+	writeData(w, res)
+}
+
+// getDepositContract serves GET /eth/v1/config/deposit_contract. The eth1 chain ID is
+// not known to the beacon node, so only the contract address is returned.
+func (s *Server) getDepositContract(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	addr, err := s.BeaconDB.DepositContractAddress(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not retrieve deposit contract address: "+err.Error())
+		return
+	}
+	if len(addr) == 0 {
+		writeError(w, http.StatusNotFound, "Deposit contract address is not yet known")
+		return
+	}
+	writeData(w, &depositContractJSON{Address: common.BytesToAddress(addr).Hex()})
+}
+
+// specValue formats a config value the way the spec config files write it: byte
+// arrays and slices as 0x-prefixed hex and everything else in its default format.
+func specValue(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return hexString(v.Bytes())
+	case v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexString(b)
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
@@ -0,0 +1,52 @@
+package beaconapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestGetSpec(t *testing.T) {
+	res := make(map[string]string)
+	get(t, &Server{}, "/eth/v1/config/spec", &res)
+	if res["SLOTS_PER_EPOCH"] != uintString(params.BeaconConfig().SlotsPerEpoch) {
+		t.Errorf("Expected SLOTS_PER_EPOCH %d, received %s", params.BeaconConfig().SlotsPerEpoch, res["SLOTS_PER_EPOCH"])
+	}
+	if res["GENESIS_FORK_VERSION"] != hexString(params.BeaconConfig().GenesisForkVersion) {
+		t.Errorf("Expected GENESIS_FORK_VERSION %#x, received %s", params.BeaconConfig().GenesisForkVersion, res["GENESIS_FORK_VERSION"])
+	}
+	if _, ok := res["TargetAggregatorsPerCommittee"]; ok {
+		t.Error("Expected config values without a YAML name to be omitted")
+	}
+}
+
+func TestGetForkSchedule(t *testing.T) {
+	var res []*forkJSON
+	get(t, &Server{}, "/eth/v1/config/fork_schedule", &res)
+	if len(res) != len(params.BeaconConfig().ForkVersionSchedule) {
+		t.Fatalf("Expected %d forks, received %d", len(params.BeaconConfig().ForkVersionSchedule), len(res))
+	}
+	if res[0].PreviousVersion != hexString(params.BeaconConfig().GenesisForkVersion) {
+		t.Errorf("Expected first fork to follow the genesis fork version, received %s", res[0].PreviousVersion)
+	}
+}
+
+func TestGetDepositContract(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	s := &Server{BeaconDB: db}
+	expectStatus(t, s, "/eth/v1/config/deposit_contract", http.StatusNotFound)
+
+	addr := common.Address{1, 2, 3}
+	if err := db.SaveDepositContractAddress(context.Background(), addr); err != nil {
+		t.Fatal(err)
+	}
+	res := &depositContractJSON{}
+	get(t, s, "/eth/v1/config/deposit_contract", res)
+	if res.Address != addr.Hex() {
+		t.Errorf("Expected address %s, received %s", addr.Hex(), res.Address)
+	}
+}
@@ -0,0 +1,243 @@
+package beaconapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+const (
+	headTopic                = "head"
+	blockTopic               = "block"
+	attestationTopic         = "attestation"
+	voluntaryExitTopic       = "voluntary_exit"
+	finalizedCheckpointTopic = "finalized_checkpoint"
+	chainReorgTopic          = "chain_reorg"
+)
+
+// eventChannelSize is the number of feed events buffered per client. A client lagging
+// further behind is disconnected, so it never blocks the feeds it is subscribed to.
+const eventChannelSize = 64
+
+var stateTopics = map[string]bool{
+	headTopic:                true,
+	blockTopic:               true,
+	finalizedCheckpointTopic: true,
+	chainReorgTopic:          true,
+}
+
+var operationTopics = map[string]bool{
+	attestationTopic:   true,
+	voluntaryExitTopic: true,
+}
+
+type headEventJSON struct {
+	Slot            string `json:"slot"`
+	Block           string `json:"block"`
+	State           string `json:"state"`
+	EpochTransition bool   `json:"epoch_transition"`
+}
+
+type blockEventJSON struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block"`
+}
+
+type finalizedCheckpointEventJSON struct {
+	Block string `json:"block"`
+	State string `json:"state"`
+	Epoch string `json:"epoch"`
+}
+
+type chainReorgEventJSON struct {
+	Slot         string `json:"slot"`
+	Depth        string `json:"depth"`
+	OldHeadBlock string `json:"old_head_block"`
+	NewHeadBlock string `json:"new_head_block"`
+	Epoch        string `json:"epoch"`
+}
+
+// streamEvents serves GET /eth/v1/events as a server-sent event stream. Every event
+// on one of the requested topics is written as an "event: <topic>" line followed by
+// a "data: <json>" line, until the client disconnects.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	topics := queryValues(r, "topics")
+	if len(topics) == 0 {
+		writeError(w, http.StatusBadRequest, "No topics requested")
+		return
+	}
+	requested := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		if !stateTopics[topic] && !operationTopics[topic] {
+			writeError(w, http.StatusBadRequest, "Invalid topic: "+topic)
+			return
+		}
+		requested[topic] = true
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	// The stream ends once a feed subscription ends, or the client lags behind the feeds.
+	stopped := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() {
+			close(stopped)
+		})
+	}
+
+	// Only feeds with a requested topic are subscribed to. Receiving from the nil
+	// channels of the others blocks forever, which leaves them out of the select below.
+	var stateChannel, opChannel chan *feed.Event
+	for topic := range requested {
+		if stateTopics[topic] && stateChannel == nil {
+			stateChannel = make(chan *feed.Event, eventChannelSize)
+			received := make(chan *feed.Event, 1)
+			sub := s.StateNotifier.StateFeed().Subscribe(received)
+			go forwardEvents(ctx, sub, received, stateChannel, stop)
+		}
+		if operationTopics[topic] && opChannel == nil {
+			opChannel = make(chan *feed.Event, eventChannelSize)
+			received := make(chan *feed.Event, 1)
+			sub := s.OperationNotifier.OperationFeed().Subscribe(received)
+			go forwardEvents(ctx, sub, received, opChannel, stop)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		var topic string
+		var data interface{}
+		select {
+		case ev := <-stateChannel:
+			topic, data = stateEvent(ev)
+		case ev := <-opChannel:
+			topic, data = operationEvent(ev)
+		case <-stopped:
+			return
+		case <-ctx.Done():
+			return
+		}
+		if topic == "" || !requested[topic] {
+			continue
+		}
+		if err := writeEvent(w, topic, data); err != nil {
+			log.WithError(err).Debug("Could not write event, closing stream")
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// forwardEvents forwards the events received from a feed subscription to the buffered events
+// channel of a stream until the context is done, so that the feed never waits on the client of
+// the stream. If the buffer is full the client is lagging: the subscription ends and stop is
+// called, as it is when the subscription fails.
+func forwardEvents(ctx context.Context, sub event.Subscription, received <-chan *feed.Event, events chan<- *feed.Event, stop func()) {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case ev := <-received:
+			select {
+			case events <- ev:
+			default:
+				log.Debug("Event stream client is lagging, closing stream")
+				sub.Unsubscribe()
+				stop()
+				return
+			}
+		case <-sub.Err():
+			stop()
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stateEvent converts a state feed event to its topic and JSON representation. An
+// empty topic is returned for events which are not part of the event stream.
+func stateEvent(ev *feed.Event) (string, interface{}) {
+	switch data := ev.Data.(type) {
+	case *statefeed.NewHeadData:
+		return headTopic, &headEventJSON{
+			Slot:            uintString(data.Slot),
+			Block:           hexString(data.BlockRoot[:]),
+			State:           hexString(data.StateRoot[:]),
+			EpochTransition: data.EpochTransition,
+		}
+	case *statefeed.BlockProcessedData:
+		return blockTopic, &blockEventJSON{
+			Slot:  uintString(data.Slot),
+			Block: hexString(data.BlockRoot[:]),
+		}
+	case *statefeed.FinalizedCheckpointData:
+		return finalizedCheckpointTopic, &finalizedCheckpointEventJSON{
+			Block: hexString(data.BlockRoot[:]),
+			State: hexString(data.StateRoot[:]),
+			Epoch: uintString(data.Epoch),
+		}
+	case *statefeed.ReorgData:
+		return chainReorgTopic, &chainReorgEventJSON{
+			Slot:         uintString(data.NewSlot),
+			Depth:        uintString(data.Depth),
+			OldHeadBlock: hexString(data.OldHeadBlockRoot[:]),
+			NewHeadBlock: hexString(data.NewHeadBlockRoot[:]),
+			Epoch:        uintString(helpers.SlotToEpoch(data.NewSlot)),
+		}
+	default:
+		return "", nil
+	}
+}
+
+// operationEvent converts an operation feed event to its topic and JSON representation.
+// An empty topic is returned for events which are not part of the event stream.
+func operationEvent(ev *feed.Event) (string, interface{}) {
+	switch data := ev.Data.(type) {
+	case *opfeed.UnAggregatedAttReceivedData:
+		if data.Attestation == nil {
+			return "", nil
+		}
+		return attestationTopic, attestationToJSON(data.Attestation)
+	case *opfeed.AggregatedAttReceivedData:
+		if data.Attestation == nil || data.Attestation.Aggregate == nil {
+			return "", nil
+		}
+		return attestationTopic, attestationToJSON(data.Attestation.Aggregate)
+	case *opfeed.ExitReceivedData:
+		if data.Exit == nil {
+			return "", nil
+		}
+		return voluntaryExitTopic, voluntaryExitsToJSON([]*ethpb.SignedVoluntaryExit{data.Exit})[0]
+	default:
+		return "", nil
+	}
+}
+
+func writeEvent(w http.ResponseWriter, topic string, data interface{}) error {
+	enc, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", topic, enc)
+	return err
+}
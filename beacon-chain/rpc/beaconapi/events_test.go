@@ -0,0 +1,157 @@
+package beaconapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+func TestStreamEvents_InvalidTopics(t *testing.T) {
+	s := &Server{}
+	expectStatus(t, s, "/eth/v1/events", http.StatusBadRequest)
+	expectStatus(t, s, "/eth/v1/events?topics=head,unknown", http.StatusBadRequest)
+}
+
+func TestStreamEvents(t *testing.T) {
+	chain := &mock.ChainService{}
+	// Create the feeds before the handler and the test use them concurrently.
+	stateFeed := chain.StateNotifier().StateFeed()
+	opFeed := chain.OperationNotifier().OperationFeed()
+	s := &Server{StateNotifier: chain.StateNotifier(), OperationNotifier: chain.OperationNotifier()}
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/eth/v1/events?topics=head,chain_reorg,voluntary_exit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, received %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected event stream content type, received %s", ct)
+	}
+
+	// Wait for the handler to subscribe to both feeds.
+	for stateFeed.Send(&feed.Event{Type: statefeed.Synced, Data: &statefeed.SyncedData{}}) == 0 ||
+		opFeed.Send(&feed.Event{Type: opfeed.UnaggregatedAttReceived, Data: &opfeed.UnAggregatedAttReceivedData{}}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Block events were not requested and must not be streamed.
+	stateFeed.Send(&feed.Event{Type: statefeed.BlockProcessed, Data: &statefeed.BlockProcessedData{Slot: 3}})
+	stateFeed.Send(&feed.Event{
+		Type: statefeed.NewHead,
+		Data: &statefeed.NewHeadData{Slot: 4, BlockRoot: [32]byte{'a'}, StateRoot: [32]byte{'b'}},
+	})
+	stateFeed.Send(&feed.Event{
+		Type: statefeed.Reorg,
+		Data: &statefeed.ReorgData{NewSlot: 5, OldSlot: 4, NewHeadBlockRoot: [32]byte{'c'}, OldHeadBlockRoot: [32]byte{'a'}, Depth: 2},
+	})
+
+	reader := bufio.NewReader(resp.Body)
+	topic, data := readEvent(t, reader)
+	head := &headEventJSON{}
+	if err := json.Unmarshal(data, head); err != nil {
+		t.Fatal(err)
+	}
+	blockRoot, stateRoot := [32]byte{'a'}, [32]byte{'b'}
+	want := &headEventJSON{Slot: "4", Block: hexString(blockRoot[:]), State: hexString(stateRoot[:])}
+	if topic != headTopic || *head != *want {
+		t.Errorf("Expected head event %v, received %s %v", want, topic, head)
+	}
+
+	topic, data = readEvent(t, reader)
+	reorg := &chainReorgEventJSON{}
+	if err := json.Unmarshal(data, reorg); err != nil {
+		t.Fatal(err)
+	}
+	if topic != chainReorgTopic || reorg.Slot != "5" || reorg.Depth != "2" || reorg.OldHeadBlock != want.Block {
+		t.Errorf("Unexpected reorg event %s %v", topic, reorg)
+	}
+
+	opFeed.Send(&feed.Event{
+		Type: opfeed.ExitReceived,
+		Data: &opfeed.ExitReceivedData{Exit: &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 7}}},
+	})
+	topic, data = readEvent(t, reader)
+	exit := &signedVoluntaryExitJSON{}
+	if err := json.Unmarshal(data, exit); err != nil {
+		t.Fatal(err)
+	}
+	if topic != voluntaryExitTopic || exit.Message == nil || exit.Message.ValidatorIndex != "7" {
+		t.Errorf("Unexpected voluntary exit event %s %v", topic, exit)
+	}
+}
+
+func TestForwardEvents_StopsLaggingClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := new(event.Feed)
+	received := make(chan *feed.Event, 1)
+	sub := f.Subscribe(received)
+	events := make(chan *feed.Event, 1)
+	stopped := make(chan struct{})
+	go forwardEvents(ctx, sub, received, events, func() {
+		close(stopped)
+	})
+
+	// The client never reads its events: the feed must not wait on it.
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			f.Send(&feed.Event{Type: statefeed.BlockProcessed})
+		}
+		close(sent)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the lagging client to be stopped")
+	}
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the feed not to be blocked by the lagging client")
+	}
+	if n := f.Send(&feed.Event{Type: statefeed.BlockProcessed}); n != 0 {
+		t.Errorf("Expected the lagging client to be unsubscribed, sent to %d subscribers", n)
+	}
+}
+
+func readEvent(t *testing.T, r *bufio.Reader) (string, []byte) {
+	var topic, data string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return topic, []byte(data)
+		case strings.HasPrefix(line, "event: "):
+			topic = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		default:
+			t.Fatalf("Unexpected line in event stream: %q", line)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package beaconapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Named identifiers accepted for the {state_id} and {block_id} path parameters.
+// Both also accept a slot number or a 0x-prefixed 32 byte root.
+const (
+	idHead      = "head"
+	idGenesis   = "genesis"
+	idFinalized = "finalized"
+	idJustified = "justified"
+)
+
+// stateByID resolves a {state_id}: head, genesis, finalized, justified, a slot,
+// or the hex encoded root of a state within the head state's history.
+func (s *Server) stateByID(ctx context.Context, stateID string) (*stateTrie.BeaconState, error) {
+	var st *stateTrie.BeaconState
+	var err error
+	switch stateID {
+	case idHead:
+		st, err = s.HeadFetcher.HeadState(ctx)
+	case idGenesis:
+		st, err = s.BeaconDB.GenesisState(ctx)
+	case idFinalized:
+		st, err = s.stateByBlockRoot(ctx, bytesutil.ToBytes32(s.FinalizationFetcher.FinalizedCheckpt().Root))
+	case idJustified:
+		st, err = s.stateByBlockRoot(ctx, bytesutil.ToBytes32(s.FinalizationFetcher.CurrentJustifiedCheckpt().Root))
+	default:
+		if root, ok, parseErr := parseRoot(stateID); parseErr != nil {
+			return nil, parseErr
+		} else if ok {
+			st, err = s.stateByStateRoot(ctx, root)
+			break
+		}
+		slot, parseErr := parseUint(stateID, "state_id")
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		st, err = s.stateBySlot(ctx, slot)
+	}
+	if err != nil {
+		if _, ok := err.(*apiError); ok {
+			return nil, err
+		}
+		return nil, errors.Wrapf(err, "could not retrieve state %s", stateID)
+	}
+	if st == nil {
+		return nil, newAPIError(http.StatusNotFound, "State %s not found", stateID)
+	}
+	return st, nil
+}
+
+func (s *Server) stateByBlockRoot(ctx context.Context, blockRoot [32]byte) (*stateTrie.BeaconState, error) {
+	if featureconfig.Get().NewStateMgmt {
+		return s.StateGen.StateByRoot(ctx, blockRoot)
+	}
+	return s.BeaconDB.State(ctx, blockRoot)
+}
+
+func (s *Server) stateBySlot(ctx context.Context, slot uint64) (*stateTrie.BeaconState, error) {
+	if slot > s.HeadFetcher.HeadSlot() {
+		return nil, newAPIError(http.StatusNotFound, "State for future slot %d not found", slot)
+	}
+	if featureconfig.Get().NewStateMgmt {
+		return s.StateGen.StateBySlot(ctx, slot)
+	}
+	states, err := s.BeaconDB.HighestSlotStatesBelow(ctx, slot+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 || states[0] == nil {
+		return nil, nil
+	}
+	st := states[0]
+	if st.Slot() < slot {
+		return state.ProcessSlots(ctx, st.Copy(), slot)
+	}
+	return st, nil
+}
+
+// stateByStateRoot finds the state with the given root among the head state and
+// the states recorded in its state roots history.
+func (s *Server) stateByStateRoot(ctx context.Context, root [32]byte) (*stateTrie.BeaconState, error) {
+	headState, err := s.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headRoot, err := headState.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if headRoot == root {
+		return headState, nil
+	}
+	stateRoots := headState.StateRoots()
+	historyLength := params.BeaconConfig().SlotsPerHistoricalRoot
+	headSlot := headState.Slot()
+	for slot := headSlot; slot > 0 && headSlot-slot < historyLength; slot-- {
+		if bytes.Equal(stateRoots[(slot-1)%historyLength], root[:]) {
+			return s.stateBySlot(ctx, slot-1)
+		}
+	}
+	return nil, nil
+}
+
+// blockByID resolves a {block_id}: head, genesis, finalized, a slot, or a block
+// root, returning the block together with its root.
+func (s *Server) blockByID(ctx context.Context, blockID string) (*ethpb.SignedBeaconBlock, [32]byte, error) {
+	var blk *ethpb.SignedBeaconBlock
+	var err error
+	switch blockID {
+	case idHead:
+		blk, err = s.HeadFetcher.HeadBlock(ctx)
+	case idGenesis:
+		blk, err = s.BeaconDB.GenesisBlock(ctx)
+	case idFinalized:
+		blk, err = s.BeaconDB.Block(ctx, bytesutil.ToBytes32(s.FinalizationFetcher.FinalizedCheckpt().Root))
+	default:
+		if root, ok, parseErr := parseRoot(blockID); parseErr != nil {
+			return nil, [32]byte{}, parseErr
+		} else if ok {
+			blk, err = s.BeaconDB.Block(ctx, root)
+			break
+		}
+		slot, parseErr := parseUint(blockID, "block_id")
+		if parseErr != nil {
+			return nil, [32]byte{}, parseErr
+		}
+		blk, err = s.canonicalBlockAtSlot(ctx, slot)
+	}
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrapf(err, "could not retrieve block %s", blockID)
+	}
+	if blk == nil || blk.Block == nil {
+		return nil, [32]byte{}, newAPIError(http.StatusNotFound, "Block %s not found", blockID)
+	}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not compute block root")
+	}
+	return blk, root, nil
+}
+
+// canonicalBlockAtSlot returns the canonical block at slot, or nil if the slot was skipped.
+func (s *Server) canonicalBlockAtSlot(ctx context.Context, slot uint64) (*ethpb.SignedBeaconBlock, error) {
+	blks, err := s.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(slot).SetEndSlot(slot))
+	if err != nil {
+		return nil, err
+	}
+	for _, blk := range blks {
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			return nil, err
+		}
+		canonical, err := s.CanonicalFetcher.IsCanonical(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		if canonical {
+			return blk, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseRoot decodes a 0x-prefixed 32 byte root. It reports false if id is not
+// hex prefixed, and an error if it is but does not decode to 32 bytes.
+func parseRoot(id string) ([32]byte, bool, error) {
+	if !strings.HasPrefix(id, "0x") {
+		return [32]byte{}, false, nil
+	}
+	b, err := hex.DecodeString(id[2:])
+	if err != nil || len(b) != 32 {
+		return [32]byte{}, false, newAPIError(http.StatusBadRequest, "Invalid root %s", id)
+	}
+	return bytesutil.ToBytes32(b), true, nil
+}
+
+func parseUint(s string, name string) (uint64, error) {
+	i, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, newAPIError(http.StatusBadRequest, "Invalid %s %s", name, s)
+	}
+	return i, nil
+}
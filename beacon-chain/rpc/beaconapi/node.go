@@ -0,0 +1,165 @@
+package beaconapi
+
+import (
+	"net/http"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/shared/version"
+)
+
+type metadataJSON struct {
+	SeqNumber string `json:"seq_number"`
+	Attnets   string `json:"attnets"`
+}
+
+type identityJSON struct {
+	PeerID   string        `json:"peer_id"`
+	Metadata *metadataJSON `json:"metadata"`
+}
+
+type peerJSON struct {
+	PeerID    string `json:"peer_id"`
+	Address   string `json:"last_seen_p2p_address"`
+	State     string `json:"state"`
+	Direction string `json:"direction"`
+}
+
+type versionJSON struct {
+	Version string `json:"version"`
+}
+
+type syncingJSON struct {
+	HeadSlot     string `json:"head_slot"`
+	SyncDistance string `json:"sync_distance"`
+	IsSyncing    bool   `json:"is_syncing"`
+}
+
+// getIdentity serves GET /eth/v1/node/identity.
+func (s *Server) getIdentity(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	res := &identityJSON{PeerID: s.PeerManager.PeerID().Pretty()}
+	if md := s.MetadataProvider.Metadata(); md != nil {
+		res.Metadata = &metadataJSON{
+			SeqNumber: uintString(md.SeqNumber),
+			Attnets:   hexString(md.Attnets),
+		}
+	}
+	writeData(w, res)
+}
+
+// listPeers serves GET /eth/v1/node/peers, optionally filtered by the state and
+// direction query parameters.
+func (s *Server) listPeers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	states := queryValues(r, "state")
+	directions := queryValues(r, "direction")
+	res := make([]*peerJSON, 0)
+	for _, pid := range s.PeersFetcher.Peers().All() {
+		p, err := s.peerInfo(pid)
+		if err != nil {
+			continue
+		}
+		if !containsString(states, p.State) || !containsString(directions, p.Direction) {
+			continue
+		}
+		res = append(res, p)
+	}
+	writeData(w, res)
+}
+
+// getPeer serves GET /eth/v1/node/peers/{peer_id}.
+func (s *Server) getPeer(w http.ResponseWriter, _ *http.Request, p map[string]string) {
+	pid, err := peer.IDB58Decode(p["peer_id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid peer ID "+p["peer_id"])
+		return
+	}
+	info, err := s.peerInfo(pid)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Peer "+p["peer_id"]+" not found")
+		return
+	}
+	writeData(w, info)
+}
+
+// getVersion serves GET /eth/v1/node/version.
+func (s *Server) getVersion(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeData(w, &versionJSON{Version: version.GetVersion()})
+}
+
+// getSyncing serves GET /eth/v1/node/syncing.
+func (s *Server) getSyncing(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	headSlot := s.HeadFetcher.HeadSlot()
+	var distance uint64
+	if currentSlot := s.GenesisTimeFetcher.CurrentSlot(); currentSlot > headSlot {
+		distance = currentSlot - headSlot
+	}
+	writeData(w, &syncingJSON{
+		HeadSlot:     uintString(headSlot),
+		SyncDistance: uintString(distance),
+		IsSyncing:    s.SyncChecker.Syncing(),
+	})
+}
+
+// getHealth serves GET /eth/v1/node/health, replying 200 when the node is synced
+// and 206 while it is syncing.
+func (s *Server) getHealth(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	if s.SyncChecker.Syncing() {
+		w.WriteHeader(http.StatusPartialContent)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) peerInfo(pid peer.ID) (*peerJSON, error) {
+	status := s.PeersFetcher.Peers()
+	connState, err := status.ConnectionState(pid)
+	if err != nil {
+		return nil, err
+	}
+	direction, err := status.Direction(pid)
+	if err != nil {
+		return nil, err
+	}
+	res := &peerJSON{
+		PeerID:    pid.Pretty(),
+		State:     connectionStateString(connState),
+		Direction: "unknown",
+	}
+	switch direction {
+	case network.DirInbound:
+		res.Direction = "inbound"
+	case network.DirOutbound:
+		res.Direction = "outbound"
+	}
+	if address, err := status.Address(pid); err == nil && address != nil {
+		res.Address = address.String()
+	}
+	return res, nil
+}
+
+func connectionStateString(state peers.PeerConnectionState) string {
+	switch state {
+	case peers.PeerConnecting:
+		return "connecting"
+	case peers.PeerConnected:
+		return "connected"
+	case peers.PeerDisconnecting:
+		return "disconnecting"
+	default:
+		return "disconnected"
+	}
+}
+
+// containsString reports whether s is in values, treating no values as matching anything.
+func containsString(values []string, s string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
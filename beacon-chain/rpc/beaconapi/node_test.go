@@ -0,0 +1,103 @@
+package beaconapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/version"
+)
+
+func TestGetIdentity(t *testing.T) {
+	p2p := mockP2p.NewTestP2P(t)
+	p2p.LocalMetadata = &pb.MetaData{SeqNumber: 3, Attnets: []byte{1, 0, 0, 0, 0, 0, 0, 0}}
+	s := &Server{PeerManager: p2p, MetadataProvider: p2p}
+
+	res := &identityJSON{}
+	get(t, s, "/eth/v1/node/identity", res)
+	if res.PeerID != p2p.PeerID().Pretty() {
+		t.Errorf("Expected peer ID %s, received %s", p2p.PeerID().Pretty(), res.PeerID)
+	}
+	if res.Metadata == nil || res.Metadata.SeqNumber != "3" || res.Metadata.Attnets != "0x0100000000000000" {
+		t.Errorf("Unexpected metadata %v", res.Metadata)
+	}
+}
+
+func TestListPeers(t *testing.T) {
+	p2p := mockP2p.NewTestP2P(t)
+	pid, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2p.Peers().Add(new(enr.Record), pid, address, network.DirInbound)
+	p2p.Peers().SetConnectionState(pid, peers.PeerConnected)
+	s := &Server{PeersFetcher: p2p}
+
+	var res []*peerJSON
+	get(t, s, "/eth/v1/node/peers?state=connected", &res)
+	if len(res) != 1 {
+		t.Fatalf("Expected 1 connected peer, received %d", len(res))
+	}
+	want := &peerJSON{PeerID: pid.Pretty(), Address: address.String(), State: "connected", Direction: "inbound"}
+	if *res[0] != *want {
+		t.Errorf("Expected %v, received %v", want, res[0])
+	}
+	get(t, s, "/eth/v1/node/peers?direction=outbound", &res)
+	if len(res) != 0 {
+		t.Errorf("Expected no outbound peers, received %v", res)
+	}
+
+	p := &peerJSON{}
+	get(t, s, "/eth/v1/node/peers/"+pid.Pretty(), p)
+	if *p != *want {
+		t.Errorf("Expected %v, received %v", want, p)
+	}
+	expectStatus(t, s, "/eth/v1/node/peers/not-a-peer", http.StatusBadRequest)
+}
+
+func TestGetVersion(t *testing.T) {
+	res := &versionJSON{}
+	get(t, &Server{}, "/eth/v1/node/version", res)
+	if res.Version != version.GetVersion() {
+		t.Errorf("Expected version %s, received %s", version.GetVersion(), res.Version)
+	}
+}
+
+func TestGetSyncingAndHealth(t *testing.T) {
+	st := testutil.NewBeaconState()
+	if err := st.SetSlot(5); err != nil {
+		t.Fatal(err)
+	}
+	genesis := time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	chain := &mock.ChainService{State: st, Genesis: genesis}
+	s := &Server{HeadFetcher: chain, GenesisTimeFetcher: chain, SyncChecker: &mockSync.Sync{IsSyncing: true}}
+
+	res := &syncingJSON{}
+	get(t, s, "/eth/v1/node/syncing", res)
+	if res.HeadSlot != "5" || res.SyncDistance != "5" || !res.IsSyncing {
+		t.Errorf("Unexpected sync status %v", res)
+	}
+	if rec := serve(t, s, http.MethodGet, "/eth/v1/node/health"); rec.Code != http.StatusPartialContent {
+		t.Errorf("Expected status 206 while syncing, received %d", rec.Code)
+	}
+
+	s.SyncChecker = &mockSync.Sync{IsSyncing: false}
+	if rec := serve(t, s, http.MethodGet, "/eth/v1/node/health"); rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 when synced, received %d", rec.Code)
+	}
+}
@@ -0,0 +1,182 @@
+package beaconapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handlerFunc serves a request whose path matched a route. Path parameters
+// are keyed by their name in the route pattern, without braces.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// router is a minimal HTTP router matching paths segment by segment, where a
+// segment written as {name} in a pattern matches any single path segment.
+type router struct {
+	routes []*route
+}
+
+func (rt *router) handle(method string, pattern string, handler handlerFunc) {
+	rt.routes = append(rt.routes, &route{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+// ServeHTTP dispatches the request to the first route matching its path, replying
+// 405 if the path is known but not for the request method, and 404 otherwise.
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+	pathMatched := false
+	for _, rte := range rt.routes {
+		params, ok := rte.match(segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rte.method != r.Method {
+			continue
+		}
+		rte.handler(w, r, params)
+		return
+	}
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "Route not found")
+}
+
+func (rte *route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rte.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, s := range rte.segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			params[s[1:len(s)-1]] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// apiError is an error carrying the HTTP status code it should be reported with.
+type apiError struct {
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newAPIError(code int, format string, args ...interface{}) *apiError {
+	return &apiError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// errorResponse is the body of every non-2xx response.
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// indexedFailureJSON reports why one item of a request body holding a list was rejected.
+type indexedFailureJSON struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// indexedErrorResponse is the body of a 400 response to a request submitting a list of items,
+// some of which were rejected.
+type indexedErrorResponse struct {
+	Code     int                   `json:"code"`
+	Message  string                `json:"message"`
+	Failures []*indexedFailureJSON `json:"failures"`
+}
+
+// dataResponse wraps every successful response payload.
+type dataResponse struct {
+	Data interface{} `json:"data"`
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, &dataResponse{Data: data})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, &errorResponse{Code: code, Message: message})
+}
+
+// writeAPIError reports err with its status code if it is an *apiError, or as an
+// internal server error otherwise.
+func writeAPIError(w http.ResponseWriter, err error) {
+	if apiErr, ok := err.(*apiError); ok {
+		writeError(w, apiErr.code, apiErr.message)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+// writeFailures reports the items of a submitted list which were rejected, if any, and replies
+// 200 otherwise.
+func writeFailures(w http.ResponseWriter, message string, failures []*indexedFailureJSON) {
+	if len(failures) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, http.StatusBadRequest, &indexedErrorResponse{
+		Code:     http.StatusBadRequest,
+		Message:  message,
+		Failures: failures,
+	})
+}
+
+// decodeBody decodes the JSON request body into v.
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newAPIError(http.StatusBadRequest, "Invalid request body: %v", err)
+	}
+	return nil
+}
+
+// grpcToAPIError converts an error returned by one of the gRPC servers the API delegates to into
+// an *apiError with the matching HTTP status code.
+func grpcToAPIError(err error) error {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	}
+	return newAPIError(code, "%s", status.Convert(err).Message())
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("Could not write response")
+	}
+}
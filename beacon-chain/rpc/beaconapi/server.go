@@ -0,0 +1,95 @@
+// Package beaconapi implements the standard Eth2 Beacon Node REST API
+// (the /eth/v1 routes) natively over HTTP, backed by the same chain, database
+// and pool interfaces as the beacon node's gRPC servers.
+package beaconapi
+
+import (
+	"net/http"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "beacon-api")
+
+// Server defines a server implementation of the Eth2 Beacon Node REST API.
+type Server struct {
+	BeaconDB            db.ReadOnlyDatabase
+	HeadFetcher         blockchain.HeadFetcher
+	FinalizationFetcher blockchain.FinalizationFetcher
+	CanonicalFetcher    blockchain.CanonicalFetcher
+	GenesisTimeFetcher  blockchain.TimeFetcher
+	GenesisFetcher      blockchain.GenesisFetcher
+	StateGen            *stategen.State
+	SyncChecker         sync.Checker
+	PeersFetcher        p2p.PeersProvider
+	PeerManager         p2p.PeerManager
+	MetadataProvider    p2p.MetadataProvider
+	AttestationsPool    attestations.Pool
+	SlashingsPool       *slashings.Pool
+	ExitPool            *voluntaryexits.Pool
+	StateNotifier       statefeed.Notifier
+	OperationNotifier   opfeed.Notifier
+	ValidatorServer     ethpb.BeaconNodeValidatorServer
+	BeaconChainServer   ethpb.BeaconChainServer
+}
+
+// Handler returns the HTTP handler serving every implemented route.
+func (s *Server) Handler() http.Handler {
+	rt := &router{}
+
+	rt.handle(http.MethodGet, "/eth/v1/beacon/genesis", s.getGenesis)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/root", s.getStateRoot)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/fork", s.getStateFork)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/finality_checkpoints", s.getFinalityCheckpoints)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/validators", s.listValidators)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/validators/{validator_id}", s.getValidator)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/validator_balances", s.listValidatorBalances)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/states/{state_id}/committees", s.listCommittees)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/headers", s.listBlockHeaders)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/headers/{block_id}", s.getBlockHeader)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/blocks/{block_id}", s.getBlock)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/blocks/{block_id}/root", s.getBlockRoot)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/blocks/{block_id}/attestations", s.listBlockAttestations)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/pool/attestations", s.listPoolAttestations)
+	rt.handle(http.MethodPost, "/eth/v1/beacon/pool/attestations", s.submitPoolAttestations)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/pool/attester_slashings", s.listPoolAttesterSlashings)
+	rt.handle(http.MethodPost, "/eth/v1/beacon/pool/attester_slashings", s.submitPoolAttesterSlashing)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/pool/proposer_slashings", s.listPoolProposerSlashings)
+	rt.handle(http.MethodPost, "/eth/v1/beacon/pool/proposer_slashings", s.submitPoolProposerSlashing)
+	rt.handle(http.MethodGet, "/eth/v1/beacon/pool/voluntary_exits", s.listPoolVoluntaryExits)
+	rt.handle(http.MethodPost, "/eth/v1/beacon/pool/voluntary_exits", s.submitPoolVoluntaryExit)
+
+	rt.handle(http.MethodGet, "/eth/v1/node/identity", s.getIdentity)
+	rt.handle(http.MethodGet, "/eth/v1/node/peers", s.listPeers)
+	rt.handle(http.MethodGet, "/eth/v1/node/peers/{peer_id}", s.getPeer)
+	rt.handle(http.MethodGet, "/eth/v1/node/version", s.getVersion)
+	rt.handle(http.MethodGet, "/eth/v1/node/syncing", s.getSyncing)
+	rt.handle(http.MethodGet, "/eth/v1/node/health", s.getHealth)
+
+	rt.handle(http.MethodGet, "/eth/v1/validator/duties/attester/{epoch}", s.getAttesterDuties)
+	rt.handle(http.MethodGet, "/eth/v1/validator/duties/proposer/{epoch}", s.getProposerDuties)
+	rt.handle(http.MethodGet, "/eth/v1/validator/blocks/{slot}", s.produceBlock)
+	rt.handle(http.MethodGet, "/eth/v1/validator/attestation_data", s.produceAttestationData)
+	rt.handle(http.MethodGet, "/eth/v1/validator/aggregate_attestation", s.getAggregateAttestation)
+	rt.handle(http.MethodPost, "/eth/v1/validator/aggregate_and_proofs", s.submitAggregateAndProofs)
+	rt.handle(http.MethodPost, "/eth/v1/validator/beacon_committee_subscriptions", s.submitBeaconCommitteeSubscriptions)
+
+	rt.handle(http.MethodGet, "/eth/v1/config/spec", s.getSpec)
+	rt.handle(http.MethodGet, "/eth/v1/config/fork_schedule", s.getForkSchedule)
+	rt.handle(http.MethodGet, "/eth/v1/config/deposit_contract", s.getDepositContract)
+
+	rt.handle(http.MethodGet, "/eth/v1/events", s.streamEvents)
+
+	return rt
+}
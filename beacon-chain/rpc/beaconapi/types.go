@@ -0,0 +1,545 @@
+package beaconapi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// The Beacon API encodes every integer as a decimal string and every byte
+// array as a 0x-prefixed hex string, so responses use these JSON types rather
+// than the v1alpha1 protos.
+
+type genesisJSON struct {
+	GenesisTime           string `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+
+type rootJSON struct {
+	Root string `json:"root"`
+}
+
+type forkJSON struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+
+type checkpointJSON struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type finalityCheckpointsJSON struct {
+	PreviousJustified *checkpointJSON `json:"previous_justified"`
+	CurrentJustified  *checkpointJSON `json:"current_justified"`
+	Finalized         *checkpointJSON `json:"finalized"`
+}
+
+type validatorJSON struct {
+	PublicKey                  string `json:"pubkey"`
+	WithdrawalCredentials      string `json:"withdrawal_credentials"`
+	EffectiveBalance           string `json:"effective_balance"`
+	Slashed                    bool   `json:"slashed"`
+	ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+	ActivationEpoch            string `json:"activation_epoch"`
+	ExitEpoch                  string `json:"exit_epoch"`
+	WithdrawableEpoch          string `json:"withdrawable_epoch"`
+}
+
+type validatorContainerJSON struct {
+	Index     string         `json:"index"`
+	Balance   string         `json:"balance"`
+	Status    string         `json:"status"`
+	Validator *validatorJSON `json:"validator"`
+}
+
+type validatorBalanceJSON struct {
+	Index   string `json:"index"`
+	Balance string `json:"balance"`
+}
+
+type committeeJSON struct {
+	Index      string   `json:"index"`
+	Slot       string   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+
+type blockHeaderJSON struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type signedBlockHeaderJSON struct {
+	Message   *blockHeaderJSON `json:"message"`
+	Signature string           `json:"signature"`
+}
+
+type blockHeaderContainerJSON struct {
+	Root      string                 `json:"root"`
+	Canonical bool                   `json:"canonical"`
+	Header    *signedBlockHeaderJSON `json:"header"`
+}
+
+type eth1DataJSON struct {
+	DepositRoot  string `json:"deposit_root"`
+	DepositCount string `json:"deposit_count"`
+	BlockHash    string `json:"block_hash"`
+}
+
+type attestationDataJSON struct {
+	Slot            string          `json:"slot"`
+	Index           string          `json:"index"`
+	BeaconBlockRoot string          `json:"beacon_block_root"`
+	Source          *checkpointJSON `json:"source"`
+	Target          *checkpointJSON `json:"target"`
+}
+
+type attestationJSON struct {
+	AggregationBits string               `json:"aggregation_bits"`
+	Data            *attestationDataJSON `json:"data"`
+	Signature       string               `json:"signature"`
+}
+
+type indexedAttestationJSON struct {
+	AttestingIndices []string             `json:"attesting_indices"`
+	Data             *attestationDataJSON `json:"data"`
+	Signature        string               `json:"signature"`
+}
+
+type attesterSlashingJSON struct {
+	Attestation1 *indexedAttestationJSON `json:"attestation_1"`
+	Attestation2 *indexedAttestationJSON `json:"attestation_2"`
+}
+
+type proposerSlashingJSON struct {
+	SignedHeader1 *signedBlockHeaderJSON `json:"signed_header_1"`
+	SignedHeader2 *signedBlockHeaderJSON `json:"signed_header_2"`
+}
+
+type depositDataJSON struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+}
+
+type depositJSON struct {
+	Proof []string         `json:"proof"`
+	Data  *depositDataJSON `json:"data"`
+}
+
+type voluntaryExitJSON struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+type signedVoluntaryExitJSON struct {
+	Message   *voluntaryExitJSON `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type blockBodyJSON struct {
+	RandaoReveal      string                     `json:"randao_reveal"`
+	Eth1Data          *eth1DataJSON              `json:"eth1_data"`
+	Graffiti          string                     `json:"graffiti"`
+	ProposerSlashings []*proposerSlashingJSON    `json:"proposer_slashings"`
+	AttesterSlashings []*attesterSlashingJSON    `json:"attester_slashings"`
+	Attestations      []*attestationJSON         `json:"attestations"`
+	Deposits          []*depositJSON             `json:"deposits"`
+	VoluntaryExits    []*signedVoluntaryExitJSON `json:"voluntary_exits"`
+}
+
+type blockJSON struct {
+	Slot          string         `json:"slot"`
+	ProposerIndex string         `json:"proposer_index"`
+	ParentRoot    string         `json:"parent_root"`
+	StateRoot     string         `json:"state_root"`
+	Body          *blockBodyJSON `json:"body"`
+}
+
+type signedBlockJSON struct {
+	Message   *blockJSON `json:"message"`
+	Signature string     `json:"signature"`
+}
+
+type aggregateAndProofJSON struct {
+	AggregatorIndex string           `json:"aggregator_index"`
+	Aggregate       *attestationJSON `json:"aggregate"`
+	SelectionProof  string           `json:"selection_proof"`
+}
+
+type signedAggregateAndProofJSON struct {
+	Message   *aggregateAndProofJSON `json:"message"`
+	Signature string                 `json:"signature"`
+}
+
+type beaconCommitteeSubscriptionJSON struct {
+	ValidatorIndex   string `json:"validator_index"`
+	CommitteeIndex   string `json:"committee_index"`
+	CommitteesAtSlot string `json:"committees_at_slot"`
+	Slot             string `json:"slot"`
+	IsAggregator     bool   `json:"is_aggregator"`
+}
+
+func uintString(i uint64) string {
+	return strconv.FormatUint(i, 10)
+}
+
+func hexString(b []byte) string {
+	return fmt.Sprintf("%#x", b)
+}
+
+func uintStrings(is []uint64) []string {
+	res := make([]string, len(is))
+	for i, v := range is {
+		res[i] = uintString(v)
+	}
+	return res
+}
+
+func hexStrings(bs [][]byte) []string {
+	res := make([]string, len(bs))
+	for i, b := range bs {
+		res[i] = hexString(b)
+	}
+	return res
+}
+
+func forkToJSON(f *pbp2p.Fork) *forkJSON {
+	return &forkJSON{
+		PreviousVersion: hexString(f.PreviousVersion),
+		CurrentVersion:  hexString(f.CurrentVersion),
+		Epoch:           uintString(f.Epoch),
+	}
+}
+
+func checkpointToJSON(c *ethpb.Checkpoint) *checkpointJSON {
+	if c == nil {
+		return nil
+	}
+	return &checkpointJSON{
+		Epoch: uintString(c.Epoch),
+		Root:  hexString(c.Root),
+	}
+}
+
+func validatorToJSON(v *ethpb.Validator) *validatorJSON {
+	return &validatorJSON{
+		PublicKey:                  hexString(v.PublicKey),
+		WithdrawalCredentials:      hexString(v.WithdrawalCredentials),
+		EffectiveBalance:           uintString(v.EffectiveBalance),
+		Slashed:                    v.Slashed,
+		ActivationEligibilityEpoch: uintString(v.ActivationEligibilityEpoch),
+		ActivationEpoch:            uintString(v.ActivationEpoch),
+		ExitEpoch:                  uintString(v.ExitEpoch),
+		WithdrawableEpoch:          uintString(v.WithdrawableEpoch),
+	}
+}
+
+func blockHeaderToJSON(h *ethpb.BeaconBlockHeader) *blockHeaderJSON {
+	return &blockHeaderJSON{
+		Slot:          uintString(h.Slot),
+		ProposerIndex: uintString(h.ProposerIndex),
+		ParentRoot:    hexString(h.ParentRoot),
+		StateRoot:     hexString(h.StateRoot),
+		BodyRoot:      hexString(h.BodyRoot),
+	}
+}
+
+func signedBlockHeaderToJSON(h *ethpb.SignedBeaconBlockHeader) *signedBlockHeaderJSON {
+	if h == nil || h.Header == nil {
+		return nil
+	}
+	return &signedBlockHeaderJSON{
+		Message:   blockHeaderToJSON(h.Header),
+		Signature: hexString(h.Signature),
+	}
+}
+
+func eth1DataToJSON(d *ethpb.Eth1Data) *eth1DataJSON {
+	if d == nil {
+		return nil
+	}
+	return &eth1DataJSON{
+		DepositRoot:  hexString(d.DepositRoot),
+		DepositCount: uintString(d.DepositCount),
+		BlockHash:    hexString(d.BlockHash),
+	}
+}
+
+func attestationDataToJSON(d *ethpb.AttestationData) *attestationDataJSON {
+	if d == nil {
+		return nil
+	}
+	return &attestationDataJSON{
+		Slot:            uintString(d.Slot),
+		Index:           uintString(d.CommitteeIndex),
+		BeaconBlockRoot: hexString(d.BeaconBlockRoot),
+		Source:          checkpointToJSON(d.Source),
+		Target:          checkpointToJSON(d.Target),
+	}
+}
+
+func attestationToJSON(a *ethpb.Attestation) *attestationJSON {
+	return &attestationJSON{
+		AggregationBits: hexString(a.AggregationBits),
+		Data:            attestationDataToJSON(a.Data),
+		Signature:       hexString(a.Signature),
+	}
+}
+
+func attestationsToJSON(atts []*ethpb.Attestation) []*attestationJSON {
+	res := make([]*attestationJSON, len(atts))
+	for i, a := range atts {
+		res[i] = attestationToJSON(a)
+	}
+	return res
+}
+
+func indexedAttestationToJSON(a *ethpb.IndexedAttestation) *indexedAttestationJSON {
+	if a == nil {
+		return nil
+	}
+	return &indexedAttestationJSON{
+		AttestingIndices: uintStrings(a.AttestingIndices),
+		Data:             attestationDataToJSON(a.Data),
+		Signature:        hexString(a.Signature),
+	}
+}
+
+func attesterSlashingsToJSON(slashings []*ethpb.AttesterSlashing) []*attesterSlashingJSON {
+	res := make([]*attesterSlashingJSON, len(slashings))
+	for i, s := range slashings {
+		res[i] = &attesterSlashingJSON{
+			Attestation1: indexedAttestationToJSON(s.Attestation_1),
+			Attestation2: indexedAttestationToJSON(s.Attestation_2),
+		}
+	}
+	return res
+}
+
+func proposerSlashingsToJSON(slashings []*ethpb.ProposerSlashing) []*proposerSlashingJSON {
+	res := make([]*proposerSlashingJSON, len(slashings))
+	for i, s := range slashings {
+		res[i] = &proposerSlashingJSON{
+			SignedHeader1: signedBlockHeaderToJSON(s.Header_1),
+			SignedHeader2: signedBlockHeaderToJSON(s.Header_2),
+		}
+	}
+	return res
+}
+
+func depositsToJSON(deposits []*ethpb.Deposit) []*depositJSON {
+	res := make([]*depositJSON, len(deposits))
+	for i, d := range deposits {
+		res[i] = &depositJSON{Proof: hexStrings(d.Proof)}
+		if d.Data != nil {
+			res[i].Data = &depositDataJSON{
+				PublicKey:             hexString(d.Data.PublicKey),
+				WithdrawalCredentials: hexString(d.Data.WithdrawalCredentials),
+				Amount:                uintString(d.Data.Amount),
+				Signature:             hexString(d.Data.Signature),
+			}
+		}
+	}
+	return res
+}
+
+func voluntaryExitsToJSON(exits []*ethpb.SignedVoluntaryExit) []*signedVoluntaryExitJSON {
+	res := make([]*signedVoluntaryExitJSON, len(exits))
+	for i, e := range exits {
+		res[i] = &signedVoluntaryExitJSON{Signature: hexString(e.Signature)}
+		if e.Exit != nil {
+			res[i].Message = &voluntaryExitJSON{
+				Epoch:          uintString(e.Exit.Epoch),
+				ValidatorIndex: uintString(e.Exit.ValidatorIndex),
+			}
+		}
+	}
+	return res
+}
+
+func signedBlockToJSON(b *ethpb.SignedBeaconBlock) *signedBlockJSON {
+	return &signedBlockJSON{
+		Message:   blockToJSON(b.Block),
+		Signature: hexString(b.Signature),
+	}
+}
+
+func blockToJSON(blk *ethpb.BeaconBlock) *blockJSON {
+	res := &blockJSON{
+		Slot:          uintString(blk.Slot),
+		ProposerIndex: uintString(blk.ProposerIndex),
+		ParentRoot:    hexString(blk.ParentRoot),
+		StateRoot:     hexString(blk.StateRoot),
+	}
+	if body := blk.Body; body != nil {
+		res.Body = &blockBodyJSON{
+			RandaoReveal:      hexString(body.RandaoReveal),
+			Eth1Data:          eth1DataToJSON(body.Eth1Data),
+			Graffiti:          hexString(body.Graffiti),
+			ProposerSlashings: proposerSlashingsToJSON(body.ProposerSlashings),
+			AttesterSlashings: attesterSlashingsToJSON(body.AttesterSlashings),
+			Attestations:      attestationsToJSON(body.Attestations),
+			Deposits:          depositsToJSON(body.Deposits),
+			VoluntaryExits:    voluntaryExitsToJSON(body.VoluntaryExits),
+		}
+	}
+	return res
+}
+
+// jsonDecoder converts request bodies in the Beacon API JSON types back to the v1alpha1
+// protos. It records the first invalid or missing field, so a whole object can be converted
+// before checking err once.
+type jsonDecoder struct {
+	err error
+}
+
+func (d *jsonDecoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = newAPIError(http.StatusBadRequest, format, args...)
+	}
+}
+
+func (d *jsonDecoder) uint(s string, name string) uint64 {
+	i, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		d.fail("Invalid %s %q", name, s)
+	}
+	return i
+}
+
+func (d *jsonDecoder) hex(s string, name string) []byte {
+	if !strings.HasPrefix(s, "0x") {
+		d.fail("Invalid %s %q", name, s)
+		return nil
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		d.fail("Invalid %s %q", name, s)
+	}
+	return b
+}
+
+func (d *jsonDecoder) checkpoint(c *checkpointJSON, name string) *ethpb.Checkpoint {
+	if c == nil {
+		d.fail("Missing %s", name)
+		return nil
+	}
+	return &ethpb.Checkpoint{
+		Epoch: d.uint(c.Epoch, name+".epoch"),
+		Root:  d.hex(c.Root, name+".root"),
+	}
+}
+
+func (d *jsonDecoder) attestationData(a *attestationDataJSON) *ethpb.AttestationData {
+	if a == nil {
+		d.fail("Missing attestation data")
+		return nil
+	}
+	return &ethpb.AttestationData{
+		Slot:            d.uint(a.Slot, "slot"),
+		CommitteeIndex:  d.uint(a.Index, "index"),
+		BeaconBlockRoot: d.hex(a.BeaconBlockRoot, "beacon_block_root"),
+		Source:          d.checkpoint(a.Source, "source"),
+		Target:          d.checkpoint(a.Target, "target"),
+	}
+}
+
+func (d *jsonDecoder) attestation(a *attestationJSON) *ethpb.Attestation {
+	if a == nil {
+		d.fail("Missing attestation")
+		return nil
+	}
+	return &ethpb.Attestation{
+		AggregationBits: d.hex(a.AggregationBits, "aggregation_bits"),
+		Data:            d.attestationData(a.Data),
+		Signature:       d.hex(a.Signature, "signature"),
+	}
+}
+
+func (d *jsonDecoder) indexedAttestation(a *indexedAttestationJSON) *ethpb.IndexedAttestation {
+	if a == nil {
+		d.fail("Missing indexed attestation")
+		return nil
+	}
+	indices := make([]uint64, len(a.AttestingIndices))
+	for i, idx := range a.AttestingIndices {
+		indices[i] = d.uint(idx, "attesting_indices")
+	}
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             d.attestationData(a.Data),
+		Signature:        d.hex(a.Signature, "signature"),
+	}
+}
+
+func (d *jsonDecoder) attesterSlashing(s *attesterSlashingJSON) *ethpb.AttesterSlashing {
+	return &ethpb.AttesterSlashing{
+		Attestation_1: d.indexedAttestation(s.Attestation1),
+		Attestation_2: d.indexedAttestation(s.Attestation2),
+	}
+}
+
+func (d *jsonDecoder) signedBlockHeader(h *signedBlockHeaderJSON) *ethpb.SignedBeaconBlockHeader {
+	if h == nil || h.Message == nil {
+		d.fail("Missing block header")
+		return nil
+	}
+	return &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          d.uint(h.Message.Slot, "slot"),
+			ProposerIndex: d.uint(h.Message.ProposerIndex, "proposer_index"),
+			ParentRoot:    d.hex(h.Message.ParentRoot, "parent_root"),
+			StateRoot:     d.hex(h.Message.StateRoot, "state_root"),
+			BodyRoot:      d.hex(h.Message.BodyRoot, "body_root"),
+		},
+		Signature: d.hex(h.Signature, "signature"),
+	}
+}
+
+func (d *jsonDecoder) proposerSlashing(s *proposerSlashingJSON) *ethpb.ProposerSlashing {
+	return &ethpb.ProposerSlashing{
+		Header_1: d.signedBlockHeader(s.SignedHeader1),
+		Header_2: d.signedBlockHeader(s.SignedHeader2),
+	}
+}
+
+func (d *jsonDecoder) signedVoluntaryExit(e *signedVoluntaryExitJSON) *ethpb.SignedVoluntaryExit {
+	if e.Message == nil {
+		d.fail("Missing voluntary exit")
+		return nil
+	}
+	return &ethpb.SignedVoluntaryExit{
+		Exit: &ethpb.VoluntaryExit{
+			Epoch:          d.uint(e.Message.Epoch, "epoch"),
+			ValidatorIndex: d.uint(e.Message.ValidatorIndex, "validator_index"),
+		},
+		Signature: d.hex(e.Signature, "signature"),
+	}
+}
+
+func (d *jsonDecoder) signedAggregateAndProof(a *signedAggregateAndProofJSON) *ethpb.SignedAggregateAttestationAndProof {
+	if a == nil || a.Message == nil {
+		d.fail("Missing aggregate and proof")
+		return nil
+	}
+	return &ethpb.SignedAggregateAttestationAndProof{
+		Message: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: d.uint(a.Message.AggregatorIndex, "aggregator_index"),
+			Aggregate:       d.attestation(a.Message.Aggregate),
+			SelectionProof:  d.hex(a.Message.SelectionProof, "selection_proof"),
+		},
+		Signature: d.hex(a.Signature, "signature"),
+	}
+}
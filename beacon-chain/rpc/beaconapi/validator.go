@@ -0,0 +1,304 @@
+package beaconapi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+type attesterDutyJSON struct {
+	PublicKey               string `json:"pubkey"`
+	ValidatorIndex          string `json:"validator_index"`
+	CommitteeIndex          string `json:"committee_index"`
+	CommitteeLength         string `json:"committee_length"`
+	CommitteesAtSlot        string `json:"committees_at_slot"`
+	ValidatorCommitteeIndex string `json:"validator_committee_index"`
+	Slot                    string `json:"slot"`
+}
+
+type proposerDutyJSON struct {
+	PublicKey      string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+}
+
+// getAttesterDuties serves GET /eth/v1/validator/duties/attester/{epoch} for the
+// validator indices given in the index query parameter.
+func (s *Server) getAttesterDuties(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, epoch, err := s.dutiesState(r.Context(), p["epoch"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	indices, err := validatorIndices(st, queryValues(r, "index"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	activeCount, err := helpers.ActiveValidatorCount(st, epoch)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not get active validator count: "+err.Error())
+		return
+	}
+	committeesAtSlot := helpers.SlotCommitteeCount(activeCount)
+	assignments, _, err := helpers.CommitteeAssignments(st.Copy(), epoch)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not compute committee assignments: "+err.Error())
+		return
+	}
+
+	res := make([]*attesterDutyJSON, 0, len(indices))
+	for _, idx := range indices {
+		assignment, ok := assignments[idx]
+		if !ok {
+			continue
+		}
+		position := 0
+		for i, member := range assignment.Committee {
+			if member == idx {
+				position = i
+				break
+			}
+		}
+		pubKey := st.PubkeyAtIndex(idx)
+		res = append(res, &attesterDutyJSON{
+			PublicKey:               hexString(pubKey[:]),
+			ValidatorIndex:          uintString(idx),
+			CommitteeIndex:          uintString(assignment.CommitteeIndex),
+			CommitteeLength:         uintString(uint64(len(assignment.Committee))),
+			CommitteesAtSlot:        uintString(committeesAtSlot),
+			ValidatorCommitteeIndex: uintString(uint64(position)),
+			Slot:                    uintString(assignment.AttesterSlot),
+		})
+	}
+	writeData(w, res)
+}
+
+// getProposerDuties serves GET /eth/v1/validator/duties/proposer/{epoch}.
+func (s *Server) getProposerDuties(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	st, epoch, err := s.dutiesState(r.Context(), p["epoch"])
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	_, proposerIndexToSlots, err := helpers.CommitteeAssignments(st.Copy(), epoch)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not compute committee assignments: "+err.Error())
+		return
+	}
+	slotToProposer := make(map[uint64]uint64, len(proposerIndexToSlots))
+	slots := make([]uint64, 0, len(proposerIndexToSlots))
+	for idx, proposerSlots := range proposerIndexToSlots {
+		for _, slot := range proposerSlots {
+			slotToProposer[slot] = idx
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	res := make([]*proposerDutyJSON, 0, len(slots))
+	for _, slot := range slots {
+		idx := slotToProposer[slot]
+		pubKey := st.PubkeyAtIndex(idx)
+		res = append(res, &proposerDutyJSON{
+			PublicKey:      hexString(pubKey[:]),
+			ValidatorIndex: uintString(idx),
+			Slot:           uintString(slot),
+		})
+	}
+	writeData(w, res)
+}
+
+// produceBlock serves GET /eth/v1/validator/blocks/{slot}, returning an unsigned block built
+// with the randao_reveal and optional graffiti query parameters.
+func (s *Server) produceBlock(w http.ResponseWriter, r *http.Request, p map[string]string) {
+	slot, err := parseUint(p["slot"], "slot")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	d := &jsonDecoder{}
+	randaoReveal := d.hex(r.URL.Query().Get("randao_reveal"), "randao_reveal")
+	var graffiti []byte
+	if g := r.URL.Query().Get("graffiti"); g != "" {
+		graffiti = d.hex(g, "graffiti")
+	}
+	if d.err != nil {
+		writeAPIError(w, d.err)
+		return
+	}
+	blk, err := s.ValidatorServer.GetBlock(r.Context(), &ethpb.BlockRequest{
+		Slot:         slot,
+		RandaoReveal: randaoReveal,
+		Graffiti:     graffiti,
+	})
+	if err != nil {
+		writeAPIError(w, grpcToAPIError(err))
+		return
+	}
+	writeData(w, blockToJSON(blk))
+}
+
+// produceAttestationData serves GET /eth/v1/validator/attestation_data for the slot and
+// committee_index query parameters.
+func (s *Server) produceAttestationData(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	slot, err := requiredUintQuery(r, "slot")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	committeeIndex, err := requiredUintQuery(r, "committee_index")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	data, err := s.ValidatorServer.GetAttestationData(r.Context(), &ethpb.AttestationDataRequest{
+		Slot:           slot,
+		CommitteeIndex: committeeIndex,
+	})
+	if err != nil {
+		writeAPIError(w, grpcToAPIError(err))
+		return
+	}
+	writeData(w, attestationDataToJSON(data))
+}
+
+// getAggregateAttestation serves GET /eth/v1/validator/aggregate_attestation, returning the
+// aggregate with the most attesters among the pooled attestations of the slot whose data hashes
+// to the attestation_data_root query parameter.
+func (s *Server) getAggregateAttestation(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	slot, err := requiredUintQuery(r, "slot")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	dataRoot, ok, err := parseRoot(r.URL.Query().Get("attestation_data_root"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid attestation_data_root")
+		return
+	}
+	if err := s.AttestationsPool.AggregateUnaggregatedAttestations(); err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not aggregate attestations: "+err.Error())
+		return
+	}
+	var best *ethpb.Attestation
+	for _, att := range s.AttestationsPool.AggregatedAttestations() {
+		if att.Data.Slot != slot {
+			continue
+		}
+		root, err := ssz.HashTreeRoot(att.Data)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Could not hash attestation data: "+err.Error())
+			return
+		}
+		if root != dataRoot {
+			continue
+		}
+		if best == nil || att.AggregationBits.Count() > best.AggregationBits.Count() {
+			best = att
+		}
+	}
+	if best == nil {
+		writeError(w, http.StatusNotFound, "No aggregate attestation found for the attestation data root")
+		return
+	}
+	writeData(w, attestationToJSON(best))
+}
+
+// submitAggregateAndProofs serves POST /eth/v1/validator/aggregate_and_proofs. Every aggregate
+// is submitted even if others are rejected, and the rejected ones are reported by their index.
+func (s *Server) submitAggregateAndProofs(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var req []*signedAggregateAndProofJSON
+	if err := decodeBody(r, &req); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var failures []*indexedFailureJSON
+	for i, a := range req {
+		d := &jsonDecoder{}
+		aggregate := d.signedAggregateAndProof(a)
+		err := d.err
+		if err == nil {
+			_, err = s.ValidatorServer.SubmitSignedAggregateSelectionProof(r.Context(), &ethpb.SignedAggregateSubmitRequest{
+				SignedAggregateAndProof: aggregate,
+			})
+			if err != nil {
+				err = grpcToAPIError(err)
+			}
+		}
+		if err != nil {
+			failures = append(failures, &indexedFailureJSON{Index: i, Message: err.Error()})
+		}
+	}
+	writeFailures(w, "Some aggregates could not be submitted", failures)
+}
+
+// submitBeaconCommitteeSubscriptions serves POST /eth/v1/validator/beacon_committee_subscriptions,
+// subscribing the beacon node to the attestation subnets of the given committees.
+func (s *Server) submitBeaconCommitteeSubscriptions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var req []*beaconCommitteeSubscriptionJSON
+	if err := decodeBody(r, &req); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	subscriptions := &ethpb.CommitteeSubnetsSubscribeRequest{
+		Slots:        make([]uint64, len(req)),
+		CommitteeIds: make([]uint64, len(req)),
+		IsAggregator: make([]bool, len(req)),
+	}
+	d := &jsonDecoder{}
+	for i, sub := range req {
+		if sub == nil {
+			d.fail("Missing subscription")
+			break
+		}
+		subscriptions.Slots[i] = d.uint(sub.Slot, "slot")
+		subscriptions.CommitteeIds[i] = d.uint(sub.CommitteeIndex, "committee_index")
+		subscriptions.IsAggregator[i] = sub.IsAggregator
+	}
+	if d.err != nil {
+		writeAPIError(w, d.err)
+		return
+	}
+	if _, err := s.ValidatorServer.SubscribeCommitteeSubnets(r.Context(), subscriptions); err != nil {
+		writeAPIError(w, grpcToAPIError(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// dutiesState returns the head state advanced to the start of the requested epoch,
+// which must be the current epoch or the next one.
+func (s *Server) dutiesState(ctx context.Context, epochParam string) (*stateTrie.BeaconState, uint64, error) {
+	if s.SyncChecker.Syncing() {
+		return nil, 0, newAPIError(http.StatusServiceUnavailable, "Beacon node is currently syncing and not serving duties")
+	}
+	epoch, err := parseUint(epochParam, "epoch")
+	if err != nil {
+		return nil, 0, err
+	}
+	currentEpoch := helpers.SlotToEpoch(s.GenesisTimeFetcher.CurrentSlot())
+	if epoch < currentEpoch || epoch > currentEpoch+1 {
+		return nil, 0, newAPIError(http.StatusBadRequest, "Epoch %d is not the current epoch %d or the next one", epoch, currentEpoch)
+	}
+	st, err := s.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if epochStartSlot := helpers.StartSlot(epoch); st.Slot() < epochStartSlot {
+		st, err = state.ProcessSlots(ctx, st.Copy(), epochStartSlot)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return st, epoch, nil
+}
@@ -0,0 +1,226 @@
+package beaconapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestGetProposerDuties(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	chain := &mock.ChainService{State: st, Genesis: time.Now()}
+	s := &Server{HeadFetcher: chain, GenesisTimeFetcher: chain, SyncChecker: &mockSync.Sync{}}
+
+	var res []*proposerDutyJSON
+	get(t, s, "/eth/v1/validator/duties/proposer/0", &res)
+	if uint64(len(res)) != params.BeaconConfig().SlotsPerEpoch {
+		t.Fatalf("Expected %d proposer duties, received %d", params.BeaconConfig().SlotsPerEpoch, len(res))
+	}
+	for i, duty := range res {
+		if duty.Slot != uintString(uint64(i)) {
+			t.Errorf("Expected duty %d for slot %d, received slot %s", i, i, duty.Slot)
+		}
+	}
+
+	expectStatus(t, s, "/eth/v1/validator/duties/proposer/2", http.StatusBadRequest)
+	chain.Genesis = time.Now().Add(-time.Duration(2*params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	expectStatus(t, s, "/eth/v1/validator/duties/proposer/1", http.StatusBadRequest)
+	chain.Genesis = time.Now()
+	s.SyncChecker = &mockSync.Sync{IsSyncing: true}
+	expectStatus(t, s, "/eth/v1/validator/duties/proposer/0", http.StatusServiceUnavailable)
+}
+
+func TestGetAttesterDuties(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	chain := &mock.ChainService{State: st, Genesis: time.Now()}
+	s := &Server{HeadFetcher: chain, GenesisTimeFetcher: chain, SyncChecker: &mockSync.Sync{}}
+
+	var res []*attesterDutyJSON
+	get(t, s, "/eth/v1/validator/duties/attester/1?index=0,7", &res)
+	if len(res) != 2 {
+		t.Fatalf("Expected 2 attester duties, received %d", len(res))
+	}
+	for i, duty := range res {
+		slot, err := parseUint(duty.Slot, "slot")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slot < params.BeaconConfig().SlotsPerEpoch || slot >= 2*params.BeaconConfig().SlotsPerEpoch {
+			t.Errorf("Duty %d has slot %d outside of epoch 1", i, slot)
+		}
+		pubKey := st.PubkeyAtIndex([]uint64{0, 7}[i])
+		if duty.PublicKey != hexString(pubKey[:]) {
+			t.Errorf("Expected public key %s, received %s", hexString(pubKey[:]), duty.PublicKey)
+		}
+	}
+	expectStatus(t, s, "/eth/v1/validator/duties/attester/1?index=64", http.StatusNotFound)
+}
+
+type mockValidatorServer struct {
+	ethpb.BeaconNodeValidatorServer
+	attestations  []*ethpb.Attestation
+	exits         []*ethpb.SignedVoluntaryExit
+	exitErr       error
+	aggregates    []*ethpb.SignedAggregateAttestationAndProof
+	subscriptions []*ethpb.CommitteeSubnetsSubscribeRequest
+}
+
+func (m *mockValidatorServer) GetBlock(_ context.Context, req *ethpb.BlockRequest) (*ethpb.BeaconBlock, error) {
+	blk := testutil.NewBeaconBlock().Block
+	blk.Slot = req.Slot
+	blk.Body.RandaoReveal = req.RandaoReveal
+	blk.Body.Graffiti = req.Graffiti
+	return blk, nil
+}
+
+func (m *mockValidatorServer) GetAttestationData(_ context.Context, req *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	return &ethpb.AttestationData{
+		Slot:            req.Slot,
+		CommitteeIndex:  req.CommitteeIndex,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+	}, nil
+}
+
+func (m *mockValidatorServer) ProposeAttestation(_ context.Context, att *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	m.attestations = append(m.attestations, att)
+	return &ethpb.AttestResponse{}, nil
+}
+
+func (m *mockValidatorServer) ProposeExit(_ context.Context, exit *ethpb.SignedVoluntaryExit) (*ptypes.Empty, error) {
+	if m.exitErr != nil {
+		return nil, m.exitErr
+	}
+	m.exits = append(m.exits, exit)
+	return &ptypes.Empty{}, nil
+}
+
+func (m *mockValidatorServer) SubmitSignedAggregateSelectionProof(_ context.Context, req *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	m.aggregates = append(m.aggregates, req.SignedAggregateAndProof)
+	return &ethpb.SignedAggregateSubmitResponse{}, nil
+}
+
+func (m *mockValidatorServer) SubscribeCommitteeSubnets(_ context.Context, req *ethpb.CommitteeSubnetsSubscribeRequest) (*ptypes.Empty, error) {
+	m.subscriptions = append(m.subscriptions, req)
+	return &ptypes.Empty{}, nil
+}
+
+func TestProduceBlock(t *testing.T) {
+	s := &Server{ValidatorServer: &mockValidatorServer{}}
+
+	res := &blockJSON{}
+	randaoReveal := hexString(make([]byte, 96))
+	get(t, s, "/eth/v1/validator/blocks/5?randao_reveal="+randaoReveal+"&graffiti=0x01", res)
+	if res.Slot != "5" || res.Body.RandaoReveal != randaoReveal || res.Body.Graffiti != "0x01" {
+		t.Errorf("Unexpected block %v", res)
+	}
+	expectStatus(t, s, "/eth/v1/validator/blocks/5", http.StatusBadRequest)
+}
+
+func TestProduceAttestationData(t *testing.T) {
+	s := &Server{ValidatorServer: &mockValidatorServer{}}
+
+	res := &attestationDataJSON{}
+	get(t, s, "/eth/v1/validator/attestation_data?slot=3&committee_index=1", res)
+	if res.Slot != "3" || res.Index != "1" {
+		t.Errorf("Unexpected attestation data %v", res)
+	}
+	expectStatus(t, s, "/eth/v1/validator/attestation_data?slot=3", http.StatusBadRequest)
+}
+
+func TestGetAggregateAttestation(t *testing.T) {
+	pool := attestations.NewPool()
+	s := &Server{AttestationsPool: pool}
+
+	data := &ethpb.AttestationData{
+		Slot:            1,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+	}
+	atts := []*ethpb.Attestation{
+		{AggregationBits: bitfield.Bitlist{0b10011}, Data: data, Signature: make([]byte, 96)},
+		{AggregationBits: bitfield.Bitlist{0b11101}, Data: data, Signature: make([]byte, 96)},
+	}
+	if err := pool.SaveAggregatedAttestations(atts); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := &attestationJSON{}
+	get(t, s, "/eth/v1/validator/aggregate_attestation?slot=1&attestation_data_root="+hexString(root[:]), res)
+	if res.AggregationBits != hexString(atts[1].AggregationBits) {
+		t.Errorf("Expected the aggregate with the most attesters, received %v", res)
+	}
+	expectStatus(t, s, "/eth/v1/validator/aggregate_attestation?slot=2&attestation_data_root="+hexString(root[:]), http.StatusNotFound)
+	expectStatus(t, s, "/eth/v1/validator/aggregate_attestation?slot=1", http.StatusBadRequest)
+}
+
+func TestSubmitAggregateAndProofs(t *testing.T) {
+	vs := &mockValidatorServer{}
+	s := &Server{ValidatorServer: vs}
+
+	aggregate := &signedAggregateAndProofJSON{
+		Message: &aggregateAndProofJSON{
+			AggregatorIndex: "4",
+			Aggregate:       testAttestationJSON(),
+			SelectionProof:  hexString(make([]byte, 96)),
+		},
+		Signature: hexString(make([]byte, 96)),
+	}
+	rec := post(t, s, "/eth/v1/validator/aggregate_and_proofs", []*signedAggregateAndProofJSON{aggregate, {}})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, received %d", rec.Code)
+	}
+	resp := &indexedErrorResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Failures) != 1 || resp.Failures[0].Index != 1 {
+		t.Errorf("Expected only the second aggregate to fail, received %v", resp.Failures)
+	}
+	if len(vs.aggregates) != 1 || vs.aggregates[0].Message.AggregatorIndex != 4 {
+		t.Errorf("Expected the valid aggregate to be submitted, received %v", vs.aggregates)
+	}
+}
+
+func TestSubmitBeaconCommitteeSubscriptions(t *testing.T) {
+	vs := &mockValidatorServer{}
+	s := &Server{ValidatorServer: vs}
+
+	subscriptions := []*beaconCommitteeSubscriptionJSON{
+		{ValidatorIndex: "1", CommitteeIndex: "2", CommitteesAtSlot: "4", Slot: "10", IsAggregator: true},
+		{ValidatorIndex: "3", CommitteeIndex: "1", CommitteesAtSlot: "4", Slot: "11"},
+	}
+	if rec := post(t, s, "/eth/v1/validator/beacon_committee_subscriptions", subscriptions); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, received %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(vs.subscriptions) != 1 {
+		t.Fatalf("Expected one subscription request, received %d", len(vs.subscriptions))
+	}
+	req := vs.subscriptions[0]
+	if req.Slots[0] != 10 || req.CommitteeIds[0] != 2 || !req.IsAggregator[0] || req.Slots[1] != 11 || req.IsAggregator[1] {
+		t.Errorf("Unexpected subscription request %v", req)
+	}
+
+	subscriptions[1].Slot = "eleven"
+	if rec := post(t, s, "/eth/v1/validator/beacon_committee_subscriptions", subscriptions); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid slot, received %d", rec.Code)
+	}
+}
@@ -0,0 +1,44 @@
+package debug
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+)
+
+// ListPeerScores returns the scores of the peers known to the beacon node.
+func (ds *Server) ListPeerScores(ctx context.Context, _ *ptypes.Empty) (*pbrpc.PeerScoresResponse, error) {
+	status := ds.PeersFetcher.Peers()
+	pids := status.All()
+	scores := make([]*pbrpc.PeerScore, 0, len(pids))
+	for _, pid := range pids {
+		info, err := status.ScoreInfo(pid)
+		if err != nil {
+			continue
+		}
+		connState, err := status.ConnectionState(pid)
+		if err != nil {
+			continue
+		}
+		score := &pbrpc.PeerScore{
+			PeerId:          pid.Pretty(),
+			ConnectionState: connState.String(),
+			Score:           info.Score,
+			InvalidGossip:   info.InvalidGossip,
+			BadResponses:    info.BadResponses,
+			Timeouts:        info.Timeouts,
+			UsefulBlocks:    info.UsefulBlocks,
+			Uptime:          uint64(info.Uptime.Seconds()),
+			Graylisted:      status.IsGraylisted(pid),
+			Bad:             status.IsBad(pid),
+			DropReason:      info.DropReason,
+			GossipScore:     info.GossipScore,
+		}
+		if status.IsBanned(pid) {
+			score.BannedUntil = info.BannedUntil.Unix()
+		}
+		scores = append(scores, score)
+	}
+	return &pbrpc.PeerScoresResponse{Scores: scores}, nil
+}
@@ -0,0 +1,61 @@
+package debug
+
+import (
+	"context"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+)
+
+func TestServer_ListPeerScores(t *testing.T) {
+	peersProvider := &mockp2p.MockPeersProvider{}
+	status := peersProvider.Peers()
+	// The mock peers provider is connected to two peers, penalize the second one.
+	good, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad, err := peer.IDB58Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < status.MaxBadResponses(); i++ {
+		status.IncrementBadResponses(bad)
+	}
+	status.SetGossipScores(map[peer.ID]float64{good: 12.5})
+
+	ds := &Server{PeersFetcher: peersProvider}
+	res, err := ds.ListPeerScores(context.Background(), &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Scores) != 2 {
+		t.Fatalf("Expected 2 peer scores, received %d", len(res.Scores))
+	}
+	for _, score := range res.Scores {
+		switch score.PeerId {
+		case good.Pretty():
+			if score.Bad || score.Score != 0 || score.DropReason != "" {
+				t.Errorf("Expected %s to be a good peer, received %v", good.Pretty(), score)
+			}
+			if score.GossipScore != 12.5 {
+				t.Errorf("Expected gossip score %v, received %v", 12.5, score.GossipScore)
+			}
+		case bad.Pretty():
+			if !score.Bad || score.BadResponses != float64(status.MaxBadResponses()) {
+				t.Errorf("Expected %s to be a bad peer, received %v", bad.Pretty(), score)
+			}
+			if score.DropReason != peers.ReasonBadResponse {
+				t.Errorf("Expected drop reason %q, received %q", peers.ReasonBadResponse, score.DropReason)
+			}
+		default:
+			t.Errorf("Unexpected peer %s", score.PeerId)
+		}
+		if score.ConnectionState != peers.PeerConnected.String() {
+			t.Errorf("Expected connected peer, received %s", score.ConnectionState)
+		}
+	}
+}
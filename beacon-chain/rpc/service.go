@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
 	"os"
+	"time"
 
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -27,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beacon"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconapi"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/debug"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/node"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
@@ -47,6 +50,10 @@ import (
 
 var log logrus.FieldLogger
 
+// beaconAPIShutdownTimeout is how long the beacon REST API waits for its active requests to end
+// when the service stops, before closing their connections.
+var beaconAPIShutdownTimeout = 5 * time.Second
+
 func init() {
 	log = logrus.WithField("prefix", "rpc")
 	rand.Seed(int64(os.Getpid()))
@@ -60,6 +67,7 @@ type Service struct {
 	headFetcher             blockchain.HeadFetcher
 	forkFetcher             blockchain.ForkFetcher
 	finalizationFetcher     blockchain.FinalizationFetcher
+	canonicalFetcher        blockchain.CanonicalFetcher
 	participationFetcher    blockchain.ParticipationFetcher
 	genesisTimeFetcher      blockchain.TimeFetcher
 	genesisFetcher          blockchain.GenesisFetcher
@@ -84,6 +92,8 @@ type Service struct {
 	credentialError         error
 	p2p                     p2p.Broadcaster
 	peersFetcher            p2p.PeersProvider
+	peerManager             p2p.PeerManager
+	metadataProvider        p2p.MetadataProvider
 	depositFetcher          depositcache.DepositFetcher
 	pendingDepositFetcher   depositcache.PendingDepositsFetcher
 	stateNotifier           statefeed.Notifier
@@ -95,6 +105,8 @@ type Service struct {
 	slasherCredentialError  error
 	slasherClient           slashpb.SlasherClient
 	stateGen                *stategen.State
+	beaconAPIPort           string
+	beaconAPIServer         *http.Server
 }
 
 // Config options for the beacon node RPC server.
@@ -107,6 +119,7 @@ type Config struct {
 	HeadFetcher             blockchain.HeadFetcher
 	ForkFetcher             blockchain.ForkFetcher
 	FinalizationFetcher     blockchain.FinalizationFetcher
+	CanonicalFetcher        blockchain.CanonicalFetcher
 	ParticipationFetcher    blockchain.ParticipationFetcher
 	AttestationReceiver     blockchain.AttestationReceiver
 	BlockReceiver           blockchain.BlockReceiver
@@ -122,6 +135,8 @@ type Config struct {
 	SyncService             sync.Checker
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
+	PeerManager             p2p.PeerManager
+	MetadataProvider        p2p.MetadataProvider
 	DepositFetcher          depositcache.DepositFetcher
 	PendingDepositFetcher   depositcache.PendingDepositsFetcher
 	SlasherProvider         string
@@ -130,6 +145,7 @@ type Config struct {
 	BlockNotifier           blockfeed.Notifier
 	OperationNotifier       opfeed.Notifier
 	StateGen                *stategen.State
+	BeaconAPIPort           string // Port of the Eth2 Beacon REST API, disabled if empty.
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -143,6 +159,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		headFetcher:             cfg.HeadFetcher,
 		forkFetcher:             cfg.ForkFetcher,
 		finalizationFetcher:     cfg.FinalizationFetcher,
+		canonicalFetcher:        cfg.CanonicalFetcher,
 		participationFetcher:    cfg.ParticipationFetcher,
 		genesisTimeFetcher:      cfg.GenesisTimeFetcher,
 		genesisFetcher:          cfg.GenesisFetcher,
@@ -150,6 +167,8 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		blockReceiver:           cfg.BlockReceiver,
 		p2p:                     cfg.Broadcaster,
 		peersFetcher:            cfg.PeersFetcher,
+		peerManager:             cfg.PeerManager,
+		metadataProvider:        cfg.MetadataProvider,
 		powChainService:         cfg.POWChainService,
 		chainStartFetcher:       cfg.ChainStartFetcher,
 		mockEth1Votes:           cfg.MockEth1Votes,
@@ -172,6 +191,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		slasherCert:             cfg.SlasherCert,
 		stateGen:                cfg.StateGen,
 		enableDebugRPCEndpoints: cfg.EnableDebugRPCEndpoints,
+		beaconAPIPort:           cfg.BeaconAPIPort,
 	}
 }
 
@@ -282,6 +302,7 @@ func (s *Service) Start() {
 			GenesisTimeFetcher: s.genesisTimeFetcher,
 			StateGen:           s.stateGen,
 			HeadFetcher:        s.headFetcher,
+			PeersFetcher:       s.peersFetcher,
 		}
 		pbrpc.RegisterDebugServer(s.grpcServer, debugServer)
 	}
@@ -297,11 +318,54 @@ func (s *Service) Start() {
 			}
 		}
 	}()
+	if s.beaconAPIPort != "" {
+		s.startBeaconAPI(validatorServer, beaconChainServer)
+	}
 	if featureconfig.Get().EnableSlasherConnection {
 		s.startSlasherClient()
 	}
 }
 
+// startBeaconAPI serves the standard Eth2 Beacon REST API over HTTP. Block and attestation
+// production and operation submissions are delegated to the given gRPC servers.
+func (s *Service) startBeaconAPI(validatorServer ethpb.BeaconNodeValidatorServer, beaconChainServer ethpb.BeaconChainServer) {
+	apiServer := &beaconapi.Server{
+		BeaconDB:            s.beaconDB,
+		HeadFetcher:         s.headFetcher,
+		FinalizationFetcher: s.finalizationFetcher,
+		CanonicalFetcher:    s.canonicalFetcher,
+		GenesisTimeFetcher:  s.genesisTimeFetcher,
+		GenesisFetcher:      s.genesisFetcher,
+		StateGen:            s.stateGen,
+		SyncChecker:         s.syncService,
+		PeersFetcher:        s.peersFetcher,
+		PeerManager:         s.peerManager,
+		MetadataProvider:    s.metadataProvider,
+		AttestationsPool:    s.attestationsPool,
+		SlashingsPool:       s.slashingsPool,
+		ExitPool:            s.exitPool,
+		StateNotifier:       s.stateNotifier,
+		OperationNotifier:   s.operationNotifier,
+		ValidatorServer:     validatorServer,
+		BeaconChainServer:   beaconChainServer,
+	}
+	address := fmt.Sprintf("%s:%s", s.host, s.beaconAPIPort)
+	s.beaconAPIServer = &http.Server{
+		Addr:    address,
+		Handler: apiServer.Handler(),
+		// Long lived requests, such as event streams, end when the service stops.
+		BaseContext: func(net.Listener) context.Context {
+			return s.ctx
+		},
+	}
+	log.WithField("address", address).Info("Beacon REST API listening on port")
+	go func() {
+		if err := s.beaconAPIServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Errorf("Could not serve beacon REST API: %v", err)
+		}
+	}()
+}
+
 func (s *Service) startSlasherClient() {
 	var dialOpt grpc.DialOption
 	if s.slasherCert != "" {
@@ -344,6 +408,19 @@ func (s *Service) Stop() error {
 		s.grpcServer.GracefulStop()
 		log.Debug("Initiated graceful stop of gRPC server")
 	}
+	if s.beaconAPIServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), beaconAPIShutdownTimeout)
+		defer cancel()
+		if err := s.beaconAPIServer.Shutdown(ctx); err != nil {
+			if err != context.DeadlineExceeded {
+				return err
+			}
+			log.Debug("Closing the remaining beacon REST API connections")
+			if err := s.beaconAPIServer.Close(); err != nil {
+				return err
+			}
+		}
+	}
 	if s.slasherConn != nil {
 		if err := s.slasherConn.Close(); err != nil {
 			return err
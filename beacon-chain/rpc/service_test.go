@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"net/http"
 	"testing"
 	"time"
 
@@ -80,3 +82,52 @@ func TestRPC_InsecureEndpoint(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestStop_ClosesBeaconAPIConnections(t *testing.T) {
+	defer func(timeout time.Duration) {
+		beaconAPIShutdownTimeout = timeout
+	}(beaconAPIShutdownTimeout)
+	beaconAPIShutdownTimeout = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{ctx: ctx, cancel: cancel}
+	started := make(chan struct{})
+	block := make(chan struct{})
+	defer close(block)
+	// The request never ends on its own, like an event stream whose client stays connected.
+	s.beaconAPIServer = &http.Server{
+		Handler: http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			close(started)
+			<-block
+		}),
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := s.beaconAPIServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		resp, err := http.Get(fmt.Sprintf("http://%s", lis.Addr()))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- s.Stop()
+	}()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected stop not to wait for the open request to end")
+	}
+}
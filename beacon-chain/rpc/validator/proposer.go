@@ -24,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -398,7 +399,6 @@ func (vs *Server) filterAttestationsForBlockInclusion(ctx context.Context, state
 	validAtts := make([]*ethpb.Attestation, 0, len(atts))
 	inValidAtts := make([]*ethpb.Attestation, 0, len(atts))
 
-	// TODO(3916): Insert optimizations to sort out the most profitable attestations
 	for i, att := range atts {
 		if i == int(params.BeaconConfig().MaxAttestations) {
 			break
@@ -454,6 +454,10 @@ func constructMerkleProof(trie *trieutil.SparseMerkleTrie, index int, deposit *e
 	return deposit, nil
 }
 
+// packAttestations selects the attestations of the pool to include in a block of the given slot.
+// The attestations adding the most votes which are not included yet in the chain are selected first.
+// When no attestation adds a new vote and the block is not full, the remaining valid attestations
+// fill the block in pool order, as they may still carry better head or target votes.
 func (vs *Server) packAttestations(ctx context.Context, slot uint64) ([]*ethpb.Attestation, error) {
 	ctx, span := trace.StartSpan(ctx, "validatorServer.packAttestations")
 	defer span.End()
@@ -468,20 +472,50 @@ func (vs *Server) packAttestations(ctx context.Context, slot uint64) ([]*ethpb.A
 		}
 	}
 
-	atts := vs.AttPool.AggregatedAttestations()
-	atts, err = vs.filterAttestationsForBlockInclusion(ctx, st, atts)
+	packer, err := newAttestationPacker(st)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not filter attestations")
+		return nil, errors.Wrap(err, "could not create attestation packer")
+	}
+	poolAtts := append(vs.AttPool.AggregatedAttestations(), vs.AttPool.UnaggregatedAttestations()...)
+	candidates, err := packer.candidates(poolAtts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not score attestations")
+	}
+	remaining, err := packer.pack(ctx, candidates)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not pack attestations")
+	}
+	if err := vs.deleteAttsInPool(ctx, packer.invalid); err != nil {
+		return nil, err
 	}
 
-	// If there is any room left in the block, consider unaggregated attestations as well.
-	if len(atts) < int(params.BeaconConfig().MaxAttestations) {
-		uAtts := vs.AttPool.UnaggregatedAttestations()
-		uAtts, err = vs.filterAttestationsForBlockInclusion(ctx, st, uAtts)
-		if len(uAtts)+len(atts) > int(params.BeaconConfig().MaxAttestations) {
-			uAtts = uAtts[:int(params.BeaconConfig().MaxAttestations)-len(atts)]
+	atts := packer.selected
+	// If there is any room left in the block, consider the attestations without new votes as well.
+	if room := int(params.BeaconConfig().MaxAttestations) - len(atts); room > 0 && len(remaining) > 0 {
+		rest := make([]*ethpb.Attestation, len(remaining))
+		for i, c := range remaining {
+			rest[i] = c.att
 		}
-		atts = append(atts, uAtts...)
+		rest, err = vs.filterAttestationsForBlockInclusion(ctx, st, rest)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not filter attestations")
+		}
+		if len(rest) > room {
+			rest = rest[:room]
+		}
+		atts = append(atts, rest...)
 	}
+
+	span.AddAttributes(
+		trace.Int64Attribute("attestations", int64(len(atts))),
+		trace.Int64Attribute("newVotes", int64(packer.newVotes)),
+		trace.Int64Attribute("rewardGain", int64(packer.rewardGain)),
+	)
+	log.WithFields(logrus.Fields{
+		"slot":               slot,
+		"attestations":       len(atts),
+		"newVotes":           packer.newVotes,
+		"expectedRewardGwei": packer.rewardGain,
+	}).Debug("Packed attestations for block proposal")
 	return atts, nil
 }
@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// committeeKey identifies the committee which cast the votes of an attestation.
+type committeeKey struct {
+	slot           uint64
+	committeeIndex uint64
+}
+
+// attestationCandidate is an attestation considered for inclusion in a block.
+type attestationCandidate struct {
+	att       *ethpb.Attestation
+	key       committeeKey
+	committee []uint64
+	// newVotes is the number of votes of the attestation not included yet, and
+	// reward the proposer reward in Gwei for including them.
+	newVotes uint64
+	reward   uint64
+}
+
+// attestationPacker selects the attestations of a block by weighted max-coverage. A validator
+// votes once per epoch, and the proposer is rewarded for the first inclusion of the vote only,
+// so the attestation adding the largest reward for votes not included yet is selected first.
+type attestationPacker struct {
+	state *stateTrie.BeaconState
+	// included holds, for each committee, the votes included in the state or in the
+	// attestations selected so far.
+	included     map[committeeKey]bitfield.Bitlist
+	sqrtBalance  uint64
+	selected     []*ethpb.Attestation
+	invalid      []*ethpb.Attestation
+	newVotes     uint64
+	rewardGain   uint64
+	maxSelection int
+}
+
+// newAttestationPacker creates a packer for a block built on top of the given state. The
+// state must not have processed any of the candidate attestations yet.
+func newAttestationPacker(st *stateTrie.BeaconState) (*attestationPacker, error) {
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not calculate active balance")
+	}
+	p := &attestationPacker{
+		state:        st,
+		included:     make(map[committeeKey]bitfield.Bitlist),
+		sqrtBalance:  mathutil.IntegerSquareRoot(totalBalance),
+		maxSelection: int(params.BeaconConfig().MaxAttestations),
+	}
+	pending := append(st.PreviousEpochAttestations(), st.CurrentEpochAttestations()...)
+	for _, att := range pending {
+		if att.Data == nil {
+			continue
+		}
+		p.include(committeeKey{slot: att.Data.Slot, committeeIndex: att.Data.CommitteeIndex}, att.AggregationBits)
+	}
+	return p, nil
+}
+
+// include records the votes of the given bits as included for a committee.
+func (p *attestationPacker) include(key committeeKey, bits bitfield.Bitlist) {
+	included, ok := p.included[key]
+	if !ok {
+		p.included[key] = bitfield.Bitlist(append([]byte{}, bits...))
+		return
+	}
+	if included.Len() == bits.Len() {
+		p.included[key] = included.Or(bits)
+	}
+}
+
+// score updates the new votes and reward of a candidate with the votes included so far.
+func (p *attestationPacker) score(c *attestationCandidate) error {
+	included, hasIncluded := p.included[c.key]
+	if hasIncluded && included.Len() != c.att.AggregationBits.Len() {
+		hasIncluded = false
+	}
+	c.newVotes, c.reward = 0, 0
+	for i, validatorIdx := range c.committee {
+		if !c.att.AggregationBits.BitAt(uint64(i)) || (hasIncluded && included.BitAt(uint64(i))) {
+			continue
+		}
+		reward, err := p.proposerReward(validatorIdx)
+		if err != nil {
+			return err
+		}
+		c.newVotes++
+		c.reward += reward
+	}
+	return nil
+}
+
+// proposerReward returns the reward of the proposer for including the vote of a validator.
+func (p *attestationPacker) proposerReward(validatorIdx uint64) (uint64, error) {
+	if p.sqrtBalance == 0 {
+		return 0, nil
+	}
+	val, err := p.state.ValidatorAtIndexReadOnly(validatorIdx)
+	if err != nil {
+		return 0, err
+	}
+	cfg := params.BeaconConfig()
+	baseReward := val.EffectiveBalance() * cfg.BaseRewardFactor / p.sqrtBalance / cfg.BaseRewardsPerEpoch
+	return baseReward / cfg.ProposerRewardQuotient, nil
+}
+
+// candidates returns the candidates of the given attestations. Attestations whose committee
+// cannot be computed are recorded as invalid.
+func (p *attestationPacker) candidates(atts []*ethpb.Attestation) ([]*attestationCandidate, error) {
+	candidates := make([]*attestationCandidate, 0, len(atts))
+	for _, att := range atts {
+		if att == nil || att.Data == nil {
+			continue
+		}
+		committee, err := helpers.BeaconCommitteeFromState(p.state, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil || att.AggregationBits.Len() != uint64(len(committee)) {
+			p.invalid = append(p.invalid, att)
+			continue
+		}
+		c := &attestationCandidate{
+			att:       att,
+			key:       committeeKey{slot: att.Data.Slot, committeeIndex: att.Data.CommitteeIndex},
+			committee: committee,
+		}
+		if err := p.score(c); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// pack greedily selects the candidate with the largest reward for new votes until the block
+// is full or no candidate adds any new vote. Selected candidates are processed on the state of
+// the packer, invalid ones are recorded and skipped. It returns the candidates left aside.
+func (p *attestationPacker) pack(ctx context.Context, candidates []*attestationCandidate) ([]*attestationCandidate, error) {
+	remaining := candidates
+	for len(p.selected) < p.maxSelection {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		best := -1
+		for i, c := range remaining {
+			if c.newVotes == 0 {
+				continue
+			}
+			if best == -1 || c.reward > remaining[best].reward ||
+				(c.reward == remaining[best].reward && c.newVotes > remaining[best].newVotes) {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		c := remaining[best]
+		remaining = append(remaining[:best:best], remaining[best+1:]...)
+		if _, err := blocks.ProcessAttestation(ctx, p.state, c.att); err != nil {
+			p.invalid = append(p.invalid, c.att)
+			continue
+		}
+		p.selected = append(p.selected, c.att)
+		p.newVotes += c.newVotes
+		p.rewardGain += c.reward
+
+		// Only the candidates of the same committee have votes in common with the selected one.
+		p.include(c.key, c.att.AggregationBits)
+		for _, other := range remaining {
+			if other.key != c.key || other.newVotes == 0 {
+				continue
+			}
+			if err := p.score(other); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return remaining, nil
+}
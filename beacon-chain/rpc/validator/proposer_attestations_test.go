@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestAttestationPacker_MaxCoverage(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+	ctx := context.Background()
+
+	// 256 validators make a single committee of 8 validators per slot.
+	state, privKeys := testutil.DeterministicGenesisState(t, 256)
+	if err := state.SetGenesisValidatorRoot(params.BeaconConfig().ZeroHash[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.SetSlot(1); err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(state, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := &ethpb.AttestationData{
+		Target: &ethpb.Checkpoint{},
+		Source: &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]},
+	}
+	domain, err := helpers.Domain(state.Fork(), 0, params.BeaconConfig().DomainBeaconAttester, params.BeaconConfig().ZeroHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingRoot, err := helpers.ComputeSigningRoot(data, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestation := func(bits ...uint64) *ethpb.Attestation {
+		aggBits := bitfield.NewBitlist(uint64(len(committee)))
+		for _, b := range bits {
+			aggBits.SetBitAt(b, true)
+		}
+		var sigs []*bls.Signature
+		for _, idx := range attestationutil.AttestingIndices(aggBits, committee) {
+			sigs = append(sigs, privKeys[idx].Sign(signingRoot[:]))
+		}
+		return &ethpb.Attestation{
+			Data:            data,
+			AggregationBits: aggBits,
+			Signature:       bls.AggregateSignatures(sigs).Marshal(),
+		}
+	}
+
+	// The vote of the last validator of the committee is already included in the state.
+	included := bitfield.NewBitlist(uint64(len(committee)))
+	included.SetBitAt(7, true)
+	if err := state.AppendCurrentEpochAttestations(&pbp2p.PendingAttestation{
+		Data:            data,
+		AggregationBits: included,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	subset := attestation(0, 1, 2, 3)
+	superset := attestation(0, 1, 2, 3, 4)
+	tail := attestation(4, 5, 6, 7)
+	small := attestation(5)
+	packer, err := newAttestationPacker(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates, err := packer.candidates([]*ethpb.Attestation{subset, small, superset, tail})
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := packer.pack(ctx, candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The superset adds 5 votes, then the tail adds the votes of validators 5 and 6. The subset
+	// and the small attestation have no new vote left.
+	want := []*ethpb.Attestation{superset, tail}
+	if !reflect.DeepEqual(packer.selected, want) {
+		t.Errorf("Wanted selected attestations %v, received %v", want, packer.selected)
+	}
+	if len(remaining) != 2 || remaining[0].att != subset || remaining[1].att != small {
+		t.Errorf("Unexpected remaining candidates %v", remaining)
+	}
+	if packer.newVotes != 7 {
+		t.Errorf("Wanted 7 new votes, received %d", packer.newVotes)
+	}
+	reward, err := packer.proposerReward(committee[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reward == 0 || packer.rewardGain != 7*reward {
+		t.Errorf("Wanted a reward gain of %d, received %d", 7*reward, packer.rewardGain)
+	}
+}
+
+func TestAttestationPacker_SkipsInvalidAttestations(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+	ctx := context.Background()
+
+	state, _ := testutil.DeterministicGenesisState(t, 256)
+	if err := state.SetSlot(1); err != nil {
+		t.Fatal(err)
+	}
+	aggBits := bitfield.NewBitlist(8)
+	aggBits.SetBitAt(0, true)
+	unsigned := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Target: &ethpb.Checkpoint{},
+			Source: &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]},
+		},
+		AggregationBits: aggBits,
+		Signature:       make([]byte, 96),
+	}
+	wrongLength := &ethpb.Attestation{
+		Data:            unsigned.Data,
+		AggregationBits: bitfield.NewBitlist(3),
+	}
+
+	packer, err := newAttestationPacker(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates, err := packer.candidates([]*ethpb.Attestation{unsigned, wrongLength})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := packer.pack(ctx, candidates); err != nil {
+		t.Fatal(err)
+	}
+	if len(packer.selected) != 0 {
+		t.Errorf("Expected no attestation to be selected, received %v", packer.selected)
+	}
+	if len(packer.invalid) != 2 {
+		t.Errorf("Expected 2 invalid attestations, received %d", len(packer.invalid))
+	}
+}
@@ -0,0 +1,25 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillBlocksCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_blocks_total",
+		Help: "Count the number of blocks saved by backfill.",
+	})
+	backfillBatchFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_batch_failures_total",
+		Help: "Count the number of block batches that could not be downloaded or verified.",
+	})
+	backfillLowestSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_lowest_slot",
+		Help: "The slot of the oldest block linked to the origin of the chain.",
+	})
+	backfillComplete = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_complete",
+		Help: "Whether all blocks down to genesis are stored, 1 if complete.",
+	})
+)
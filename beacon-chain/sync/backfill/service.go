@@ -0,0 +1,364 @@
+// Package backfill downloads the blocks preceding the origin of a beacon node started
+// from a weak subjectivity checkpoint, so the node can serve the full history of the
+// chain to its peers. Blocks are requested backwards from the origin block and are only
+// verified by their parent root linkage to it, without running any state transition.
+package backfill
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = shared.Service(&Service{})
+
+const (
+	// peerPollingInterval is how long to wait before looking for a suitable peer again.
+	peerPollingInterval = 5 * time.Second
+	// requestTimeout bounds a single blocks by range request to a peer.
+	requestTimeout = 10 * time.Second
+	// failureBackoff is how long to wait before retrying a batch that failed.
+	failureBackoff = time.Second
+)
+
+// Config to set up the backfill service.
+type Config struct {
+	DB  db.NoHeadAccessDatabase
+	P2P p2p.P2P
+}
+
+// Service walks backwards from the origin block of the chain, saving the blocks
+// peers return for earlier slots until the genesis block is reached.
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	db          db.NoHeadAccessDatabase
+	p2p         p2p.P2P
+	originEpoch uint64
+	lock        sync.RWMutex
+	lowest      *ethpb.SignedBeaconBlock
+	lowestRoot  [32]byte
+	cursor      uint64
+	complete    bool
+	runError    error
+}
+
+// NewService configures the backfill service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		db:     cfg.DB,
+		p2p:    cfg.P2P,
+	}
+}
+
+// Start backfilling blocks in the background.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status returns an error if backfill could not be started.
+func (s *Service) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.runError
+}
+
+// Complete returns true once every block down to genesis is stored, or if the node
+// started from genesis and there is nothing to backfill.
+func (s *Service) Complete() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.complete
+}
+
+// LowestSlot returns the slot of the oldest block linked to the origin block.
+func (s *Service) LowestSlot() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.lowest == nil {
+		return 0
+	}
+	return s.lowest.Block.Slot
+}
+
+func (s *Service) run() {
+	if err := s.initialize(s.ctx); err != nil {
+		log.WithError(err).Error("Could not initialize backfill")
+		s.lock.Lock()
+		s.runError = err
+		s.lock.Unlock()
+		return
+	}
+	if s.Complete() {
+		return
+	}
+	log.WithField("lowestSlot", s.LowestSlot()).Info("Backfilling blocks preceding the checkpoint origin")
+	for !s.Complete() {
+		pid, err := s.waitForPeer(s.ctx)
+		if err != nil {
+			log.Debug("Context closed, exiting backfill")
+			return
+		}
+		if err := s.backfillBatch(s.ctx, pid); err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			backfillBatchFailures.Inc()
+			s.p2p.Peers().IncrementBadResponses(pid)
+			log.WithError(err).WithField("peer", pid.Pretty()).Debug("Could not backfill batch")
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(failureBackoff):
+			}
+		}
+	}
+}
+
+// initialize finds the oldest block of the database that is linked to the origin
+// block, which is where a previous run of backfill stopped.
+func (s *Service) initialize(ctx context.Context) error {
+	originRoot, err := s.db.OriginBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block root")
+	}
+	if originRoot == params.BeaconConfig().ZeroHash {
+		// The node started from genesis and has the full history of the chain.
+		s.markComplete()
+		return nil
+	}
+	genesis, err := s.db.GenesisBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block")
+	}
+	if genesis != nil {
+		s.markComplete()
+		return nil
+	}
+	origin, err := s.db.Block(ctx, originRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block")
+	}
+	if origin == nil || origin.Block == nil {
+		return errors.Errorf("origin block %#x not found", originRoot)
+	}
+	s.originEpoch = helpers.SlotToEpoch(origin.Block.Slot)
+
+	lowest, lowestRoot := origin, originRoot
+	for lowest.Block.Slot > 0 {
+		parentRoot := bytesutil.ToBytes32(lowest.Block.ParentRoot)
+		if !s.db.HasBlock(ctx, parentRoot) {
+			break
+		}
+		parent, err := s.db.Block(ctx, parentRoot)
+		if err != nil {
+			return errors.Wrap(err, "could not get parent block")
+		}
+		lowest, lowestRoot = parent, parentRoot
+	}
+	return s.setLowest(ctx, lowest, lowestRoot)
+}
+
+// backfillBatch requests the blocks of the batch of slots below the cursor from a peer,
+// and saves them once they are verified to be the ancestors of the lowest block.
+func (s *Service) backfillBatch(ctx context.Context, pid peer.ID) error {
+	s.lock.RLock()
+	end := s.cursor
+	lowestSlot := s.lowest.Block.Slot
+	parentRoot := bytesutil.ToBytes32(s.lowest.Block.ParentRoot)
+	s.lock.RUnlock()
+
+	if end == 0 {
+		// Earlier empty batches did not lead to the genesis block, one of them must have been
+		// withheld by a peer. Restart from the lowest verified block.
+		s.resetCursor(lowestSlot)
+		return errors.New("reached slot 0 without finding the genesis block")
+	}
+	batchSize := uint64(flags.Get().BlockBatchLimit)
+	if batchSize == 0 {
+		batchSize = params.BeaconConfig().SlotsPerEpoch
+	}
+	start := uint64(0)
+	if end > batchSize {
+		start = end - batchSize
+	}
+	blks, err := s.requestBlocks(ctx, pid, start, end-start)
+	if err != nil {
+		return err
+	}
+	if len(blks) == 0 {
+		// Skipped slots, the blocks of this batch can only be verified once an earlier
+		// batch links to the lowest block.
+		s.lock.Lock()
+		s.cursor = start
+		s.lock.Unlock()
+		return nil
+	}
+	lowestRoot, err := verifyBatch(blks, parentRoot, start, end)
+	if err != nil {
+		s.resetCursor(lowestSlot)
+		return err
+	}
+	if err := s.db.SaveBlocks(ctx, blks); err != nil {
+		return errors.Wrap(err, "could not save blocks")
+	}
+	backfillBlocksCount.Add(float64(len(blks)))
+	if err := s.setLowest(ctx, blks[0], lowestRoot); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"lowestSlot": blks[0].Block.Slot,
+		"blocks":     len(blks),
+		"peer":       pid.Pretty(),
+	}).Debug("Backfilled blocks")
+	return nil
+}
+
+// requestBlocks sends a blocks by range request to a peer and reads the streamed blocks.
+func (s *Service) requestBlocks(ctx context.Context, pid peer.ID, start, count uint64) ([]*ethpb.SignedBeaconBlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     count,
+		Step:      1,
+	}
+	stream, err := s.p2p.Send(ctx, req, p2p.RPCBlocksByRangeTopic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := stream.Reset(); err != nil {
+			log.WithError(err).Errorf("Failed to reset stream with protocol %s", stream.Protocol())
+		}
+	}()
+
+	blks := make([]*ethpb.SignedBeaconBlock, 0, count)
+	for uint64(len(blks)) < count {
+		blk, err := prysmsync.ReadChunkedBlock(stream, s.p2p)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, blk)
+	}
+	return blks, nil
+}
+
+// verifyBatch sorts the blocks by slot and checks they are all within the [start, end)
+// slot range and form a chain ending at the given parent root. The root of the lowest
+// block of the batch is returned.
+func verifyBatch(blks []*ethpb.SignedBeaconBlock, parentRoot [32]byte, start, end uint64) ([32]byte, error) {
+	for _, b := range blks {
+		if b == nil || b.Block == nil {
+			return [32]byte{}, errors.New("nil block in batch")
+		}
+	}
+	sort.Slice(blks, func(i, j int) bool {
+		return blks[i].Block.Slot < blks[j].Block.Slot
+	})
+	expected := parentRoot
+	var lowestRoot [32]byte
+	for i := len(blks) - 1; i >= 0; i-- {
+		blk := blks[i].Block
+		if blk.Slot < start || blk.Slot >= end {
+			return [32]byte{}, errors.Errorf("block slot %d outside of requested range [%d, %d)", blk.Slot, start, end)
+		}
+		if i < len(blks)-1 && blk.Slot == blks[i+1].Block.Slot {
+			return [32]byte{}, errors.Errorf("duplicate block for slot %d", blk.Slot)
+		}
+		root, err := stateutil.BlockRoot(blk)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not get block root")
+		}
+		if root != expected {
+			return [32]byte{}, errors.Errorf("block root %#x at slot %d is not the expected parent root %#x", root, blk.Slot, expected)
+		}
+		lowestRoot = root
+		expected = bytesutil.ToBytes32(blk.ParentRoot)
+	}
+	return lowestRoot, nil
+}
+
+// setLowest records the oldest verified block, and marks backfill as complete once it
+// is the genesis block.
+func (s *Service) setLowest(ctx context.Context, blk *ethpb.SignedBeaconBlock, root [32]byte) error {
+	s.lock.Lock()
+	s.lowest = blk
+	s.lowestRoot = root
+	s.cursor = blk.Block.Slot
+	s.lock.Unlock()
+	backfillLowestSlot.Set(float64(blk.Block.Slot))
+
+	if blk.Block.Slot != 0 {
+		return nil
+	}
+	if err := s.db.SaveGenesisBlockRoot(ctx, root); err != nil {
+		return errors.Wrap(err, "could not save genesis block root")
+	}
+	s.markComplete()
+	log.WithField("genesisRoot", hex.EncodeToString(bytesutil.Trunc(root[:]))).Info("Backfill complete")
+	return nil
+}
+
+func (s *Service) resetCursor(slot uint64) {
+	s.lock.Lock()
+	s.cursor = slot
+	s.lock.Unlock()
+}
+
+func (s *Service) markComplete() {
+	s.lock.Lock()
+	s.complete = true
+	s.lock.Unlock()
+	backfillComplete.Set(1)
+}
+
+// waitForPeer returns a random connected peer that finalized the origin epoch, and so
+// should be able to serve the blocks preceding it.
+func (s *Service) waitForPeer(ctx context.Context) (peer.ID, error) {
+	randGenerator := rand.New(rand.NewSource(roughtime.Now().Unix()))
+	for {
+		_, _, peers := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, s.originEpoch)
+		if len(peers) > 0 {
+			return peers[randGenerator.Intn(len(peers))], nil
+		}
+		log.Debug("Waiting for a suitable peer to backfill blocks from")
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(peerPollingInterval):
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// makeChain returns a chain of blocks at the given increasing slots, each block being
+// the child of the previous one, along with their roots.
+func makeChain(t *testing.T, slots []uint64) ([]*ethpb.SignedBeaconBlock, [][32]byte) {
+	blks := make([]*ethpb.SignedBeaconBlock, len(slots))
+	roots := make([][32]byte, len(slots))
+	parentRoot := params.BeaconConfig().ZeroHash
+	for i, slot := range slots {
+		blks[i] = &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		root, err := stateutil.BlockRoot(blks[i].Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots[i] = root
+		parentRoot = root
+	}
+	return blks, roots
+}
+
+func TestVerifyBatch(t *testing.T) {
+	blks, roots := makeChain(t, []uint64{0, 1, 3, 4, 7})
+	parentRoot := roots[4]
+
+	// Blocks may be received in any order.
+	batch := []*ethpb.SignedBeaconBlock{blks[3], blks[1], blks[2]}
+	lowestRoot, err := verifyBatch(batch, roots[3], 1, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lowestRoot != roots[1] {
+		t.Errorf("Expected lowest root %#x, received %#x", roots[1], lowestRoot)
+	}
+
+	if _, err := verifyBatch([]*ethpb.SignedBeaconBlock{blks[1], blks[2]}, roots[3], 1, 7); err == nil {
+		t.Error("Expected an error for a batch not linked to the parent root")
+	}
+	if _, err := verifyBatch([]*ethpb.SignedBeaconBlock{blks[1], blks[3]}, roots[3], 1, 7); err == nil {
+		t.Error("Expected an error for a batch with a missing block")
+	}
+	if _, err := verifyBatch([]*ethpb.SignedBeaconBlock{blks[4]}, parentRoot, 0, 7); err == nil {
+		t.Error("Expected an error for a block outside of the requested range")
+	}
+	forked := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 3, ParentRoot: roots[0][:]}}
+	if _, err := verifyBatch([]*ethpb.SignedBeaconBlock{blks[1], forked}, roots[3], 1, 7); err == nil {
+		t.Error("Expected an error for a block of another fork")
+	}
+}
+
+func TestService_InitializeResumesFromLowestLinkedBlock(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	ctx := context.Background()
+	blks, roots := makeChain(t, []uint64{0, 2, 3, 5, 8})
+	// A previous run saved the blocks down to slot 3.
+	if err := db.SaveBlocks(ctx, blks[2:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveOriginBlockRoot(ctx, roots[4]); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(ctx, &Config{DB: db, P2P: p2ptest.NewTestP2P(t)})
+	if err := s.initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s.Complete() {
+		t.Error("Expected backfill not to be complete")
+	}
+	if s.LowestSlot() != 3 {
+		t.Errorf("Expected lowest slot 3, received %d", s.LowestSlot())
+	}
+}
+
+func TestService_InitializeFromGenesis(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	s := NewService(context.Background(), &Config{DB: db, P2P: p2ptest.NewTestP2P(t)})
+	if err := s.initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Complete() {
+		t.Error("Expected nothing to backfill for a node started from genesis")
+	}
+}
+
+func TestService_BackfillsToGenesis(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{BlockBatchLimit: 4})
+	defer flags.Init(resetFlags)
+
+	db := dbtest.SetupDB(t)
+	ctx := context.Background()
+	// Slots 9 to 13 are skipped and are bigger than a batch.
+	slots := []uint64{0, 1, 2, 4, 5, 8, 14, 15, 17}
+	blks, roots := makeChain(t, slots)
+	origin := len(blks) - 1
+	if err := db.SaveBlock(ctx, blks[origin]); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveOriginBlockRoot(ctx, roots[origin]); err != nil {
+		t.Fatal(err)
+	}
+
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p2.SetStreamHandler(p2p.RPCBlocksByRangeTopic+p2.Encoding().ProtocolSuffix(), func(stream network.Stream) {
+		defer func() {
+			if err := stream.Close(); err != nil {
+				t.Log(err)
+			}
+		}()
+		req := &pb.BeaconBlocksByRangeRequest{}
+		if err := p2.Encoding().DecodeWithLength(stream, req); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, b := range blks {
+			if b.Block.Slot >= req.StartSlot && b.Block.Slot < req.StartSlot+req.Count {
+				if err := prysmsync.WriteChunk(stream, p2.Encoding(), b); err != nil {
+					t.Error(err)
+				}
+			}
+		}
+	})
+	p1.Connect(p2)
+	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
+	p1.Peers().SetConnectionState(p2.PeerID(), peers.PeerConnected)
+	p1.Peers().SetChainState(p2.PeerID(), &pb.Status{
+		FinalizedRoot:  roots[origin][:],
+		FinalizedEpoch: 1,
+	})
+
+	s := NewService(ctx, &Config{DB: db, P2P: p1})
+	s.Start()
+	defer func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	timeout := time.After(10 * time.Second)
+	for !s.Complete() {
+		select {
+		case <-timeout:
+			t.Fatalf("Backfill did not complete, lowest slot %d", s.LowestSlot())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	for i, r := range roots {
+		if !db.HasBlock(ctx, r) {
+			t.Errorf("Expected block at slot %d to be backfilled", slots[i])
+		}
+	}
+	genesis, err := db.GenesisBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis == nil || genesis.Block.Slot != 0 {
+		t.Errorf("Expected the genesis block to be saved, received %v", genesis)
+	}
+	if err := s.Status(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,140 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+// signatureVerificationInterval is how long signatures of gossip messages are accumulated
+// before they are verified together.
+const signatureVerificationInterval = 50 * time.Millisecond
+
+// verifierLimit is the number of messages whose signatures are verified together without
+// waiting for the end of the interval.
+const verifierLimit = 50
+
+// signatureVerification is a request to verify the signatures of a gossip message. The
+// result is sent on the result channel once the batch it was queued in has been verified.
+type signatureVerification struct {
+	batch    *bls.SignatureBatch
+	queuedAt time.Time
+	result   chan error
+}
+
+// verifierRoutine accumulates the signatures of gossip messages and verifies them in batches,
+// either when the verification interval ticks or when enough messages are queued. Each batch
+// is verified on its own goroutine so messages keep being collected in the meantime.
+func (r *Service) verifierRoutine() {
+	ticker := time.NewTicker(signatureVerificationInterval)
+	defer ticker.Stop()
+	var pending []*signatureVerification
+	for {
+		select {
+		case <-r.ctx.Done():
+			// Callers stop waiting for their result when the service stops.
+			return
+		case v := <-r.signatureChan:
+			pending = append(pending, v)
+			if len(pending) >= verifierLimit {
+				go verifyBatch(pending)
+				pending = nil
+			}
+		case <-ticker.C:
+			if len(pending) > 0 {
+				go verifyBatch(pending)
+				pending = nil
+			}
+		}
+	}
+}
+
+// verifyBatch verifies the signatures of all the queued messages in a single check. When the
+// check fails, the batch is bisected so only the invalid messages are rejected.
+func verifyBatch(pending []*signatureVerification) {
+	signatureBatchSize.Observe(float64(len(pending)))
+	if batchValid(pending) {
+		sendResults(pending, nil)
+		return
+	}
+	signatureBatchFallbackCounter.Inc()
+	bisectBatch(pending)
+}
+
+// bisectBatch splits a batch which failed verification in two halves and verifies each of
+// them, recursing into the invalid ones until the invalid messages are found.
+func bisectBatch(pending []*signatureVerification) {
+	if len(pending) == 1 {
+		sendResults(pending, pending[0].batch.Verify())
+		return
+	}
+	mid := len(pending) / 2
+	for _, half := range [][]*signatureVerification{pending[:mid], pending[mid:]} {
+		if batchValid(half) {
+			sendResults(half, nil)
+			continue
+		}
+		bisectBatch(half)
+	}
+}
+
+// batchValid returns whether the signatures of all the given messages are valid.
+func batchValid(pending []*signatureVerification) bool {
+	joined := bls.NewSignatureBatch()
+	for _, v := range pending {
+		joined.Join(v.batch)
+	}
+	valid, err := joined.BatchVerify()
+	return err == nil && valid
+}
+
+// sendResults sends the verification result to each of the given messages.
+func sendResults(pending []*signatureVerification, res error) {
+	for _, v := range pending {
+		signatureBatchLatency.Observe(float64(time.Since(v.queuedAt).Milliseconds()))
+		v.result <- res
+	}
+}
+
+// validateWithBatchVerifier queues the signatures of a gossip message for verification and
+// waits for the result. The signatures are verified immediately when the verifier routine of
+// the service is not running.
+func (r *Service) validateWithBatchVerifier(ctx context.Context, message string, batch *bls.SignatureBatch) pubsub.ValidationResult {
+	ctx, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
+	defer span.End()
+
+	if r.signatureChan == nil {
+		if err := batch.Verify(); err != nil {
+			log.WithError(err).Debugf("Could not verify %s", message)
+			traceutil.AnnotateError(span, err)
+			return reject(ctx, reasonInvalidSignature)
+		}
+		return pubsub.ValidationAccept
+	}
+
+	v := &signatureVerification{batch: batch, queuedAt: time.Now(), result: make(chan error, 1)}
+	select {
+	case r.signatureChan <- v:
+	case <-ctx.Done():
+		return ignore(ctx, reasonTimeout)
+	case <-r.ctx.Done():
+		return ignore(ctx, reasonInternalError)
+	}
+	select {
+	case err := <-v.result:
+		if err != nil {
+			log.WithError(err).Debugf("Could not verify %s", message)
+			traceutil.AnnotateError(span, err)
+			return reject(ctx, reasonInvalidSignature)
+		}
+		return pubsub.ValidationAccept
+	case <-ctx.Done():
+		return ignore(ctx, reasonTimeout)
+	case <-r.ctx.Done():
+		return ignore(ctx, reasonInternalError)
+	}
+}
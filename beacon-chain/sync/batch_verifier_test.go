@@ -0,0 +1,97 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+func signedMessageBatch(valid bool) *bls.SignatureBatch {
+	priv := bls.RandKey()
+	msg := [32]byte{'m', 's', 'g'}
+	sig := priv.Sign(msg[:])
+	if !valid {
+		sig = bls.RandKey().Sign(msg[:])
+	}
+	batch := bls.NewSignatureBatch()
+	batch.Add(sig, []*bls.PublicKey{priv.PublicKey()}, msg, "message")
+	return batch
+}
+
+func TestValidateWithBatchVerifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &Service{ctx: ctx, signatureChan: make(chan *signatureVerification, verifierLimit)}
+	go r.verifierRoutine()
+
+	// Queue more messages than fit in a single batch, one in every ten of them invalid.
+	count := 2*verifierLimit + 5
+	results := make([]pubsub.ValidationResult, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = r.validateWithBatchVerifier(context.Background(), "message", signedMessageBatch(i%10 != 0))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, res := range results {
+		want := pubsub.ValidationAccept
+		if i%10 == 0 {
+			want = pubsub.ValidationReject
+		}
+		if res != want {
+			t.Errorf("Message %d: wanted %v, received %v", i, want, res)
+		}
+	}
+}
+
+func TestVerifyBatch_Bisect(t *testing.T) {
+	invalid := map[int]bool{2: true, 5: true, 6: true}
+	pending := make([]*signatureVerification, 7)
+	for i := range pending {
+		pending[i] = &signatureVerification{
+			batch:    signedMessageBatch(!invalid[i]),
+			queuedAt: time.Now(),
+			result:   make(chan error, 1),
+		}
+	}
+	verifyBatch(pending)
+
+	for i, v := range pending {
+		select {
+		case err := <-v.result:
+			if invalid[i] && err == nil {
+				t.Errorf("Message %d: expected an invalid signature", i)
+			}
+			if !invalid[i] && err != nil {
+				t.Errorf("Message %d: could not verify a valid signature: %v", i, err)
+			}
+		default:
+			t.Errorf("Message %d: no result", i)
+		}
+	}
+}
+
+func TestValidateWithBatchVerifier_Stopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Service{ctx: ctx, signatureChan: make(chan *signatureVerification)}
+	cancel()
+
+	if res := r.validateWithBatchVerifier(context.Background(), "message", signedMessageBatch(true)); res != pubsub.ValidationIgnore {
+		t.Errorf("Wanted %v, received %v", pubsub.ValidationIgnore, res)
+	}
+}
+
+func TestValidateWithBatchVerifier_NoRoutine(t *testing.T) {
+	r := &Service{}
+	if res := r.validateWithBatchVerifier(context.Background(), "message", signedMessageBatch(false)); res != pubsub.ValidationReject {
+		t.Errorf("Wanted %v, received %v", pubsub.ValidationReject, res)
+	}
+}
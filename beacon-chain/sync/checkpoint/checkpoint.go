@@ -0,0 +1,143 @@
+// Package checkpoint allows a beacon node to start from a trusted, weak subjectivity
+// finalized state and its block instead of genesis, loaded from SSZ files or fetched
+// from another beacon node. The node then syncs forward from that checkpoint while
+// older blocks are backfilled separately.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "checkpoint")
+
+// ErrDatabaseNotEmpty is returned when trying to start from a checkpoint with a database
+// that already holds a chain.
+var ErrDatabaseNotEmpty = errors.New("database already contains a chain")
+
+// Origin is a trusted finalized beacon state together with the block it is the post state of.
+type Origin struct {
+	State *stateTrie.BeaconState
+	Block *ethpb.SignedBeaconBlock
+}
+
+// NewOrigin decodes an SSZ encoded beacon state and signed block into a verified origin.
+func NewOrigin(encodedState []byte, encodedBlock []byte) (*Origin, error) {
+	st := &pb.BeaconState{}
+	if err := ssz.Unmarshal(encodedState, st); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal checkpoint state")
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := ssz.Unmarshal(encodedBlock, blk); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal checkpoint block")
+	}
+	trie, err := stateTrie.InitializeFromProto(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state trie")
+	}
+	o := &Origin{State: trie, Block: blk}
+	if err := o.verify(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// LoadFiles reads an origin from SSZ encoded beacon state and signed block files.
+func LoadFiles(statePath string, blockPath string) (*Origin, error) {
+	encodedState, err := ioutil.ReadFile(statePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read checkpoint state file")
+	}
+	encodedBlock, err := ioutil.ReadFile(blockPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read checkpoint block file")
+	}
+	return NewOrigin(encodedState, encodedBlock)
+}
+
+// verify checks the state is the post state of the block, so the block root can be
+// trusted as the root of the checkpoint.
+func (o *Origin) verify() error {
+	if o.State == nil {
+		return errors.New("nil checkpoint state")
+	}
+	if o.Block == nil || o.Block.Block == nil {
+		return errors.New("nil checkpoint block")
+	}
+	if o.State.Slot() != o.Block.Block.Slot {
+		return errors.Errorf("checkpoint state slot %d does not match block slot %d", o.State.Slot(), o.Block.Block.Slot)
+	}
+	stateRoot, err := o.State.HashTreeRoot(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "could not hash checkpoint state")
+	}
+	if !bytes.Equal(stateRoot[:], o.Block.Block.StateRoot) {
+		return errors.Errorf("checkpoint state root %#x does not match block state root %#x", stateRoot, o.Block.Block.StateRoot)
+	}
+	return nil
+}
+
+// Save seeds an empty database with the origin as the head, justified and finalized
+// checkpoint of the chain, and as the last archived point of the cold state section.
+// The origin block root is recorded so the node knows its chain starts there instead
+// of at genesis. Everything is written in a single transaction, so an interrupted save
+// leaves the database empty.
+func Save(ctx context.Context, beaconDB db.HeadAccessDatabase, o *Origin) error {
+	if err := o.verify(); err != nil {
+		return err
+	}
+	head, err := beaconDB.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head block")
+	}
+	genesis, err := beaconDB.GenesisBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block")
+	}
+	if head != nil || genesis != nil {
+		return ErrDatabaseNotEmpty
+	}
+
+	root, err := stateutil.BlockRoot(o.Block.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not get checkpoint block root")
+	}
+	checkpoint := &ethpb.Checkpoint{Epoch: boundaryEpoch(o.Block.Block.Slot), Root: root[:]}
+	if finalized := o.State.FinalizedCheckpoint(); finalized != nil && finalized.Epoch > checkpoint.Epoch {
+		return errors.Errorf("checkpoint state finalized epoch %d is past the checkpoint epoch %d", finalized.Epoch, checkpoint.Epoch)
+	}
+	if err := beaconDB.SaveOrigin(ctx, o.Block, o.State, checkpoint); err != nil {
+		return errors.Wrap(err, "could not save checkpoint")
+	}
+
+	log.WithFields(logrus.Fields{
+		"slot":  o.Block.Block.Slot,
+		"epoch": checkpoint.Epoch,
+		"root":  hex.EncodeToString(bytesutil.Trunc(root[:])),
+	}).Info("Saved weak subjectivity checkpoint as the finalized origin of the chain")
+	return nil
+}
+
+// boundaryEpoch returns the epoch the block at the given slot is the checkpoint of: the
+// first epoch starting at or after the slot, as a block before an epoch start is the
+// epoch boundary block when the slots up to the start are skipped.
+func boundaryEpoch(slot uint64) uint64 {
+	epoch := helpers.SlotToEpoch(slot)
+	if helpers.StartSlot(epoch) < slot {
+		epoch++
+	}
+	return epoch
+}
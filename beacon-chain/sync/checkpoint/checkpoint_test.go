@@ -0,0 +1,177 @@
+package checkpoint
+
+import (
+	"context"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"google.golang.org/grpc"
+)
+
+func testOrigin(t *testing.T) ([]byte, []byte) {
+	st, _ := testutil.DeterministicGenesisState(t, 16)
+	slot := 2 * params.BeaconConfig().SlotsPerEpoch
+	if err := st.SetSlot(slot); err != nil {
+		t.Fatal(err)
+	}
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	blk.Block.StateRoot = stateRoot[:]
+	encodedState, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedBlock, err := ssz.Marshal(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encodedState, encodedBlock
+}
+
+func TestNewOrigin(t *testing.T) {
+	encodedState, encodedBlock := testOrigin(t)
+	o, err := NewOrigin(encodedState, encodedBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.State.Slot() != o.Block.Block.Slot {
+		t.Errorf("Expected state slot %d, received %d", o.Block.Block.Slot, o.State.Slot())
+	}
+
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = o.Block.Block.Slot
+	encodedBlock, err = ssz.Marshal(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewOrigin(encodedState, encodedBlock); err == nil {
+		t.Error("Expected an error for a block with a different state root")
+	}
+}
+
+func TestSave(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	ctx := context.Background()
+	o, err := NewOrigin(testOrigin(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(ctx, db, o); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateutil.BlockRoot(o.Block.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	originRoot, err := db.OriginBlockRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if originRoot != root {
+		t.Errorf("Expected origin root %#x, received %#x", root, originRoot)
+	}
+	head, err := db.HeadBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head == nil || head.Block.Slot != o.Block.Block.Slot {
+		t.Errorf("Expected checkpoint block as head, received %v", head)
+	}
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Epoch != 2 || bytesutil.ToBytes32(cp.Root) != root {
+		t.Errorf("Unexpected finalized checkpoint %v", cp)
+	}
+	if !db.IsFinalizedBlock(ctx, root) {
+		t.Error("Expected checkpoint block to be finalized")
+	}
+	if db.LastArchivedIndexRoot(ctx) != root {
+		t.Error("Expected checkpoint block to be the last archived point")
+	}
+
+	if err := Save(ctx, db, o); err != ErrDatabaseNotEmpty {
+		t.Errorf("Expected %v, received %v", ErrDatabaseNotEmpty, err)
+	}
+}
+
+func TestBoundaryEpoch(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	tests := []struct {
+		slot  uint64
+		epoch uint64
+	}{
+		{slot: 0, epoch: 0},
+		{slot: 2 * slotsPerEpoch, epoch: 2},
+		{slot: 2*slotsPerEpoch - 1, epoch: 2},
+		{slot: 2*slotsPerEpoch + 1, epoch: 3},
+	}
+	for _, tt := range tests {
+		if epoch := boundaryEpoch(tt.slot); epoch != tt.epoch {
+			t.Errorf("Expected block at slot %d to be the checkpoint of epoch %d, received %d", tt.slot, tt.epoch, epoch)
+		}
+	}
+}
+
+type mockChainHeadClient struct {
+	head *ethpb.ChainHead
+}
+
+func (m *mockChainHeadClient) GetChainHead(_ context.Context, _ *ptypes.Empty, _ ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	return m.head, nil
+}
+
+type mockSSZClient struct {
+	state []byte
+	block []byte
+	roots [][]byte
+}
+
+func (m *mockSSZClient) GetBeaconState(_ context.Context, req *pbrpc.BeaconStateRequest, _ ...grpc.CallOption) (*pbrpc.SSZResponse, error) {
+	m.roots = append(m.roots, req.GetBlockRoot())
+	return &pbrpc.SSZResponse{Encoded: m.state}, nil
+}
+
+func (m *mockSSZClient) GetBlock(_ context.Context, req *pbrpc.BlockRequest, _ ...grpc.CallOption) (*pbrpc.SSZResponse, error) {
+	m.roots = append(m.roots, req.BlockRoot)
+	return &pbrpc.SSZResponse{Encoded: m.block}, nil
+}
+
+func TestFetch(t *testing.T) {
+	encodedState, encodedBlock := testOrigin(t)
+	finalizedRoot := []byte{'f'}
+	debugClient := &mockSSZClient{state: encodedState, block: encodedBlock}
+	beaconClient := &mockChainHeadClient{head: &ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: finalizedRoot}}
+
+	o, err := fetch(context.Background(), beaconClient, debugClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Block.Block.Slot != 2*params.BeaconConfig().SlotsPerEpoch {
+		t.Errorf("Unexpected checkpoint block slot %d", o.Block.Block.Slot)
+	}
+	for _, r := range debugClient.roots {
+		if string(r) != string(finalizedRoot) {
+			t.Errorf("Expected request for finalized root %#x, received %#x", finalizedRoot, r)
+		}
+	}
+
+	debugClient.block = nil
+	if _, err := fetch(context.Background(), beaconClient, debugClient); err == nil {
+		t.Error("Expected an error when the finalized block is not found")
+	}
+}
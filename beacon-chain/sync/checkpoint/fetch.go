@@ -0,0 +1,50 @@
+package checkpoint
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc"
+)
+
+// chainHeadClient is the part of the beacon chain gRPC API used to find the finalized checkpoint.
+type chainHeadClient interface {
+	GetChainHead(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.ChainHead, error)
+}
+
+// sszClient is the part of the debug gRPC API used to download the SSZ encoded checkpoint.
+type sszClient interface {
+	GetBeaconState(ctx context.Context, in *pbrpc.BeaconStateRequest, opts ...grpc.CallOption) (*pbrpc.SSZResponse, error)
+	GetBlock(ctx context.Context, in *pbrpc.BlockRequest, opts ...grpc.CallOption) (*pbrpc.SSZResponse, error)
+}
+
+// Fetch downloads the latest finalized state and block of a trusted beacon node. The node
+// must run with debug RPC endpoints and the new state management enabled.
+func Fetch(ctx context.Context, conn *grpc.ClientConn) (*Origin, error) {
+	return fetch(ctx, ethpb.NewBeaconChainClient(conn), pbrpc.NewDebugClient(conn))
+}
+
+func fetch(ctx context.Context, beaconClient chainHeadClient, debugClient sszClient) (*Origin, error) {
+	head, err := beaconClient.GetChainHead(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get chain head")
+	}
+	block, err := debugClient.GetBlock(ctx, &pbrpc.BlockRequest{BlockRoot: head.FinalizedBlockRoot})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block")
+	}
+	if len(block.Encoded) == 0 {
+		return nil, errors.Errorf("finalized block %#x not found", head.FinalizedBlockRoot)
+	}
+	st, err := debugClient.GetBeaconState(ctx, &pbrpc.BeaconStateRequest{
+		QueryFilter: &pbrpc.BeaconStateRequest_BlockRoot{BlockRoot: head.FinalizedBlockRoot},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized state")
+	}
+	log.WithField("epoch", head.FinalizedEpoch).Info("Downloaded finalized checkpoint state and block")
+	return NewOrigin(st.Encoded, block.Encoded)
+}
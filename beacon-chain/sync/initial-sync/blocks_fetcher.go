@@ -7,6 +7,7 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"net"
 	"sort"
 	"sync"
 	"time"
@@ -455,6 +456,9 @@ func (f *blocksFetcher) requestBlocks(
 	l.Unlock()
 	stream, err := f.p2p.Send(ctx, req, p2p.RPCBlocksByRangeTopic, pid)
 	if err != nil {
+		if isTimeout(err) {
+			f.p2p.Peers().IncrementTimeouts(pid)
+		}
 		return nil, err
 	}
 	defer func() {
@@ -470,14 +474,27 @@ func (f *blocksFetcher) requestBlocks(
 			break
 		}
 		if err != nil {
+			if isTimeout(err) {
+				f.p2p.Peers().IncrementTimeouts(pid)
+			}
 			return nil, err
 		}
 		resp = append(resp, blk)
 	}
+	f.p2p.Peers().IncrementUsefulBlocks(pid, len(resp))
 
 	return resp, nil
 }
 
+// isTimeout states if the given error is due to a request timing out.
+func isTimeout(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	netErr, ok := errors.Cause(err).(net.Error)
+	return ok && netErr.Timeout()
+}
+
 // getPeerLock returns peer lock for a given peer. If lock is not found, it is created.
 func (f *blocksFetcher) getPeerLock(pid peer.ID) *peerLock {
 	f.Lock()
@@ -32,6 +32,20 @@ var (
 		},
 		[]string{"topic"},
 	)
+	messageRejectedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_rejected_total",
+			Help: "Count of messages rejected by validation, by reason.",
+		},
+		[]string{"topic", "reason"},
+	)
+	messageIgnoredCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_ignored_total",
+			Help: "Count of messages ignored by validation, by reason.",
+		},
+		[]string{"topic", "reason"},
+	)
 	messageFailedProcessingCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "p2p_message_failed_processing_total",
@@ -69,6 +83,26 @@ var (
 			Help: "Count the number of times attestation not recovered and pruned because of missing block",
 		},
 	)
+	signatureBatchSize = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "gossip_signature_batch_size",
+			Help:    "The number of gossip messages whose signatures are verified together.",
+			Buckets: []float64{1, 2, 5, 10, 20, 30, 40, 50},
+		},
+	)
+	signatureBatchLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "gossip_signature_batch_latency_milliseconds",
+			Help:    "Captures the time from queueing the signatures of a gossip message to their verification.",
+			Buckets: []float64{10, 25, 50, 75, 100, 150, 250, 500},
+		},
+	)
+	signatureBatchFallbackCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_signature_batch_fallback_total",
+			Help: "Count the number of signature batches which failed and were bisected to find the invalid messages.",
+		},
+	)
 	arrivalBlockPropagationHistogram = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "block_arrival_latency_milliseconds",
@@ -84,6 +118,10 @@ func (r *Service) updateMetrics() {
 	if r.chain.GenesisTime().IsZero() {
 		return
 	}
+	ps := r.p2p.PubSub()
+	if ps == nil {
+		return
+	}
 	// We update the dynamic subnet topics.
 	digest, err := r.forkDigest()
 	if err != nil {
@@ -94,7 +132,7 @@ func (r *Service) updateMetrics() {
 	attTopic += r.p2p.Encoding().ProtocolSuffix()
 	for _, committeeIdx := range indices {
 		formattedTopic := fmt.Sprintf(attTopic, digest, committeeIdx)
-		topicPeerCount.WithLabelValues(formattedTopic).Set(float64(len(r.p2p.PubSub().ListPeers(formattedTopic))))
+		topicPeerCount.WithLabelValues(formattedTopic).Set(float64(len(ps.ListPeers(formattedTopic))))
 	}
 	// We update all other gossip topics.
 	for topic := range p2p.GossipTopicMappings {
@@ -104,10 +142,10 @@ func (r *Service) updateMetrics() {
 		}
 		topic += r.p2p.Encoding().ProtocolSuffix()
 		if !strings.Contains(topic, "%x") {
-			topicPeerCount.WithLabelValues(topic).Set(float64(len(r.p2p.PubSub().ListPeers(topic))))
+			topicPeerCount.WithLabelValues(topic).Set(float64(len(ps.ListPeers(topic))))
 			continue
 		}
 		formattedTopic := fmt.Sprintf(topic, digest)
-		topicPeerCount.WithLabelValues(formattedTopic).Set(float64(len(r.p2p.PubSub().ListPeers(formattedTopic))))
+		topicPeerCount.WithLabelValues(formattedTopic).Set(float64(len(ps.ListPeers(formattedTopic))))
 	}
 }
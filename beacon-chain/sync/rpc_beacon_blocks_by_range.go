@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
 )
@@ -141,8 +142,11 @@ func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 			traceutil.AnnotateError(span, err)
 			return err
 		}
-		blks = append([]*ethpb.SignedBeaconBlock{genBlock}, blks...)
-		roots = append([][32]byte{genRoot}, roots...)
+		// A node started from a checkpoint has no genesis block until it is backfilled.
+		if genBlock != nil {
+			blks = append([]*ethpb.SignedBeaconBlock{genBlock}, blks...)
+			roots = append([][32]byte{genRoot}, roots...)
+		}
 	}
 	blks, roots = r.sortBlocksAndRoots(blks, roots)
 	checkpoint, err := r.db.FinalizedCheckpoint(ctx)
@@ -152,6 +156,13 @@ func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 		traceutil.AnnotateError(span, err)
 		return err
 	}
+	originSlot, err := r.originSlot(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve origin block")
+		r.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+		traceutil.AnnotateError(span, err)
+		return err
+	}
 	for i, b := range blks {
 		if b == nil || b.Block == nil {
 			continue
@@ -160,7 +171,9 @@ func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 
 		isRequestedSlotStep := (blk.Slot-startSlot)%step == 0
 		isRecentUnfinalizedSlot := blk.Slot >= helpers.StartSlot(checkpoint.Epoch+1) || checkpoint.Epoch == 0
-		if isRequestedSlotStep && (isRecentUnfinalizedSlot || r.db.IsFinalizedBlock(ctx, roots[i])) {
+		// Blocks preceding the origin are only saved by backfill, which links them to the finalized origin block.
+		isBackfilledSlot := blk.Slot < originSlot
+		if isRequestedSlotStep && (isRecentUnfinalizedSlot || isBackfilledSlot || r.db.IsFinalizedBlock(ctx, roots[i])) {
 			if err := r.chunkWriter(stream, b); err != nil {
 				log.WithError(err).Error("Failed to send a chunked response")
 				return err
@@ -186,9 +199,32 @@ func (r *Service) retrieveGenesisBlock(ctx context.Context) (*ethpb.SignedBeacon
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
+	if genBlock == nil || genBlock.Block == nil {
+		return nil, [32]byte{}, nil
+	}
 	genRoot, err := stateutil.BlockRoot(genBlock.Block)
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
 	return genBlock, genRoot, nil
 }
+
+// originSlot returns the slot of the block the node started its chain from, which is 0
+// unless the node started from a weak subjectivity checkpoint.
+func (r *Service) originSlot(ctx context.Context) (uint64, error) {
+	originRoot, err := r.db.OriginBlockRoot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if originRoot == params.BeaconConfig().ZeroHash {
+		return 0, nil
+	}
+	origin, err := r.db.Block(ctx, originRoot)
+	if err != nil {
+		return 0, err
+	}
+	if origin == nil || origin.Block == nil {
+		return 0, nil
+	}
+	return origin.Block.Slot, nil
+}
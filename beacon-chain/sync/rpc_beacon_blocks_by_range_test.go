@@ -370,3 +370,76 @@ func TestRPCBeaconBlocksByRange_RPCHandlerRateLimitOverflow(t *testing.T) {
 		}
 	})
 }
+
+func TestRPCBeaconBlocksByRange_ReturnsBackfilledBlocks(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	if len(p1.Host.Network().Peers()) != 1 {
+		t.Error("Expected peers to be connected")
+	}
+	d := db.SetupDB(t)
+	ctx := context.Background()
+
+	// The node started from a finalized origin block at slot 128.
+	origin := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 128}}
+	originRoot, err := stateutil.BlockRoot(origin.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveBlock(ctx, origin); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveState(ctx, testutil.NewBeaconState(), originRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveOriginBlockRoot(ctx, originRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 4, Root: originRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 10,
+		Step:      1,
+		Count:     5,
+	}
+	// Blocks preceding the origin are saved by backfill and are not indexed as finalized.
+	for i := req.StartSlot; i < req.StartSlot+req.Count; i++ {
+		if err := d.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &Service{p2p: p1, db: d, blocksRateLimiter: leakybucket.NewCollector(10000, 10000, false)}
+	pcl := protocol.ID("/testing")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for i := req.StartSlot; i < req.StartSlot+req.Count; i++ {
+			expectSuccess(t, r, stream)
+			res := &ethpb.SignedBeaconBlock{}
+			if err := r.p2p.Encoding().DecodeWithLength(stream, res); err != nil {
+				t.Error(err)
+			}
+			if res.Block.Slot != i {
+				t.Errorf("Received unexpected block slot %d but wanted %d", res.Block.Slot, i)
+			}
+		}
+	})
+
+	stream1, err := p1.Host.NewStream(ctx, p2.Host.ID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.beaconBlocksByRangeRPCHandler(ctx, req, stream1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
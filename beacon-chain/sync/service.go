@@ -103,6 +103,7 @@ type Service struct {
 	seenAttesterSlashingCache *lru.Cache
 	stateSummaryCache         *cache.StateSummaryCache
 	stateGen                  *stategen.State
+	signatureChan             chan *signatureVerification
 }
 
 // NewRegularSync service.
@@ -130,6 +131,7 @@ func NewRegularSync(cfg *Config) *Service {
 		blockNotifier:        cfg.BlockNotifier,
 		stateSummaryCache:    cfg.StateSummaryCache,
 		stateGen:             cfg.StateGen,
+		signatureChan:        make(chan *signatureVerification, verifierLimit),
 		blocksRateLimiter:    leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */),
 	}
 
@@ -148,6 +150,7 @@ func (r *Service) Start() {
 	r.p2p.AddDisconnectionHandler(r.removeDisconnectedPeerStatus)
 	r.p2p.AddPingMethod(r.sendPingRequest)
 	r.processPendingBlocksQueue()
+	go r.verifierRoutine()
 	r.processPendingAttsQueue()
 	r.maintainPeerStatuses()
 	r.resyncIfBehind()
@@ -241,6 +244,11 @@ func (r *Service) registerHandlers() {
 	}
 	// Register respective rpc and pubsub handlers.
 	r.registerRPCHandlers()
+	// The p2p service creates the pubsub router on the same state initialized event.
+	if err := r.p2p.WaitForPubSub(r.ctx); err != nil {
+		log.WithError(err).Error("Could not subscribe to gossip topics")
+		return
+	}
 	r.registerSubscribers()
 }
 
@@ -36,7 +36,7 @@ func (r *Service) noopValidator(ctx context.Context, _ peer.ID, msg *pubsub.Mess
 	m, err := r.decodePubsubMessage(msg)
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
-		return pubsub.ValidationReject
+		return reject(ctx, reasonDecodeFailed)
 	}
 	msg.ValidatorData = m
 	return pubsub.ValidationAccept
@@ -99,7 +99,7 @@ func (r *Service) subscribeWithBase(base proto.Message, topic string, validator
 	topic += r.p2p.Encoding().ProtocolSuffix()
 	log := log.WithField("topic", topic)
 
-	if err := r.p2p.PubSub().RegisterTopicValidator(wrapAndReportValidation(topic, validator)); err != nil {
+	if err := r.p2p.PubSub().RegisterTopicValidator(r.wrapAndReportValidation(topic, validator)); err != nil {
 		log.WithError(err).Error("Failed to register validator")
 	}
 
@@ -166,16 +166,32 @@ func (r *Service) subscribeWithBase(base proto.Message, topic string, validator
 }
 
 // Wrap the pubsub validator with a metric monitoring function. This function increments the
-// appropriate counter if the particular message fails to validate.
-func wrapAndReportValidation(topic string, v pubsub.ValidatorEx) (string, pubsub.ValidatorEx) {
+// appropriate counters if the particular message is rejected or ignored, and penalizes the score
+// of the peer which sent a rejected message. Messages from graylisted peers are ignored without
+// being validated.
+func (r *Service) wrapAndReportValidation(topic string, v pubsub.ValidatorEx) (string, pubsub.ValidatorEx) {
 	return topic, func(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
 		defer messagehandler.HandlePanic(ctx, msg)
 		ctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
 		defer cancel()
 		messageReceivedCounter.WithLabelValues(topic).Inc()
+		// Our own messages are not scored.
+		remote := pid != r.p2p.PeerID()
+		if remote && r.p2p.Peers().IsGraylisted(pid) {
+			messageIgnoredCounter.WithLabelValues(topic, reasonGraylisted).Inc()
+			return pubsub.ValidationIgnore
+		}
+		ctx, reason := withValidationReason(ctx)
 		b := v(ctx, pid, msg)
-		if b == pubsub.ValidationReject {
+		switch b {
+		case pubsub.ValidationReject:
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
+			messageRejectedCounter.WithLabelValues(topic, reason.reason).Inc()
+			if remote {
+				r.p2p.Peers().IncrementInvalidGossip(pid)
+			}
+		case pubsub.ValidationIgnore:
+			messageIgnoredCounter.WithLabelValues(topic, reason.reason).Inc()
 		}
 		return b
 	}
@@ -222,15 +238,11 @@ func (r *Service) subscribeDynamicWithSubnets(
 				// Resize as appropriate.
 				r.reValidateSubscriptions(subscriptions, wantedSubs, topicFormat, digest)
 
-				// subscribe desired aggregator subnets.
+				// subscribe desired aggregator subnets. Peers on the subnets of our attesters and
+				// aggregators are searched for by the p2p service.
 				for _, idx := range wantedSubs {
 					r.subscribeAggregatorSubnet(subscriptions, idx, base, digest, validate, handle)
 				}
-				// find desired subs for attesters
-				attesterSubs := r.attesterCommitteeIndices(currentSlot)
-				for _, idx := range attesterSubs {
-					r.lookupAttesterSubnets(digest, idx)
-				}
 			}
 		}
 	}()
@@ -312,50 +324,12 @@ func (r *Service) reValidateSubscriptions(subscriptions map[uint64]*pubsub.Subsc
 // subscribe missing subnets for our aggregators.
 func (r *Service) subscribeAggregatorSubnet(subscriptions map[uint64]*pubsub.Subscription, idx uint64,
 	base proto.Message, digest [4]byte, validate pubsub.ValidatorEx, handle subHandler) {
-	// do not subscribe if we have no peers in the same
-	// subnet
 	topic := p2p.GossipTypeMapping[reflect.TypeOf(&pb.Attestation{})]
 	subnetTopic := fmt.Sprintf(topic, digest, idx)
 	// check if subscription exists and if not subscribe the relevant subnet.
 	if _, exists := subscriptions[idx]; !exists {
 		subscriptions[idx] = r.subscribeWithBase(base, subnetTopic, validate, handle)
 	}
-	if !r.validPeersExist(subnetTopic, idx) {
-		log.Debugf("No peers found subscribed to attestation gossip subnet with "+
-			"committee index %d. Searching network for peers subscribed to the subnet.", idx)
-		go func(idx uint64) {
-			_, err := r.p2p.FindPeersWithSubnet(idx)
-			if err != nil {
-				log.Errorf("Could not search for peers: %v", err)
-				return
-			}
-		}(idx)
-		return
-	}
-}
-
-// lookup peers for attester specific subnets.
-func (r *Service) lookupAttesterSubnets(digest [4]byte, idx uint64) {
-	topic := p2p.GossipTypeMapping[reflect.TypeOf(&pb.Attestation{})]
-	subnetTopic := fmt.Sprintf(topic, digest, idx)
-	if !r.validPeersExist(subnetTopic, idx) {
-		log.Debugf("No peers found subscribed to attestation gossip subnet with "+
-			"committee index %d. Searching network for peers subscribed to the subnet.", idx)
-		go func(idx uint64) {
-			// perform a search for peers with the desired committee index.
-			_, err := r.p2p.FindPeersWithSubnet(idx)
-			if err != nil {
-				log.Errorf("Could not search for peers: %v", err)
-				return
-			}
-		}(idx)
-	}
-}
-
-// find if we have peers who are subscribed to the same subnet
-func (r *Service) validPeersExist(subnetTopic string, idx uint64) bool {
-	numOfPeers := r.p2p.PubSub().ListPeers(subnetTopic + r.p2p.Encoding().ProtocolSuffix())
-	return len(r.p2p.Peers().SubscribedToSubnet(idx)) > 0 || len(numOfPeers) > 0
 }
 
 // Add fork digest to topic.
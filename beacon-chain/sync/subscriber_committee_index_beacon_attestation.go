@@ -67,13 +67,3 @@ func (r *Service) aggregatorCommitteeIndices(currentSlot uint64) []uint64 {
 	}
 	return sliceutil.SetUint64(commIds)
 }
-
-func (r *Service) attesterCommitteeIndices(currentSlot uint64) []uint64 {
-	endEpoch := helpers.SlotToEpoch(currentSlot) + 1
-	endSlot := endEpoch * params.BeaconConfig().SlotsPerEpoch
-	commIds := []uint64{}
-	for i := currentSlot; i <= endSlot; i++ {
-		commIds = append(commIds, cache.CommitteeIDs.GetAttesterCommitteeIDs(i)...)
-	}
-	return sliceutil.SetUint64(commIds)
-}
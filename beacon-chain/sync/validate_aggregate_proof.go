@@ -35,39 +35,40 @@ func (r *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	// To process the following it requires the recent blocks to be present in the database, so we'll skip
 	// validating or processing aggregated attestations until fully synced.
 	if r.initialSync.Syncing() {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonSyncing)
 	}
 
 	raw, err := r.decodePubsubMessage(msg)
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationReject
+		return reject(ctx, reasonDecodeFailed)
 	}
 	m, ok := raw.(*ethpb.SignedAggregateAttestationAndProof)
 	if !ok {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 
-	if m.Message == nil || m.Message.Aggregate == nil || m.Message.Aggregate.Data == nil {
-		return pubsub.ValidationReject
+	if m.Message == nil || m.Message.Aggregate == nil || m.Message.Aggregate.Data == nil ||
+		m.Message.Aggregate.Data.Target == nil {
+		return reject(ctx, reasonMalformed)
 	}
 	// Verify this is the first aggregate received from the aggregator with index and slot.
 	if r.hasSeenAggregatorIndexEpoch(m.Message.Aggregate.Data.Target.Epoch, m.Message.AggregatorIndex) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 
 	// Verify aggregate attestation has not already been seen via aggregate gossip, within a block, or through the creation locally.
 	seen, err := r.attPool.HasAggregatedAttestation(m.Message.Aggregate)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 	if seen {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 	if !r.validateBlockInAttestation(ctx, m) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonUnknownBlock)
 	}
 
 	validationRes := r.validateAggregatedAtt(ctx, m)
@@ -89,13 +90,13 @@ func (r *Service) validateAggregatedAtt(ctx context.Context, signed *ethpb.Signe
 	attSlot := signed.Message.Aggregate.Data.Slot
 	if err := validateAggregateAttTime(attSlot, uint64(r.chain.GenesisTime().Unix())); err != nil {
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonOutOfRange)
 	}
 
 	s, err := r.chain.AttestationPreState(ctx, signed.Message.Aggregate)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 
 	// Only advance state if different epoch as the committee can only change on an epoch transition.
@@ -103,37 +104,40 @@ func (r *Service) validateAggregatedAtt(ctx context.Context, signed *ethpb.Signe
 		s, err = state.ProcessSlots(ctx, s, helpers.StartSlot(helpers.SlotToEpoch(attSlot)))
 		if err != nil {
 			traceutil.AnnotateError(span, err)
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonInternalError)
 		}
 	}
 
 	// Verify validator index is within the aggregate's committee.
 	if err := validateIndexInCommittee(ctx, s, signed.Message.Aggregate, signed.Message.AggregatorIndex); err != nil {
 		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not validate index in committee"))
-		return pubsub.ValidationReject
+		return reject(ctx, reasonNotInCommittee)
 	}
 
+	// The signatures are collected to be verified together with those of other messages.
+	batch := bls.NewSignatureBatch()
+
 	// Verify selection proof reflects to the right validator and signature is valid.
-	if err := validateSelection(ctx, s, signed.Message.Aggregate.Data, signed.Message.AggregatorIndex, signed.Message.SelectionProof); err != nil {
+	if err := validateSelection(ctx, s, signed.Message.Aggregate.Data, signed.Message.AggregatorIndex, signed.Message.SelectionProof, batch); err != nil {
 		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not validate selection for validator %d", signed.Message.AggregatorIndex))
-		return pubsub.ValidationReject
+		return reject(ctx, reasonNotAggregator)
 	}
 
 	// Verify the aggregator's signature is valid.
-	if err := validateAggregatorSignature(s, signed); err != nil {
+	if err := validateAggregatorSignature(s, signed, batch); err != nil {
 		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not verify aggregator signature %d", signed.Message.AggregatorIndex))
-		return pubsub.ValidationReject
+		return reject(ctx, reasonInvalidSignature)
 	}
 
 	// Verify aggregated attestation has a valid signature.
 	if !featureconfig.Get().DisableStrictAttestationPubsubVerification {
-		if err := blocks.VerifyAttestation(ctx, s, signed.Message.Aggregate); err != nil {
+		if err := blocks.AddAttestationSignature(ctx, batch, s, signed.Message.Aggregate); err != nil {
 			traceutil.AnnotateError(span, err)
-			return pubsub.ValidationReject
+			return reject(ctx, reasonInvalidSignature)
 		}
 	}
 
-	return pubsub.ValidationAccept
+	return r.validateWithBatchVerifier(ctx, "aggregate and proof", batch)
 }
 
 func (r *Service) validateBlockInAttestation(ctx context.Context, s *ethpb.SignedAggregateAttestationAndProof) bool {
@@ -211,8 +215,15 @@ func validateAggregateAttTime(attSlot uint64, genesisTime uint64) error {
 }
 
 // This validates selection proof by validating it's from the correct validator index of the slot and selection
-// proof is a valid signature.
-func validateSelection(ctx context.Context, s *stateTrie.BeaconState, data *ethpb.AttestationData, validatorIndex uint64, proof []byte) error {
+// proof is a valid signature. The signature is added to the batch instead of verified when there is one.
+func validateSelection(
+	ctx context.Context,
+	s *stateTrie.BeaconState,
+	data *ethpb.AttestationData,
+	validatorIndex uint64,
+	proof []byte,
+	batch *bls.SignatureBatch,
+) error {
 	_, span := trace.StartSpan(ctx, "sync.validateSelection")
 	defer span.End()
 
@@ -237,6 +248,9 @@ func validateSelection(ctx context.Context, s *stateTrie.BeaconState, data *ethp
 		return err
 	}
 	pubkeyState := s.PubkeyAtIndex(validatorIndex)
+	if batch != nil {
+		return batch.AddBytes(proof, pubkeyState[:], slotMsg, "selection proof")
+	}
 	pubKey, err := bls.PublicKeyFromBytes(pubkeyState[:])
 	if err != nil {
 		return err
@@ -252,8 +266,9 @@ func validateSelection(ctx context.Context, s *stateTrie.BeaconState, data *ethp
 	return nil
 }
 
-// This verifies aggregator signature over the signed aggregate and proof object.
-func validateAggregatorSignature(s *stateTrie.BeaconState, a *ethpb.SignedAggregateAttestationAndProof) error {
+// This verifies aggregator signature over the signed aggregate and proof object, or adds it to the batch
+// when there is one.
+func validateAggregatorSignature(s *stateTrie.BeaconState, a *ethpb.SignedAggregateAttestationAndProof, batch *bls.SignatureBatch) error {
 	aggregator, err := s.ValidatorAtIndex(a.Message.AggregatorIndex)
 	if err != nil {
 		return err
@@ -265,6 +280,12 @@ func validateAggregatorSignature(s *stateTrie.BeaconState, a *ethpb.SignedAggreg
 		return err
 	}
 
+	if batch != nil {
+		root, err := helpers.ComputeSigningRoot(a.Message, domain)
+		if err != nil {
+			return err
+		}
+		return batch.AddBytes(a.Signature, aggregator.PublicKey, root, "aggregator signature")
+	}
 	return helpers.VerifySigningRoot(a.Message, aggregator.PublicKey, a.Signature, domain)
-
 }
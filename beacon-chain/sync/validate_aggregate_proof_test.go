@@ -76,7 +76,7 @@ func TestVerifySelection_NotAnAggregator(t *testing.T) {
 	data := &ethpb.AttestationData{}
 
 	wanted := "validator is not an aggregator for slot"
-	if err := validateSelection(ctx, beaconState, data, 0, sig.Marshal()); err == nil || !strings.Contains(err.Error(), wanted) {
+	if err := validateSelection(ctx, beaconState, data, 0, sig.Marshal(), nil); err == nil || !strings.Contains(err.Error(), wanted) {
 		t.Error("Did not receive wanted error")
 	}
 }
@@ -90,7 +90,7 @@ func TestVerifySelection_BadSignature(t *testing.T) {
 	data := &ethpb.AttestationData{}
 
 	wanted := "could not validate slot signature"
-	if err := validateSelection(ctx, beaconState, data, 0, sig.Marshal()); err == nil || !strings.Contains(err.Error(), wanted) {
+	if err := validateSelection(ctx, beaconState, data, 0, sig.Marshal(), nil); err == nil || !strings.Contains(err.Error(), wanted) {
 		t.Error("Did not receive wanted error")
 	}
 }
@@ -111,7 +111,7 @@ func TestVerifySelection_CanVerify(t *testing.T) {
 	}
 	sig := privKeys[0].Sign(slotRoot[:])
 
-	if err := validateSelection(ctx, beaconState, data, 0, sig.Marshal()); err != nil {
+	if err := validateSelection(ctx, beaconState, data, 0, sig.Marshal(), nil); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -27,7 +27,7 @@ func (r *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 
 	// The head state will be too far away to validate any slashing.
 	if r.initialSync.Syncing() {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateAttesterSlashing")
@@ -37,40 +37,40 @@ func (r *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationReject
+		return reject(ctx, reasonDecodeFailed)
 	}
 	slashing, ok := m.(*ethpb.AttesterSlashing)
 	if !ok {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 
 	if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 	if r.hasSeenAttesterSlashingIndices(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 
 	// Retrieve head state, advance state to the epoch slot used specified in slashing message.
 	s, err := r.chain.HeadState(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 	slashSlot := slashing.Attestation_1.Data.Target.Epoch * params.BeaconConfig().SlotsPerEpoch
 	if s.Slot() < slashSlot {
 		if ctx.Err() != nil {
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonTimeout)
 		}
 
 		var err error
 		s, err = state.ProcessSlots(ctx, s, slashSlot)
 		if err != nil {
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonInternalError)
 		}
 	}
 
 	if err := blocks.VerifyAttesterSlashing(ctx, s, slashing); err != nil {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonInvalidOperation)
 	}
 
 	msg.ValidatorData = slashing // Used in downstream subscriber
@@ -29,7 +29,7 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 
 	// We should not attempt to process blocks until fully synced, but propagation is OK.
 	if r.initialSync.Syncing() {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateBeaconBlockPubSub")
@@ -39,7 +39,7 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationReject
+		return reject(ctx, reasonDecodeFailed)
 	}
 
 	r.validateBlockLock.Lock()
@@ -47,46 +47,46 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 
 	blk, ok := m.(*ethpb.SignedBeaconBlock)
 	if !ok {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 
 	if blk.Block == nil {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 
 	// Verify the block is the first block received for the proposer for the slot.
 	if r.hasSeenBlockIndexSlot(blk.Block.Slot, blk.Block.ProposerIndex) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 
 	blockRoot, err := stateutil.BlockRoot(blk.Block)
 	if err != nil {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 	if r.db.HasBlock(ctx, blockRoot) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 
 	r.pendingQueueLock.RLock()
 	if r.seenPendingBlocks[blockRoot] {
 		r.pendingQueueLock.RUnlock()
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 	r.pendingQueueLock.RUnlock()
 
 	// Add metrics for block arrival time subtracts slot start time.
 	if captureArrivalTimeMetric(uint64(r.chain.GenesisTime().Unix()), blk.Block.Slot) != nil {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonOutOfRange)
 	}
 
 	if err := helpers.VerifySlotTime(uint64(r.chain.GenesisTime().Unix()), blk.Block.Slot, maximumGossipClockDisparity); err != nil {
 		log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Rejecting incoming block.")
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonOutOfRange)
 	}
 
 	if helpers.StartSlot(r.chain.FinalizedCheckpt().Epoch) >= blk.Block.Slot {
 		log.Debug("Block slot older/equal than last finalized epoch start slot, rejecting it")
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonFinalized)
 	}
 
 	// Handle block when the parent is unknown.
@@ -95,7 +95,7 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		r.slotToPendingBlocks[blk.Block.Slot] = blk
 		r.seenPendingBlocks[blockRoot] = true
 		r.pendingQueueLock.Unlock()
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonUnknownBlock)
 	}
 
 	if featureconfig.Get().NewStateMgmt {
@@ -103,32 +103,32 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		hasStateSummaryCache := r.stateSummaryCache.Has(bytesutil.ToBytes32(blk.Block.ParentRoot))
 		if !hasStateSummaryDB && !hasStateSummaryCache {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("No access to parent state")
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonUnknownBlock)
 		}
 		parentState, err := r.stateGen.StateByRoot(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot))
 		if err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not get parent state")
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonInternalError)
 		}
 
 		if err := blocks.VerifyBlockSignature(parentState, blk); err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not verify block signature")
-			return pubsub.ValidationReject
+			return reject(ctx, reasonInvalidSignature)
 		}
 
 		err = parentState.SetSlot(blk.Block.Slot)
 		if err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not set parent state slot")
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonInternalError)
 		}
 		idx, err := helpers.BeaconProposerIndex(parentState)
 		if err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not get proposer index using parent state")
-			return pubsub.ValidationIgnore
+			return ignore(ctx, reasonInternalError)
 		}
 		if blk.Block.ProposerIndex != idx {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Incorrect proposer index")
-			return pubsub.ValidationReject
+			return reject(ctx, reasonInvalidProposer)
 		}
 	}
 
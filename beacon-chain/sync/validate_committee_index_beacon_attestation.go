@@ -11,6 +11,7 @@ import (
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -30,7 +31,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	// Attestation processing requires the target block to be present in the database, so we'll skip
 	// validating or processing attestations until fully synced.
 	if s.initialSync.Syncing() {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonSyncing)
 	}
 	ctx, span := trace.StartSpan(ctx, "sync.validateCommitteeIndexBeaconAttestation")
 	defer span.End()
@@ -41,25 +42,25 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	msg.TopicIDs[0] = format
 
 	m, err := s.decodePubsubMessage(msg)
+	// Restore topic.
+	msg.TopicIDs[0] = originalTopic
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationReject
+		return reject(ctx, reasonDecodeFailed)
 	}
-	// Restore topic.
-	msg.TopicIDs[0] = originalTopic
 
 	att, ok := m.(*eth.Attestation)
 	if !ok {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 
 	if att.Data == nil {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 	// Verify this the first attestation received for the participating validator for the slot.
 	if s.hasSeenCommitteeIndicesSlot(att.Data.Slot, att.Data.CommitteeIndex, att.AggregationBits) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 
 	// The attestation's committee index (attestation.data.index) is for the correct subnet.
@@ -67,27 +68,21 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if err != nil {
 		log.WithError(err).Error("Failed to compute fork digest")
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationIgnore
-	}
-	preState, err := s.chain.AttestationPreState(ctx, att)
-	if err != nil {
-		log.WithError(err).Error("Failed to retrieve pre state")
-		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 	if !strings.HasPrefix(originalTopic, fmt.Sprintf(format, digest, att.Data.CommitteeIndex)) {
-		return pubsub.ValidationIgnore
+		return reject(ctx, reasonWrongSubnet)
 	}
 
 	// Attestation must be unaggregated.
 	if att.AggregationBits == nil || att.AggregationBits.Count() != 1 {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonAggregated)
 	}
 
 	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE.
 	if err := validateAggregateAttTime(att.Data.Slot, uint64(s.chain.GenesisTime().Unix())); err != nil {
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonOutOfRange)
 	}
 
 	// Verify the block being voted and the processed state is in DB and. The block should have passed validation if it's in the DB.
@@ -98,15 +93,25 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if !(hasState && hasBlock) {
 		// A node doesn't have the block, it'll request from peer while saving the pending attestation to a queue.
 		s.savePendingAtt(&eth.SignedAggregateAttestationAndProof{Message: &eth.AggregateAttestationAndProof{Aggregate: att}})
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonUnknownBlock)
 	}
 
 	// Attestation's signature is a valid BLS signature and belongs to correct public key..
 	if !featureconfig.Get().DisableStrictAttestationPubsubVerification {
-		if err := blocks.VerifyAttestation(ctx, preState, att); err != nil {
+		preState, err := s.chain.AttestationPreState(ctx, att)
+		if err != nil {
+			log.WithError(err).Error("Failed to retrieve pre state")
+			traceutil.AnnotateError(span, err)
+			return ignore(ctx, reasonInternalError)
+		}
+		batch := bls.NewSignatureBatch()
+		if err := blocks.AddAttestationSignature(ctx, batch, preState, att); err != nil {
 			log.WithError(err).Error("Could not verify attestation")
 			traceutil.AnnotateError(span, err)
-			return pubsub.ValidationReject
+			return reject(ctx, reasonInvalidSignature)
+		}
+		if res := s.validateWithBatchVerifier(ctx, "attestation", batch); res != pubsub.ValidationAccept {
+			return res
 		}
 	}
 
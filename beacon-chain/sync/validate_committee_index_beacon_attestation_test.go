@@ -10,15 +10,18 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
@@ -193,3 +196,136 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 		})
 	}
 }
+
+// preStateChainService fails to retrieve the pre state of attestations, counting its calls.
+type preStateChainService struct {
+	*mockChain.ChainService
+	preStateCalls int
+}
+
+func (c *preStateChainService) AttestationPreState(_ context.Context, _ *ethpb.Attestation) (*stateTrie.BeaconState, error) {
+	c.preStateCalls++
+	return nil, errors.New("no pre state")
+}
+
+func setupCommitteeIndexAttestationService(t *testing.T) (*Service, *preStateChainService, [32]byte) {
+	db := dbtest.SetupDB(t)
+	chain := &preStateChainService{
+		ChainService: &mockChain.ChainService{
+			Genesis:        time.Now().Add(time.Duration(-64*int64(params.BeaconConfig().SecondsPerSlot)) * time.Second), // 64 slots ago
+			ValidatorsRoot: [32]byte{'A'},
+		},
+	}
+	c, err := lru.New(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		initialSync:          &mockSync.Sync{IsSyncing: false},
+		p2p:                  p2ptest.NewTestP2P(t),
+		db:                   db,
+		chain:                chain,
+		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		seenAttestationCache: c,
+		stateSummaryCache:    cache.NewStateSummaryCache(),
+	}
+
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 55}}
+	if err := db.SaveBlock(context.Background(), blk); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateutil.BlockRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(context.Background(), testutil.NewBeaconState(), root); err != nil {
+		t.Fatal(err)
+	}
+	return s, chain, root
+}
+
+func committeeIndexAttestationMessage(t *testing.T, s *Service, att *ethpb.Attestation, subnet uint64) *pubsub.Message {
+	digest, err := s.forkDigest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := s.p2p.Encoding().EncodeGossip(buf, att); err != nil {
+		t.Fatal(err)
+	}
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:     buf.Bytes(),
+			TopicIDs: []string{fmt.Sprintf("/eth2/%x/committee_index%d_beacon_attestation", digest, subnet)},
+		},
+	}
+}
+
+func TestService_validateCommitteeIndexBeaconAttestation_WrongSubnet(t *testing.T) {
+	s, chain, root := setupCommitteeIndexAttestationService(t)
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1010},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: root[:],
+			CommitteeIndex:  2,
+			Slot:            63,
+			Target:          &ethpb.Checkpoint{},
+		},
+	}
+	msg := committeeIndexAttestationMessage(t, s, att, 3)
+	topic := msg.TopicIDs[0]
+
+	ctx, reason := withValidationReason(context.Background())
+	if res := s.validateCommitteeIndexBeaconAttestation(ctx, "" /*peerID*/, msg); res != pubsub.ValidationReject {
+		t.Fatalf("Expected attestation on the wrong subnet to be rejected, received %v", res)
+	}
+	if reason.reason != reasonWrongSubnet {
+		t.Errorf("Expected reason %q, received %q", reasonWrongSubnet, reason.reason)
+	}
+	if chain.preStateCalls != 0 {
+		t.Errorf("Expected no pre state to be retrieved, received %d calls", chain.preStateCalls)
+	}
+	if msg.TopicIDs[0] != topic {
+		t.Errorf("Expected topic %s to be restored, received %s", topic, msg.TopicIDs[0])
+	}
+}
+
+func TestService_validateCommitteeIndexBeaconAttestation_PreStateOnlyForStrictVerification(t *testing.T) {
+	s, chain, root := setupCommitteeIndexAttestationService(t)
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1010},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: root[:],
+			CommitteeIndex:  1,
+			Slot:            63,
+			Target:          &ethpb.Checkpoint{},
+		},
+	}
+
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{})
+	ctx, reason := withValidationReason(context.Background())
+	res := s.validateCommitteeIndexBeaconAttestation(ctx, "" /*peerID*/, committeeIndexAttestationMessage(t, s, att, 1))
+	resetCfg()
+	if res != pubsub.ValidationIgnore {
+		t.Fatalf("Expected attestation without pre state to be ignored, received %v", res)
+	}
+	if reason.reason != reasonInternalError {
+		t.Errorf("Expected reason %q, received %q", reasonInternalError, reason.reason)
+	}
+	if chain.preStateCalls != 1 {
+		t.Errorf("Expected pre state to be retrieved once, received %d calls", chain.preStateCalls)
+	}
+
+	resetCfg = featureconfig.InitWithReset(&featureconfig.Flags{DisableStrictAttestationPubsubVerification: true})
+	defer resetCfg()
+	msg := committeeIndexAttestationMessage(t, s, att, 1)
+	if res := s.validateCommitteeIndexBeaconAttestation(context.Background(), "" /*peerID*/, msg); res != pubsub.ValidationAccept {
+		t.Fatalf("Expected attestation to be accepted without strict verification, received %v", res)
+	}
+	if chain.preStateCalls != 1 {
+		t.Errorf("Expected no pre state to be retrieved without strict verification, received %d calls", chain.preStateCalls-1)
+	}
+	if msg.ValidatorData == nil {
+		t.Error("Expected validator data to be set")
+	}
+}
@@ -23,7 +23,7 @@ func (r *Service) validateVoluntaryExit(ctx context.Context, pid peer.ID, msg *p
 
 	// The head state will be too far away to validate any voluntary exit.
 	if r.initialSync.Syncing() {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateVoluntaryExit")
@@ -33,36 +33,36 @@ func (r *Service) validateVoluntaryExit(ctx context.Context, pid peer.ID, msg *p
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return pubsub.ValidationReject
+		return reject(ctx, reasonDecodeFailed)
 	}
 
 	exit, ok := m.(*ethpb.SignedVoluntaryExit)
 	if !ok {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 
 	if exit.Exit == nil {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonMalformed)
 	}
 	if r.hasSeenExitIndex(exit.Exit.ValidatorIndex) {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonAlreadySeen)
 	}
 
 	s, err := r.chain.HeadState(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 
 	exitedEpochSlot := exit.Exit.Epoch * params.BeaconConfig().SlotsPerEpoch
 	if int(exit.Exit.ValidatorIndex) >= s.NumValidators() {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonUnknownValidator)
 	}
 	val, err := s.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
 	if err != nil {
-		return pubsub.ValidationIgnore
+		return ignore(ctx, reasonInternalError)
 	}
 	if err := blocks.VerifyExit(val, exitedEpochSlot, s.Fork(), exit, s.GenesisValidatorRoot()); err != nil {
-		return pubsub.ValidationReject
+		return reject(ctx, reasonInvalidOperation)
 	}
 
 	msg.ValidatorData = exit // Used in downstream subscriber
@@ -0,0 +1,64 @@
+package sync
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// Reasons for which gossip messages are rejected or ignored, recorded in the metrics of their topic.
+const (
+	reasonGraylisted       = "graylisted"
+	reasonSyncing          = "syncing"
+	reasonDecodeFailed     = "decode_failed"
+	reasonMalformed        = "malformed"
+	reasonAlreadySeen      = "already_seen"
+	reasonInternalError    = "internal_error"
+	reasonUnknownBlock     = "unknown_block"
+	reasonOutOfRange       = "out_of_range"
+	reasonFinalized        = "finalized_slot"
+	reasonWrongSubnet      = "wrong_subnet"
+	reasonAggregated       = "not_unaggregated"
+	reasonInvalidProposer  = "invalid_proposer"
+	reasonNotInCommittee   = "not_in_committee"
+	reasonNotAggregator    = "not_aggregator"
+	reasonInvalidSignature = "invalid_signature"
+	reasonInvalidOperation = "invalid_operation"
+	reasonUnknownValidator = "unknown_validator"
+	reasonTimeout          = "timeout"
+	reasonUnspecified      = "unspecified"
+)
+
+type validationReasonKey struct{}
+
+// validationReason holds the reason for which a gossip message was rejected or ignored.
+type validationReason struct {
+	reason string
+}
+
+// withValidationReason returns a context in which validators can record the reason for which they
+// reject or ignore a message.
+func withValidationReason(ctx context.Context) (context.Context, *validationReason) {
+	v := &validationReason{reason: reasonUnspecified}
+	return context.WithValue(ctx, validationReasonKey{}, v), v
+}
+
+// reject records the reason for which a message is rejected. The sender of a rejected message is
+// penalized.
+func reject(ctx context.Context, reason string) pubsub.ValidationResult {
+	setValidationReason(ctx, reason)
+	return pubsub.ValidationReject
+}
+
+// ignore records the reason for which a message is ignored. An ignored message is dropped
+// without penalizing its sender, as it may be valid.
+func ignore(ctx context.Context, reason string) pubsub.ValidationResult {
+	setValidationReason(ctx, reason)
+	return pubsub.ValidationIgnore
+}
+
+func setValidationReason(ctx context.Context, reason string) {
+	if v, ok := ctx.Value(validationReasonKey{}).(*validationReason); ok {
+		v.reason = reason
+	}
+}
@@ -0,0 +1,77 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+)
+
+func TestValidationReason_Recorded(t *testing.T) {
+	ctx, reason := withValidationReason(context.Background())
+	if reason.reason != reasonUnspecified {
+		t.Errorf("Expected unspecified reason, received %q", reason.reason)
+	}
+	if res := reject(ctx, reasonMalformed); res != pubsub.ValidationReject {
+		t.Errorf("Expected rejection, received %v", res)
+	}
+	if reason.reason != reasonMalformed {
+		t.Errorf("Expected reason %q, received %q", reasonMalformed, reason.reason)
+	}
+	if res := ignore(ctx, reasonAlreadySeen); res != pubsub.ValidationIgnore {
+		t.Errorf("Expected ignore, received %v", res)
+	}
+	if reason.reason != reasonAlreadySeen {
+		t.Errorf("Expected reason %q, received %q", reasonAlreadySeen, reason.reason)
+	}
+	// Validators called outside of the pubsub router have no reason to record.
+	if res := reject(context.Background(), reasonMalformed); res != pubsub.ValidationReject {
+		t.Errorf("Expected rejection, received %v", res)
+	}
+}
+
+func TestWrapAndReportValidation_PenalizesRejectedMessages(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	r := &Service{p2p: p}
+	pid, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := pubsub.ValidationIgnore
+	_, validator := r.wrapAndReportValidation("topic", func(ctx context.Context, _ peer.ID, _ *pubsub.Message) pubsub.ValidationResult {
+		if result == pubsub.ValidationReject {
+			return reject(ctx, reasonInvalidSignature)
+		}
+		return ignore(ctx, reasonUnknownBlock)
+	})
+
+	if res := validator(context.Background(), pid, &pubsub.Message{}); res != pubsub.ValidationIgnore {
+		t.Fatalf("Expected ignore, received %v", res)
+	}
+	if _, err := p.Peers().ScoreInfo(pid); err == nil {
+		t.Error("Expected peer not to be penalized for an ignored message")
+	}
+
+	result = pubsub.ValidationReject
+	if res := validator(context.Background(), pid, &pubsub.Message{}); res != pubsub.ValidationReject {
+		t.Fatalf("Expected rejection, received %v", res)
+	}
+	info, err := p.Peers().ScoreInfo(pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.InvalidGossip != 1 {
+		t.Errorf("Expected peer to be penalized once, received %v", info.InvalidGossip)
+	}
+
+	// Our own messages are not penalized.
+	if res := validator(context.Background(), p.PeerID(), &pubsub.Message{}); res != pubsub.ValidationReject {
+		t.Fatalf("Expected rejection, received %v", res)
+	}
+	if _, err := p.Peers().ScoreInfo(p.PeerID()); err == nil {
+		t.Error("Expected local peer not to be penalized")
+	}
+}
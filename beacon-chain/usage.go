@@ -90,6 +90,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.KeyFlag,
 			flags.DisableGRPCGateway,
 			flags.GRPCGatewayPort,
+			flags.DisableBeaconAPI,
+			flags.BeaconAPIPort,
 			flags.HTTPWeb3ProviderFlag,
 			flags.SetGCPercent,
 			flags.UnsafeSync,
@@ -97,10 +99,16 @@ var appHelpFlagGroups = []flagGroup{
 			flags.SlasherProviderFlag,
 			flags.SlotsPerArchivedPoint,
 			flags.DisableDiscv5,
+			flags.MinPeersPerSubnet,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.EnableDebugRPCEndpoints,
 			flags.SlotsPerArchivedPoint,
+			flags.CheckpointStateFlag,
+			flags.CheckpointBlockFlag,
+			flags.CheckpointSyncNodeFlag,
+			flags.PruneDBFlag,
+			flags.PruneRetentionEpochsFlag,
 		},
 	},
 	{
@@ -118,6 +126,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.EnableUPnPFlag,
 			cmd.P2PEncoding,
 			cmd.P2PPubsub,
+			cmd.P2PPeerStoreExpiry,
 			flags.MinSyncPeers,
 		},
 	},
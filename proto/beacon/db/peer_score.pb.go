@@ -0,0 +1,571 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/beacon/db/peer_score.proto
+
+package db
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type PeerScore struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	InvalidGossip        float64  `protobuf:"fixed64,2,opt,name=invalid_gossip,json=invalidGossip,proto3" json:"invalid_gossip,omitempty"`
+	BadResponses         float64  `protobuf:"fixed64,3,opt,name=bad_responses,json=badResponses,proto3" json:"bad_responses,omitempty"`
+	Timeouts             float64  `protobuf:"fixed64,4,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	UsefulBlocks         float64  `protobuf:"fixed64,5,opt,name=useful_blocks,json=usefulBlocks,proto3" json:"useful_blocks,omitempty"`
+	Uptime               uint64   `protobuf:"varint,6,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	BannedUntil          int64    `protobuf:"varint,7,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	DropReason           string   `protobuf:"bytes,8,opt,name=drop_reason,json=dropReason,proto3" json:"drop_reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerScore) Reset()         { *m = PeerScore{} }
+func (m *PeerScore) String() string { return proto.CompactTextString(m) }
+func (*PeerScore) ProtoMessage()    {}
+func (*PeerScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5d0f85b72f889b41, []int{0}
+}
+func (m *PeerScore) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerScore.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerScore.Merge(m, src)
+}
+func (m *PeerScore) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerScore proto.InternalMessageInfo
+
+func (m *PeerScore) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *PeerScore) GetInvalidGossip() float64 {
+	if m != nil {
+		return m.InvalidGossip
+	}
+	return 0
+}
+
+func (m *PeerScore) GetBadResponses() float64 {
+	if m != nil {
+		return m.BadResponses
+	}
+	return 0
+}
+
+func (m *PeerScore) GetTimeouts() float64 {
+	if m != nil {
+		return m.Timeouts
+	}
+	return 0
+}
+
+func (m *PeerScore) GetUsefulBlocks() float64 {
+	if m != nil {
+		return m.UsefulBlocks
+	}
+	return 0
+}
+
+func (m *PeerScore) GetUptime() uint64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *PeerScore) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
+func (m *PeerScore) GetDropReason() string {
+	if m != nil {
+		return m.DropReason
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*PeerScore)(nil), "prysm.beacon.db.PeerScore")
+}
+
+func init() { proto.RegisterFile("proto/beacon/db/peer_score.proto", fileDescriptor_5d0f85b72f889b41) }
+
+var fileDescriptor_5d0f85b72f889b41 = []byte{
+	// 294 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0xd0, 0xb1, 0x4e, 0xc3, 0x30,
+	0x10, 0x06, 0x60, 0xb9, 0x2d, 0x69, 0xeb, 0xb6, 0x20, 0x79, 0x00, 0x8b, 0xa1, 0x04, 0x10, 0x52,
+	0xa6, 0x64, 0x60, 0x65, 0xea, 0x82, 0xd8, 0x50, 0x10, 0x0b, 0x4b, 0x64, 0xc7, 0x47, 0xb1, 0x48,
+	0x6d, 0xcb, 0x97, 0x20, 0xf1, 0x10, 0xbc, 0x17, 0x23, 0x8f, 0x80, 0xf2, 0x24, 0xc8, 0x4e, 0x61,
+	0x60, 0xfc, 0x3f, 0xff, 0x77, 0x92, 0x8f, 0xa6, 0xce, 0xdb, 0xd6, 0x16, 0x12, 0x44, 0x6d, 0x4d,
+	0xa1, 0x64, 0xe1, 0x00, 0x7c, 0x85, 0xb5, 0xf5, 0x90, 0xc7, 0x27, 0x76, 0xe4, 0xfc, 0x3b, 0xee,
+	0xf2, 0xa1, 0x91, 0x2b, 0x79, 0xf1, 0x31, 0xa2, 0xf3, 0x7b, 0x00, 0xff, 0x10, 0x4a, 0xec, 0x84,
+	0x4e, 0xe3, 0x88, 0x56, 0x9c, 0xa4, 0x24, 0x9b, 0x97, 0x49, 0x88, 0x77, 0x8a, 0x5d, 0xd1, 0x43,
+	0x6d, 0xde, 0x44, 0xa3, 0x55, 0xb5, 0xb5, 0x88, 0xda, 0xf1, 0x51, 0x4a, 0x32, 0x52, 0xae, 0xf6,
+	0x7a, 0x1b, 0x91, 0x5d, 0xd2, 0x95, 0x14, 0xaa, 0xf2, 0x80, 0xce, 0x1a, 0x04, 0xe4, 0xe3, 0xd8,
+	0x5a, 0x4a, 0xa1, 0xca, 0x5f, 0x63, 0xa7, 0x74, 0xd6, 0xea, 0x1d, 0xd8, 0xae, 0x45, 0x3e, 0x89,
+	0xef, 0x7f, 0x39, 0x2c, 0xe8, 0x10, 0x9e, 0xbb, 0xa6, 0x92, 0x8d, 0xad, 0x5f, 0x91, 0x1f, 0x0c,
+	0x0b, 0x06, 0xdc, 0x44, 0x63, 0xc7, 0x34, 0xe9, 0x5c, 0x18, 0xe1, 0x49, 0x4a, 0xb2, 0x49, 0xb9,
+	0x4f, 0xec, 0x9c, 0x2e, 0xa5, 0x30, 0x06, 0x54, 0xd5, 0x99, 0x56, 0x37, 0x7c, 0x9a, 0x92, 0x6c,
+	0x5c, 0x2e, 0x06, 0x7b, 0x0c, 0xc4, 0xce, 0xe8, 0x42, 0x79, 0xeb, 0x2a, 0x0f, 0x02, 0xad, 0xe1,
+	0xb3, 0xf8, 0x49, 0x1a, 0xa8, 0x8c, 0xb2, 0xb9, 0xf9, 0xec, 0xd7, 0xe4, 0xab, 0x5f, 0x93, 0xef,
+	0x7e, 0x4d, 0x9e, 0xf2, 0xad, 0x6e, 0x5f, 0x3a, 0x99, 0xd7, 0x76, 0x57, 0xc4, 0xcb, 0x89, 0x56,
+	0xd7, 0x8d, 0x90, 0x38, 0xa4, 0xe2, 0xdf, 0xbd, 0x65, 0x12, 0xe1, 0xfa, 0x67, 0x00, 0xd9, 0x05,
+	0xae, 0x78, 0x89, 0x01, 0x00, 0x00,
+}
+
+func (m *PeerScore) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerScore) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerScore) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DropReason) > 0 {
+		i -= len(m.DropReason)
+		copy(dAtA[i:], m.DropReason)
+		i = encodeVarintPeerScore(dAtA, i, uint64(len(m.DropReason)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.BannedUntil != 0 {
+		i = encodeVarintPeerScore(dAtA, i, uint64(m.BannedUntil))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Uptime != 0 {
+		i = encodeVarintPeerScore(dAtA, i, uint64(m.Uptime))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.UsefulBlocks != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.UsefulBlocks))))
+		i--
+		dAtA[i] = 0x29
+	}
+	if m.Timeouts != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Timeouts))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.BadResponses != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.BadResponses))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.InvalidGossip != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.InvalidGossip))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.PeerId) > 0 {
+		i -= len(m.PeerId)
+		copy(dAtA[i:], m.PeerId)
+		i = encodeVarintPeerScore(dAtA, i, uint64(len(m.PeerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPeerScore(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPeerScore(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PeerScore) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PeerId)
+	if l > 0 {
+		n += 1 + l + sovPeerScore(uint64(l))
+	}
+	if m.InvalidGossip != 0 {
+		n += 9
+	}
+	if m.BadResponses != 0 {
+		n += 9
+	}
+	if m.Timeouts != 0 {
+		n += 9
+	}
+	if m.UsefulBlocks != 0 {
+		n += 9
+	}
+	if m.Uptime != 0 {
+		n += 1 + sovPeerScore(uint64(m.Uptime))
+	}
+	if m.BannedUntil != 0 {
+		n += 1 + sovPeerScore(uint64(m.BannedUntil))
+	}
+	l = len(m.DropReason)
+	if l > 0 {
+		n += 1 + l + sovPeerScore(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovPeerScore(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPeerScore(x uint64) (n int) {
+	return sovPeerScore(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PeerScore) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPeerScore
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerScore: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerScore: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPeerScore
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPeerScore
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPeerScore
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidGossip", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.InvalidGossip = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BadResponses", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.BadResponses = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeouts", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Timeouts = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsefulBlocks", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.UsefulBlocks = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			}
+			m.Uptime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPeerScore
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Uptime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BannedUntil", wireType)
+			}
+			m.BannedUntil = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPeerScore
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BannedUntil |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DropReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPeerScore
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPeerScore
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPeerScore
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DropReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPeerScore(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPeerScore
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPeerScore
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipPeerScore(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowPeerScore
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowPeerScore
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowPeerScore
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthPeerScore
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupPeerScore
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthPeerScore
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthPeerScore        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowPeerScore          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupPeerScore = fmt.Errorf("proto: unexpected end of group")
+)
@@ -5,6 +5,7 @@ package ethereum_beacon_rpc_v1
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	types "github.com/gogo/protobuf/types"
@@ -468,6 +469,196 @@ func (m *ProtoArrayNode) GetBestDescendant() uint64 {
 	return 0
 }
 
+type PeerScoresResponse struct {
+	Scores               []*PeerScore `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PeerScoresResponse) Reset()         { *m = PeerScoresResponse{} }
+func (m *PeerScoresResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerScoresResponse) ProtoMessage()    {}
+func (*PeerScoresResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_851e5cb2de3d61dd, []int{6}
+}
+func (m *PeerScoresResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerScoresResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerScoresResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerScoresResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerScoresResponse.Merge(m, src)
+}
+func (m *PeerScoresResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerScoresResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerScoresResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerScoresResponse proto.InternalMessageInfo
+
+func (m *PeerScoresResponse) GetScores() []*PeerScore {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type PeerScore struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	ConnectionState      string   `protobuf:"bytes,2,opt,name=connection_state,json=connectionState,proto3" json:"connection_state,omitempty"`
+	Score                float64  `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	InvalidGossip        float64  `protobuf:"fixed64,4,opt,name=invalid_gossip,json=invalidGossip,proto3" json:"invalid_gossip,omitempty"`
+	BadResponses         float64  `protobuf:"fixed64,5,opt,name=bad_responses,json=badResponses,proto3" json:"bad_responses,omitempty"`
+	Timeouts             float64  `protobuf:"fixed64,6,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	UsefulBlocks         float64  `protobuf:"fixed64,7,opt,name=useful_blocks,json=usefulBlocks,proto3" json:"useful_blocks,omitempty"`
+	Uptime               uint64   `protobuf:"varint,8,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Graylisted           bool     `protobuf:"varint,9,opt,name=graylisted,proto3" json:"graylisted,omitempty"`
+	Bad                  bool     `protobuf:"varint,10,opt,name=bad,proto3" json:"bad,omitempty"`
+	BannedUntil          int64    `protobuf:"varint,11,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	DropReason           string   `protobuf:"bytes,12,opt,name=drop_reason,json=dropReason,proto3" json:"drop_reason,omitempty"`
+	GossipScore          float64  `protobuf:"fixed64,13,opt,name=gossip_score,json=gossipScore,proto3" json:"gossip_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerScore) Reset()         { *m = PeerScore{} }
+func (m *PeerScore) String() string { return proto.CompactTextString(m) }
+func (*PeerScore) ProtoMessage()    {}
+func (*PeerScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_851e5cb2de3d61dd, []int{7}
+}
+func (m *PeerScore) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerScore.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerScore.Merge(m, src)
+}
+func (m *PeerScore) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerScore proto.InternalMessageInfo
+
+func (m *PeerScore) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *PeerScore) GetConnectionState() string {
+	if m != nil {
+		return m.ConnectionState
+	}
+	return ""
+}
+
+func (m *PeerScore) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PeerScore) GetInvalidGossip() float64 {
+	if m != nil {
+		return m.InvalidGossip
+	}
+	return 0
+}
+
+func (m *PeerScore) GetBadResponses() float64 {
+	if m != nil {
+		return m.BadResponses
+	}
+	return 0
+}
+
+func (m *PeerScore) GetTimeouts() float64 {
+	if m != nil {
+		return m.Timeouts
+	}
+	return 0
+}
+
+func (m *PeerScore) GetUsefulBlocks() float64 {
+	if m != nil {
+		return m.UsefulBlocks
+	}
+	return 0
+}
+
+func (m *PeerScore) GetUptime() uint64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *PeerScore) GetGraylisted() bool {
+	if m != nil {
+		return m.Graylisted
+	}
+	return false
+}
+
+func (m *PeerScore) GetBad() bool {
+	if m != nil {
+		return m.Bad
+	}
+	return false
+}
+
+func (m *PeerScore) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
+func (m *PeerScore) GetDropReason() string {
+	if m != nil {
+		return m.DropReason
+	}
+	return ""
+}
+
+func (m *PeerScore) GetGossipScore() float64 {
+	if m != nil {
+		return m.GossipScore
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("ethereum.beacon.rpc.v1.LoggingLevelRequest_Level", LoggingLevelRequest_Level_name, LoggingLevelRequest_Level_value)
 	proto.RegisterType((*BeaconStateRequest)(nil), "ethereum.beacon.rpc.v1.BeaconStateRequest")
@@ -477,60 +668,79 @@ func init() {
 	proto.RegisterType((*ProtoArrayForkChoiceResponse)(nil), "ethereum.beacon.rpc.v1.ProtoArrayForkChoiceResponse")
 	proto.RegisterMapType((map[string]uint64)(nil), "ethereum.beacon.rpc.v1.ProtoArrayForkChoiceResponse.IndicesEntry")
 	proto.RegisterType((*ProtoArrayNode)(nil), "ethereum.beacon.rpc.v1.ProtoArrayNode")
+	proto.RegisterType((*PeerScoresResponse)(nil), "ethereum.beacon.rpc.v1.PeerScoresResponse")
+	proto.RegisterType((*PeerScore)(nil), "ethereum.beacon.rpc.v1.PeerScore")
 }
 
 func init() { proto.RegisterFile("proto/beacon/rpc/v1/debug.proto", fileDescriptor_851e5cb2de3d61dd) }
 
 var fileDescriptor_851e5cb2de3d61dd = []byte{
-	// 761 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0x13, 0x27, 0x6d, 0xb6, 0x51, 0x1a, 0x96, 0x2a, 0x98, 0xb4, 0x4d, 0x83, 0x8b, 0xda,
-	0x0a, 0x84, 0xad, 0x04, 0x0e, 0xa8, 0xb7, 0xa6, 0x4d, 0xd3, 0x4a, 0x55, 0x41, 0x4e, 0xb9, 0xd0,
-	0x43, 0xe4, 0xd8, 0x93, 0xd8, 0xc4, 0xf5, 0xba, 0xf6, 0x3a, 0x10, 0xb8, 0x55, 0x08, 0x8e, 0x1c,
-	0x38, 0xf0, 0x4a, 0x1c, 0x91, 0x78, 0x01, 0x54, 0xf1, 0x20, 0x68, 0xd7, 0x4e, 0x9a, 0xa8, 0x89,
-	0xf8, 0x11, 0xb7, 0x9d, 0x6f, 0xbe, 0xf9, 0x66, 0x77, 0x66, 0x67, 0xd0, 0xba, 0xe7, 0x13, 0x4a,
-	0xd4, 0x36, 0xe8, 0x06, 0x71, 0x55, 0xdf, 0x33, 0xd4, 0x7e, 0x45, 0x35, 0xa1, 0x1d, 0x76, 0x15,
-	0xee, 0xc1, 0x05, 0xa0, 0x16, 0xf8, 0x10, 0x9e, 0x2b, 0x11, 0x47, 0xf1, 0x3d, 0x43, 0xe9, 0x57,
-	0x8a, 0x93, 0x81, 0x5e, 0xd5, 0x63, 0x81, 0x74, 0xe0, 0x41, 0x10, 0x05, 0x16, 0x57, 0xbb, 0x84,
-	0x74, 0x1d, 0x50, 0x75, 0xcf, 0x56, 0x75, 0xd7, 0x25, 0x54, 0xa7, 0x36, 0x71, 0x87, 0xde, 0x95,
-	0xd8, 0xcb, 0xad, 0x76, 0xd8, 0x51, 0xe1, 0xdc, 0xa3, 0x83, 0xc8, 0x29, 0x9f, 0x21, 0x5c, 0xe3,
-	0xba, 0x4d, 0xaa, 0x53, 0xd0, 0xe0, 0x22, 0x84, 0x80, 0xe2, 0x65, 0x24, 0x06, 0x0e, 0xa1, 0x92,
-	0x50, 0x16, 0xb6, 0xc5, 0xc3, 0x39, 0x8d, 0x5b, 0x78, 0x1d, 0xa1, 0xb6, 0x43, 0x8c, 0x5e, 0xcb,
-	0x27, 0x84, 0x4a, 0x89, 0xb2, 0xb0, 0x9d, 0x3d, 0x9c, 0xd3, 0x32, 0x1c, 0xd3, 0x08, 0xa1, 0xb5,
-	0x1c, 0xca, 0x5e, 0x84, 0xe0, 0x0f, 0x5a, 0x1d, 0xdb, 0xa1, 0xe0, 0xcb, 0x8f, 0x50, 0xb6, 0xc6,
-	0x9d, 0xb1, 0xec, 0xda, 0x84, 0x00, 0x13, 0xcf, 0x8e, 0x85, 0xcb, 0x5b, 0x68, 0xb1, 0xd9, 0x7c,
-	0xa9, 0x41, 0xe0, 0x11, 0x37, 0x00, 0x2c, 0xa1, 0x79, 0x70, 0x0d, 0x62, 0x82, 0x19, 0x53, 0x87,
-	0xa6, 0xfc, 0x51, 0x40, 0xb7, 0x8f, 0x49, 0xb7, 0x6b, 0xbb, 0xdd, 0x63, 0xe8, 0x83, 0x33, 0xd4,
-	0x6f, 0xa0, 0x94, 0xc3, 0x6c, 0xce, 0xcf, 0x55, 0x2b, 0xca, 0xf4, 0x82, 0x2a, 0x53, 0x62, 0x95,
-	0xc8, 0x88, 0xe2, 0xe5, 0x2d, 0x94, 0xe2, 0x36, 0x5e, 0x40, 0xe2, 0xd1, 0xc9, 0xc1, 0xb3, 0xfc,
-	0x1c, 0xce, 0xa0, 0xd4, 0x7e, 0xbd, 0xf6, 0xa2, 0x91, 0x17, 0xd8, 0xf1, 0x54, 0xdb, 0xdd, 0xab,
-	0xe7, 0x13, 0xf2, 0x87, 0x24, 0x5a, 0x7d, 0xce, 0x0a, 0xb9, 0xeb, 0xfb, 0xfa, 0xe0, 0x80, 0xf8,
-	0xbd, 0x3d, 0x8b, 0xd8, 0x06, 0x8c, 0x1e, 0xb1, 0x85, 0x96, 0x3c, 0x3f, 0x74, 0xa1, 0x45, 0x2d,
-	0x1f, 0x02, 0x8b, 0x38, 0xd1, 0x63, 0x44, 0x2d, 0xc7, 0xe1, 0xd3, 0x21, 0xca, 0x88, 0xaf, 0xc2,
-	0x80, 0xda, 0x1d, 0x1b, 0xcc, 0x16, 0x78, 0xc4, 0xb0, 0x78, 0x85, 0x45, 0x2d, 0x37, 0x82, 0xeb,
-	0x0c, 0x65, 0xc4, 0x8e, 0xed, 0xea, 0x8e, 0xfd, 0x76, 0x44, 0x4c, 0x46, 0xc4, 0x11, 0x1c, 0x11,
-	0x35, 0x74, 0x8b, 0xf7, 0xb8, 0xa5, 0xb3, 0xbb, 0xb5, 0x5c, 0x62, 0x42, 0x20, 0x89, 0xe5, 0xe4,
-	0xf6, 0x62, 0x75, 0x73, 0x56, 0x65, 0xae, 0xdf, 0x72, 0x42, 0x4c, 0xd0, 0x96, 0xbc, 0x09, 0x3b,
-	0xc0, 0x67, 0x68, 0xde, 0x76, 0x4d, 0xdb, 0x80, 0x40, 0x4a, 0x71, 0xa5, 0xdd, 0xdf, 0x2b, 0xdd,
-	0xac, 0x8a, 0x72, 0x14, 0x69, 0xd4, 0x5d, 0xea, 0x0f, 0xb4, 0xa1, 0x62, 0x71, 0x07, 0x65, 0xc7,
-	0x1d, 0x38, 0x8f, 0x92, 0x3d, 0x18, 0xf0, 0x7a, 0x65, 0x34, 0x76, 0xc4, 0xcb, 0x28, 0xd5, 0xd7,
-	0x9d, 0x10, 0xe2, 0xd2, 0x44, 0xc6, 0x4e, 0xe2, 0xa9, 0x20, 0x5f, 0x26, 0x50, 0x6e, 0xf2, 0xf2,
-	0x18, 0x8f, 0x7f, 0xe2, 0xf8, 0x0b, 0x63, 0x24, 0x5e, 0x7f, 0x5e, 0x8d, 0x9f, 0x71, 0x01, 0xa5,
-	0x3d, 0xdd, 0x07, 0x97, 0xc6, 0x75, 0x8c, 0xad, 0x69, 0x1d, 0x11, 0xff, 0xb4, 0x23, 0xa9, 0xa9,
-	0x1d, 0x29, 0xa0, 0xf4, 0x6b, 0xb0, 0xbb, 0x16, 0x95, 0xd2, 0x51, 0xa6, 0xc8, 0xe2, 0x73, 0x01,
-	0x01, 0x6d, 0x19, 0x96, 0xed, 0x98, 0xd2, 0x3c, 0xf7, 0x65, 0x18, 0xb2, 0xc7, 0x00, 0xa6, 0xcf,
-	0xdd, 0x26, 0x04, 0x06, 0xb8, 0xa6, 0xee, 0x52, 0x69, 0x21, 0xd2, 0x67, 0xf0, 0xfe, 0x08, 0xad,
-	0x7e, 0x11, 0x51, 0x6a, 0x9f, 0x2d, 0x14, 0xfc, 0x5e, 0x40, 0xb9, 0x06, 0xd0, 0xb1, 0xd1, 0xc6,
-	0x0f, 0x66, 0x75, 0xea, 0xe6, 0xfc, 0x17, 0x37, 0x66, 0x71, 0xc7, 0xe6, 0x53, 0xbe, 0x77, 0xf9,
-	0xfd, 0xe7, 0xe7, 0xc4, 0x0a, 0xbe, 0xab, 0x02, 0xb5, 0xd4, 0x7e, 0x45, 0x77, 0x3c, 0x4b, 0x8f,
-	0x37, 0x9a, 0x1a, 0xf0, 0x9c, 0x6f, 0xd0, 0x02, 0xbb, 0x05, 0x9b, 0x70, 0x7c, 0x7f, 0x66, 0xfe,
-	0xb1, 0x15, 0xf1, 0x1f, 0x32, 0xf3, 0x7d, 0x82, 0xdf, 0xa1, 0xa5, 0x26, 0xd0, 0xf1, 0x41, 0xc7,
-	0x0f, 0xff, 0x62, 0x1d, 0x14, 0x0b, 0x4a, 0xb4, 0x35, 0x95, 0xe1, 0xd6, 0x54, 0xea, 0x6c, 0x6b,
-	0xca, 0x1b, 0x3c, 0xf5, 0x9a, 0xbc, 0x32, 0x2d, 0xb5, 0x13, 0x09, 0xe1, 0x4f, 0x02, 0xba, 0xd3,
-	0x00, 0x3a, 0x6d, 0x04, 0xf0, 0x0c, 0xe1, 0xe2, 0x93, 0x7f, 0x19, 0x24, 0x79, 0x93, 0x5f, 0xa7,
-	0x8c, 0x4b, 0xd3, 0xae, 0xd3, 0x21, 0x7e, 0xcf, 0xe0, 0xfc, 0x5a, 0xf6, 0xeb, 0x55, 0x49, 0xf8,
-	0x76, 0x55, 0x12, 0x7e, 0x5c, 0x95, 0x84, 0x76, 0x9a, 0xe7, 0x7e, 0xfc, 0x2b, 0x00, 0x00, 0xff,
-	0xff, 0x88, 0x5e, 0x27, 0x92, 0x92, 0x06, 0x00, 0x00,
+	// 1040 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xef, 0xc6, 0x7f, 0x12, 0x3f, 0x3b, 0x4e, 0x18, 0xaa, 0x74, 0x71, 0xda, 0xc4, 0xd9, 0x40,
+	0x63, 0x8a, 0xb0, 0x95, 0xc0, 0x01, 0x7a, 0xcb, 0xbf, 0xa6, 0x91, 0xa2, 0xb6, 0x9a, 0xb4, 0x17,
+	0x7a, 0x58, 0xad, 0x77, 0x9f, 0xed, 0x25, 0x9b, 0x99, 0xed, 0xcc, 0xac, 0xc1, 0x20, 0x71, 0xa8,
+	0x10, 0x1c, 0x39, 0x70, 0xe0, 0x03, 0xf0, 0x65, 0x38, 0x22, 0xf1, 0x05, 0x50, 0xc4, 0x07, 0x41,
+	0x33, 0xb3, 0x76, 0x1c, 0xd5, 0x56, 0x01, 0x71, 0xdb, 0xf7, 0x7b, 0xef, 0xfd, 0xde, 0xcc, 0xef,
+	0xcd, 0xbe, 0x07, 0x9b, 0xa9, 0xe0, 0x8a, 0x77, 0xba, 0x18, 0x84, 0x9c, 0x75, 0x44, 0x1a, 0x76,
+	0x86, 0xbb, 0x9d, 0x08, 0xbb, 0x59, 0xbf, 0x6d, 0x3c, 0x64, 0x0d, 0xd5, 0x00, 0x05, 0x66, 0x97,
+	0x6d, 0x1b, 0xd3, 0x16, 0x69, 0xd8, 0x1e, 0xee, 0x36, 0x6e, 0x26, 0xa6, 0x7b, 0xa9, 0x4e, 0x54,
+	0xa3, 0x14, 0xa5, 0x4d, 0x6c, 0xdc, 0xed, 0x73, 0xde, 0x4f, 0xb0, 0x13, 0xa4, 0x71, 0x27, 0x60,
+	0x8c, 0xab, 0x40, 0xc5, 0x9c, 0x8d, 0xbd, 0xeb, 0xb9, 0xd7, 0x58, 0xdd, 0xac, 0xd7, 0xc1, 0xcb,
+	0x54, 0x8d, 0xac, 0xd3, 0x7b, 0x09, 0xe4, 0xc0, 0xf0, 0x9e, 0xab, 0x40, 0x21, 0xc5, 0x57, 0x19,
+	0x4a, 0x45, 0x6e, 0x43, 0x51, 0x26, 0x5c, 0xb9, 0x4e, 0xd3, 0x69, 0x15, 0x1f, 0xdf, 0xa2, 0xc6,
+	0x22, 0x9b, 0x00, 0xdd, 0x84, 0x87, 0x17, 0xbe, 0xe0, 0x5c, 0xb9, 0x0b, 0x4d, 0xa7, 0x55, 0x7b,
+	0x7c, 0x8b, 0x56, 0x0c, 0x46, 0x39, 0x57, 0x07, 0x75, 0xa8, 0xbd, 0xca, 0x50, 0x8c, 0xfc, 0x5e,
+	0x9c, 0x28, 0x14, 0xde, 0xc7, 0x50, 0x3b, 0x30, 0xce, 0x9c, 0xf6, 0xde, 0x0d, 0x02, 0x4d, 0x5e,
+	0x9b, 0x4a, 0xf7, 0x76, 0xa0, 0x7a, 0x7e, 0xfe, 0x05, 0x45, 0x99, 0x72, 0x26, 0x91, 0xb8, 0xb0,
+	0x88, 0x2c, 0xe4, 0x11, 0x46, 0x79, 0xe8, 0xd8, 0xf4, 0x7e, 0x74, 0xe0, 0xdd, 0x33, 0xde, 0xef,
+	0xc7, 0xac, 0x7f, 0x86, 0x43, 0x4c, 0xc6, 0xfc, 0x27, 0x50, 0x4a, 0xb4, 0x6d, 0xe2, 0xeb, 0x7b,
+	0xbb, 0xed, 0xd9, 0x82, 0xb6, 0x67, 0xe4, 0xb6, 0xad, 0x61, 0xf3, 0xbd, 0x1d, 0x28, 0x19, 0x9b,
+	0x2c, 0x41, 0xf1, 0xf4, 0xc9, 0xa3, 0xa7, 0xab, 0xb7, 0x48, 0x05, 0x4a, 0x47, 0xc7, 0x07, 0x2f,
+	0x4e, 0x56, 0x1d, 0xfd, 0xf9, 0x9c, 0xee, 0x1f, 0x1e, 0xaf, 0x2e, 0x78, 0x3f, 0x14, 0xe0, 0xee,
+	0x33, 0x2d, 0xe4, 0xbe, 0x10, 0xc1, 0xe8, 0x11, 0x17, 0x17, 0x87, 0x03, 0x1e, 0x87, 0x38, 0xb9,
+	0xc4, 0x0e, 0xac, 0xa4, 0x22, 0x63, 0xe8, 0xab, 0x81, 0x40, 0x39, 0xe0, 0x89, 0xbd, 0x4c, 0x91,
+	0xd6, 0x0d, 0xfc, 0x7c, 0x8c, 0xea, 0xc0, 0x2f, 0x33, 0xa9, 0xe2, 0x5e, 0x8c, 0x91, 0x8f, 0x29,
+	0x0f, 0x07, 0x46, 0xe1, 0x22, 0xad, 0x4f, 0xe0, 0x63, 0x8d, 0xea, 0xc0, 0x5e, 0xcc, 0x82, 0x24,
+	0xfe, 0x66, 0x12, 0x58, 0xb0, 0x81, 0x13, 0xd8, 0x06, 0x52, 0x78, 0xc7, 0xf4, 0xd8, 0x0f, 0xf4,
+	0xd9, 0x7c, 0xc6, 0x23, 0x94, 0x6e, 0xb1, 0x59, 0x68, 0x55, 0xf7, 0xee, 0xcf, 0x53, 0xe6, 0xfa,
+	0x2e, 0x4f, 0x78, 0x84, 0x74, 0x25, 0xbd, 0x61, 0x4b, 0xf2, 0x12, 0x16, 0x63, 0x16, 0xc5, 0x21,
+	0x4a, 0xb7, 0x64, 0x98, 0xf6, 0xdf, 0xce, 0xf4, 0xa6, 0x2a, 0xed, 0x53, 0xcb, 0x71, 0xcc, 0x94,
+	0x18, 0xd1, 0x31, 0x63, 0xe3, 0x21, 0xd4, 0xa6, 0x1d, 0x64, 0x15, 0x0a, 0x17, 0x38, 0x32, 0x7a,
+	0x55, 0xa8, 0xfe, 0x24, 0xb7, 0xa1, 0x34, 0x0c, 0x92, 0x0c, 0x73, 0x69, 0xac, 0xf1, 0x70, 0xe1,
+	0x33, 0xc7, 0x7b, 0xbd, 0x00, 0xf5, 0x9b, 0x87, 0x27, 0x64, 0xfa, 0x11, 0xe7, 0x4f, 0x98, 0x40,
+	0xf1, 0xfa, 0xf1, 0x52, 0xf3, 0x4d, 0xd6, 0xa0, 0x9c, 0x06, 0x02, 0x99, 0xca, 0x75, 0xcc, 0xad,
+	0x59, 0x1d, 0x29, 0xfe, 0xd3, 0x8e, 0x94, 0x66, 0x76, 0x64, 0x0d, 0xca, 0x5f, 0x61, 0xdc, 0x1f,
+	0x28, 0xb7, 0x6c, 0x2b, 0x59, 0xcb, 0xfc, 0x17, 0x28, 0x95, 0x1f, 0x0e, 0xe2, 0x24, 0x72, 0x17,
+	0x8d, 0xaf, 0xa2, 0x91, 0x43, 0x0d, 0x68, 0x7e, 0xe3, 0x8e, 0x50, 0x86, 0xc8, 0xa2, 0x80, 0x29,
+	0x77, 0xc9, 0xf2, 0x6b, 0xf8, 0x68, 0x82, 0x7a, 0x4f, 0x81, 0x3c, 0x43, 0x14, 0xe7, 0x21, 0x17,
+	0x28, 0x27, 0x4f, 0xf0, 0x73, 0x28, 0x4b, 0x83, 0xb8, 0x8e, 0x69, 0xd9, 0xd6, 0xdc, 0x96, 0x8d,
+	0x73, 0x69, 0x9e, 0xe0, 0xfd, 0x5a, 0x80, 0xca, 0x04, 0x25, 0x77, 0x60, 0x31, 0x45, 0x14, 0x7e,
+	0x1c, 0xe5, 0x3d, 0x29, 0x6b, 0xf3, 0x34, 0x22, 0x1f, 0xc2, 0x6a, 0xc8, 0x19, 0xc3, 0x50, 0x8f,
+	0x1d, 0x5f, 0xea, 0x49, 0x62, 0x14, 0xae, 0xd0, 0x95, 0x6b, 0xdc, 0x0c, 0x18, 0xdd, 0x41, 0xc3,
+	0x6d, 0xb4, 0x76, 0xa8, 0x35, 0xc8, 0x07, 0x50, 0x8f, 0xd9, 0x30, 0x48, 0xe2, 0xc8, 0xef, 0x73,
+	0x29, 0xe3, 0xd4, 0x28, 0xed, 0xd0, 0xe5, 0x1c, 0x3d, 0x31, 0x20, 0xd9, 0x86, 0xe5, 0x6e, 0x10,
+	0xf9, 0x22, 0xbf, 0x99, 0x34, 0x32, 0x3b, 0xb4, 0xd6, 0x0d, 0xa2, 0xf1, 0x6d, 0x25, 0x69, 0xc0,
+	0x92, 0x8a, 0x2f, 0x91, 0x67, 0x4a, 0x1a, 0x99, 0x1d, 0x3a, 0xb1, 0x35, 0x41, 0x26, 0xb1, 0x97,
+	0x25, 0xbe, 0x99, 0x3a, 0xd2, 0x68, 0xed, 0xd0, 0x9a, 0x05, 0xcd, 0xac, 0x92, 0xba, 0x4b, 0x59,
+	0xaa, 0x53, 0x72, 0x95, 0x73, 0x8b, 0x6c, 0x00, 0xf4, 0x45, 0x30, 0x4a, 0x62, 0xa9, 0x30, 0x72,
+	0x2b, 0x4d, 0xa7, 0xb5, 0x44, 0xa7, 0x10, 0xfd, 0x5c, 0xbb, 0x41, 0xe4, 0x82, 0x71, 0xe8, 0x4f,
+	0xb2, 0x05, 0xb5, 0x6e, 0xc0, 0x18, 0x46, 0x7e, 0xc6, 0x54, 0x9c, 0xb8, 0xd5, 0xa6, 0xd3, 0x2a,
+	0xd0, 0xaa, 0xc5, 0x5e, 0x68, 0x88, 0x6c, 0x42, 0x35, 0x12, 0x3c, 0xf5, 0x05, 0x06, 0x92, 0x33,
+	0xb7, 0x66, 0x54, 0x03, 0x0d, 0x51, 0x83, 0x68, 0x0e, 0x2b, 0x89, 0x6f, 0x75, 0x5b, 0x36, 0x27,
+	0xae, 0x5a, 0xcc, 0xf4, 0x65, 0xef, 0x97, 0x12, 0x94, 0x8e, 0xf4, 0x1e, 0x21, 0xdf, 0x3b, 0x50,
+	0x3f, 0x41, 0x35, 0x35, 0xd1, 0xc9, 0x83, 0x79, 0xdd, 0x7e, 0x73, 0xec, 0x37, 0xb6, 0xe7, 0xc5,
+	0x4e, 0x8d, 0x65, 0x6f, 0xeb, 0xf5, 0x1f, 0x7f, 0xfd, 0xbc, 0xb0, 0x4e, 0xde, 0xeb, 0xa0, 0x1a,
+	0x74, 0x86, 0xbb, 0x41, 0x92, 0x0e, 0x82, 0x7c, 0x91, 0x75, 0x4c, 0xef, 0xc9, 0xd7, 0xb0, 0xa4,
+	0x4f, 0xa1, 0xe5, 0x24, 0xef, 0xcf, 0xad, 0x3f, 0xb5, 0x19, 0xfe, 0x87, 0xca, 0xa6, 0xa1, 0xe4,
+	0x5b, 0x58, 0x39, 0x47, 0x35, 0x3d, 0xdf, 0xc9, 0x47, 0xff, 0x62, 0x0b, 0x34, 0xd6, 0xda, 0x76,
+	0x59, 0xb6, 0xc7, 0xcb, 0xb2, 0x7d, 0xac, 0x97, 0xa5, 0xb7, 0x6d, 0x4a, 0xdf, 0xf3, 0xd6, 0x67,
+	0x95, 0x4e, 0x2c, 0x11, 0xf9, 0xc9, 0x81, 0x3b, 0x27, 0xa8, 0x66, 0x4d, 0x3e, 0x32, 0x87, 0xb8,
+	0xf1, 0xe9, 0x7f, 0x99, 0x9f, 0xde, 0x7d, 0x73, 0x9c, 0x26, 0xd9, 0x98, 0x75, 0x9c, 0x1e, 0x17,
+	0x17, 0xa1, 0xad, 0xfa, 0x1d, 0xd4, 0xcf, 0x62, 0xa9, 0xae, 0x87, 0xc2, 0xdc, 0x73, 0x3c, 0x78,
+	0xeb, 0x50, 0x98, 0x0c, 0x14, 0xaf, 0x65, 0xaa, 0x7b, 0xa4, 0x39, 0xab, 0x7a, 0x8a, 0x28, 0x64,
+	0xc7, 0xce, 0x8f, 0x83, 0xda, 0x6f, 0x57, 0x1b, 0xce, 0xef, 0x57, 0x1b, 0xce, 0x9f, 0x57, 0x1b,
+	0x4e, 0xb7, 0x6c, 0x6a, 0x7e, 0xf2, 0xf7, 0x00, 0xd9, 0x58, 0xd8, 0xa4, 0x09, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -549,6 +759,7 @@ type DebugClient interface {
 	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*SSZResponse, error)
 	SetLoggingLevel(ctx context.Context, in *LoggingLevelRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	GetProtoArrayForkChoice(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ProtoArrayForkChoiceResponse, error)
+	ListPeerScores(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*PeerScoresResponse, error)
 }
 
 type debugClient struct {
@@ -595,12 +806,22 @@ func (c *debugClient) GetProtoArrayForkChoice(ctx context.Context, in *types.Emp
 	return out, nil
 }
 
+func (c *debugClient) ListPeerScores(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*PeerScoresResponse, error) {
+	out := new(PeerScoresResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.beacon.rpc.v1.Debug/ListPeerScores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	GetBeaconState(context.Context, *BeaconStateRequest) (*SSZResponse, error)
 	GetBlock(context.Context, *BlockRequest) (*SSZResponse, error)
 	SetLoggingLevel(context.Context, *LoggingLevelRequest) (*types.Empty, error)
 	GetProtoArrayForkChoice(context.Context, *types.Empty) (*ProtoArrayForkChoiceResponse, error)
+	ListPeerScores(context.Context, *types.Empty) (*PeerScoresResponse, error)
 }
 
 // UnimplementedDebugServer can be embedded to have forward compatible implementations.
@@ -619,6 +840,9 @@ func (*UnimplementedDebugServer) SetLoggingLevel(ctx context.Context, req *Loggi
 func (*UnimplementedDebugServer) GetProtoArrayForkChoice(ctx context.Context, req *types.Empty) (*ProtoArrayForkChoiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProtoArrayForkChoice not implemented")
 }
+func (*UnimplementedDebugServer) ListPeerScores(ctx context.Context, req *types.Empty) (*PeerScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerScores not implemented")
+}
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
 	s.RegisterService(&_Debug_serviceDesc, srv)
@@ -696,6 +920,24 @@ func _Debug_GetProtoArrayForkChoice_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_ListPeerScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).ListPeerScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.beacon.rpc.v1.Debug/ListPeerScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).ListPeerScores(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ethereum.beacon.rpc.v1.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -716,6 +958,10 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetProtoArrayForkChoice",
 			Handler:    _Debug_GetProtoArrayForkChoice_Handler,
 		},
+		{
+			MethodName: "ListPeerScores",
+			Handler:    _Debug_ListPeerScores_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/beacon/rpc/v1/debug.proto",
@@ -1027,6 +1273,161 @@ func (m *ProtoArrayNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PeerScoresResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerScoresResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerScoresResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Scores) > 0 {
+		for iNdEx := len(m.Scores) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Scores[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDebug(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerScore) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerScore) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerScore) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.GossipScore != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.GossipScore))))
+		i--
+		dAtA[i] = 0x69
+	}
+	if len(m.DropReason) > 0 {
+		i -= len(m.DropReason)
+		copy(dAtA[i:], m.DropReason)
+		i = encodeVarintDebug(dAtA, i, uint64(len(m.DropReason)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.BannedUntil != 0 {
+		i = encodeVarintDebug(dAtA, i, uint64(m.BannedUntil))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.Bad {
+		i--
+		if m.Bad {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Graylisted {
+		i--
+		if m.Graylisted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Uptime != 0 {
+		i = encodeVarintDebug(dAtA, i, uint64(m.Uptime))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.UsefulBlocks != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.UsefulBlocks))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.Timeouts != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Timeouts))))
+		i--
+		dAtA[i] = 0x31
+	}
+	if m.BadResponses != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.BadResponses))))
+		i--
+		dAtA[i] = 0x29
+	}
+	if m.InvalidGossip != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.InvalidGossip))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if len(m.ConnectionState) > 0 {
+		i -= len(m.ConnectionState)
+		copy(dAtA[i:], m.ConnectionState)
+		i = encodeVarintDebug(dAtA, i, uint64(len(m.ConnectionState)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PeerId) > 0 {
+		i -= len(m.PeerId)
+		copy(dAtA[i:], m.PeerId)
+		i = encodeVarintDebug(dAtA, i, uint64(len(m.PeerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintDebug(dAtA []byte, offset int, v uint64) int {
 	offset -= sovDebug(v)
 	base := offset
@@ -1193,25 +1594,97 @@ func (m *ProtoArrayNode) Size() (n int) {
 	return n
 }
 
-func sovDebug(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozDebug(x uint64) (n int) {
-	return sovDebug(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *PeerScoresResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Scores) > 0 {
+		for _, e := range m.Scores {
+			l = e.Size()
+			n += 1 + l + sovDebug(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (m *BeaconStateRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDebug
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
+
+func (m *PeerScore) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PeerId)
+	if l > 0 {
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	l = len(m.ConnectionState)
+	if l > 0 {
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	if m.InvalidGossip != 0 {
+		n += 9
+	}
+	if m.BadResponses != 0 {
+		n += 9
+	}
+	if m.Timeouts != 0 {
+		n += 9
+	}
+	if m.UsefulBlocks != 0 {
+		n += 9
+	}
+	if m.Uptime != 0 {
+		n += 1 + sovDebug(uint64(m.Uptime))
+	}
+	if m.Graylisted {
+		n += 2
+	}
+	if m.Bad {
+		n += 2
+	}
+	if m.BannedUntil != 0 {
+		n += 1 + sovDebug(uint64(m.BannedUntil))
+	}
+	l = len(m.DropReason)
+	if l > 0 {
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	if m.GossipScore != 0 {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovDebug(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDebug(x uint64) (n int) {
+	return sovDebug(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *BeaconStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
 			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
@@ -2034,6 +2507,388 @@ func (m *ProtoArrayNode) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PeerScoresResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerScoresResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerScoresResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scores", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scores = append(m.Scores, &PeerScore{})
+			if err := m.Scores[len(m.Scores)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerScore) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerScore: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerScore: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionState", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnectionState = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidGossip", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.InvalidGossip = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BadResponses", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.BadResponses = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeouts", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Timeouts = float64(math.Float64frombits(v))
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsefulBlocks", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.UsefulBlocks = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			}
+			m.Uptime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Uptime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Graylisted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Graylisted = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bad", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Bad = bool(v != 0)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BannedUntil", wireType)
+			}
+			m.BannedUntil = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BannedUntil |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DropReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DropReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GossipScore", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.GossipScore = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipDebug(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
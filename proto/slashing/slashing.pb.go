@@ -5,6 +5,7 @@ package ethereum_slashing
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	io "io"
 	math "math"
@@ -12,6 +13,7 @@ import (
 
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
 	v1alpha1 "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	github_com_prysmaticlabs_go_bitfield "github.com/prysmaticlabs/go-bitfield"
 	grpc "google.golang.org/grpc"
@@ -30,6 +32,154 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// SlashingStatus of a slashing found by the slasher.
+type SlashingStatus int32
+
+const (
+	SlashingStatus_UNKNOWN SlashingStatus = 0
+	// Slashing found and not included in a block yet.
+	SlashingStatus_ACTIVE SlashingStatus = 1
+	// Slashing included in a block of the canonical chain.
+	SlashingStatus_INCLUDED SlashingStatus = 2
+	// Slashing whose inclusion was reverted.
+	SlashingStatus_REVERTED SlashingStatus = 3
+)
+
+var SlashingStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACTIVE",
+	2: "INCLUDED",
+	3: "REVERTED",
+}
+
+var SlashingStatus_value = map[string]int32{
+	"UNKNOWN":  0,
+	"ACTIVE":   1,
+	"INCLUDED": 2,
+	"REVERTED": 3,
+}
+
+func (x SlashingStatus) String() string {
+	return proto.EnumName(SlashingStatus_name, int32(x))
+}
+
+func (SlashingStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_da7e95107d0081b4, []int{0}
+}
+
+type SlashingsRequest struct {
+	// Status of the requested slashings, required.
+	Status SlashingStatus `protobuf:"varint,1,opt,name=status,proto3,enum=ethereum.slashing.SlashingStatus" json:"status,omitempty"`
+	// First epoch of the requested range.
+	StartEpoch uint64 `protobuf:"varint,2,opt,name=start_epoch,json=startEpoch,proto3" json:"start_epoch,omitempty"`
+	// Last epoch of the requested range, an end epoch of 0 means no upper bound.
+	EndEpoch             uint64   `protobuf:"varint,3,opt,name=end_epoch,json=endEpoch,proto3" json:"end_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlashingsRequest) Reset()         { *m = SlashingsRequest{} }
+func (m *SlashingsRequest) String() string { return proto.CompactTextString(m) }
+func (*SlashingsRequest) ProtoMessage()    {}
+func (*SlashingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da7e95107d0081b4, []int{0}
+}
+func (m *SlashingsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SlashingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SlashingsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SlashingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlashingsRequest.Merge(m, src)
+}
+func (m *SlashingsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SlashingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlashingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlashingsRequest proto.InternalMessageInfo
+
+func (m *SlashingsRequest) GetStatus() SlashingStatus {
+	if m != nil {
+		return m.Status
+	}
+	return SlashingStatus_UNKNOWN
+}
+
+func (m *SlashingsRequest) GetStartEpoch() uint64 {
+	if m != nil {
+		return m.StartEpoch
+	}
+	return 0
+}
+
+func (m *SlashingsRequest) GetEndEpoch() uint64 {
+	if m != nil {
+		return m.EndEpoch
+	}
+	return 0
+}
+
+type ConflictingAttestationsRequest struct {
+	ValidatorIndex       uint64   `protobuf:"varint,1,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConflictingAttestationsRequest) Reset()         { *m = ConflictingAttestationsRequest{} }
+func (m *ConflictingAttestationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ConflictingAttestationsRequest) ProtoMessage()    {}
+func (*ConflictingAttestationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da7e95107d0081b4, []int{1}
+}
+func (m *ConflictingAttestationsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConflictingAttestationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConflictingAttestationsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConflictingAttestationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConflictingAttestationsRequest.Merge(m, src)
+}
+func (m *ConflictingAttestationsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConflictingAttestationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConflictingAttestationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConflictingAttestationsRequest proto.InternalMessageInfo
+
+func (m *ConflictingAttestationsRequest) GetValidatorIndex() uint64 {
+	if m != nil {
+		return m.ValidatorIndex
+	}
+	return 0
+}
+
 type ProposerSlashingResponse struct {
 	ProposerSlashing     []*v1alpha1.ProposerSlashing `protobuf:"bytes,1,rep,name=proposer_slashing,json=proposerSlashing,proto3" json:"proposer_slashing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
@@ -41,7 +191,7 @@ func (m *ProposerSlashingResponse) Reset()         { *m = ProposerSlashingRespon
 func (m *ProposerSlashingResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposerSlashingResponse) ProtoMessage()    {}
 func (*ProposerSlashingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da7e95107d0081b4, []int{0}
+	return fileDescriptor_da7e95107d0081b4, []int{2}
 }
 func (m *ProposerSlashingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -88,7 +238,7 @@ func (m *AttesterSlashingResponse) Reset()         { *m = AttesterSlashingRespon
 func (m *AttesterSlashingResponse) String() string { return proto.CompactTextString(m) }
 func (*AttesterSlashingResponse) ProtoMessage()    {}
 func (*AttesterSlashingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da7e95107d0081b4, []int{1}
+	return fileDescriptor_da7e95107d0081b4, []int{3}
 }
 func (m *AttesterSlashingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -124,6 +274,94 @@ func (m *AttesterSlashingResponse) GetAttesterSlashing() []*v1alpha1.AttesterSla
 	return nil
 }
 
+type DetectionStatusResponse struct {
+	// Latest target epoch of the attestations processed by the detector.
+	LatestEpochProcessed uint64 `protobuf:"varint,1,opt,name=latest_epoch_processed,json=latestEpochProcessed,proto3" json:"latest_epoch_processed,omitempty"`
+	// Whether detection is running on historical chain data.
+	HistoricalDetectionRunning bool `protobuf:"varint,2,opt,name=historical_detection_running,json=historicalDetectionRunning,proto3" json:"historical_detection_running,omitempty"`
+	// Epoch from which historical detection started.
+	HistoricalStartEpoch uint64 `protobuf:"varint,3,opt,name=historical_start_epoch,json=historicalStartEpoch,proto3" json:"historical_start_epoch,omitempty"`
+	// Chain head epoch up to which historical detection runs.
+	HistoricalTargetEpoch uint64 `protobuf:"varint,4,opt,name=historical_target_epoch,json=historicalTargetEpoch,proto3" json:"historical_target_epoch,omitempty"`
+	// Percentage of the historical chain data processed.
+	HistoricalProgressPercentage float64  `protobuf:"fixed64,5,opt,name=historical_progress_percentage,json=historicalProgressPercentage,proto3" json:"historical_progress_percentage,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *DetectionStatusResponse) Reset()         { *m = DetectionStatusResponse{} }
+func (m *DetectionStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*DetectionStatusResponse) ProtoMessage()    {}
+func (*DetectionStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da7e95107d0081b4, []int{4}
+}
+func (m *DetectionStatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DetectionStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DetectionStatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DetectionStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DetectionStatusResponse.Merge(m, src)
+}
+func (m *DetectionStatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DetectionStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DetectionStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DetectionStatusResponse proto.InternalMessageInfo
+
+func (m *DetectionStatusResponse) GetLatestEpochProcessed() uint64 {
+	if m != nil {
+		return m.LatestEpochProcessed
+	}
+	return 0
+}
+
+func (m *DetectionStatusResponse) GetHistoricalDetectionRunning() bool {
+	if m != nil {
+		return m.HistoricalDetectionRunning
+	}
+	return false
+}
+
+func (m *DetectionStatusResponse) GetHistoricalStartEpoch() uint64 {
+	if m != nil {
+		return m.HistoricalStartEpoch
+	}
+	return 0
+}
+
+func (m *DetectionStatusResponse) GetHistoricalTargetEpoch() uint64 {
+	if m != nil {
+		return m.HistoricalTargetEpoch
+	}
+	return 0
+}
+
+func (m *DetectionStatusResponse) GetHistoricalProgressPercentage() float64 {
+	if m != nil {
+		return m.HistoricalProgressPercentage
+	}
+	return 0
+}
+
+// ProposalHistory defines the structure for recording a validator's historical proposals.
+// Using a bitlist to represent the epochs and an uint64 to mark the latest marked
+// epoch of the bitlist, we can easily store which epochs a validator has proposed
+// a block for while pruning the older data.
 type ProposalHistory struct {
 	EpochBits            github_com_prysmaticlabs_go_bitfield.Bitlist `protobuf:"bytes,1,opt,name=epoch_bits,json=epochBits,proto3,casttype=github.com/prysmaticlabs/go-bitfield.Bitlist" json:"epoch_bits,omitempty"`
 	LatestEpochWritten   uint64                                       `protobuf:"varint,2,opt,name=latest_epoch_written,json=latestEpochWritten,proto3" json:"latest_epoch_written,omitempty"`
@@ -136,7 +374,7 @@ func (m *ProposalHistory) Reset()         { *m = ProposalHistory{} }
 func (m *ProposalHistory) String() string { return proto.CompactTextString(m) }
 func (*ProposalHistory) ProtoMessage()    {}
 func (*ProposalHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da7e95107d0081b4, []int{2}
+	return fileDescriptor_da7e95107d0081b4, []int{5}
 }
 func (m *ProposalHistory) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -179,6 +417,11 @@ func (m *ProposalHistory) GetLatestEpochWritten() uint64 {
 	return 0
 }
 
+// AttestationHistory defines the structure for recording a validator's historical attestation.
+// Using a map[uint64]uint64 to map its target epoch to its source epoch, in order to detect if a
+// vote being created is not a double vote and surrounded by, or surrounding any other votes.
+// Using an uint64 to mark the latest written epoch, we can safely perform a rolling prune whenever
+// the history is updated.
 type AttestationHistory struct {
 	TargetToSource       map[uint64]uint64 `protobuf:"bytes,1,rep,name=target_to_source,json=targetToSource,proto3" json:"target_to_source,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	LatestEpochWritten   uint64            `protobuf:"varint,2,opt,name=latest_epoch_written,json=latestEpochWritten,proto3" json:"latest_epoch_written,omitempty"`
@@ -191,7 +434,7 @@ func (m *AttestationHistory) Reset()         { *m = AttestationHistory{} }
 func (m *AttestationHistory) String() string { return proto.CompactTextString(m) }
 func (*AttestationHistory) ProtoMessage()    {}
 func (*AttestationHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da7e95107d0081b4, []int{3}
+	return fileDescriptor_da7e95107d0081b4, []int{6}
 }
 func (m *AttestationHistory) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -235,8 +478,12 @@ func (m *AttestationHistory) GetLatestEpochWritten() uint64 {
 }
 
 func init() {
+	proto.RegisterEnum("ethereum.slashing.SlashingStatus", SlashingStatus_name, SlashingStatus_value)
+	proto.RegisterType((*SlashingsRequest)(nil), "ethereum.slashing.SlashingsRequest")
+	proto.RegisterType((*ConflictingAttestationsRequest)(nil), "ethereum.slashing.ConflictingAttestationsRequest")
 	proto.RegisterType((*ProposerSlashingResponse)(nil), "ethereum.slashing.ProposerSlashingResponse")
 	proto.RegisterType((*AttesterSlashingResponse)(nil), "ethereum.slashing.AttesterSlashingResponse")
+	proto.RegisterType((*DetectionStatusResponse)(nil), "ethereum.slashing.DetectionStatusResponse")
 	proto.RegisterType((*ProposalHistory)(nil), "ethereum.slashing.ProposalHistory")
 	proto.RegisterType((*AttestationHistory)(nil), "ethereum.slashing.AttestationHistory")
 	proto.RegisterMapType((map[uint64]uint64)(nil), "ethereum.slashing.AttestationHistory.TargetToSourceEntry")
@@ -245,38 +492,62 @@ func init() {
 func init() { proto.RegisterFile("proto/slashing/slashing.proto", fileDescriptor_da7e95107d0081b4) }
 
 var fileDescriptor_da7e95107d0081b4 = []byte{
-	// 483 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0xd5, 0xb6, 0x05, 0xc4, 0x52, 0x41, 0xba, 0x54, 0x28, 0x8a, 0x44, 0x1a, 0xe5, 0x42, 0x10,
-	0x74, 0xdd, 0x96, 0x0b, 0x70, 0xab, 0xa5, 0x4a, 0xed, 0x09, 0xe4, 0x44, 0xe2, 0x68, 0xad, 0xed,
-	0xa9, 0xbd, 0xea, 0xc6, 0xbb, 0xda, 0x1d, 0x17, 0xf2, 0x1f, 0x7c, 0x14, 0x47, 0xbe, 0x00, 0xa1,
-	0x9c, 0x11, 0x1f, 0xc0, 0x09, 0x79, 0xed, 0x54, 0xa1, 0x49, 0x24, 0x7a, 0x9b, 0x99, 0x37, 0xf3,
-	0xde, 0xf3, 0x78, 0x87, 0x3e, 0x37, 0x56, 0xa3, 0x0e, 0x9c, 0x12, 0xae, 0x90, 0x65, 0x7e, 0x13,
-	0x70, 0x5f, 0x67, 0x7b, 0x80, 0x05, 0x58, 0xa8, 0xa6, 0x7c, 0x01, 0xf4, 0x0e, 0x00, 0x8b, 0xe0,
-	0xfa, 0x58, 0x28, 0x53, 0x88, 0xe3, 0x20, 0x01, 0x91, 0xea, 0x32, 0x4e, 0x94, 0x4e, 0xaf, 0x9a,
-	0x99, 0xde, 0x61, 0x2e, 0xb1, 0xa8, 0x12, 0x9e, 0xea, 0x69, 0x90, 0xeb, 0x5c, 0x07, 0xbe, 0x9c,
-	0x54, 0x97, 0x3e, 0x6b, 0xf4, 0xea, 0xa8, 0x69, 0x1f, 0x1a, 0xda, 0xfd, 0x68, 0xb5, 0xd1, 0x0e,
-	0xec, 0xb8, 0xd5, 0x88, 0xc0, 0x19, 0x5d, 0x3a, 0x60, 0x13, 0xba, 0x67, 0x5a, 0x2c, 0x5e, 0x18,
-	0xe8, 0x92, 0xc1, 0xf6, 0xe8, 0xd1, 0xc9, 0x0b, 0x7e, 0x63, 0x0d, 0xb0, 0xe0, 0x0b, 0x43, 0x7c,
-	0x85, 0xab, 0x63, 0x6e, 0x55, 0x6a, 0xc5, 0x53, 0x44, 0x70, 0xb8, 0x5e, 0x51, 0xb4, 0xd8, 0xff,
-	0x2a, 0xae, 0x70, 0x75, 0xc4, 0xad, 0xca, 0xf0, 0x2b, 0xa1, 0x4f, 0x1a, 0x63, 0x42, 0x9d, 0x4b,
-	0x87, 0xda, 0xce, 0xd8, 0x07, 0x4a, 0xc1, 0xe8, 0xb4, 0x88, 0x13, 0x89, 0xae, 0x4b, 0x06, 0x64,
-	0xb4, 0x1b, 0x1e, 0xfd, 0xf9, 0x71, 0xf0, 0x7a, 0x69, 0x7d, 0xc6, 0xce, 0xdc, 0x54, 0xa0, 0x4c,
-	0x95, 0x48, 0x5c, 0x90, 0xeb, 0xc3, 0x44, 0xe2, 0xa5, 0x04, 0x95, 0xf1, 0x50, 0xa2, 0x92, 0x0e,
-	0xa3, 0x87, 0x9e, 0x23, 0x94, 0xe8, 0xd8, 0x11, 0xdd, 0x57, 0xa2, 0x16, 0x8e, 0x1b, 0xde, 0xcf,
-	0x56, 0x22, 0x42, 0xd9, 0xdd, 0x1a, 0x90, 0xd1, 0x4e, 0xc4, 0x1a, 0xec, 0xac, 0x86, 0x3e, 0x35,
-	0xc8, 0xf0, 0x37, 0xa1, 0xac, 0x71, 0x2f, 0x50, 0xea, 0x72, 0xe1, 0x2c, 0xa5, 0x1d, 0x14, 0x36,
-	0x07, 0x8c, 0x51, 0xc7, 0x4e, 0x57, 0x36, 0x85, 0x76, 0x05, 0xef, 0xf8, 0xca, 0x7b, 0xe0, 0xab,
-	0x04, 0x7c, 0xe2, 0xa7, 0x27, 0x7a, 0xec, 0x67, 0xcf, 0x4a, 0xb4, 0xb3, 0xe8, 0x31, 0xfe, 0x53,
-	0xbc, 0xbb, 0xdb, 0xde, 0x29, 0x7d, 0xba, 0x86, 0x98, 0x75, 0xe8, 0xf6, 0x15, 0xcc, 0xfc, 0x02,
-	0x77, 0xa2, 0x3a, 0x64, 0xfb, 0xf4, 0xde, 0xb5, 0x50, 0x15, 0xb4, 0x5c, 0x4d, 0xf2, 0x7e, 0xeb,
-	0x2d, 0x39, 0xf9, 0x45, 0xe8, 0x03, 0xff, 0x53, 0xc0, 0x32, 0x43, 0x9f, 0x5d, 0x38, 0x9f, 0x88,
-	0x44, 0xc1, 0xd2, 0x57, 0xb0, 0x97, 0x1b, 0x7e, 0xf4, 0x45, 0x99, 0xc1, 0x17, 0xc8, 0x96, 0x5a,
-	0x7b, 0xaf, 0x36, 0x2e, 0x64, 0xcd, 0xdb, 0xd2, 0xb4, 0xb3, 0xa4, 0x18, 0xd6, 0x27, 0xc3, 0xf8,
-	0x06, 0xad, 0xb1, 0xcc, 0x4b, 0xc8, 0x42, 0x7f, 0x5d, 0xbe, 0xf3, 0x1c, 0x44, 0x06, 0x76, 0xad,
-	0xe0, 0xa6, 0xf3, 0x09, 0x77, 0xbf, 0xcd, 0xfb, 0xe4, 0xfb, 0xbc, 0x4f, 0x7e, 0xce, 0xfb, 0x24,
-	0xb9, 0xef, 0xef, 0xed, 0xcd, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x11, 0xe3, 0x4b, 0x92, 0xf3,
-	0x03, 0x00, 0x00,
+	// 872 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x51, 0x6f, 0xe3, 0x44,
+	0x10, 0xc6, 0x6d, 0xaf, 0xd7, 0x9b, 0x56, 0xad, 0xbb, 0x94, 0x36, 0xca, 0x41, 0x5a, 0xc2, 0xc3,
+	0x95, 0x83, 0x73, 0xda, 0x82, 0x10, 0xc7, 0x13, 0x4d, 0x63, 0xe9, 0x22, 0x50, 0xaf, 0x72, 0xd2,
+	0xbb, 0x17, 0x24, 0x6b, 0x6d, 0x4f, 0x9d, 0xd5, 0x39, 0x5e, 0xb3, 0xbb, 0x29, 0xe4, 0x37, 0xf0,
+	0xca, 0x5f, 0x42, 0xba, 0x47, 0x7e, 0x01, 0x42, 0xfd, 0x01, 0xfc, 0x00, 0x9e, 0x90, 0xd7, 0x76,
+	0xe2, 0x26, 0xf1, 0xe9, 0x22, 0xde, 0x76, 0xe7, 0x9b, 0xf9, 0x66, 0x76, 0x66, 0x67, 0x06, 0x3e,
+	0x49, 0x04, 0x57, 0xbc, 0x25, 0x23, 0x2a, 0x07, 0x2c, 0x0e, 0x27, 0x07, 0x4b, 0xcb, 0xc9, 0x2e,
+	0xaa, 0x01, 0x0a, 0x1c, 0x0d, 0xad, 0x02, 0xa8, 0x1f, 0xa2, 0x1a, 0xb4, 0x6e, 0x4f, 0x69, 0x94,
+	0x0c, 0xe8, 0x69, 0xcb, 0x43, 0xea, 0xf3, 0xd8, 0xf5, 0x22, 0xee, 0xbf, 0xc9, 0x6c, 0xea, 0xcf,
+	0x42, 0xa6, 0x06, 0x23, 0xcf, 0xf2, 0xf9, 0xb0, 0x15, 0xf2, 0x90, 0xb7, 0xb4, 0xd8, 0x1b, 0xdd,
+	0xe8, 0x5b, 0xe6, 0x2f, 0x3d, 0xe5, 0xea, 0x8f, 0x43, 0xce, 0xc3, 0x08, 0xa7, 0x5a, 0x38, 0x4c,
+	0xd4, 0x38, 0x03, 0x9b, 0xbf, 0x19, 0x60, 0xf6, 0x72, 0xcf, 0xd2, 0xc1, 0x9f, 0x47, 0x28, 0x15,
+	0x79, 0x0e, 0xeb, 0x52, 0x51, 0x35, 0x92, 0x35, 0xe3, 0xc8, 0x38, 0xde, 0x3e, 0xfb, 0xd4, 0x9a,
+	0x8b, 0xd2, 0x2a, 0x8c, 0x7a, 0x5a, 0xd1, 0xc9, 0x0d, 0xc8, 0x21, 0x6c, 0x4a, 0x45, 0x85, 0x72,
+	0x31, 0xe1, 0xfe, 0xa0, 0xb6, 0x72, 0x64, 0x1c, 0xaf, 0x39, 0xa0, 0x45, 0x76, 0x2a, 0x21, 0x8f,
+	0xe1, 0x11, 0xc6, 0x41, 0x0e, 0xaf, 0x6a, 0x78, 0x03, 0xe3, 0x40, 0x83, 0xcd, 0x2e, 0x34, 0x2e,
+	0x78, 0x7c, 0x13, 0x31, 0x5f, 0xb1, 0x38, 0x3c, 0x57, 0x0a, 0x53, 0x5a, 0xc6, 0xe3, 0x49, 0x68,
+	0x4f, 0x60, 0xe7, 0x96, 0x46, 0x2c, 0xa0, 0x8a, 0x0b, 0x97, 0xc5, 0x01, 0xfe, 0xaa, 0x63, 0x5c,
+	0x73, 0xb6, 0x27, 0xe2, 0x6e, 0x2a, 0x6d, 0x26, 0x50, 0xbb, 0x12, 0x3c, 0xe1, 0x12, 0x45, 0x11,
+	0xaa, 0x83, 0x32, 0xe1, 0xb1, 0x44, 0xd2, 0x87, 0xdd, 0x24, 0xc7, 0xdc, 0xe2, 0x41, 0x35, 0xe3,
+	0x68, 0xf5, 0x78, 0xf3, 0xec, 0xc9, 0xf4, 0xa9, 0xa8, 0x06, 0x56, 0x51, 0x06, 0x6b, 0x8e, 0xcb,
+	0x4c, 0x66, 0x24, 0xa9, 0xc7, 0x2c, 0xe2, 0xc5, 0x1e, 0x69, 0x8e, 0xbd, 0xaf, 0xc7, 0x39, 0x2e,
+	0x93, 0xce, 0x48, 0x9a, 0x7f, 0xac, 0xc0, 0x41, 0x07, 0x15, 0xfa, 0x69, 0x8a, 0xf2, 0x42, 0x14,
+	0x1e, 0xbf, 0x86, 0xfd, 0x88, 0xa6, 0xfa, 0x59, 0xaa, 0xdd, 0x44, 0x70, 0x1f, 0xa5, 0xc4, 0x20,
+	0xcf, 0xd7, 0x5e, 0x86, 0xea, 0xbc, 0x5f, 0x15, 0x18, 0xf9, 0x1e, 0x3e, 0x1e, 0x30, 0xa9, 0xb8,
+	0x60, 0x3e, 0x8d, 0xdc, 0xa0, 0xe0, 0x76, 0xc5, 0x28, 0x8e, 0xd3, 0x90, 0xd3, 0x7a, 0x6e, 0x38,
+	0xf5, 0xa9, 0xce, 0xc4, 0xbd, 0x93, 0x69, 0xa4, 0x7e, 0x4b, 0x0c, 0xe5, 0xbf, 0x90, 0x15, 0x7b,
+	0x6f, 0x8a, 0xf6, 0xa6, 0xbf, 0xe2, 0x1b, 0x38, 0x28, 0x59, 0x29, 0x2a, 0x42, 0x2c, 0xcc, 0xd6,
+	0xb4, 0xd9, 0x47, 0x53, 0xb8, 0xaf, 0xd1, 0xcc, 0xae, 0x03, 0x8d, 0x92, 0x5d, 0x22, 0x78, 0x28,
+	0x50, 0x4a, 0x37, 0x41, 0xe1, 0x63, 0xac, 0x68, 0x88, 0xb5, 0x07, 0x47, 0xc6, 0xb1, 0xe1, 0x94,
+	0x5e, 0x75, 0x95, 0x2b, 0x5d, 0x4d, 0x74, 0x9a, 0xbf, 0x1b, 0xb0, 0x93, 0x15, 0x98, 0x46, 0x2f,
+	0xb4, 0xe2, 0x98, 0xbc, 0x04, 0xc8, 0x12, 0xe7, 0x31, 0x95, 0xf5, 0xc1, 0x56, 0xfb, 0xe4, 0xdf,
+	0xbf, 0x0e, 0xbf, 0x2c, 0x35, 0x5f, 0x22, 0xc6, 0x72, 0x48, 0x15, 0xf3, 0x23, 0xea, 0xc9, 0x56,
+	0xc8, 0x9f, 0x79, 0x4c, 0xdd, 0x30, 0x8c, 0x02, 0xab, 0xcd, 0x54, 0xc4, 0xa4, 0x72, 0x1e, 0x69,
+	0x8e, 0x36, 0x53, 0x92, 0x9c, 0xc0, 0xde, 0xbd, 0x82, 0xfc, 0x22, 0x98, 0x52, 0x18, 0xe7, 0x2d,
+	0x42, 0x4a, 0xe5, 0x78, 0x9d, 0x21, 0xcd, 0x7f, 0x0c, 0x20, 0xa5, 0x1e, 0x28, 0x22, 0xf3, 0xc1,
+	0xcc, 0x13, 0xa4, 0xb8, 0x2b, 0xf9, 0x48, 0xf8, 0x98, 0x7f, 0xa5, 0xe7, 0x0b, 0xfa, 0x74, 0x9e,
+	0xc0, 0xca, 0x12, 0xd8, 0xe7, 0x3d, 0x6d, 0x6b, 0xc7, 0x4a, 0x8c, 0x9d, 0x6d, 0x75, 0x4f, 0xb8,
+	0x7c, 0xb4, 0xf5, 0x73, 0xf8, 0x70, 0x01, 0x31, 0x31, 0x61, 0xf5, 0x0d, 0x8e, 0xf3, 0x4f, 0x97,
+	0x1e, 0xc9, 0x1e, 0x3c, 0xb8, 0xa5, 0xd1, 0x08, 0x73, 0xae, 0xec, 0xf2, 0xdd, 0xca, 0xb7, 0xc6,
+	0x53, 0x1b, 0xb6, 0xef, 0x8f, 0x15, 0xb2, 0x09, 0x0f, 0xaf, 0x2f, 0x7f, 0xb8, 0x7c, 0xf9, 0xfa,
+	0xd2, 0xfc, 0x80, 0x00, 0xac, 0x9f, 0x5f, 0xf4, 0xbb, 0xaf, 0x6c, 0xd3, 0x20, 0x5b, 0xb0, 0xd1,
+	0xbd, 0xbc, 0xf8, 0xf1, 0xba, 0x63, 0x77, 0xcc, 0x95, 0xf4, 0xe6, 0xd8, 0xaf, 0x6c, 0xa7, 0x6f,
+	0x77, 0xcc, 0xd5, 0xb3, 0xb7, 0xeb, 0xf0, 0x50, 0xf3, 0xa0, 0x20, 0x09, 0xec, 0x77, 0xa5, 0xbe,
+	0x50, 0x2f, 0xc2, 0x52, 0x32, 0xc8, 0xe7, 0x15, 0x7d, 0xa7, 0xc7, 0x07, 0x06, 0x25, 0xd5, 0xfa,
+	0x17, 0x95, 0x79, 0x5d, 0xd0, 0xea, 0x1c, 0xcc, 0x92, 0xc7, 0x76, 0x3a, 0xb7, 0x89, 0x55, 0xe1,
+	0xab, 0xc7, 0xc2, 0x18, 0x83, 0xb6, 0x1e, 0xf1, 0x5a, 0xf3, 0x05, 0xd2, 0x00, 0xc5, 0x42, 0x87,
+	0x95, 0xd3, 0x0c, 0x61, 0x77, 0x36, 0x18, 0x49, 0x3e, 0x7b, 0xc7, 0xc8, 0x2e, 0x86, 0xe9, 0x72,
+	0xef, 0x42, 0xd8, 0x9d, 0x0d, 0xe1, 0x7f, 0xb8, 0xa9, 0x7c, 0xcd, 0x18, 0x0e, 0x2a, 0x56, 0x00,
+	0x39, 0x5d, 0xc0, 0xf3, 0xee, 0x75, 0xb1, 0xdc, 0x0b, 0x7f, 0x82, 0x83, 0x9e, 0x12, 0x48, 0x87,
+	0xf3, 0xe9, 0xdc, 0xb7, 0xb2, 0x25, 0x6a, 0x15, 0x4b, 0xd4, 0xb2, 0xd3, 0x25, 0x5a, 0x7f, 0xdf,
+	0xe1, 0x7d, 0x62, 0x4c, 0xd9, 0xe7, 0xb3, 0xb8, 0x2c, 0xfb, 0x2c, 0xc3, 0x89, 0x41, 0xae, 0x61,
+	0x67, 0x66, 0x13, 0x54, 0xb2, 0x3e, 0x5d, 0x90, 0x93, 0x8a, 0x2d, 0xd2, 0xde, 0x7a, 0x7b, 0xd7,
+	0x30, 0xfe, 0xbc, 0x6b, 0x18, 0x7f, 0xdf, 0x35, 0x0c, 0x6f, 0x5d, 0x33, 0x7d, 0xf5, 0xdf, 0x00,
+	0xa4, 0x6e, 0xfd, 0x22, 0xd4, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -291,8 +562,25 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type SlasherClient interface {
+	// Returns any found attester slashings if the passed in attestation conflicts with a validators history.
 	IsSlashableAttestation(ctx context.Context, in *v1alpha1.IndexedAttestation, opts ...grpc.CallOption) (*AttesterSlashingResponse, error)
+	// Returns any found proposer slashings if the passed in proposal conflicts with a validators history.
 	IsSlashableBlock(ctx context.Context, in *v1alpha1.SignedBeaconBlockHeader, opts ...grpc.CallOption) (*ProposerSlashingResponse, error)
+	// Returns the attester slashings found by the slasher with the requested status,
+	// for offenses committed within the requested epoch range.
+	AttesterSlashings(ctx context.Context, in *SlashingsRequest, opts ...grpc.CallOption) (*AttesterSlashingResponse, error)
+	// Returns the proposer slashings found by the slasher with the requested status,
+	// for offenses committed within the requested epoch range.
+	ProposerSlashings(ctx context.Context, in *SlashingsRequest, opts ...grpc.CallOption) (*ProposerSlashingResponse, error)
+	// Returns the conflicting indexed attestations of a validator, as the attester
+	// slashings found by the slasher for this validator.
+	ConflictingAttestations(ctx context.Context, in *ConflictingAttestationsRequest, opts ...grpc.CallOption) (*AttesterSlashingResponse, error)
+	// Streams the attester slashings found by the slasher as they are detected.
+	StreamAttesterSlashings(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (Slasher_StreamAttesterSlashingsClient, error)
+	// Streams the proposer slashings found by the slasher as they are detected.
+	StreamProposerSlashings(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (Slasher_StreamProposerSlashingsClient, error)
+	// Returns the progress of slashing detection, including the catch-up on historical chain data.
+	DetectionStatus(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*DetectionStatusResponse, error)
 }
 
 type slasherClient struct {
@@ -321,10 +609,127 @@ func (c *slasherClient) IsSlashableBlock(ctx context.Context, in *v1alpha1.Signe
 	return out, nil
 }
 
+func (c *slasherClient) AttesterSlashings(ctx context.Context, in *SlashingsRequest, opts ...grpc.CallOption) (*AttesterSlashingResponse, error) {
+	out := new(AttesterSlashingResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.slashing.Slasher/AttesterSlashings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slasherClient) ProposerSlashings(ctx context.Context, in *SlashingsRequest, opts ...grpc.CallOption) (*ProposerSlashingResponse, error) {
+	out := new(ProposerSlashingResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.slashing.Slasher/ProposerSlashings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slasherClient) ConflictingAttestations(ctx context.Context, in *ConflictingAttestationsRequest, opts ...grpc.CallOption) (*AttesterSlashingResponse, error) {
+	out := new(AttesterSlashingResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.slashing.Slasher/ConflictingAttestations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slasherClient) StreamAttesterSlashings(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (Slasher_StreamAttesterSlashingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Slasher_serviceDesc.Streams[0], "/ethereum.slashing.Slasher/StreamAttesterSlashings", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &slasherStreamAttesterSlashingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Slasher_StreamAttesterSlashingsClient interface {
+	Recv() (*v1alpha1.AttesterSlashing, error)
+	grpc.ClientStream
+}
+
+type slasherStreamAttesterSlashingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *slasherStreamAttesterSlashingsClient) Recv() (*v1alpha1.AttesterSlashing, error) {
+	m := new(v1alpha1.AttesterSlashing)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *slasherClient) StreamProposerSlashings(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (Slasher_StreamProposerSlashingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Slasher_serviceDesc.Streams[1], "/ethereum.slashing.Slasher/StreamProposerSlashings", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &slasherStreamProposerSlashingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Slasher_StreamProposerSlashingsClient interface {
+	Recv() (*v1alpha1.ProposerSlashing, error)
+	grpc.ClientStream
+}
+
+type slasherStreamProposerSlashingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *slasherStreamProposerSlashingsClient) Recv() (*v1alpha1.ProposerSlashing, error) {
+	m := new(v1alpha1.ProposerSlashing)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *slasherClient) DetectionStatus(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*DetectionStatusResponse, error) {
+	out := new(DetectionStatusResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.slashing.Slasher/DetectionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SlasherServer is the server API for Slasher service.
 type SlasherServer interface {
+	// Returns any found attester slashings if the passed in attestation conflicts with a validators history.
 	IsSlashableAttestation(context.Context, *v1alpha1.IndexedAttestation) (*AttesterSlashingResponse, error)
+	// Returns any found proposer slashings if the passed in proposal conflicts with a validators history.
 	IsSlashableBlock(context.Context, *v1alpha1.SignedBeaconBlockHeader) (*ProposerSlashingResponse, error)
+	// Returns the attester slashings found by the slasher with the requested status,
+	// for offenses committed within the requested epoch range.
+	AttesterSlashings(context.Context, *SlashingsRequest) (*AttesterSlashingResponse, error)
+	// Returns the proposer slashings found by the slasher with the requested status,
+	// for offenses committed within the requested epoch range.
+	ProposerSlashings(context.Context, *SlashingsRequest) (*ProposerSlashingResponse, error)
+	// Returns the conflicting indexed attestations of a validator, as the attester
+	// slashings found by the slasher for this validator.
+	ConflictingAttestations(context.Context, *ConflictingAttestationsRequest) (*AttesterSlashingResponse, error)
+	// Streams the attester slashings found by the slasher as they are detected.
+	StreamAttesterSlashings(*types.Empty, Slasher_StreamAttesterSlashingsServer) error
+	// Streams the proposer slashings found by the slasher as they are detected.
+	StreamProposerSlashings(*types.Empty, Slasher_StreamProposerSlashingsServer) error
+	// Returns the progress of slashing detection, including the catch-up on historical chain data.
+	DetectionStatus(context.Context, *types.Empty) (*DetectionStatusResponse, error)
 }
 
 // UnimplementedSlasherServer can be embedded to have forward compatible implementations.
@@ -337,6 +742,24 @@ func (*UnimplementedSlasherServer) IsSlashableAttestation(ctx context.Context, r
 func (*UnimplementedSlasherServer) IsSlashableBlock(ctx context.Context, req *v1alpha1.SignedBeaconBlockHeader) (*ProposerSlashingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsSlashableBlock not implemented")
 }
+func (*UnimplementedSlasherServer) AttesterSlashings(ctx context.Context, req *SlashingsRequest) (*AttesterSlashingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttesterSlashings not implemented")
+}
+func (*UnimplementedSlasherServer) ProposerSlashings(ctx context.Context, req *SlashingsRequest) (*ProposerSlashingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposerSlashings not implemented")
+}
+func (*UnimplementedSlasherServer) ConflictingAttestations(ctx context.Context, req *ConflictingAttestationsRequest) (*AttesterSlashingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConflictingAttestations not implemented")
+}
+func (*UnimplementedSlasherServer) StreamAttesterSlashings(req *types.Empty, srv Slasher_StreamAttesterSlashingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAttesterSlashings not implemented")
+}
+func (*UnimplementedSlasherServer) StreamProposerSlashings(req *types.Empty, srv Slasher_StreamProposerSlashingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProposerSlashings not implemented")
+}
+func (*UnimplementedSlasherServer) DetectionStatus(ctx context.Context, req *types.Empty) (*DetectionStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectionStatus not implemented")
+}
 
 func RegisterSlasherServer(s *grpc.Server, srv SlasherServer) {
 	s.RegisterService(&_Slasher_serviceDesc, srv)
@@ -378,48 +801,263 @@ func _Slasher_IsSlashableBlock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Slasher_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "ethereum.slashing.Slasher",
-	HandlerType: (*SlasherServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "IsSlashableAttestation",
-			Handler:    _Slasher_IsSlashableAttestation_Handler,
-		},
-		{
-			MethodName: "IsSlashableBlock",
-			Handler:    _Slasher_IsSlashableBlock_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/slashing/slashing.proto",
+func _Slasher_AttesterSlashings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlashingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlasherServer).AttesterSlashings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.slashing.Slasher/AttesterSlashings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlasherServer).AttesterSlashings(ctx, req.(*SlashingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ProposerSlashingResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Slasher_ProposerSlashings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlashingsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(SlasherServer).ProposerSlashings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.slashing.Slasher/ProposerSlashings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlasherServer).ProposerSlashings(ctx, req.(*SlashingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ProposerSlashingResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _Slasher_ConflictingAttestations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConflictingAttestationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlasherServer).ConflictingAttestations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.slashing.Slasher/ConflictingAttestations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlasherServer).ConflictingAttestations(ctx, req.(*ConflictingAttestationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ProposerSlashingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func _Slasher_StreamAttesterSlashings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(types.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	if len(m.ProposerSlashing) > 0 {
+	return srv.(SlasherServer).StreamAttesterSlashings(m, &slasherStreamAttesterSlashingsServer{stream})
+}
+
+type Slasher_StreamAttesterSlashingsServer interface {
+	Send(*v1alpha1.AttesterSlashing) error
+	grpc.ServerStream
+}
+
+type slasherStreamAttesterSlashingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *slasherStreamAttesterSlashingsServer) Send(m *v1alpha1.AttesterSlashing) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Slasher_StreamProposerSlashings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(types.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SlasherServer).StreamProposerSlashings(m, &slasherStreamProposerSlashingsServer{stream})
+}
+
+type Slasher_StreamProposerSlashingsServer interface {
+	Send(*v1alpha1.ProposerSlashing) error
+	grpc.ServerStream
+}
+
+type slasherStreamProposerSlashingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *slasherStreamProposerSlashingsServer) Send(m *v1alpha1.ProposerSlashing) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Slasher_DetectionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlasherServer).DetectionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.slashing.Slasher/DetectionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlasherServer).DetectionStatus(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Slasher_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ethereum.slashing.Slasher",
+	HandlerType: (*SlasherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IsSlashableAttestation",
+			Handler:    _Slasher_IsSlashableAttestation_Handler,
+		},
+		{
+			MethodName: "IsSlashableBlock",
+			Handler:    _Slasher_IsSlashableBlock_Handler,
+		},
+		{
+			MethodName: "AttesterSlashings",
+			Handler:    _Slasher_AttesterSlashings_Handler,
+		},
+		{
+			MethodName: "ProposerSlashings",
+			Handler:    _Slasher_ProposerSlashings_Handler,
+		},
+		{
+			MethodName: "ConflictingAttestations",
+			Handler:    _Slasher_ConflictingAttestations_Handler,
+		},
+		{
+			MethodName: "DetectionStatus",
+			Handler:    _Slasher_DetectionStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAttesterSlashings",
+			Handler:       _Slasher_StreamAttesterSlashings_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamProposerSlashings",
+			Handler:       _Slasher_StreamProposerSlashings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/slashing/slashing.proto",
+}
+
+func (m *SlashingsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SlashingsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SlashingsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.EndEpoch != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.EndEpoch))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.StartEpoch != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.StartEpoch))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConflictingAttestationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConflictingAttestationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConflictingAttestationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ValidatorIndex != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.ValidatorIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProposerSlashingResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProposerSlashingResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProposerSlashingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ProposerSlashing) > 0 {
 		for iNdEx := len(m.ProposerSlashing) - 1; iNdEx >= 0; iNdEx-- {
 			{
 				size, err := m.ProposerSlashing[iNdEx].MarshalToSizedBuffer(dAtA[:i])
@@ -477,6 +1115,64 @@ func (m *AttesterSlashingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error
 	return len(dAtA) - i, nil
 }
 
+func (m *DetectionStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DetectionStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DetectionStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.HistoricalProgressPercentage != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.HistoricalProgressPercentage))))
+		i--
+		dAtA[i] = 0x29
+	}
+	if m.HistoricalTargetEpoch != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.HistoricalTargetEpoch))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.HistoricalStartEpoch != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.HistoricalStartEpoch))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.HistoricalDetectionRunning {
+		i--
+		if m.HistoricalDetectionRunning {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LatestEpochProcessed != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.LatestEpochProcessed))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ProposalHistory) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -574,6 +1270,42 @@ func encodeVarintSlashing(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
+func (m *SlashingsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovSlashing(uint64(m.Status))
+	}
+	if m.StartEpoch != 0 {
+		n += 1 + sovSlashing(uint64(m.StartEpoch))
+	}
+	if m.EndEpoch != 0 {
+		n += 1 + sovSlashing(uint64(m.EndEpoch))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConflictingAttestationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ValidatorIndex != 0 {
+		n += 1 + sovSlashing(uint64(m.ValidatorIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ProposerSlashingResponse) Size() (n int) {
 	if m == nil {
 		return 0
@@ -610,6 +1342,33 @@ func (m *AttesterSlashingResponse) Size() (n int) {
 	return n
 }
 
+func (m *DetectionStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LatestEpochProcessed != 0 {
+		n += 1 + sovSlashing(uint64(m.LatestEpochProcessed))
+	}
+	if m.HistoricalDetectionRunning {
+		n += 2
+	}
+	if m.HistoricalStartEpoch != 0 {
+		n += 1 + sovSlashing(uint64(m.HistoricalStartEpoch))
+	}
+	if m.HistoricalTargetEpoch != 0 {
+		n += 1 + sovSlashing(uint64(m.HistoricalTargetEpoch))
+	}
+	if m.HistoricalProgressPercentage != 0 {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ProposalHistory) Size() (n int) {
 	if m == nil {
 		return 0
@@ -658,6 +1417,190 @@ func sovSlashing(x uint64) (n int) {
 func sozSlashing(x uint64) (n int) {
 	return sovSlashing(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
+func (m *SlashingsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSlashing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SlashingsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SlashingsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= SlashingStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartEpoch", wireType)
+			}
+			m.StartEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndEpoch", wireType)
+			}
+			m.EndEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSlashing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConflictingAttestationsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSlashing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConflictingAttestationsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConflictingAttestationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorIndex", wireType)
+			}
+			m.ValidatorIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidatorIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSlashing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ProposerSlashingResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -834,6 +1777,148 @@ func (m *AttesterSlashingResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DetectionStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSlashing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DetectionStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DetectionStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestEpochProcessed", wireType)
+			}
+			m.LatestEpochProcessed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatestEpochProcessed |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalDetectionRunning", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HistoricalDetectionRunning = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalStartEpoch", wireType)
+			}
+			m.HistoricalStartEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HistoricalStartEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalTargetEpoch", wireType)
+			}
+			m.HistoricalTargetEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HistoricalTargetEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalProgressPercentage", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.HistoricalProgressPercentage = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSlashing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ProposalHistory) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
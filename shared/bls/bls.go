@@ -4,6 +4,7 @@
 package bls
 
 import (
+	"crypto/rand"
 	"fmt"
 
 	"github.com/dgraph-io/ristretto"
@@ -236,6 +237,51 @@ func (s *Signature) FastAggregateVerify(pubKeys []*PublicKey, msg [32]byte) bool
 	return s.s.FastAggregateVerify(rawKeys, msg[:])
 }
 
+// VerifyMultipleSignatures verifies the signatures of distinct messages by distinct public keys
+// at once. Each signature and public key is multiplied by a random scalar before aggregating
+// them, so invalid signatures cannot cancel each other out, and the aggregate is verified in
+// a single multi-pairing check.
+func VerifyMultipleSignatures(sigs []*Signature, msgs [][32]byte, pubKeys []*PublicKey) (bool, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return true, nil
+	}
+	length := len(sigs)
+	if length == 0 {
+		return false, nil
+	}
+	if length != len(msgs) || length != len(pubKeys) {
+		return false, fmt.Errorf("provided signatures, messages and public keys have different lengths: %d, %d, %d",
+			length, len(msgs), len(pubKeys))
+	}
+	randNums := make([]bls12.Fr, length)
+	signatures := make([]bls12.G2, length)
+	multiKeys := make([]bls12.PublicKey, length)
+	msgSlices := make([]byte, 0, 32*length)
+	buf := make([]byte, 8)
+	for i := 0; i < length; i++ {
+		if sigs[i] == nil || pubKeys[i] == nil {
+			return false, errors.New("nil signature or public key")
+		}
+		// A 64 bit scalar is enough to make forging the batch as hard as forging a signature.
+		for randNums[i].IsZero() {
+			if _, err := rand.Read(buf); err != nil {
+				return false, errors.Wrap(err, "could not generate random scalar")
+			}
+			if err := randNums[i].SetLittleEndian(buf); err != nil {
+				return false, err
+			}
+		}
+		signatures[i] = *bls12.CastFromSign(sigs[i].s)
+		g1 := new(bls12.G1)
+		bls12.G1Mul(g1, bls12.CastFromPublicKey(pubKeys[i].p), &randNums[i])
+		multiKeys[i] = *bls12.CastToPublicKey(g1)
+		msgSlices = append(msgSlices, msgs[i][:]...)
+	}
+	aggSig := new(bls12.G2)
+	bls12.G2MulVec(aggSig, signatures, randNums)
+	return bls12.CastToSign(aggSig).AggregateVerifyNoCheck(multiKeys, msgSlices), nil
+}
+
 // NewAggregateSignature creates a blank aggregate signature.
 func NewAggregateSignature() *Signature {
 	return &Signature{s: bls12.HashAndMapToSignature([]byte{'m', 'o', 'c', 'k'})}
@@ -0,0 +1,102 @@
+package bls
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+// SignatureBatch collects signatures to verify them all at once with VerifyMultipleSignatures,
+// which is much faster than verifying them one by one.
+type SignatureBatch struct {
+	signatures   []*Signature
+	publicKeys   []*PublicKey
+	messages     [][32]byte
+	descriptions []string
+}
+
+// NewSignatureBatch creates an empty signature batch.
+func NewSignatureBatch() *SignatureBatch {
+	return &SignatureBatch{}
+}
+
+// Add adds the signature of the message by the given public keys to the batch. The public keys
+// are aggregated, as in FastAggregateVerify. The description identifies the signature in the
+// error returned when it is invalid.
+func (b *SignatureBatch) Add(sig *Signature, pubKeys []*PublicKey, msg [32]byte, description string) {
+	if featureconfig.Get().SkipBLSVerify {
+		return
+	}
+	var aggregated *PublicKey
+	for i, pubKey := range pubKeys {
+		if i == 0 {
+			// Copy the first key, as aggregating modifies it.
+			np := *pubKey.p
+			aggregated = &PublicKey{p: &np}
+			continue
+		}
+		aggregated.Aggregate(pubKey)
+	}
+	b.signatures = append(b.signatures, sig)
+	b.publicKeys = append(b.publicKeys, aggregated)
+	b.messages = append(b.messages, msg)
+	b.descriptions = append(b.descriptions, description)
+}
+
+// AddBytes adds the serialized signature of the message by the serialized public key to the batch.
+func (b *SignatureBatch) AddBytes(sig []byte, pubKey []byte, msg [32]byte, description string) error {
+	signature, err := SignatureFromBytes(sig)
+	if err != nil {
+		return fmt.Errorf("could not convert bytes to signature of %s: %v", description, err)
+	}
+	publicKey, err := PublicKeyFromBytes(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not convert bytes to public key of %s: %v", description, err)
+	}
+	b.Add(signature, []*PublicKey{publicKey}, msg, description)
+	return nil
+}
+
+// Join adds the signatures of the other batch to this batch.
+func (b *SignatureBatch) Join(other *SignatureBatch) *SignatureBatch {
+	b.signatures = append(b.signatures, other.signatures...)
+	b.publicKeys = append(b.publicKeys, other.publicKeys...)
+	b.messages = append(b.messages, other.messages...)
+	b.descriptions = append(b.descriptions, other.descriptions...)
+	return b
+}
+
+// Len returns the number of signatures in the batch.
+func (b *SignatureBatch) Len() int {
+	return len(b.signatures)
+}
+
+// BatchVerify verifies all the signatures of the batch in a single multi-pairing check, without
+// falling back to verifying them one by one when the check fails. An empty batch is valid.
+func (b *SignatureBatch) BatchVerify() (bool, error) {
+	if len(b.signatures) == 0 {
+		return true, nil
+	}
+	for _, pubKey := range b.publicKeys {
+		// A signature without any public key can never be valid.
+		if pubKey == nil {
+			return false, nil
+		}
+	}
+	return VerifyMultipleSignatures(b.signatures, b.messages, b.publicKeys)
+}
+
+// Verify verifies all the signatures of the batch at once. When the batch is invalid, the
+// signatures are verified one by one to return an error describing the first invalid one.
+func (b *SignatureBatch) Verify() error {
+	if ok, err := b.BatchVerify(); err == nil && ok {
+		return nil
+	}
+	for i, sig := range b.signatures {
+		if b.publicKeys[i] == nil || !sig.Verify(b.publicKeys[i], b.messages[i][:]) {
+			return fmt.Errorf("invalid signature of %s", b.descriptions[i])
+		}
+	}
+	// Each signature is valid on its own, so the batch failed on a randomness error only.
+	return nil
+}
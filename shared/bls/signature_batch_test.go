@@ -0,0 +1,100 @@
+package bls_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+func signedBatch(size int) (*bls.SignatureBatch, []*bls.SecretKey) {
+	batch := bls.NewSignatureBatch()
+	privs := make([]*bls.SecretKey, size)
+	for i := 0; i < size; i++ {
+		msg := [32]byte{'h', 'e', 'l', 'l', 'o', byte(i)}
+		privs[i] = bls.RandKey()
+		batch.Add(privs[i].Sign(msg[:]), []*bls.PublicKey{privs[i].PublicKey()}, msg, fmt.Sprintf("message %d", i))
+	}
+	return batch, privs
+}
+
+func TestVerifyMultipleSignatures(t *testing.T) {
+	var sigs []*bls.Signature
+	var msgs [][32]byte
+	var pubKeys []*bls.PublicKey
+	for i := 0; i < 10; i++ {
+		msg := [32]byte{'h', 'e', 'l', 'l', 'o', byte(i)}
+		priv := bls.RandKey()
+		sigs = append(sigs, priv.Sign(msg[:]))
+		msgs = append(msgs, msg)
+		pubKeys = append(pubKeys, priv.PublicKey())
+	}
+	valid, err := bls.VerifyMultipleSignatures(sigs, msgs, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Error("Signatures did not verify")
+	}
+
+	// Swapping two signatures keeps the aggregate identical, but must not verify.
+	sigs[0], sigs[1] = sigs[1], sigs[0]
+	valid, err = bls.VerifyMultipleSignatures(sigs, msgs, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid {
+		t.Error("Expected swapped signatures not to verify")
+	}
+}
+
+func TestSignatureBatch_Verify(t *testing.T) {
+	batch, _ := signedBatch(10)
+	if batch.Len() != 10 {
+		t.Errorf("Wanted 10 signatures, received %d", batch.Len())
+	}
+	if err := batch.Verify(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSignatureBatch_VerifyAggregatedPublicKeys(t *testing.T) {
+	batch, _ := signedBatch(3)
+	msg := [32]byte{'a', 'g', 'g'}
+	var sigs []*bls.Signature
+	var pubKeys []*bls.PublicKey
+	for i := 0; i < 5; i++ {
+		priv := bls.RandKey()
+		sigs = append(sigs, priv.Sign(msg[:]))
+		pubKeys = append(pubKeys, priv.PublicKey())
+	}
+	batch.Add(bls.AggregateSignatures(sigs), pubKeys, msg, "aggregate")
+	if err := batch.Verify(); err != nil {
+		t.Error(err)
+	}
+	// The public keys of the batch must not have been modified by the aggregation.
+	if !sigs[0].Verify(pubKeys[0], msg[:]) {
+		t.Error("Public key was modified")
+	}
+}
+
+func TestSignatureBatch_InvalidSignature(t *testing.T) {
+	batch, privs := signedBatch(10)
+	other, _ := signedBatch(2)
+	msg := [32]byte{'b', 'a', 'd'}
+	// Signed by another key than the one it is verified with.
+	other.Add(privs[0].Sign(msg[:]), []*bls.PublicKey{privs[1].PublicKey()}, msg, "bad message")
+	batch.Join(other)
+
+	err := batch.Verify()
+	if err == nil || !strings.Contains(err.Error(), "bad message") {
+		t.Errorf("Expected error naming the invalid signature, received %v", err)
+	}
+}
+
+func TestSignatureBatch_Empty(t *testing.T) {
+	if err := bls.NewSignatureBatch().Verify(); err != nil {
+		t.Errorf("Expected empty batch to verify, received %v", err)
+	}
+}
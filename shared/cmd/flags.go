@@ -2,6 +2,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -144,6 +146,12 @@ var (
 		Usage: "The name of the pubsub router to use. Supported values are: gossip, flood, random",
 		Value: "gossip",
 	}
+	// P2PPeerStoreExpiry defines how long known peers are remembered after they were last reachable.
+	P2PPeerStoreExpiry = &cli.DurationFlag{
+		Name:  "p2p-peer-store-expiry",
+		Usage: "How long a known peer is kept in the database after it was last connected.",
+		Value: 7 * 24 * time.Hour,
+	}
 	// ForceClearDB removes any previously stored data at the data directory.
 	ForceClearDB = &cli.BoolFlag{
 		Name:  "force-clear-db",
@@ -3,7 +3,9 @@ package beaconclient
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/blockutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
@@ -50,3 +52,52 @@ func (bs *Service) RequestHistoricalAttestations(
 	}
 	return indexedAtts, nil
 }
+
+// RequestHistoricalBlockHeaders requests the headers of all blocks known by
+// a beacon node for a given epoch via gRPC, including blocks which are not
+// part of the canonical chain.
+func (bs *Service) RequestHistoricalBlockHeaders(
+	ctx context.Context,
+	epoch uint64,
+) ([]*ethpb.SignedBeaconBlockHeader, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.RequestHistoricalBlockHeaders")
+	defer span.End()
+	headers := make([]*ethpb.SignedBeaconBlockHeader, 0)
+	res := &ethpb.ListBlocksResponse{}
+	var err error
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if res == nil {
+			res = &ethpb.ListBlocksResponse{}
+		}
+		res, err = bs.beaconClient.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+			QueryFilter: &ethpb.ListBlocksRequest_Epoch{
+				Epoch: epoch,
+			},
+			PageSize:  int32(params.BeaconConfig().DefaultPageSize),
+			PageToken: res.NextPageToken,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not request blocks for epoch: %d", epoch)
+		}
+		for _, container := range res.BlockContainers {
+			header, err := blockutil.SignedBeaconBlockHeaderFromBlock(container.Block)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get block header from block")
+			}
+			headers = append(headers, header)
+		}
+		log.Infof(
+			"Retrieved %d/%d block headers for epoch %d",
+			len(headers),
+			res.TotalSize,
+			epoch,
+		)
+		if res.NextPageToken == "" || res.TotalSize == 0 || len(headers) == int(res.TotalSize) {
+			break
+		}
+	}
+	return headers, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/blockutil"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -87,3 +88,65 @@ func TestService_RequestHistoricalAttestations(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Retrieved 500/1000 indexed attestations for epoch 0")
 	testutil.AssertLogsContain(t, hook, "Retrieved 1000/1000 indexed attestations for epoch 0")
 }
+
+func TestService_RequestHistoricalBlockHeaders(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+
+	bs := Service{
+		beaconClient: client,
+	}
+
+	numBlocks := 4
+	containers := make([]*ethpb.BeaconBlockContainer, numBlocks)
+	wanted := make([]*ethpb.SignedBeaconBlockHeader, numBlocks)
+	for i := 0; i < numBlocks; i++ {
+		blk := &ethpb.SignedBeaconBlock{
+			Block: &ethpb.BeaconBlock{
+				// Two blocks are proposed for every slot, on different forks.
+				Slot:          uint64(i / 2),
+				ProposerIndex: 1,
+				ParentRoot:    []byte{byte(i)},
+				StateRoot:     make([]byte, 32),
+				Body:          &ethpb.BeaconBlockBody{},
+			},
+			Signature: []byte{byte(i)},
+		}
+		containers[i] = &ethpb.BeaconBlockContainer{Block: blk}
+		header, err := blockutil.SignedBeaconBlockHeaderFromBlock(blk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wanted[i] = header
+	}
+
+	cfg := params.BeaconConfig()
+	cfg.DefaultPageSize = 2
+	params.OverrideBeaconConfig(cfg)
+	client.EXPECT().ListBlocks(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: containers[:2],
+		NextPageToken:   "1",
+		TotalSize:       int32(numBlocks),
+	}, nil)
+	client.EXPECT().ListBlocks(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: containers[2:],
+		NextPageToken:   "",
+		TotalSize:       int32(numBlocks),
+	}, nil)
+
+	res, err := bs.RequestHistoricalBlockHeaders(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, wanted) {
+		t.Errorf("Wanted %v, received %v", wanted, res)
+	}
+}
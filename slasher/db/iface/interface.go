@@ -22,8 +22,8 @@ type ReadOnlyDatabase interface {
 	GetLatestEpochDetected(ctx context.Context) (uint64, error)
 
 	// BlockHeader related methods.
-	BlockHeaders(ctx context.Context, epoch uint64, validatorID uint64) ([]*ethpb.SignedBeaconBlockHeader, error)
-	HasBlockHeader(ctx context.Context, epoch uint64, validatorID uint64) bool
+	BlockHeaders(ctx context.Context, slot uint64, validatorID uint64) ([]*ethpb.SignedBeaconBlockHeader, error)
+	HasBlockHeader(ctx context.Context, slot uint64, validatorID uint64) bool
 
 	// IndexedAttestations related methods.
 	HasIndexedAttestation(ctx context.Context, att *ethpb.IndexedAttestation) (bool, error)
@@ -37,6 +37,11 @@ type ReadOnlyDatabase interface {
 	EpochSpanByValidatorIndex(ctx context.Context, validatorIdx uint64, epoch uint64) (detectionTypes.Span, error)
 	EpochsSpanByValidatorsIndices(ctx context.Context, validatorIndices []uint64, maxEpoch uint64) (map[uint64]map[uint64]detectionTypes.Span, error)
 
+	// Span chunks related methods.
+	SpanChunks(ctx context.Context, kind detectionTypes.ChunkKind, keys []uint64) (map[uint64][]uint16, error)
+	LatestEpochsWritten(ctx context.Context, validatorIndices []uint64) (map[uint64]uint64, error)
+	AttestationRecords(ctx context.Context, targetEpoch uint64, validatorChunk uint64) (map[uint64][2]byte, error)
+
 	// ProposerSlashing related methods.
 	ProposalSlashingsByStatus(ctx context.Context, status types.SlashingStatus) ([]*ethpb.ProposerSlashing, error)
 	HasProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) (bool, types.SlashingStatus, error)
@@ -78,6 +83,11 @@ type WriteAccessDatabase interface {
 	DeleteEpochSpans(ctx context.Context, validatorIdx uint64) error
 	DeleteValidatorSpanByEpoch(ctx context.Context, validatorIdx uint64, epoch uint64) error
 
+	// Span chunks related methods.
+	SaveSpanChunks(ctx context.Context, kind detectionTypes.ChunkKind, chunks map[uint64][]uint16) error
+	SaveLatestEpochsWritten(ctx context.Context, epochs map[uint64]uint64) error
+	SaveAttestationRecords(ctx context.Context, targetEpoch uint64, validatorChunk uint64, records map[uint64][2]byte) error
+
 	// ProposerSlashing related methods.
 	DeleteProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error
 	SaveProposerSlashing(ctx context.Context, status types.SlashingStatus, slashing *ethpb.ProposerSlashing) error
@@ -89,6 +99,9 @@ type WriteAccessDatabase interface {
 
 	// Chain data related methods.
 	SaveChainHead(ctx context.Context, head *ethpb.ChainHead) error
+
+	// Pruning related methods.
+	Prune(ctx context.Context, epoch uint64) (*types.PruneStats, error)
 }
 
 // FullAccessDatabase represents a full access database with only DB interaction functions.
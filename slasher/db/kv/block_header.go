@@ -7,7 +7,6 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
@@ -26,7 +25,7 @@ func unmarshalBlockHeader(ctx context.Context, enc []byte) (*ethpb.SignedBeaconB
 	return protoBlockHeader, nil
 }
 
-// BlockHeaders accepts an slot and validator id and returns the corresponding block header array.
+// BlockHeaders accepts a slot and validator id and returns the corresponding block header array.
 // Returns nil if the block header for those values does not exist.
 func (db *Store) BlockHeaders(ctx context.Context, slot uint64, validatorID uint64) ([]*ethpb.SignedBeaconBlockHeader, error) {
 	ctx, span := trace.StartSpan(ctx, "slasherDB.BlockHeaders")
@@ -68,34 +67,24 @@ func (db *Store) HasBlockHeader(ctx context.Context, slot uint64, validatorID ui
 	return hasBlockHeader
 }
 
-// SaveBlockHeader accepts a block header and writes it to disk.
+// SaveBlockHeader accepts a block header and writes it to disk. Block headers are kept
+// until they are pruned along with the other data older than the retention window.
 func (db *Store) SaveBlockHeader(ctx context.Context, blockHeader *ethpb.SignedBeaconBlockHeader) error {
 	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveBlockHeader")
 	defer span.End()
-	epoch := helpers.SlotToEpoch(blockHeader.Header.Slot)
 	key := encodeSlotValidatorIDSig(blockHeader.Header.Slot, blockHeader.Header.ProposerIndex, blockHeader.Signature)
 	enc, err := proto.Marshal(blockHeader)
 	if err != nil {
 		return errors.Wrap(err, "failed to encode block")
 	}
 
-	err = db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicBlockHeadersBucket)
 		if err := bucket.Put(key, enc); err != nil {
 			return errors.Wrap(err, "failed to include block header in the historical bucket")
 		}
-
-		return err
+		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	// Prune block header history every 10th epoch.
-	if epoch%params.BeaconConfig().PruneSlasherStoragePeriod == 0 {
-		return db.PruneBlockHistory(ctx, epoch, params.BeaconConfig().WeakSubjectivityPeriod)
-	}
-	return nil
 }
 
 // DeleteBlockHeader deletes a block header using the slot and validator id.
@@ -116,6 +116,10 @@ func NewKVStore(dirPath string, cfg *Config) (*Store, error) {
 			validatorsPublicKeysBucket,
 			validatorsMinMaxSpanBucket,
 			validatorsMinMaxSpanBucketNew,
+			minSpanChunksBucket,
+			maxSpanChunksBucket,
+			latestEpochWrittenBucket,
+			attestationRecordsBucket,
 			slashingBucket,
 			chainDataBucket,
 		)
@@ -0,0 +1,236 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// pruneBatchSize is the maximum number of records deleted in a single transaction, so
+// pruning does not hold the write lock of the database for long.
+const pruneBatchSize = 1000
+
+// Prune deletes the indexed attestations, block headers, epoch spans and attestation records
+// of epochs before the given epoch, and the slashings of these epochs which are no longer
+// active. The min-max span chunks are not pruned, they are reused as epochs go by.
+//
+// Records are deleted in small transactions so detection keeps writing to the database
+// while it is pruned.
+func (db *Store) Prune(ctx context.Context, epoch uint64) (*types.PruneStats, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.Prune")
+	defer span.End()
+	stats := &types.PruneStats{}
+	if epoch == 0 {
+		return stats, nil
+	}
+
+	// Records keyed by epoch or slot are looked up from the epoch the database was last pruned
+	// up to, so pruning does not iterate over the records which are kept.
+	from, err := db.lastPrunedEpoch()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve last pruned epoch")
+	}
+	if from > epoch {
+		from = epoch
+	}
+	stats.IndexedAttestations, err = db.pruneRange(ctx, historicIndexedAttestationsBucket, from, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not prune indexed attestations")
+	}
+	stats.BlockHeaders, err = db.pruneRange(ctx, historicBlockHeadersBucket, helpers.StartSlot(from), helpers.StartSlot(epoch))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not prune block headers")
+	}
+	stats.Slashings, err = db.pruneBucket(ctx, slashingBucket, func(k []byte, v []byte) (bool, error) {
+		return isSlashingExpired(ctx, k, v, epoch)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not prune slashings")
+	}
+	for _, bucket := range [][]byte{validatorsMinMaxSpanBucket, validatorsMinMaxSpanBucketNew} {
+		pruned, err := db.pruneRange(ctx, bucket, from, epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not prune epoch spans")
+		}
+		stats.EpochSpans += pruned
+	}
+	stats.AttestationRecords, err = db.pruneRange(ctx, attestationRecordsBucket, from, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not prune attestation records")
+	}
+	if err := db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainDataBucket).Put([]byte(lastPrunedEpochKey), bytesutil.Bytes8(epoch))
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not save last pruned epoch")
+	}
+	return stats, nil
+}
+
+// isSlashingExpired returns true if a slashing is not active anymore and its offense
+// was committed before the given epoch.
+func isSlashingExpired(ctx context.Context, key []byte, enc []byte, epoch uint64) (bool, error) {
+	if len(key) == 0 || len(enc) == 0 {
+		return false, nil
+	}
+	status := types.SlashingStatus(enc[0])
+	if status == types.Active || status == types.Reverted {
+		return false, nil
+	}
+	switch types.SlashingType(key[0]) {
+	case types.Attestation:
+		slashing, err := unmarshalAttSlashing(enc[1:])
+		if err != nil {
+			return false, err
+		}
+		att1, att2 := slashing.Attestation_1, slashing.Attestation_2
+		if att1 == nil || att1.Data == nil || att1.Data.Target == nil ||
+			att2 == nil || att2.Data == nil || att2.Data.Target == nil {
+			return false, nil
+		}
+		return att1.Data.Target.Epoch < epoch && att2.Data.Target.Epoch < epoch, nil
+	case types.Proposal:
+		slashing, err := unmarshalProposerSlashing(ctx, enc[1:])
+		if err != nil {
+			return false, err
+		}
+		if slashing.Header_1 == nil || slashing.Header_1.Header == nil {
+			return false, nil
+		}
+		return helpers.SlotToEpoch(slashing.Header_1.Header.Slot) < epoch, nil
+	default:
+		return false, nil
+	}
+}
+
+// pruneBucket deletes the records of a bucket for which expired returns true, in transactions of
+// at most pruneBatchSize records. Nested buckets are deleted along with their records. It returns
+// the number of records deleted.
+func (db *Store) pruneBucket(ctx context.Context, bucket []byte, expired func(k []byte, v []byte) (bool, error)) (int, error) {
+	pruned := 0
+	var start []byte
+	for {
+		if ctx.Err() != nil {
+			return pruned, ctx.Err()
+		}
+		var keys [][]byte
+		var next []byte
+		if err := db.view(func(tx *bolt.Tx) error {
+			c := tx.Bucket(bucket).Cursor()
+			var k, v []byte
+			if start == nil {
+				k, v = c.First()
+			} else {
+				k, v = c.Seek(start)
+			}
+			for ; k != nil; k, v = c.Next() {
+				if len(keys) == pruneBatchSize {
+					next = append([]byte{}, k...)
+					return nil
+				}
+				ok, err := expired(k, v)
+				if err != nil {
+					return err
+				}
+				if ok {
+					keys = append(keys, append([]byte{}, k...))
+				}
+			}
+			return nil
+		}); err != nil {
+			return pruned, err
+		}
+
+		if err := db.deleteKeys(bucket, keys); err != nil {
+			return pruned, err
+		}
+		pruned += len(keys)
+		if next == nil {
+			return pruned, nil
+		}
+		start = next
+	}
+}
+
+// pruneRange deletes the records of a bucket whose keys are prefixed by an epoch or a slot in
+// [start, end), in transactions of at most pruneBatchSize records. The keys are little-endian
+// encoded so the records of each prefix are sought in turn. It returns the number of records
+// deleted.
+func (db *Store) pruneRange(ctx context.Context, bucket []byte, start uint64, end uint64) (int, error) {
+	pruned := 0
+	var resume []byte
+	for start < end {
+		if ctx.Err() != nil {
+			return pruned, ctx.Err()
+		}
+		var keys [][]byte
+		if err := db.view(func(tx *bolt.Tx) error {
+			c := tx.Bucket(bucket).Cursor()
+			for ; start < end; start++ {
+				prefix := bytesutil.Bytes8(start)
+				seek := prefix
+				if resume != nil {
+					seek, resume = resume, nil
+				}
+				for k, _ := c.Seek(seek); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+					if len(keys) == pruneBatchSize {
+						resume = append([]byte{}, k...)
+						return nil
+					}
+					keys = append(keys, append([]byte{}, k...))
+				}
+			}
+			return nil
+		}); err != nil {
+			return pruned, err
+		}
+
+		if err := db.deleteKeys(bucket, keys); err != nil {
+			return pruned, err
+		}
+		pruned += len(keys)
+	}
+	return pruned, nil
+}
+
+// deleteKeys deletes the given records of a bucket in a single transaction. Nested buckets are
+// deleted along with their records.
+func (db *Store) deleteKeys(bucket []byte, keys [][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for _, k := range keys {
+			if b.Bucket(k) != nil {
+				if err := b.DeleteBucket(k); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// lastPrunedEpoch returns the epoch the database was last pruned up to, or 0 if it was never
+// pruned.
+func (db *Store) lastPrunedEpoch() (uint64, error) {
+	var epoch uint64
+	err := db.view(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainDataBucket).Get([]byte(lastPrunedEpochKey))
+		if len(enc) == 8 {
+			epoch = bytesutil.FromBytes8(enc)
+		}
+		return nil
+	})
+	return epoch, err
+}
@@ -0,0 +1,173 @@
+package kv
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/urfave/cli/v2"
+)
+
+func TestStore_Prune(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	ctx := context.Background()
+
+	attestation := func(target uint64) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: target - 1},
+				Target: &ethpb.Checkpoint{Epoch: target},
+			},
+			Signature: []byte{byte(target), 1, 2},
+		}
+	}
+	for epoch := uint64(1); epoch <= 4; epoch++ {
+		if err := db.SaveIndexedAttestation(ctx, attestation(epoch)); err != nil {
+			t.Fatal(err)
+		}
+		header := &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: epoch * params.BeaconConfig().SlotsPerEpoch},
+			Signature: []byte{byte(epoch)},
+		}
+		if err := db.SaveBlockHeader(ctx, header); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveEpochSpans(ctx, epoch, make(EpochStore, spannerEncodedLength)); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveAttestationRecords(ctx, epoch, 0, map[uint64][2]byte{1: {1, 2}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	included := &ethpb.AttesterSlashing{Attestation_1: attestation(1), Attestation_2: attestation(2)}
+	active := &ethpb.AttesterSlashing{Attestation_1: attestation(2), Attestation_2: attestation(1)}
+	recent := &ethpb.AttesterSlashing{Attestation_1: attestation(3), Attestation_2: attestation(4)}
+	if err := db.SaveAttesterSlashings(ctx, types.Included, []*ethpb.AttesterSlashing{included, recent}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashing(ctx, types.Active, active); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := db.Prune(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.PruneStats{
+		IndexedAttestations: 2,
+		BlockHeaders:        2,
+		Slashings:           1,
+		EpochSpans:          2,
+		AttestationRecords:  2,
+	}
+	if *stats != want {
+		t.Errorf("Wanted prune stats %+v, received %+v", want, *stats)
+	}
+
+	for epoch := uint64(1); epoch <= 4; epoch++ {
+		atts, err := db.IndexedAttestationsForTarget(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		headers, err := db.BlockHeaders(ctx, epoch*params.BeaconConfig().SlotsPerEpoch, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := db.AttestationRecords(ctx, epoch, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kept := epoch >= 3
+		if (len(atts) > 0) != kept || (len(headers) > 0) != kept || (len(records) > 0) != kept {
+			t.Errorf("Unexpected data at epoch %d: %d attestations, %d headers, %d records", epoch, len(atts), len(headers), len(records))
+		}
+	}
+	slashings, err := db.AttesterSlashings(ctx, types.Included)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Errorf("Expected the recent included slashing to be kept, received %d slashings", len(slashings))
+	}
+	slashings, err = db.AttesterSlashings(ctx, types.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Errorf("Expected the active slashing to be kept, received %d slashings", len(slashings))
+	}
+}
+
+func TestStore_Prune_FromLastPrunedEpoch(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	ctx := context.Background()
+
+	if _, err := db.Prune(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	last, err := db.lastPrunedEpoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last != 3 {
+		t.Errorf("Expected last pruned epoch 3, received %d", last)
+	}
+	// Records saved below the last pruned epoch are not looked up again.
+	if err := db.SaveAttestationRecords(ctx, 1, 0, map[uint64][2]byte{1: {1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttestationRecords(ctx, 4, 0, map[uint64][2]byte{1: {1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := db.Prune(ctx, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.AttestationRecords != 1 {
+		t.Errorf("Expected only the records from the last pruned epoch to be pruned, received %d", stats.AttestationRecords)
+	}
+}
+
+func TestStore_PruneRange_Batches(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	ctx := context.Background()
+
+	// The records of the first epoch do not fit in a single batch.
+	records := map[uint64][2]byte{1: {1, 2}}
+	saved := pruneBatchSize + 1
+	for chunk := 0; chunk < saved; chunk++ {
+		if err := db.SaveAttestationRecords(ctx, 1, uint64(chunk), records); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for epoch := uint64(2); epoch <= 3; epoch++ {
+		if err := db.SaveAttestationRecords(ctx, epoch, 0, records); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pruned, err := db.pruneRange(ctx, attestationRecordsBucket, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned != saved+1 {
+		t.Errorf("Expected %d records to be pruned, received %d", saved+1, pruned)
+	}
+	kept, err := db.AttestationRecords(ctx, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) == 0 {
+		t.Error("Expected the records past the range to be kept")
+	}
+}
@@ -8,6 +8,8 @@ import (
 const (
 	latestEpochKey = "LATEST_EPOCH_DETECTED"
 	chainHeadKey   = "CHAIN_HEAD"
+	// lastPrunedEpochKey is the key of the epoch the database was last pruned up to.
+	lastPrunedEpochKey = "LAST_PRUNED_EPOCH"
 	// spannerEncodedLength the byte length of validator span data structure.
 	spannerEncodedLength = 7
 )
@@ -27,6 +29,12 @@ var (
 	// see https://github.com/protolambda/eth2-surround/blob/master/README.md#min-max-surround
 	validatorsMinMaxSpanBucket    = []byte("validators-min-max-span-bucket")
 	validatorsMinMaxSpanBucketNew = []byte("validators-min-max-span-bucket-new")
+	// Min and max spans stored in chunks of validators and epochs, along with the latest
+	// epoch written for each validator and the attestation records used to detect double votes.
+	minSpanChunksBucket      = []byte("min-span-chunks-bucket")
+	maxSpanChunksBucket      = []byte("max-span-chunks-bucket")
+	latestEpochWrittenBucket = []byte("latest-epoch-written-bucket")
+	attestationRecordsBucket = []byte("attestation-records-bucket")
 )
 
 func encodeSlotValidatorID(slot uint64, validatorID uint64) []byte {
@@ -40,6 +48,11 @@ func encodeSlotValidatorIDSig(slot uint64, validatorID uint64, sig []byte) []byt
 func encodeEpochSig(targetEpoch uint64, sig []byte) []byte {
 	return append(bytesutil.Bytes8(targetEpoch), sig...)
 }
+
+func encodeEpochValidatorChunk(targetEpoch uint64, validatorChunk uint64) []byte {
+	return append(bytesutil.Bytes8(targetEpoch), bytesutil.Bytes8(validatorChunk)...)
+}
+
 func encodeType(st types.SlashingType) []byte {
 	return []byte{byte(st)}
 }
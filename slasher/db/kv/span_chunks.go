@@ -0,0 +1,178 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// attestationRecordLength is the byte length of an encoded attestation record,
+// the validator index followed by the first 2 bytes of the attestation signature.
+const attestationRecordLength = 10
+
+func spanChunksBucket(kind types.ChunkKind) ([]byte, error) {
+	switch kind {
+	case types.MinSpan:
+		return minSpanChunksBucket, nil
+	case types.MaxSpan:
+		return maxSpanChunksBucket, nil
+	default:
+		return nil, errors.Errorf("unknown chunk kind %d", kind)
+	}
+}
+
+// SpanChunks returns the chunks of min or max spans stored at the given keys. Chunks
+// that were never saved are not part of the returned map.
+func (db *Store) SpanChunks(ctx context.Context, kind types.ChunkKind, keys []uint64) (map[uint64][]uint16, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SpanChunks")
+	defer span.End()
+	bucket, err := spanChunksBucket(kind)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make(map[uint64][]uint16, len(keys))
+	err = db.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for _, key := range keys {
+			enc := b.Get(bytesutil.Bytes8(key))
+			if enc == nil {
+				continue
+			}
+			chunk, err := decodeSpanChunk(enc)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode span chunk %d", key)
+			}
+			chunks[key] = chunk
+		}
+		return nil
+	})
+	return chunks, err
+}
+
+// SaveSpanChunks saves the chunks of min or max spans at their keys, in a single transaction.
+func (db *Store) SaveSpanChunks(ctx context.Context, kind types.ChunkKind, chunks map[uint64][]uint16) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveSpanChunks")
+	defer span.End()
+	bucket, err := spanChunksBucket(kind)
+	if err != nil {
+		return err
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for key, chunk := range chunks {
+			if err := b.Put(bytesutil.Bytes8(key), encodeSpanChunk(chunk)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LatestEpochsWritten returns the latest target epoch for which the spans of each of the given
+// validators were written. Validators whose spans were never written are not part of the
+// returned map.
+func (db *Store) LatestEpochsWritten(ctx context.Context, validatorIndices []uint64) (map[uint64]uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.LatestEpochsWritten")
+	defer span.End()
+	epochs := make(map[uint64]uint64, len(validatorIndices))
+	err := db.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(latestEpochWrittenBucket)
+		for _, idx := range validatorIndices {
+			if enc := b.Get(bytesutil.Bytes8(idx)); enc != nil {
+				epochs[idx] = binary.LittleEndian.Uint64(enc)
+			}
+		}
+		return nil
+	})
+	return epochs, err
+}
+
+// SaveLatestEpochsWritten saves the latest target epoch for which the spans of each validator were written.
+func (db *Store) SaveLatestEpochsWritten(ctx context.Context, epochs map[uint64]uint64) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveLatestEpochsWritten")
+	defer span.End()
+	return db.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(latestEpochWrittenBucket)
+		for idx, epoch := range epochs {
+			if err := b.Put(bytesutil.Bytes8(idx), bytesutil.Bytes8(epoch)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AttestationRecords returns the first 2 bytes of the signature of the attestation of each validator
+// in the given validator chunk for the target epoch, by validator index.
+func (db *Store) AttestationRecords(ctx context.Context, targetEpoch uint64, validatorChunk uint64) (map[uint64][2]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.AttestationRecords")
+	defer span.End()
+	records := make(map[uint64][2]byte)
+	err := db.view(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(attestationRecordsBucket).Get(encodeEpochValidatorChunk(targetEpoch, validatorChunk))
+		if len(enc)%attestationRecordLength != 0 {
+			return ErrWrongSize
+		}
+		for i := 0; i < len(enc); i += attestationRecordLength {
+			var sigBytes [2]byte
+			copy(sigBytes[:], enc[i+8:i+attestationRecordLength])
+			records[binary.LittleEndian.Uint64(enc[i:i+8])] = sigBytes
+		}
+		return nil
+	})
+	return records, err
+}
+
+// SaveAttestationRecords saves the attestation records of a validator chunk for the target epoch,
+// replacing the previously saved ones.
+func (db *Store) SaveAttestationRecords(ctx context.Context, targetEpoch uint64, validatorChunk uint64, records map[uint64][2]byte) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveAttestationRecords")
+	defer span.End()
+	indices := make([]uint64, 0, len(records))
+	for idx := range records {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+	enc := make([]byte, 0, len(records)*attestationRecordLength)
+	for _, idx := range indices {
+		sigBytes := records[idx]
+		enc = append(enc, bytesutil.Bytes8(idx)...)
+		enc = append(enc, sigBytes[:]...)
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(attestationRecordsBucket).Put(encodeEpochValidatorChunk(targetEpoch, validatorChunk), enc)
+	})
+}
+
+// Span chunks are mostly made of repeated and small values, they are compressed with snappy.
+func encodeSpanChunk(chunk []uint16) []byte {
+	enc := make([]byte, 2*len(chunk))
+	for i, value := range chunk {
+		binary.LittleEndian.PutUint16(enc[2*i:], value)
+	}
+	return snappy.Encode(nil, enc)
+}
+
+func decodeSpanChunk(enc []byte) ([]uint16, error) {
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	if len(dec)%2 != 0 {
+		return nil, ErrWrongSize
+	}
+	chunk := make([]uint16, len(dec)/2)
+	for i := range chunk {
+		chunk[i] = binary.LittleEndian.Uint16(dec[2*i:])
+	}
+	return chunk, nil
+}
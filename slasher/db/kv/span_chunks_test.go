@@ -0,0 +1,96 @@
+package kv
+
+import (
+	"context"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"github.com/urfave/cli/v2"
+)
+
+func TestStore_SaveReadSpanChunks(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	ctx := context.Background()
+
+	minChunks := map[uint64][]uint16{
+		0: {0, 1, 2, 3},
+		7: {65535, 0, 0, 4},
+	}
+	maxChunks := map[uint64][]uint16{
+		0: {5, 5, 5, 5},
+	}
+	if err := db.SaveSpanChunks(ctx, types.MinSpan, minChunks); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveSpanChunks(ctx, types.MaxSpan, maxChunks); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := db.SpanChunks(ctx, types.MinSpan, []uint64{0, 1, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(chunks, minChunks) {
+		t.Errorf("Wanted min span chunks %v, received %v", minChunks, chunks)
+	}
+	chunks, err = db.SpanChunks(ctx, types.MaxSpan, []uint64{0, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(chunks, maxChunks) {
+		t.Errorf("Wanted max span chunks %v, received %v", maxChunks, chunks)
+	}
+}
+
+func TestStore_SaveReadLatestEpochsWritten(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	ctx := context.Background()
+
+	epochs := map[uint64]uint64{1: 10, 300: 2}
+	if err := db.SaveLatestEpochsWritten(ctx, epochs); err != nil {
+		t.Fatal(err)
+	}
+	received, err := db.LatestEpochsWritten(ctx, []uint64{1, 2, 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(received, epochs) {
+		t.Errorf("Wanted latest epochs %v, received %v", epochs, received)
+	}
+}
+
+func TestStore_SaveReadAttestationRecords(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	ctx := context.Background()
+
+	records, err := db.AttestationRecords(ctx, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Expected no records, received %v", records)
+	}
+
+	records = map[uint64][2]byte{4: {1, 2}, 2: {3, 4}}
+	if err := db.SaveAttestationRecords(ctx, 3, 0, records); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttestationRecords(ctx, 3, 1, map[uint64][2]byte{300: {5, 6}}); err != nil {
+		t.Fatal(err)
+	}
+	received, err := db.AttestationRecords(ctx, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(received, records) {
+		t.Errorf("Wanted records %v, received %v", records, received)
+	}
+}
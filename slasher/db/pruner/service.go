@@ -0,0 +1,120 @@
+// Package pruner deletes the data of the slasher database which is older than
+// the retention period, as the chain advances.
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/sirupsen/logrus"
+)
+
+// pruneInterval is the number of epochs between two prunes of the database.
+const pruneInterval = 32
+
+// Service prunes the slasher database as the chain head advances.
+type Service struct {
+	ctx             context.Context
+	cancel          context.CancelFunc
+	slasherDB       db.Database
+	chainFetcher    beaconclient.ChainFetcher
+	retentionEpochs uint64
+	lastPrunedEpoch uint64
+}
+
+// Config options for the pruner service.
+type Config struct {
+	SlasherDB    db.Database
+	ChainFetcher beaconclient.ChainFetcher
+	// RetentionEpochs is the number of epochs to keep data of, the weak subjectivity period of
+	// the chain config if 0.
+	RetentionEpochs uint64
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	retentionEpochs := cfg.RetentionEpochs
+	if retentionEpochs == 0 {
+		retentionEpochs = params.BeaconConfig().WeakSubjectivityPeriod
+	}
+	return &Service{
+		ctx:             ctx,
+		cancel:          cancel,
+		slasherDB:       cfg.SlasherDB,
+		chainFetcher:    cfg.ChainFetcher,
+		retentionEpochs: retentionEpochs,
+	}
+}
+
+// Start the pruner service event loop.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the pruner service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the pruner. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	epochDuration := time.Duration(params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second
+	ticker := time.NewTicker(epochDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			head, err := s.chainFetcher.ChainHead(s.ctx)
+			if err != nil {
+				log.WithError(err).Error("Could not fetch chain head")
+				continue
+			}
+			if err := s.onEpoch(head.HeadEpoch); err != nil {
+				log.WithError(err).Error("Could not prune database")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		}
+	}
+}
+
+// onEpoch prunes the data older than the retention period once the chain head advanced
+// pruneInterval epochs since the last prune.
+func (s *Service) onEpoch(epoch uint64) error {
+	if epoch < s.retentionEpochs {
+		return nil
+	}
+	pruneEpoch := epoch - s.retentionEpochs
+	if pruneEpoch < s.lastPrunedEpoch+pruneInterval {
+		return nil
+	}
+	stats, err := s.slasherDB.Prune(s.ctx, pruneEpoch)
+	if err != nil {
+		return err
+	}
+	s.lastPrunedEpoch = pruneEpoch
+	logStats(stats).WithField("epoch", pruneEpoch).Info("Pruned database")
+	return nil
+}
+
+func logStats(stats *types.PruneStats) *logrus.Entry {
+	return log.WithFields(logrus.Fields{
+		"indexedAttestations": stats.IndexedAttestations,
+		"blockHeaders":        stats.BlockHeaders,
+		"slashings":           stats.Slashings,
+		"epochSpans":          stats.EpochSpans,
+		"attestationRecords":  stats.AttestationRecords,
+	})
+}
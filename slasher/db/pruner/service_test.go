@@ -0,0 +1,53 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+)
+
+func TestService_PrunesEveryInterval(t *testing.T) {
+	retention := uint64(100)
+	s := NewService(context.Background(), &Config{
+		SlasherDB:       testDB.SetupSlasherDB(t, false),
+		RetentionEpochs: retention,
+	})
+
+	if err := s.onEpoch(retention + pruneInterval - 1); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != 0 {
+		t.Errorf("Expected no prune before the interval, last pruned epoch %d", s.lastPrunedEpoch)
+	}
+	if err := s.onEpoch(retention + pruneInterval); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != pruneInterval {
+		t.Errorf("Expected last pruned epoch %d, received %d", pruneInterval, s.lastPrunedEpoch)
+	}
+	if err := s.onEpoch(retention + pruneInterval + 1); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastPrunedEpoch != pruneInterval {
+		t.Errorf("Expected no prune before the next interval, last pruned epoch %d", s.lastPrunedEpoch)
+	}
+}
+
+func TestNewService_DefaultRetention(t *testing.T) {
+	cfg := params.BeaconConfig()
+	defer params.OverrideBeaconConfig(cfg)
+	custom := cfg.Copy()
+	custom.WeakSubjectivityPeriod = 42
+	params.OverrideBeaconConfig(custom)
+
+	s := NewService(context.Background(), &Config{})
+	if s.retentionEpochs != 42 {
+		t.Errorf("Expected the weak subjectivity period of the chain config to be retained, received %d", s.retentionEpochs)
+	}
+	s = NewService(context.Background(), &Config{RetentionEpochs: 100})
+	if s.retentionEpochs != 100 {
+		t.Errorf("Expected 100 retention epochs, received %d", s.retentionEpochs)
+	}
+}
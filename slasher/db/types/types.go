@@ -54,3 +54,12 @@ func (status SlashingType) String() string {
 	}
 	return names[status]
 }
+
+// PruneStats reports the number of records deleted from each bucket of the slasher database when pruning it.
+type PruneStats struct {
+	IndexedAttestations int
+	BlockHeaders        int
+	Slashings           int
+	EpochSpans          int
+	AttestationRecords  int
+}
@@ -0,0 +1,451 @@
+package attestations
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"go.opencensus.io/trace"
+)
+
+var (
+	attestationsOutsideHistory = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chunked_spanner_attestations_outside_history_total",
+		Help: "The number of attestations not processed because their source is older than the span history",
+	})
+	spanChunksWritten = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chunked_spanner_span_chunks_written_total",
+		Help: "The number of min or max span chunks written to the database",
+	})
+)
+
+var _ = iface.SpanDetector(&ChunkedSpanDetector{})
+
+// ChunkedSpanDetector defines a struct which can detect slashable attestation offenses
+// by tracking validator min-max spans, stored in chunks of validators and epochs. Spans
+// are kept for the full history length of the chunk parameters, and attestations are
+// processed in batches so each chunk is read and written once per batch.
+type ChunkedSpanDetector struct {
+	slasherDB db.Database
+	params    *ChunkParams
+	// Batches read, modify and write back the same chunks, they must not run concurrently.
+	lock sync.Mutex
+}
+
+// NewChunkedSpanDetector creates a new instance of a chunked span detector. The default
+// chunk parameters are used when params is nil.
+func NewChunkedSpanDetector(db db.Database, params *ChunkParams) *ChunkedSpanDetector {
+	if params == nil {
+		params = DefaultChunkParams()
+	}
+	return &ChunkedSpanDetector{
+		slasherDB: db,
+		params:    params,
+	}
+}
+
+// DetectSlashingsForAttestation detects the slashable offenses committed by the attesting
+// validators of an attestation, without recording the attestation.
+func (s *ChunkedSpanDetector) DetectSlashingsForAttestation(
+	ctx context.Context,
+	att *ethpb.IndexedAttestation,
+) ([]*types.DetectionResult, error) {
+	ctx, span := trace.StartSpan(ctx, "chunkedSpanner.DetectSlashingsForAttestation")
+	defer span.End()
+	results, err := s.process(ctx, []*ethpb.IndexedAttestation{att}, true /* detect */, false /* update */)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// UpdateSpans records an attestation in the min-max spans of its attesting validators.
+func (s *ChunkedSpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
+	ctx, span := trace.StartSpan(ctx, "chunkedSpanner.UpdateSpans")
+	defer span.End()
+	_, err := s.process(ctx, []*ethpb.IndexedAttestation{att}, false /* detect */, true /* update */)
+	return err
+}
+
+// ProcessAttestations detects the slashable offenses committed by the attesting validators of
+// a batch of attestations and records them, in order, so offenses between attestations of the
+// batch are detected as well. The results are returned in the order of the attestations.
+func (s *ChunkedSpanDetector) ProcessAttestations(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+) ([][]*types.DetectionResult, error) {
+	ctx, span := trace.StartSpan(ctx, "chunkedSpanner.ProcessAttestations")
+	defer span.End()
+	return s.process(ctx, atts, true /* detect */, true /* update */)
+}
+
+func (s *ChunkedSpanDetector) process(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+	detect bool,
+	update bool,
+) ([][]*types.DetectionResult, error) {
+	if err := s.params.validate(); err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batch := newSpanBatch(s.slasherDB, s.params)
+	if err := batch.prefetch(ctx, atts); err != nil {
+		return nil, errors.Wrap(err, "could not load span chunks")
+	}
+	results := make([][]*types.DetectionResult, len(atts))
+	for i, att := range atts {
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(ctx.Err(), "could not process attestations")
+		}
+		if att == nil || att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+			continue
+		}
+		source := att.Data.Source.Epoch
+		target := att.Data.Target.Epoch
+		if source > target {
+			continue
+		}
+		if target-source >= s.params.HistoryLength {
+			attestationsOutsideHistory.Inc()
+			continue
+		}
+		sigBytes := [2]byte{}
+		if len(att.Signature) > 1 {
+			sigBytes = [2]byte{att.Signature[0], att.Signature[1]}
+		}
+		for _, idx := range att.AttestingIndices {
+			minEpoch, err := batch.advance(ctx, idx, target)
+			if err != nil {
+				return nil, err
+			}
+			if source < minEpoch {
+				attestationsOutsideHistory.Inc()
+				continue
+			}
+			if detect {
+				result, err := batch.detect(ctx, idx, source, target)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil {
+					results[i] = append(results[i], result)
+				}
+			}
+			if update {
+				if err := batch.updateMinSpans(ctx, idx, source, target, minEpoch); err != nil {
+					return nil, err
+				}
+				if err := batch.updateMaxSpans(ctx, idx, source, target); err != nil {
+					return nil, err
+				}
+				if err := batch.recordAttestation(ctx, idx, target, sigBytes); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if update {
+		if err := batch.save(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not save span chunks")
+		}
+	}
+	return results, nil
+}
+
+type recordsKey struct {
+	targetEpoch    uint64
+	validatorChunk uint64
+}
+
+// spanBatch holds the chunks, latest epochs written and attestation records read while
+// processing a batch of attestations, along with the ones modified by the batch.
+type spanBatch struct {
+	slasherDB    db.Database
+	params       *ChunkParams
+	chunks       map[types.ChunkKind]map[uint64][]uint16
+	dirtyChunks  map[types.ChunkKind]map[uint64]bool
+	latest       map[uint64]uint64
+	dirtyLatest  map[uint64]bool
+	records      map[recordsKey]map[uint64][2]byte
+	dirtyRecords map[recordsKey]bool
+}
+
+func newSpanBatch(slasherDB db.Database, params *ChunkParams) *spanBatch {
+	b := &spanBatch{
+		slasherDB:    slasherDB,
+		params:       params,
+		chunks:       make(map[types.ChunkKind]map[uint64][]uint16),
+		dirtyChunks:  make(map[types.ChunkKind]map[uint64]bool),
+		dirtyLatest:  make(map[uint64]bool),
+		records:      make(map[recordsKey]map[uint64][2]byte),
+		dirtyRecords: make(map[recordsKey]bool),
+	}
+	for _, kind := range []types.ChunkKind{types.MinSpan, types.MaxSpan} {
+		b.chunks[kind] = make(map[uint64][]uint16)
+		b.dirtyChunks[kind] = make(map[uint64]bool)
+	}
+	return b
+}
+
+// prefetch loads the latest epochs written of the attesting validators, and the chunks
+// holding their spans at the source and target epochs of the attestations, which are
+// read for every attestation.
+func (b *spanBatch) prefetch(ctx context.Context, atts []*ethpb.IndexedAttestation) error {
+	indices := make(map[uint64]bool)
+	keys := make(map[uint64]bool)
+	for _, att := range atts {
+		if att == nil || att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+			continue
+		}
+		for _, idx := range att.AttestingIndices {
+			indices[idx] = true
+			validatorChunk := b.params.validatorChunkIndex(idx)
+			keys[b.params.chunkKey(validatorChunk, att.Data.Source.Epoch)] = true
+			keys[b.params.chunkKey(validatorChunk, att.Data.Target.Epoch)] = true
+		}
+	}
+	validatorIndices := make([]uint64, 0, len(indices))
+	for idx := range indices {
+		validatorIndices = append(validatorIndices, idx)
+	}
+	latest, err := b.slasherDB.LatestEpochsWritten(ctx, validatorIndices)
+	if err != nil {
+		return err
+	}
+	b.latest = latest
+	chunkKeys := make([]uint64, 0, len(keys))
+	for key := range keys {
+		chunkKeys = append(chunkKeys, key)
+	}
+	for kind := range b.chunks {
+		if err := b.loadChunks(ctx, kind, chunkKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *spanBatch) loadChunks(ctx context.Context, kind types.ChunkKind, keys []uint64) error {
+	chunks, err := b.slasherDB.SpanChunks(ctx, kind, keys)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		chunk, ok := chunks[key]
+		if !ok || uint64(len(chunk)) != b.params.ValidatorChunkSize*b.params.ChunkSize {
+			chunk = b.params.emptyChunk()
+		}
+		b.chunks[kind][key] = chunk
+	}
+	return nil
+}
+
+func (b *spanBatch) chunk(ctx context.Context, kind types.ChunkKind, validatorIdx uint64, epoch uint64) ([]uint16, uint64, error) {
+	key := b.params.chunkKey(b.params.validatorChunkIndex(validatorIdx), epoch)
+	if _, ok := b.chunks[kind][key]; !ok {
+		if err := b.loadChunks(ctx, kind, []uint64{key}); err != nil {
+			return nil, 0, err
+		}
+	}
+	return b.chunks[kind][key], key, nil
+}
+
+func (b *spanBatch) span(ctx context.Context, kind types.ChunkKind, validatorIdx uint64, epoch uint64) (uint16, error) {
+	chunk, _, err := b.chunk(ctx, kind, validatorIdx, epoch)
+	if err != nil {
+		return 0, err
+	}
+	return chunk[b.params.cellIndex(validatorIdx, epoch)], nil
+}
+
+func (b *spanBatch) setSpan(ctx context.Context, kind types.ChunkKind, validatorIdx uint64, epoch uint64, value uint16) error {
+	chunk, key, err := b.chunk(ctx, kind, validatorIdx, epoch)
+	if err != nil {
+		return err
+	}
+	cell := b.params.cellIndex(validatorIdx, epoch)
+	if chunk[cell] == value {
+		return nil
+	}
+	chunk[cell] = value
+	b.dirtyChunks[kind][key] = true
+	return nil
+}
+
+// advance moves the latest epoch written of a validator up to the target epoch, clearing the
+// spans left in the cells reused for the new epochs. It returns the oldest epoch whose spans
+// are still kept for the validator.
+func (b *spanBatch) advance(ctx context.Context, validatorIdx uint64, target uint64) (uint64, error) {
+	latest, ok := b.latest[validatorIdx]
+	if !ok || target > latest {
+		if ok {
+			// The cells of the epochs after the latest epoch written were last
+			// written one history length ago, or never.
+			start := latest
+			if target-latest >= b.params.HistoryLength {
+				start = target - b.params.HistoryLength + 1
+			}
+			for epoch := start; epoch <= target; epoch++ {
+				if err := b.setSpan(ctx, types.MinSpan, validatorIdx, epoch, 0); err != nil {
+					return 0, err
+				}
+				if err := b.setSpan(ctx, types.MaxSpan, validatorIdx, epoch, 0); err != nil {
+					return 0, err
+				}
+			}
+		}
+		latest = target
+		b.latest[validatorIdx] = latest
+		b.dirtyLatest[validatorIdx] = true
+	}
+	if latest+1 < b.params.HistoryLength {
+		return 0, nil
+	}
+	return latest + 1 - b.params.HistoryLength, nil
+}
+
+// detect checks the spans of a validator at the source epoch of an attestation for a previous
+// attestation surrounding it or surrounded by it, then the attestation records of the target
+// epoch for a double vote.
+func (b *spanBatch) detect(ctx context.Context, validatorIdx uint64, source uint64, target uint64) (*types.DetectionResult, error) {
+	distance := uint16(target - source)
+	minSpan, err := b.span(ctx, types.MinSpan, validatorIdx, source)
+	if err != nil {
+		return nil, err
+	}
+	if minSpan > 0 && minSpan < distance {
+		return b.result(ctx, validatorIdx, source+uint64(minSpan), types.SurroundVote)
+	}
+	maxSpan, err := b.span(ctx, types.MaxSpan, validatorIdx, source)
+	if err != nil {
+		return nil, err
+	}
+	if maxSpan > distance {
+		return b.result(ctx, validatorIdx, source+uint64(maxSpan), types.SurroundVote)
+	}
+	records, err := b.attestationRecords(ctx, validatorIdx, target)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := records[validatorIdx]; ok {
+		return b.result(ctx, validatorIdx, target, types.DoubleVote)
+	}
+	return nil, nil
+}
+
+func (b *spanBatch) result(ctx context.Context, validatorIdx uint64, slashableEpoch uint64, kind types.DetectionKind) (*types.DetectionResult, error) {
+	records, err := b.attestationRecords(ctx, validatorIdx, slashableEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return &types.DetectionResult{
+		ValidatorIndex: validatorIdx,
+		SlashableEpoch: slashableEpoch,
+		Kind:           kind,
+		SigBytes:       records[validatorIdx],
+	}, nil
+}
+
+// updateMinSpans lowers the min spans of a validator at the epochs before the source epoch
+// of an attestation. The min span of an epoch is never greater than the min span of the
+// next epoch plus 1, so the update stops at the first epoch with a lower min span.
+func (b *spanBatch) updateMinSpans(ctx context.Context, validatorIdx uint64, source uint64, target uint64, minEpoch uint64) error {
+	for epoch := source; epoch > minEpoch; epoch-- {
+		newSpan := uint16(target - epoch + 1)
+		minSpan, err := b.span(ctx, types.MinSpan, validatorIdx, epoch-1)
+		if err != nil {
+			return err
+		}
+		if minSpan > 0 && minSpan <= newSpan {
+			break
+		}
+		if err := b.setSpan(ctx, types.MinSpan, validatorIdx, epoch-1, newSpan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateMaxSpans raises the max spans of a validator at the epochs between the source and
+// target epochs of an attestation. The max span of an epoch is never lower than the max span
+// of the previous epoch minus 1, so the update stops at the first epoch with a greater max span.
+func (b *spanBatch) updateMaxSpans(ctx context.Context, validatorIdx uint64, source uint64, target uint64) error {
+	for epoch := source + 1; epoch < target; epoch++ {
+		newSpan := uint16(target - epoch)
+		maxSpan, err := b.span(ctx, types.MaxSpan, validatorIdx, epoch)
+		if err != nil {
+			return err
+		}
+		if maxSpan >= newSpan {
+			break
+		}
+		if err := b.setSpan(ctx, types.MaxSpan, validatorIdx, epoch, newSpan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *spanBatch) attestationRecords(ctx context.Context, validatorIdx uint64, targetEpoch uint64) (map[uint64][2]byte, error) {
+	key := recordsKey{targetEpoch: targetEpoch, validatorChunk: b.params.validatorChunkIndex(validatorIdx)}
+	if records, ok := b.records[key]; ok {
+		return records, nil
+	}
+	records, err := b.slasherDB.AttestationRecords(ctx, key.targetEpoch, key.validatorChunk)
+	if err != nil {
+		return nil, err
+	}
+	b.records[key] = records
+	return records, nil
+}
+
+// recordAttestation saves the signature bytes of the first attestation of a validator for a target epoch,
+// later used to find the attestation in the database.
+func (b *spanBatch) recordAttestation(ctx context.Context, validatorIdx uint64, targetEpoch uint64, sigBytes [2]byte) error {
+	records, err := b.attestationRecords(ctx, validatorIdx, targetEpoch)
+	if err != nil {
+		return err
+	}
+	if _, ok := records[validatorIdx]; ok {
+		return nil
+	}
+	records[validatorIdx] = sigBytes
+	b.dirtyRecords[recordsKey{targetEpoch: targetEpoch, validatorChunk: b.params.validatorChunkIndex(validatorIdx)}] = true
+	return nil
+}
+
+// save writes the chunks, latest epochs written and attestation records modified by the batch.
+func (b *spanBatch) save(ctx context.Context) error {
+	for kind, dirty := range b.dirtyChunks {
+		chunks := make(map[uint64][]uint16, len(dirty))
+		for key := range dirty {
+			chunks[key] = b.chunks[kind][key]
+		}
+		if err := b.slasherDB.SaveSpanChunks(ctx, kind, chunks); err != nil {
+			return err
+		}
+		spanChunksWritten.Add(float64(len(chunks)))
+	}
+	latest := make(map[uint64]uint64, len(b.dirtyLatest))
+	for idx := range b.dirtyLatest {
+		latest[idx] = b.latest[idx]
+	}
+	if err := b.slasherDB.SaveLatestEpochsWritten(ctx, latest); err != nil {
+		return err
+	}
+	for key := range b.dirtyRecords {
+		if err := b.slasherDB.SaveAttestationRecords(ctx, key.targetEpoch, key.validatorChunk, b.records[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
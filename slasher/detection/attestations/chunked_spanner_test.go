@@ -0,0 +1,219 @@
+package attestations
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+)
+
+func testChunkParams() *ChunkParams {
+	return &ChunkParams{
+		ChunkSize:          2,
+		ValidatorChunkSize: 4,
+		HistoryLength:      16,
+	}
+}
+
+func TestChunkParams_Validate(t *testing.T) {
+	if err := DefaultChunkParams().validate(); err != nil {
+		t.Errorf("Default chunk parameters should be valid: %v", err)
+	}
+	if err := (&ChunkParams{ChunkSize: 3, ValidatorChunkSize: 4, HistoryLength: 16}).validate(); err == nil {
+		t.Error("Expected an error for a history length not multiple of the chunk size")
+	}
+	if err := (&ChunkParams{ChunkSize: 2, ValidatorChunkSize: 4, HistoryLength: 1 << 17}).validate(); err == nil {
+		t.Error("Expected an error for a history length greater than the maximum span")
+	}
+}
+
+func TestChunkParams_Indices(t *testing.T) {
+	p := testChunkParams()
+	// Validator 5 is the second validator of the second validator chunk.
+	if idx := p.validatorChunkIndex(5); idx != 1 {
+		t.Errorf("Wanted validator chunk 1, received %d", idx)
+	}
+	// Epoch 19 is stored in the cells of epoch 3, in the second chunk of the validator chunk.
+	if key := p.chunkKey(1, 19); key != 9 {
+		t.Errorf("Wanted chunk key 9, received %d", key)
+	}
+	if cell := p.cellIndex(5, 19); cell != 3 {
+		t.Errorf("Wanted cell 3, received %d", cell)
+	}
+}
+
+func TestChunkedSpanDetector_DetectSlashingsForAttestation(t *testing.T) {
+	tests := []struct {
+		name        string
+		savedAtts   []*ethpb.IndexedAttestation
+		incomingAtt *ethpb.IndexedAttestation
+		want        []*types.DetectionResult
+	}{
+		{
+			name:        "surrounding vote",
+			savedAtts:   []*ethpb.IndexedAttestation{indexedAttestation(3, 4, []uint64{1})},
+			incomingAtt: indexedAttestation(2, 5, []uint64{1}),
+			want: []*types.DetectionResult{
+				{ValidatorIndex: 1, SlashableEpoch: 4, Kind: types.SurroundVote, SigBytes: [2]byte{1, 2}},
+			},
+		},
+		{
+			name:        "surrounded vote",
+			savedAtts:   []*ethpb.IndexedAttestation{indexedAttestation(1, 6, []uint64{1, 6})},
+			incomingAtt: indexedAttestation(2, 4, []uint64{6}),
+			want: []*types.DetectionResult{
+				{ValidatorIndex: 6, SlashableEpoch: 6, Kind: types.SurroundVote, SigBytes: [2]byte{1, 2}},
+			},
+		},
+		{
+			name:        "double vote",
+			savedAtts:   []*ethpb.IndexedAttestation{indexedAttestation(0, 2, []uint64{1, 2})},
+			incomingAtt: indexedAttestation(1, 2, []uint64{2, 3}),
+			want: []*types.DetectionResult{
+				{ValidatorIndex: 2, SlashableEpoch: 2, Kind: types.DoubleVote, SigBytes: [2]byte{1, 2}},
+			},
+		},
+		{
+			name: "no slashable vote",
+			savedAtts: []*ethpb.IndexedAttestation{
+				indexedAttestation(0, 1, []uint64{1}),
+				indexedAttestation(1, 2, []uint64{1}),
+			},
+			incomingAtt: indexedAttestation(2, 3, []uint64{1}),
+		},
+		{
+			name:        "stale spans of reused cells are cleared",
+			savedAtts:   []*ethpb.IndexedAttestation{indexedAttestation(3, 10, []uint64{1}), indexedAttestation(17, 18, []uint64{1})},
+			incomingAtt: indexedAttestation(20, 21, []uint64{1}),
+		},
+		{
+			name:        "source older than the span history",
+			savedAtts:   []*ethpb.IndexedAttestation{indexedAttestation(3, 4, []uint64{1}), indexedAttestation(19, 20, []uint64{1})},
+			incomingAtt: indexedAttestation(2, 5, []uint64{1}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testDB.SetupSlasherDB(t, false)
+			ctx := context.Background()
+			sd := NewChunkedSpanDetector(db, testChunkParams())
+			for _, att := range tt.savedAtts {
+				if err := sd.UpdateSpans(ctx, att); err != nil {
+					t.Fatal(err)
+				}
+			}
+			res, err := sd.DetectSlashingsForAttestation(ctx, tt.incomingAtt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(res, tt.want) {
+				t.Errorf("Wanted %v, received %v", tt.want, res)
+			}
+		})
+	}
+}
+
+func TestChunkedSpanDetector_ProcessAttestations(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	sd := NewChunkedSpanDetector(db, testChunkParams())
+
+	atts := []*ethpb.IndexedAttestation{
+		indexedAttestation(3, 4, []uint64{1, 5}),
+		indexedAttestation(4, 5, []uint64{2}),
+		// Surrounds the first attestation of the batch.
+		indexedAttestation(2, 5, []uint64{5}),
+	}
+	results, err := sd.ProcessAttestations(ctx, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(atts) {
+		t.Fatalf("Wanted %d results, received %d", len(atts), len(results))
+	}
+	if len(results[0]) != 0 || len(results[1]) != 0 {
+		t.Errorf("Expected no slashing for the first attestations, received %v", results)
+	}
+	want := []*types.DetectionResult{
+		{ValidatorIndex: 5, SlashableEpoch: 4, Kind: types.SurroundVote, SigBytes: [2]byte{1, 2}},
+	}
+	if !reflect.DeepEqual(results[2], want) {
+		t.Errorf("Wanted %v, received %v", want, results[2])
+	}
+
+	// The batch is recorded, a double vote for one of its targets is detected.
+	res, err := sd.DetectSlashingsForAttestation(ctx, indexedAttestation(3, 5, []uint64{2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []*types.DetectionResult{
+		{ValidatorIndex: 2, SlashableEpoch: 5, Kind: types.DoubleVote, SigBytes: [2]byte{1, 2}},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Wanted %v, received %v", want, res)
+	}
+}
+
+func TestChunkedSpanDetector_DetectsBeyondLookback(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	sd := NewChunkedSpanDetector(db, nil)
+
+	if err := sd.UpdateSpans(ctx, indexedAttestation(2*epochLookback, 2*epochLookback+1, []uint64{3})); err != nil {
+		t.Fatal(err)
+	}
+	res, err := sd.DetectSlashingsForAttestation(ctx, indexedAttestation(1, 3*epochLookback, []uint64{3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*types.DetectionResult{
+		{ValidatorIndex: 3, SlashableEpoch: 2*epochLookback + 1, Kind: types.SurroundVote, SigBytes: [2]byte{1, 2}},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Wanted %v, received %v", want, res)
+	}
+}
+
+func BenchmarkChunkedSpanDetector_ProcessAttestations(b *testing.B) {
+	const (
+		validatorCount = 100000
+		committeeSize  = 128
+	)
+	db := testDB.SetupSlasherDB(b, false)
+	ctx := context.Background()
+	sd := NewChunkedSpanDetector(db, nil)
+
+	// Every validator attests once per epoch, in committees of committeeSize validators.
+	epochAtts := func(epoch uint64) []*ethpb.IndexedAttestation {
+		atts := make([]*ethpb.IndexedAttestation, 0, validatorCount/committeeSize+1)
+		for i := uint64(0); i < validatorCount; i += committeeSize {
+			indices := make([]uint64, 0, committeeSize)
+			for idx := i; idx < i+committeeSize && idx < validatorCount; idx++ {
+				indices = append(indices, idx)
+			}
+			atts = append(atts, indexedAttestation(epoch, epoch+1, indices))
+		}
+		return atts
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		atts := epochAtts(uint64(i))
+		b.StartTimer()
+		if _, err := sd.ProcessAttestations(ctx, atts); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	size, err := db.Size()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportMetric(float64(size)/float64(b.N), "db-bytes/epoch")
+	b.ReportMetric(float64(size), "db-bytes")
+}
@@ -0,0 +1,62 @@
+package attestations
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// ChunkParams defines how the min-max spans of validators are split in chunks. A chunk holds
+// the spans of ValidatorChunkSize validators for ChunkSize epochs, as a flat array of
+// ValidatorChunkSize * ChunkSize cells ordered by validator then by epoch. The spans of
+// HistoryLength epochs are kept for each validator, the cells of an epoch being reused
+// HistoryLength epochs later.
+type ChunkParams struct {
+	ChunkSize          uint64
+	ValidatorChunkSize uint64
+	HistoryLength      uint64
+}
+
+// DefaultChunkParams returns the chunk parameters used by the slasher, which keep the
+// spans of every validator for the full weak subjectivity period.
+func DefaultChunkParams() *ChunkParams {
+	return &ChunkParams{
+		ChunkSize:          16,
+		ValidatorChunkSize: 256,
+		HistoryLength:      params.BeaconConfig().WeakSubjectivityPeriod,
+	}
+}
+
+func (p *ChunkParams) validate() error {
+	if p.ChunkSize == 0 || p.ValidatorChunkSize == 0 || p.HistoryLength == 0 {
+		return errors.New("chunk parameters must be greater than 0")
+	}
+	if p.HistoryLength%p.ChunkSize != 0 {
+		return errors.Errorf("history length %d is not a multiple of the chunk size %d", p.HistoryLength, p.ChunkSize)
+	}
+	// Spans are stored as uint16 and are at most the history length.
+	if p.HistoryLength > 1<<16-1 {
+		return errors.Errorf("history length %d is greater than the maximum span", p.HistoryLength)
+	}
+	return nil
+}
+
+// validatorChunkIndex returns the index of the chunk of validators of the given validator.
+func (p *ChunkParams) validatorChunkIndex(validatorIdx uint64) uint64 {
+	return validatorIdx / p.ValidatorChunkSize
+}
+
+// chunkKey returns the key of the chunk holding the spans of the given validator chunk at the given epoch.
+func (p *ChunkParams) chunkKey(validatorChunkIdx uint64, epoch uint64) uint64 {
+	chunksPerValidatorChunk := p.HistoryLength / p.ChunkSize
+	return validatorChunkIdx*chunksPerValidatorChunk + (epoch%p.HistoryLength)/p.ChunkSize
+}
+
+// cellIndex returns the position of the span of the given validator at the given epoch in its chunk.
+func (p *ChunkParams) cellIndex(validatorIdx uint64, epoch uint64) uint64 {
+	return (validatorIdx%p.ValidatorChunkSize)*p.ChunkSize + epoch%p.ChunkSize
+}
+
+// emptyChunk returns a chunk in which no span was recorded.
+func (p *ChunkParams) emptyChunk() []uint16 {
+	return make([]uint16, p.ValidatorChunkSize*p.ChunkSize)
+}
@@ -18,4 +18,10 @@ type SpanDetector interface {
 
 	// Write functions.
 	UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error
+
+	// Batch functions.
+	ProcessAttestations(
+		ctx context.Context,
+		atts []*ethpb.IndexedAttestation,
+	) ([][]*types.DetectionResult, error)
 }
@@ -81,3 +81,19 @@ func (s *MockSpanDetector) DeleteValidatorSpansByEpoch(ctx context.Context, vali
 func (s *MockSpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
 	return nil
 }
+
+// ProcessAttestations mocks the detection of slashings for each attestation of a batch.
+func (s *MockSpanDetector) ProcessAttestations(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+) ([][]*types.DetectionResult, error) {
+	results := make([][]*types.DetectionResult, len(atts))
+	for i, att := range atts {
+		detections, err := s.DetectSlashingsForAttestation(ctx, att)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = detections
+	}
+	return results, nil
+}
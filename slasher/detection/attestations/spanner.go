@@ -39,6 +39,8 @@ var _ = iface.SpanDetector(&SpanDetector{})
 // SpanDetector defines a struct which can detect slashable
 // attestation offenses by tracking validator min-max
 // spans from validators and attestation data roots.
+// Min spans are only updated for a lookback period, see
+// ChunkedSpanDetector for a detector covering the full history.
 type SpanDetector struct {
 	slasherDB db.Database
 }
@@ -191,6 +193,31 @@ func (s *SpanDetector) saveSigBytes(ctx context.Context, att *ethpb.IndexedAttes
 	return s.slasherDB.SaveEpochSpansMap(ctx, target, spanMap)
 }
 
+// ProcessAttestations detects slashings for each attestation of a batch, then updates
+// the spans of the attestations for which no slashing was detected.
+func (s *SpanDetector) ProcessAttestations(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+) ([][]*types.DetectionResult, error) {
+	ctx, span := trace.StartSpan(ctx, "spanner.ProcessAttestations")
+	defer span.End()
+	results := make([][]*types.DetectionResult, len(atts))
+	for i, att := range atts {
+		detections, err := s.DetectSlashingsForAttestation(ctx, att)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = detections
+		if len(detections) > 0 {
+			continue
+		}
+		if err := s.UpdateSpans(ctx, att); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
 // Updates a min span for a validator index given a source and target epoch
 // for an attestation produced by the validator. Used for catching surrounding votes.
 func (s *SpanDetector) updateMinSpan(ctx context.Context, att *ethpb.IndexedAttestation) error {
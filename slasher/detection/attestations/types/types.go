@@ -20,6 +20,19 @@ const (
 	SurroundVote
 )
 
+// ChunkKind defines the kind of spans stored
+// in a chunk of min-max spans.
+type ChunkKind uint8
+
+const (
+	// MinSpan denotes a chunk of min spans, used
+	// to detect attestations surrounding a previous one.
+	MinSpan ChunkKind = iota
+	// MaxSpan denotes a chunk of max spans, used
+	// to detect attestations surrounded by a previous one.
+	MaxSpan
+)
+
 // DetectionResult tells us the kind of slashable
 // offense found from detecting on min-max spans +
 // the slashable epoch for the offense.
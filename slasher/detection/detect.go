@@ -31,6 +31,55 @@ func (ds *Service) DetectAttesterSlashings(
 		return nil, nil
 	}
 
+	slashings, err := ds.slashingsForResults(ctx, att, results)
+	if err != nil {
+		return nil, err
+	}
+	return ds.saveUniqueSlashings(ctx, slashings)
+}
+
+// ProcessAttestations detects double, surround and surrounding attestation offences for a batch
+// of attestations, and updates the span maps with the attestations of the batch.
+func (ds *Service) ProcessAttestations(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+) ([]*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "detection.ProcessAttestations")
+	defer span.End()
+	results, err := ds.minMaxSpanDetector.ProcessAttestations(ctx, atts)
+	if err != nil {
+		return nil, err
+	}
+	ds.recordProcessedAttestations(atts)
+
+	var slashings []*ethpb.AttesterSlashing
+	for i, att := range atts {
+		// If the response is empty, there was no slashing detected.
+		if len(results[i]) == 0 {
+			continue
+		}
+		attSlashings, err := ds.slashingsForResults(ctx, att, results[i])
+		if err != nil {
+			// The spans of the batch are already updated, the slashings
+			// found for the other attestations must not be lost.
+			log.WithError(err).Error("Could not detect attester slashings")
+			continue
+		}
+		slashings = append(slashings, attSlashings...)
+	}
+	if len(slashings) == 0 {
+		return nil, nil
+	}
+	return ds.saveUniqueSlashings(ctx, slashings)
+}
+
+// slashingsForResults finds the attestations matching the detection results of an
+// attestation and returns the slashings they make with it.
+func (ds *Service) slashingsForResults(
+	ctx context.Context,
+	att *ethpb.IndexedAttestation,
+	results []*types.DetectionResult,
+) ([]*ethpb.AttesterSlashing, error) {
 	resultsToAtts, err := ds.mapResultsToAtts(ctx, results)
 	if err != nil {
 		return nil, err
@@ -56,8 +105,11 @@ func (ds *Service) DetectAttesterSlashings(
 			slashings = append(slashings, slashing)
 		}
 	}
+	return slashings, nil
+}
 
-	// Clear out any duplicate results.
+// saveUniqueSlashings clears out any duplicate slashings, saves them and returns them.
+func (ds *Service) saveUniqueSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) ([]*ethpb.AttesterSlashing, error) {
 	keys := make(map[[32]byte]bool)
 	var slashingList []*ethpb.AttesterSlashing
 	for _, ss := range slashings {
@@ -70,7 +122,7 @@ func (ds *Service) DetectAttesterSlashings(
 			slashingList = append(slashingList, ss)
 		}
 	}
-	if err = ds.slasherDB.SaveAttesterSlashings(ctx, status.Active, slashings); err != nil {
+	if err := ds.slasherDB.SaveAttesterSlashings(ctx, status.Active, slashings); err != nil {
 		return nil, err
 	}
 	return slashingList, nil
@@ -344,6 +344,62 @@ func TestDetect_detectAttesterSlashings_Double(t *testing.T) {
 	}
 }
 
+func TestDetect_ProcessAttestations(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	ds := Service{
+		ctx:                ctx,
+		slasherDB:          db,
+		minMaxSpanDetector: attestations.NewChunkedSpanDetector(db, nil),
+	}
+	surrounded := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 3},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 2},
+			Target: &ethpb.Checkpoint{Epoch: 3},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 2}, 96),
+	}
+	other := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{2},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 2},
+			Target: &ethpb.Checkpoint{Epoch: 3},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 3}, 96),
+	}
+	surrounding := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{3},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 1},
+			Target: &ethpb.Checkpoint{Epoch: 4},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 4}, 96),
+	}
+	atts := []*ethpb.IndexedAttestation{surrounded, other, surrounding}
+	if err := db.SaveIndexedAttestations(ctx, atts); err != nil {
+		t.Fatal(err)
+	}
+
+	slashings, err := ds.ProcessAttestations(ctx, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Fatalf("Unexpected amount of slashings found, received %d, expected 1", len(slashings))
+	}
+	if !isSurrounding(slashings[0].Attestation_1, slashings[0].Attestation_2) {
+		t.Errorf("Expected a surround vote slashing, received %v", slashings[0])
+	}
+	attsl, err := db.AttesterSlashings(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attsl) != 1 {
+		t.Fatal("Did not save slashing to db")
+	}
+}
+
 func TestDetect_detectProposerSlashing(t *testing.T) {
 	type testStruct struct {
 		name        string
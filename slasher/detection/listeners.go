@@ -8,9 +8,12 @@ package detection
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/blockutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
 
@@ -48,27 +51,39 @@ func (ds *Service) detectIncomingBlocks(ctx context.Context, ch chan *ethpb.Sign
 }
 
 // detectIncomingAttestations subscribes to an event feed for
-// attestation objects from a notifier interface. Upon receiving
-// an attestation from the feed, we run surround vote and double vote
-// detection on the attestation.
+// attestation objects from a notifier interface. Received attestations
+// are accumulated and we run surround vote and double vote detection
+// on them in batches, every half slot.
 func (ds *Service) detectIncomingAttestations(ctx context.Context, ch chan *ethpb.IndexedAttestation) {
 	ctx, span := trace.StartSpan(ctx, "detection.detectIncomingAttestations")
 	defer span.End()
 	sub := ds.notifier.AttestationFeed().Subscribe(ch)
 	defer sub.Unsubscribe()
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 2)
+	defer ticker.Stop()
+	var pending []*ethpb.IndexedAttestation
 	for {
 		select {
 		case indexedAtt := <-ch:
-			slashings, err := ds.DetectAttesterSlashings(ctx, indexedAtt)
+			if indexedAtt.Data == nil || indexedAtt.Data.Source == nil || indexedAtt.Data.Target == nil {
+				continue
+			}
+			pending = append(pending, indexedAtt)
+		case <-ticker.C:
+			if len(pending) == 0 {
+				continue
+			}
+			// Attestations are processed by target epoch, so the spans
+			// of validators only move forward within a batch.
+			sort.SliceStable(pending, func(i, j int) bool {
+				return pending[i].Data.Target.Epoch < pending[j].Data.Target.Epoch
+			})
+			slashings, err := ds.ProcessAttestations(ctx, pending)
+			pending = nil
 			if err != nil {
 				log.WithError(err).Error("Could not detect attester slashings")
 				continue
 			}
-			if len(slashings) < 1 {
-				if err := ds.minMaxSpanDetector.UpdateSpans(ctx, indexedAtt); err != nil {
-					log.WithError(err).Error("Could not update spans")
-				}
-			}
 			ds.submitAttesterSlashings(ctx, slashings)
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
@@ -0,0 +1,78 @@
+package detection
+
+import (
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// Progress of slashing detection on the attestations of the chain.
+type Progress struct {
+	// LatestEpochProcessed is the latest target epoch of the attestations processed by the detector.
+	LatestEpochProcessed uint64
+	// HistoricalRunning is true while detection runs on historical chain data.
+	HistoricalRunning bool
+	// HistoricalStartEpoch is the epoch from which historical detection started.
+	HistoricalStartEpoch uint64
+	// HistoricalTargetEpoch is the chain head epoch up to which historical detection runs.
+	HistoricalTargetEpoch uint64
+	// HistoricalCurrentEpoch is the next epoch to be processed by historical detection.
+	HistoricalCurrentEpoch uint64
+}
+
+// HistoricalPercentage returns the percentage of the historical chain data processed.
+func (p Progress) HistoricalPercentage() float64 {
+	if p.HistoricalTargetEpoch <= p.HistoricalStartEpoch || p.HistoricalCurrentEpoch >= p.HistoricalTargetEpoch {
+		return 100
+	}
+	if p.HistoricalCurrentEpoch <= p.HistoricalStartEpoch {
+		return 0
+	}
+	done := p.HistoricalCurrentEpoch - p.HistoricalStartEpoch
+	return float64(done) * 100 / float64(p.HistoricalTargetEpoch-p.HistoricalStartEpoch)
+}
+
+// Progress returns the progress of slashing detection.
+func (ds *Service) Progress() Progress {
+	ds.progressLock.RLock()
+	defer ds.progressLock.RUnlock()
+	return ds.progress
+}
+
+// recordProcessedAttestations updates the latest epoch processed with the target epochs
+// of a processed batch of attestations.
+func (ds *Service) recordProcessedAttestations(atts []*ethpb.IndexedAttestation) {
+	ds.progressLock.Lock()
+	defer ds.progressLock.Unlock()
+	for _, att := range atts {
+		if att.Data == nil || att.Data.Target == nil {
+			continue
+		}
+		if att.Data.Target.Epoch > ds.progress.LatestEpochProcessed {
+			ds.progress.LatestEpochProcessed = att.Data.Target.Epoch
+		}
+	}
+}
+
+// startHistoricalProgress records that historical detection runs from the start epoch
+// up to the target epoch.
+func (ds *Service) startHistoricalProgress(start uint64, target uint64) {
+	ds.progressLock.Lock()
+	defer ds.progressLock.Unlock()
+	ds.progress.HistoricalRunning = true
+	ds.progress.HistoricalStartEpoch = start
+	ds.progress.HistoricalTargetEpoch = target
+	ds.progress.HistoricalCurrentEpoch = start
+}
+
+// updateHistoricalProgress records the next epoch to be processed by historical detection.
+func (ds *Service) updateHistoricalProgress(epoch uint64) {
+	ds.progressLock.Lock()
+	defer ds.progressLock.Unlock()
+	ds.progress.HistoricalCurrentEpoch = epoch
+}
+
+// endHistoricalProgress records that historical detection is not running anymore.
+func (ds *Service) endHistoricalProgress() {
+	ds.progressLock.Lock()
+	defer ds.progressLock.Unlock()
+	ds.progress.HistoricalRunning = false
+}
@@ -0,0 +1,61 @@
+package detection
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func TestProgress_HistoricalPercentage(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress Progress
+		want     float64
+	}{
+		{
+			name:     "not started",
+			progress: Progress{HistoricalStartEpoch: 10, HistoricalTargetEpoch: 20, HistoricalCurrentEpoch: 10},
+			want:     0,
+		},
+		{
+			name:     "halfway",
+			progress: Progress{HistoricalStartEpoch: 10, HistoricalTargetEpoch: 20, HistoricalCurrentEpoch: 15},
+			want:     50,
+		},
+		{
+			name:     "completed",
+			progress: Progress{HistoricalStartEpoch: 10, HistoricalTargetEpoch: 20, HistoricalCurrentEpoch: 20},
+			want:     100,
+		},
+		{
+			name:     "nothing to catch up",
+			progress: Progress{HistoricalStartEpoch: 20, HistoricalTargetEpoch: 20, HistoricalCurrentEpoch: 20},
+			want:     100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.progress.HistoricalPercentage(); got != tt.want {
+				t.Errorf("Wanted %f, received %f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestService_RecordProcessedAttestations(t *testing.T) {
+	ds := &Service{}
+	ds.recordProcessedAttestations([]*ethpb.IndexedAttestation{
+		{Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 5}}},
+		{Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 3}}},
+		{},
+	})
+	if epoch := ds.Progress().LatestEpochProcessed; epoch != 5 {
+		t.Errorf("Wanted latest epoch processed 5, received %d", epoch)
+	}
+	ds.recordProcessedAttestations([]*ethpb.IndexedAttestation{
+		{Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 4}}},
+	})
+	if epoch := ds.Progress().LatestEpochProcessed; epoch != 5 {
+		t.Errorf("Latest epoch processed should not go back, received %d", epoch)
+	}
+}
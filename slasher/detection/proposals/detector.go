@@ -6,6 +6,8 @@ import (
 	"bytes"
 	"context"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	status "github.com/prysmaticlabs/prysm/slasher/db/types"
@@ -25,29 +27,43 @@ func NewProposeDetector(db db.Database) *ProposeDetector {
 	}
 }
 
-// DetectDoublePropose detects double proposals given a block by looking in the db.
+// DetectDoublePropose detects double proposals given a block header by looking up the
+// headers of the same proposer and slot in the db. Headers are kept for the whole retention
+// window whether or not their block became canonical, so a proof is found even when the
+// conflicting header was received long before or belongs to a fork pruned by the beacon node.
+// The incoming header is saved so it is checked against the headers received after it.
 func (dd *ProposeDetector) DetectDoublePropose(
 	ctx context.Context,
 	incomingBlk *ethpb.SignedBeaconBlockHeader,
 ) (*ethpb.ProposerSlashing, error) {
 	ctx, span := trace.StartSpan(ctx, "detector.DetectDoublePropose")
 	defer span.End()
+	if incomingBlk == nil || incomingBlk.Header == nil {
+		return nil, errors.New("nil block header")
+	}
 	headersFromIdx, err := dd.slasherDB.BlockHeaders(ctx, incomingBlk.Header.Slot, incomingBlk.Header.ProposerIndex)
 	if err != nil {
 		return nil, err
 	}
+	var ps *ethpb.ProposerSlashing
 	for _, blockHeader := range headersFromIdx {
+		// The header was already received, it was checked at that time.
 		if bytes.Equal(blockHeader.Signature, incomingBlk.Signature) {
-			continue
+			return nil, nil
 		}
-		ps := &ethpb.ProposerSlashing{Header_1: incomingBlk, Header_2: blockHeader}
-		if err := dd.slasherDB.SaveProposerSlashing(ctx, status.Active, ps); err != nil {
-			return nil, err
+		// Headers signed twice with the same content are not slashable.
+		if ps == nil && !proto.Equal(blockHeader.Header, incomingBlk.Header) {
+			ps = &ethpb.ProposerSlashing{Header_1: incomingBlk, Header_2: blockHeader}
 		}
-		return ps, nil
 	}
 	if err := dd.slasherDB.SaveBlockHeader(ctx, incomingBlk); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	if ps == nil {
+		return nil, nil
+	}
+	if err := dd.slasherDB.SaveProposerSlashing(ctx, status.Active, ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
 }
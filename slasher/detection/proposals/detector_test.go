@@ -5,8 +5,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	status "github.com/prysmaticlabs/prysm/slasher/db/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection/proposals/iface"
 	testDetect "github.com/prysmaticlabs/prysm/slasher/detection/testing"
 )
@@ -88,3 +90,70 @@ func TestProposalsDetector_DetectSlashingsForBlockHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestProposalsDetector_DetectsAcrossHeaderHistory(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	sd := NewProposeDetector(db)
+
+	original, err := testDetect.SignedBlockHeader(testDetect.StartSlot(2), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sd.DetectDoublePropose(ctx, original); err != nil {
+		t.Fatal(err)
+	}
+	// Headers of many later epochs are received before the conflicting header.
+	for epoch := uint64(3); epoch < 1000; epoch += 50 {
+		blk, err := testDetect.SignedBlockHeader(testDetect.StartSlot(epoch), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := sd.DetectDoublePropose(ctx, blk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res != nil {
+			t.Fatalf("Unexpected slashing for header of epoch %d: %v", epoch, res)
+		}
+	}
+
+	// The same header signed again is not slashable.
+	resigned := proto.Clone(original).(*ethpb.SignedBeaconBlockHeader)
+	resigned.Signature = []byte("another signature")
+	res, err := sd.DetectDoublePropose(ctx, resigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != nil {
+		t.Errorf("Expected no slashing for a header with the same content, received %v", res)
+	}
+
+	conflicting, err := testDetect.SignedBlockHeader(testDetect.StartSlot(2), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = sd.DetectDoublePropose(ctx, conflicting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil || !proto.Equal(res.Header_1, conflicting) || !proto.Equal(res.Header_2.Header, original.Header) {
+		t.Fatalf("Expected a proof with the conflicting and original headers, received %v", res)
+	}
+
+	// The conflicting header is recorded, receiving it again does not produce a new slashing.
+	res, err = sd.DetectDoublePropose(ctx, conflicting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != nil {
+		t.Errorf("Expected no slashing for an already received header, received %v", res)
+	}
+	slashings, err := db.ProposalSlashingsByStatus(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Errorf("Wanted 1 saved slashing, received %d", len(slashings))
+	}
+}
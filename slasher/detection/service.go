@@ -2,6 +2,7 @@ package detection
 
 import (
 	"context"
+	"sync"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/event"
@@ -32,6 +33,8 @@ type Service struct {
 	proposerSlashingsFeed *event.Feed
 	minMaxSpanDetector    iface.SpanDetector
 	proposalsDetector     proposerIface.ProposalsDetector
+	progress              Progress
+	progressLock          sync.RWMutex
 }
 
 // Config options for the detection service.
@@ -58,7 +61,7 @@ func NewDetectionService(ctx context.Context, cfg *Config) *Service {
 		attsChan:              make(chan *ethpb.IndexedAttestation, 1),
 		attesterSlashingsFeed: cfg.AttesterSlashingsFeed,
 		proposerSlashingsFeed: cfg.ProposerSlashingsFeed,
-		minMaxSpanDetector:    attestations.NewSpanDetector(cfg.SlasherDB),
+		minMaxSpanDetector:    attestations.NewChunkedSpanDetector(cfg.SlasherDB, attestations.DefaultChunkParams()),
 		proposalsDetector:     proposals.NewProposeDetector(cfg.SlasherDB),
 	}
 }
@@ -115,6 +118,8 @@ func (ds *Service) detectHistoricalChainData(ctx context.Context) {
 		latestStoredEpoch = latestStoredHead.HeadEpoch
 	}
 	log.Infof("Performing historical detection from epoch %d to %d", latestStoredEpoch, currentChainHead.HeadEpoch)
+	ds.startHistoricalProgress(latestStoredEpoch, currentChainHead.HeadEpoch)
+	defer ds.endHistoricalProgress()
 
 	// We retrieve historical chain data from the last persisted chain head in the
 	// slasher DB up to the current beacon node's head epoch we retrieved via gRPC.
@@ -122,6 +127,13 @@ func (ds *Service) detectHistoricalChainData(ctx context.Context) {
 	// the genesis epoch.
 	var storedEpoch uint64
 	for epoch := latestStoredEpoch; epoch < currentChainHead.HeadEpoch; epoch++ {
+		ds.updateHistoricalProgress(epoch)
+		headers, err := ds.beaconClient.RequestHistoricalBlockHeaders(ctx, epoch)
+		if err != nil {
+			log.WithError(err).Errorf("Could not fetch block headers for epoch: %d", epoch)
+		} else {
+			ds.detectProposerSlashings(ctx, headers)
+		}
 		indexedAtts, err := ds.beaconClient.RequestHistoricalAttestations(ctx, epoch)
 		if err != nil {
 			log.WithError(err).Errorf("Could not fetch attestations for epoch: %d", epoch)
@@ -132,23 +144,17 @@ func (ds *Service) detectHistoricalChainData(ctx context.Context) {
 			continue
 		}
 
-		for _, att := range indexedAtts {
-			if ctx.Err() == context.Canceled {
-				log.WithError(ctx.Err()).Error("context has been canceled, ending detection")
-				return
-			}
-			slashings, err := ds.DetectAttesterSlashings(ctx, att)
-			if err != nil {
-				log.WithError(err).Error("Could not detect attester slashings")
-				continue
-			}
-			if len(slashings) < 1 {
-				if err := ds.minMaxSpanDetector.UpdateSpans(ctx, att); err != nil {
-					log.WithError(err).Error("Could not update spans")
-				}
-			}
-			ds.submitAttesterSlashings(ctx, slashings)
+		if ctx.Err() == context.Canceled {
+			log.WithError(ctx.Err()).Error("context has been canceled, ending detection")
+			return
+		}
+		// The attestations of an epoch are processed as a single batch.
+		slashings, err := ds.ProcessAttestations(ctx, indexedAtts)
+		if err != nil {
+			log.WithError(err).Error("Could not detect attester slashings")
+			continue
 		}
+		ds.submitAttesterSlashings(ctx, slashings)
 		latestStoredHead = &ethpb.ChainHead{HeadEpoch: epoch}
 		if err := ds.slasherDB.SaveChainHead(ctx, latestStoredHead); err != nil {
 			log.WithError(err).Error("Could not persist chain head to disk")
@@ -156,9 +162,25 @@ func (ds *Service) detectHistoricalChainData(ctx context.Context) {
 		storedEpoch = epoch
 		ds.slasherDB.RemoveOldestFromCache(ctx)
 	}
+	ds.updateHistoricalProgress(currentChainHead.HeadEpoch)
 	log.Infof("Completed slashing detection on historical chain data up to epoch %d", storedEpoch)
 }
 
+// detectProposerSlashings runs double proposal detection on block headers
+// and submits the proposer slashings found.
+func (ds *Service) detectProposerSlashings(ctx context.Context, headers []*ethpb.SignedBeaconBlockHeader) {
+	ctx, span := trace.StartSpan(ctx, "detection.detectProposerSlashings")
+	defer span.End()
+	for _, header := range headers {
+		slashing, err := ds.proposalsDetector.DetectDoublePropose(ctx, header)
+		if err != nil {
+			log.WithError(err).Error("Could not perform detection on block header")
+			continue
+		}
+		ds.submitProposerSlashing(ctx, slashing)
+	}
+}
+
 func (ds *Service) submitAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
 	ctx, span := trace.StartSpan(ctx, "detection.submitAttesterSlashings")
 	defer span.End()
@@ -10,12 +10,16 @@ import (
 )
 
 // SignedBlockHeader given slot, proposer index this function generates signed block header.
-// with random bytes as its signature.
+// with random bytes as its body root and signature.
 func SignedBlockHeader(slot uint64, proposerIdx uint64) (*ethpb.SignedBeaconBlockHeader, error) {
 	sig, err := genRandomSig()
 	if err != nil {
 		return nil, err
 	}
+	bodyRoot := make([]byte, 32)
+	if _, err := rand.Read(bodyRoot); err != nil {
+		return nil, err
+	}
 	root := [32]byte{1, 2, 3}
 	return &ethpb.SignedBeaconBlockHeader{
 		Header: &ethpb.BeaconBlockHeader{
@@ -23,7 +27,7 @@ func SignedBlockHeader(slot uint64, proposerIdx uint64) (*ethpb.SignedBeaconBloc
 			Slot:          slot,
 			ParentRoot:    root[:],
 			StateRoot:     root[:],
-			BodyRoot:      root[:],
+			BodyRoot:      bodyRoot,
 		},
 		Signature: sig,
 	}, nil
@@ -51,4 +51,15 @@ var (
 		Name:  "rebuild-span-maps",
 		Usage: "Rebuild span maps from indexed attestations in db",
 	}
+	// DisablePruningFlag disables pruning the slasher database.
+	DisablePruningFlag = &cli.BoolFlag{
+		Name:  "disable-pruning",
+		Usage: "Keep all the attestations, block headers, spans and slashings in the slasher database instead of pruning the old ones.",
+	}
+	// PruneRetentionEpochsFlag defines the number of epochs before the chain head to keep data of when pruning.
+	PruneRetentionEpochsFlag = &cli.Uint64Flag{
+		Name: "prune-retention-epochs",
+		Usage: "The number of epochs before the chain head to keep data of when pruning the slasher database. " +
+			"Slashable offenses older than this period can not be proven. Defaults to the weak subjectivity period of the chain config.",
+	}
 )
@@ -63,6 +63,8 @@ var appFlags = []cli.Flag{
 	flags.CertFlag,
 	flags.KeyFlag,
 	flags.RebuildSpanMapsFlag,
+	flags.DisablePruningFlag,
+	flags.PruneRetentionEpochsFlag,
 	flags.BeaconCertFlag,
 	flags.BeaconRPCProviderFlag,
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	"github.com/prysmaticlabs/prysm/slasher/db/pruner"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
 	"github.com/prysmaticlabs/prysm/slasher/flags"
 	"github.com/prysmaticlabs/prysm/slasher/rpc"
@@ -95,6 +96,12 @@ func NewSlasherNode(cliCtx *cli.Context) (*SlasherNode, error) {
 		return nil, err
 	}
 
+	if !cliCtx.Bool(flags.DisablePruningFlag.Name) {
+		if err := slasher.registerPrunerService(); err != nil {
+			return nil, err
+		}
+	}
+
 	return slasher, nil
 }
 
@@ -234,14 +241,29 @@ func (s *SlasherNode) registerRPCService() error {
 	cert := s.cliCtx.String(flags.CertFlag.Name)
 	key := s.cliCtx.String(flags.KeyFlag.Name)
 	rpcService := rpc.NewService(s.ctx, &rpc.Config{
-		Host:         host,
-		Port:         port,
-		CertFlag:     cert,
-		KeyFlag:      key,
-		Detector:     detectionService,
-		SlasherDB:    s.db,
-		BeaconClient: bs,
+		Host:                  host,
+		Port:                  port,
+		CertFlag:              cert,
+		KeyFlag:               key,
+		Detector:              detectionService,
+		SlasherDB:             s.db,
+		BeaconClient:          bs,
+		AttesterSlashingsFeed: s.attesterSlashingsFeed,
+		ProposerSlashingsFeed: s.proposerSlashingsFeed,
 	})
 
 	return s.services.RegisterService(rpcService)
 }
+
+func (s *SlasherNode) registerPrunerService() error {
+	var bs *beaconclient.Service
+	if err := s.services.FetchService(&bs); err != nil {
+		return err
+	}
+	prunerService := pruner.NewService(s.ctx, &pruner.Config{
+		SlasherDB:       s.db,
+		ChainFetcher:    bs,
+		RetentionEpochs: s.cliCtx.Uint64(flags.PruneRetentionEpochsFlag.Name),
+	})
+	return s.services.RegisterService(prunerService)
+}
@@ -3,16 +3,20 @@ package rpc
 import (
 	"context"
 
+	ptypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/p2putils"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
 	"github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -23,10 +27,12 @@ import (
 // Server defines a server implementation of the gRPC Slasher service,
 // providing RPC endpoints for retrieving slashing proofs for malicious validators.
 type Server struct {
-	ctx          context.Context
-	detector     *detection.Service
-	slasherDB    db.Database
-	beaconClient *beaconclient.Service
+	ctx                   context.Context
+	detector              *detection.Service
+	slasherDB             db.Database
+	beaconClient          *beaconclient.Service
+	attesterSlashingsFeed *event.Feed
+	proposerSlashingsFeed *event.Feed
 }
 
 // IsSlashableAttestation returns an attester slashing if the attestation submitted
@@ -151,3 +157,245 @@ func (ss *Server) IsSlashableBlock(ctx context.Context, req *ethpb.SignedBeaconB
 	return psr, nil
 
 }
+
+// AttesterSlashings returns the attester slashings found by the slasher with the requested
+// status, for offenses committed within the requested epoch range.
+func (ss *Server) AttesterSlashings(ctx context.Context, req *slashpb.SlashingsRequest) (*slashpb.AttesterSlashingResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "detection.AttesterSlashings")
+	defer span.End()
+
+	if err := validateSlashingsRequest(req); err != nil {
+		return nil, err
+	}
+	slashings, err := ss.slasherDB.AttesterSlashings(ctx, types.SlashingStatus(req.Status))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve attester slashings: %v", err)
+	}
+	filtered := make([]*ethpb.AttesterSlashing, 0, len(slashings))
+	for _, slashing := range slashings {
+		epoch, ok := attesterSlashingEpoch(slashing)
+		if ok && inEpochRange(epoch, req) {
+			filtered = append(filtered, slashing)
+		}
+	}
+	return &slashpb.AttesterSlashingResponse{
+		AttesterSlashing: filtered,
+	}, nil
+}
+
+// ProposerSlashings returns the proposer slashings found by the slasher with the requested
+// status, for offenses committed within the requested epoch range.
+func (ss *Server) ProposerSlashings(ctx context.Context, req *slashpb.SlashingsRequest) (*slashpb.ProposerSlashingResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "detection.ProposerSlashings")
+	defer span.End()
+
+	if err := validateSlashingsRequest(req); err != nil {
+		return nil, err
+	}
+	slashings, err := ss.slasherDB.ProposalSlashingsByStatus(ctx, types.SlashingStatus(req.Status))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve proposer slashings: %v", err)
+	}
+	filtered := make([]*ethpb.ProposerSlashing, 0, len(slashings))
+	for _, slashing := range slashings {
+		if slashing.Header_1 == nil || slashing.Header_1.Header == nil {
+			continue
+		}
+		if inEpochRange(helpers.SlotToEpoch(slashing.Header_1.Header.Slot), req) {
+			filtered = append(filtered, slashing)
+		}
+	}
+	return &slashpb.ProposerSlashingResponse{
+		ProposerSlashing: filtered,
+	}, nil
+}
+
+// ConflictingAttestations returns the attester slashings found by the slasher for a validator,
+// each of them holding a pair of conflicting indexed attestations of the validator.
+func (ss *Server) ConflictingAttestations(ctx context.Context, req *slashpb.ConflictingAttestationsRequest) (*slashpb.AttesterSlashingResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "detection.ConflictingAttestations")
+	defer span.End()
+
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "nil request provided")
+	}
+	var conflicting []*ethpb.AttesterSlashing
+	for _, st := range []types.SlashingStatus{types.Active, types.Included, types.Reverted} {
+		slashings, err := ss.slasherDB.AttesterSlashings(ctx, st)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve attester slashings: %v", err)
+		}
+		for _, slashing := range slashings {
+			if slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+				continue
+			}
+			if sliceutil.IsInUint64(req.ValidatorIndex, slashing.Attestation_1.AttestingIndices) &&
+				sliceutil.IsInUint64(req.ValidatorIndex, slashing.Attestation_2.AttestingIndices) {
+				conflicting = append(conflicting, slashing)
+			}
+		}
+	}
+	return &slashpb.AttesterSlashingResponse{
+		AttesterSlashing: conflicting,
+	}, nil
+}
+
+// slashingStreamBuffer is the number of slashings buffered for the client of a slashing stream.
+// A client falling further behind is disconnected, so that it never holds up detection.
+const slashingStreamBuffer = 64
+
+// StreamAttesterSlashings sends the attester slashings found by the slasher over a
+// stream as soon as they are detected.
+func (ss *Server) StreamAttesterSlashings(_ *ptypes.Empty, stream slashpb.Slasher_StreamAttesterSlashingsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	received := make(chan *ethpb.AttesterSlashing, 1)
+	sub := ss.attesterSlashingsFeed.Subscribe(received)
+	slashingsChannel := make(chan *ethpb.AttesterSlashing, slashingStreamBuffer)
+	forwardErr := make(chan error, 1)
+	go func() {
+		forwardErr <- forwardAttesterSlashings(ctx, sub, received, slashingsChannel)
+	}()
+	for {
+		select {
+		case slashing := <-slashingsChannel:
+			if err := stream.Send(slashing); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case err := <-forwardErr:
+			return err
+		case <-ss.ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// StreamProposerSlashings sends the proposer slashings found by the slasher over a
+// stream as soon as they are detected.
+func (ss *Server) StreamProposerSlashings(_ *ptypes.Empty, stream slashpb.Slasher_StreamProposerSlashingsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	received := make(chan *ethpb.ProposerSlashing, 1)
+	sub := ss.proposerSlashingsFeed.Subscribe(received)
+	slashingsChannel := make(chan *ethpb.ProposerSlashing, slashingStreamBuffer)
+	forwardErr := make(chan error, 1)
+	go func() {
+		forwardErr <- forwardProposerSlashings(ctx, sub, received, slashingsChannel)
+	}()
+	for {
+		select {
+		case slashing := <-slashingsChannel:
+			if err := stream.Send(slashing); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case err := <-forwardErr:
+			return err
+		case <-ss.ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// forwardAttesterSlashings forwards the attester slashings received from the feed to the
+// buffered channel of a stream until the context is done, so that the feed never waits on
+// the client. An error is returned when the buffer is full or the subscription fails.
+func forwardAttesterSlashings(
+	ctx context.Context,
+	sub event.Subscription,
+	received <-chan *ethpb.AttesterSlashing,
+	slashings chan<- *ethpb.AttesterSlashing,
+) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case slashing := <-received:
+			select {
+			case slashings <- slashing:
+			default:
+				log.Warn("Attester slashings stream client is lagging, closing stream")
+				return status.Error(codes.ResourceExhausted, "Client is too slow to receive slashings")
+			}
+		case <-sub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// forwardProposerSlashings forwards the proposer slashings received from the feed to the
+// buffered channel of a stream until the context is done, so that the feed never waits on
+// the client. An error is returned when the buffer is full or the subscription fails.
+func forwardProposerSlashings(
+	ctx context.Context,
+	sub event.Subscription,
+	received <-chan *ethpb.ProposerSlashing,
+	slashings chan<- *ethpb.ProposerSlashing,
+) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case slashing := <-received:
+			select {
+			case slashings <- slashing:
+			default:
+				log.Warn("Proposer slashings stream client is lagging, closing stream")
+				return status.Error(codes.ResourceExhausted, "Client is too slow to receive slashings")
+			}
+		case <-sub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// DetectionStatus returns the progress of slashing detection, including the catch-up
+// of the detector on historical chain data.
+func (ss *Server) DetectionStatus(_ context.Context, _ *ptypes.Empty) (*slashpb.DetectionStatusResponse, error) {
+	progress := ss.detector.Progress()
+	return &slashpb.DetectionStatusResponse{
+		LatestEpochProcessed:         progress.LatestEpochProcessed,
+		HistoricalDetectionRunning:   progress.HistoricalRunning,
+		HistoricalStartEpoch:         progress.HistoricalStartEpoch,
+		HistoricalTargetEpoch:        progress.HistoricalTargetEpoch,
+		HistoricalProgressPercentage: progress.HistoricalPercentage(),
+	}, nil
+}
+
+func validateSlashingsRequest(req *slashpb.SlashingsRequest) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "nil request provided")
+	}
+	if req.Status == slashpb.SlashingStatus_UNKNOWN {
+		return status.Error(codes.InvalidArgument, "slashing status must be provided")
+	}
+	if req.EndEpoch != 0 && req.StartEpoch > req.EndEpoch {
+		return status.Errorf(codes.InvalidArgument, "start epoch %d is after end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	return nil
+}
+
+// inEpochRange returns true if the epoch is within the epoch range of the request,
+// an end epoch of 0 meaning no upper bound.
+func inEpochRange(epoch uint64, req *slashpb.SlashingsRequest) bool {
+	return epoch >= req.StartEpoch && (req.EndEpoch == 0 || epoch <= req.EndEpoch)
+}
+
+// attesterSlashingEpoch returns the epoch at which the offense of an attester slashing
+// was committed, the latest target epoch of its attestations.
+func attesterSlashingEpoch(slashing *ethpb.AttesterSlashing) (uint64, bool) {
+	att1, att2 := slashing.Attestation_1, slashing.Attestation_2
+	if att1 == nil || att1.Data == nil || att1.Data.Target == nil ||
+		att2 == nil || att2.Data == nil || att2.Data.Target == nil {
+		return 0, false
+	}
+	if att1.Data.Target.Epoch > att2.Data.Target.Epoch {
+		return att1.Data.Target.Epoch, true
+	}
+	return att2.Data.Target.Epoch, true
+}
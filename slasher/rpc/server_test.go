@@ -3,20 +3,29 @@ package rpc
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/p2putils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
 	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestServer_IsSlashableAttestation(t *testing.T) {
@@ -239,3 +248,230 @@ func TestServer_IsSlashableBlock(t *testing.T) {
 		t.Fatalf("only one slashing should have been found. got: %v", len(slashing.ProposerSlashing))
 	}
 }
+
+func attesterSlashing(indices []uint64, target1 uint64, target2 uint64) *ethpb.AttesterSlashing {
+	att := func(target uint64, sig byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: indices,
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: 0},
+				Target: &ethpb.Checkpoint{Epoch: target},
+			},
+			Signature: []byte{sig},
+		}
+	}
+	return &ethpb.AttesterSlashing{Attestation_1: att(target1, 1), Attestation_2: att(target2, 2)}
+}
+
+func TestServer_AttesterSlashings(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	early := attesterSlashing([]uint64{1}, 2, 3)
+	late := attesterSlashing([]uint64{2}, 9, 10)
+	included := attesterSlashing([]uint64{3}, 5, 5)
+	if err := db.SaveAttesterSlashings(ctx, types.Active, []*ethpb.AttesterSlashing{early, late}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashing(ctx, types.Included, included); err != nil {
+		t.Fatal(err)
+	}
+	server := Server{ctx: ctx, slasherDB: db}
+
+	res, err := server.AttesterSlashings(ctx, &slashpb.SlashingsRequest{Status: slashpb.SlashingStatus_ACTIVE, StartEpoch: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 1 || !proto.Equal(res.AttesterSlashing[0], late) {
+		t.Errorf("Wanted the late active slashing, received %v", res.AttesterSlashing)
+	}
+	res, err = server.AttesterSlashings(ctx, &slashpb.SlashingsRequest{Status: slashpb.SlashingStatus_ACTIVE, EndEpoch: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 1 || !proto.Equal(res.AttesterSlashing[0], early) {
+		t.Errorf("Wanted the early active slashing, received %v", res.AttesterSlashing)
+	}
+	res, err = server.AttesterSlashings(ctx, &slashpb.SlashingsRequest{Status: slashpb.SlashingStatus_INCLUDED})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 1 || !proto.Equal(res.AttesterSlashing[0], included) {
+		t.Errorf("Wanted the included slashing, received %v", res.AttesterSlashing)
+	}
+
+	if _, err := server.AttesterSlashings(ctx, &slashpb.SlashingsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an invalid argument error without status, received %v", err)
+	}
+	req := &slashpb.SlashingsRequest{Status: slashpb.SlashingStatus_ACTIVE, StartEpoch: 5, EndEpoch: 4}
+	if _, err := server.AttesterSlashings(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an invalid argument error for an invalid range, received %v", err)
+	}
+}
+
+func TestServer_ProposerSlashings(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	proposerSlashing := func(slot uint64) *ethpb.ProposerSlashing {
+		header := func(sig byte) *ethpb.SignedBeaconBlockHeader {
+			return &ethpb.SignedBeaconBlockHeader{
+				Header:    &ethpb.BeaconBlockHeader{Slot: slot, ProposerIndex: 1},
+				Signature: []byte{sig},
+			}
+		}
+		return &ethpb.ProposerSlashing{Header_1: header(1), Header_2: header(2)}
+	}
+	early := proposerSlashing(params.BeaconConfig().SlotsPerEpoch)
+	late := proposerSlashing(10 * params.BeaconConfig().SlotsPerEpoch)
+	for _, slashing := range []*ethpb.ProposerSlashing{early, late} {
+		if err := db.SaveProposerSlashing(ctx, types.Active, slashing); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server := Server{ctx: ctx, slasherDB: db}
+
+	res, err := server.ProposerSlashings(ctx, &slashpb.SlashingsRequest{Status: slashpb.SlashingStatus_ACTIVE, StartEpoch: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ProposerSlashing) != 1 || !proto.Equal(res.ProposerSlashing[0], late) {
+		t.Errorf("Wanted the late slashing, received %v", res.ProposerSlashing)
+	}
+	res, err = server.ProposerSlashings(ctx, &slashpb.SlashingsRequest{Status: slashpb.SlashingStatus_INCLUDED})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ProposerSlashing) != 0 {
+		t.Errorf("Wanted no included slashing, received %v", res.ProposerSlashing)
+	}
+}
+
+func TestServer_ConflictingAttestations(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	active := attesterSlashing([]uint64{1, 2}, 2, 3)
+	included := attesterSlashing([]uint64{2}, 5, 5)
+	other := attesterSlashing([]uint64{3}, 5, 6)
+	if err := db.SaveAttesterSlashings(ctx, types.Active, []*ethpb.AttesterSlashing{active, other}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashing(ctx, types.Included, included); err != nil {
+		t.Fatal(err)
+	}
+	server := Server{ctx: ctx, slasherDB: db}
+
+	res, err := server.ConflictingAttestations(ctx, &slashpb.ConflictingAttestationsRequest{ValidatorIndex: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 2 {
+		t.Fatalf("Wanted 2 slashings, received %d", len(res.AttesterSlashing))
+	}
+	if !proto.Equal(res.AttesterSlashing[0], active) || !proto.Equal(res.AttesterSlashing[1], included) {
+		t.Errorf("Unexpected slashings for validator 2: %v", res.AttesterSlashing)
+	}
+	res, err = server.ConflictingAttestations(ctx, &slashpb.ConflictingAttestationsRequest{ValidatorIndex: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 0 {
+		t.Errorf("Wanted no slashing for validator 4, received %v", res.AttesterSlashing)
+	}
+}
+
+type mockAttesterSlashingsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *ethpb.AttesterSlashing
+}
+
+func (m *mockAttesterSlashingsStream) Send(slashing *ethpb.AttesterSlashing) error {
+	m.sent <- slashing
+	return nil
+}
+
+func (m *mockAttesterSlashingsStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestServer_StreamAttesterSlashings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := new(event.Feed)
+	server := Server{ctx: context.Background(), attesterSlashingsFeed: feed}
+	stream := &mockAttesterSlashingsStream{ctx: ctx, sent: make(chan *ethpb.AttesterSlashing, 1)}
+
+	exitRoutine := make(chan error)
+	go func() {
+		exitRoutine <- server.StreamAttesterSlashings(&ptypes.Empty{}, stream)
+	}()
+	slashing := attesterSlashing([]uint64{1}, 2, 3)
+	// Wait for the stream to subscribe to the feed.
+	for feed.Send(slashing) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if received := <-stream.sent; !proto.Equal(received, slashing) {
+		t.Errorf("Wanted %v, received %v", slashing, received)
+	}
+	cancel()
+	if err := <-exitRoutine; status.Code(err) != codes.Canceled {
+		t.Errorf("Expected the stream to be canceled, received %v", err)
+	}
+}
+
+func TestServer_StreamAttesterSlashings_LaggingClient(t *testing.T) {
+	feed := new(event.Feed)
+	server := Server{ctx: context.Background(), attesterSlashingsFeed: feed}
+	// Nothing is received from the stream, so its client never keeps up.
+	stream := &mockAttesterSlashingsStream{ctx: context.Background(), sent: make(chan *ethpb.AttesterSlashing)}
+
+	exitRoutine := make(chan error)
+	go func() {
+		exitRoutine <- server.StreamAttesterSlashings(&ptypes.Empty{}, stream)
+	}()
+	slashing := attesterSlashing([]uint64{1}, 2, 3)
+	for feed.Send(slashing) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < slashingStreamBuffer+5; i++ {
+			feed.Send(slashing)
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Feed was blocked by a lagging stream client")
+	}
+
+	go func() {
+		for range stream.sent {
+		}
+	}()
+	if err := <-exitRoutine; status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected the lagging stream to be closed, received %v", err)
+	}
+}
+
+func TestServer_DetectionStatus(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupSlasherDB(t, false)
+	ds := detection.NewDetectionService(ctx, &detection.Config{SlasherDB: db})
+	if _, err := ds.ProcessAttestations(ctx, []*ethpb.IndexedAttestation{
+		attesterSlashing([]uint64{1}, 3, 3).Attestation_1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	server := Server{ctx: ctx, detector: ds, slasherDB: db}
+
+	res, err := server.DetectionStatus(ctx, &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.LatestEpochProcessed != 3 {
+		t.Errorf("Wanted latest epoch processed 3, received %d", res.LatestEpochProcessed)
+	}
+	if res.HistoricalDetectionRunning {
+		t.Error("Historical detection should not be running")
+	}
+}
@@ -15,6 +15,7 @@ import (
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
@@ -40,17 +41,21 @@ type Service struct {
 	withKey         string
 	credentialError error
 	beaconclient    *beaconclient.Service
+	attesterFeed    *event.Feed
+	proposerFeed    *event.Feed
 }
 
 // Config options for the slasher node RPC server.
 type Config struct {
-	Host         string
-	Port         string
-	CertFlag     string
-	KeyFlag      string
-	Detector     *detection.Service
-	SlasherDB    db.Database
-	BeaconClient *beaconclient.Service
+	Host                  string
+	Port                  string
+	CertFlag              string
+	KeyFlag               string
+	Detector              *detection.Service
+	SlasherDB             db.Database
+	BeaconClient          *beaconclient.Service
+	AttesterSlashingsFeed *event.Feed
+	ProposerSlashingsFeed *event.Feed
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -65,6 +70,8 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		detector:     cfg.Detector,
 		slasherDB:    cfg.SlasherDB,
 		beaconclient: cfg.BeaconClient,
+		attesterFeed: cfg.AttesterSlashingsFeed,
+		proposerFeed: cfg.ProposerSlashingsFeed,
 	}
 }
 
@@ -111,10 +118,12 @@ func (s *Service) Start() {
 	s.grpcServer = grpc.NewServer(opts...)
 
 	slasherServer := &Server{
-		ctx:          s.ctx,
-		detector:     s.detector,
-		slasherDB:    s.slasherDB,
-		beaconClient: s.beaconclient,
+		ctx:                   s.ctx,
+		detector:              s.detector,
+		slasherDB:             s.slasherDB,
+		beaconClient:          s.beaconclient,
+		attesterSlashingsFeed: s.attesterFeed,
+		proposerSlashingsFeed: s.proposerFeed,
 	}
 	slashpb.RegisterSlasherServer(s.grpcServer, slasherServer)
 
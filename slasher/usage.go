@@ -78,6 +78,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.RPCPort,
 			flags.RPCHost,
 			flags.RebuildSpanMapsFlag,
+			flags.DisablePruningFlag,
+			flags.PruneRetentionEpochsFlag,
 			flags.BeaconRPCProviderFlag,
 		},
 	},
@@ -0,0 +1,104 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+// ExportSlashingProtection writes the slashing protection history stored in the validator
+// database in dataDir to outputFile, using the EIP-3076 interchange format.
+func ExportSlashingProtection(ctx context.Context, dataDir string, outputFile string, genesisValidatorsRoot string) (err error) {
+	root, err := parseGenesisValidatorsRoot(genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	store, err := db.GetKVStore(dataDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the validator database for export")
+	}
+	if store == nil {
+		return fmt.Errorf("no validator database found in %s", dataDir)
+	}
+	defer func() {
+		if deferErr := store.Close(); deferErr != nil {
+			if err != nil {
+				err = errors.Wrap(err, errFailedToCloseDb.Error())
+			} else {
+				err = errors.Wrap(deferErr, errFailedToCloseDb.Error())
+			}
+		}
+	}()
+
+	interchange, err := store.ExportInterchange(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not export slashing protection history")
+	}
+	enc, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not encode slashing protection history")
+	}
+	if err := ioutil.WriteFile(outputFile, enc, 0600); err != nil {
+		return errors.Wrapf(err, "could not write slashing protection history to %s", outputFile)
+	}
+	log.WithField("validators", len(interchange.Data)).Infof("Exported slashing protection history to %s", outputFile)
+	return nil
+}
+
+// ImportSlashingProtection merges the EIP-3076 interchange file at inputFile into the validator
+// database in dataDir, creating the database if it does not exist yet. The file is rejected if
+// it was not produced for the chain with the given genesis validators root.
+func ImportSlashingProtection(ctx context.Context, dataDir string, inputFile string, genesisValidatorsRoot string) (err error) {
+	root, err := parseGenesisValidatorsRoot(genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	enc, err := ioutil.ReadFile(inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not read slashing protection file %s", inputFile)
+	}
+	interchange := &db.Interchange{}
+	if err := json.Unmarshal(enc, interchange); err != nil {
+		return errors.Wrap(err, "could not decode slashing protection file")
+	}
+
+	store, err := db.NewKVStore(dataDir, [][48]byte{})
+	if err != nil {
+		return errors.Wrapf(err, "could not open the validator database in %s", dataDir)
+	}
+	defer func() {
+		if deferErr := store.Close(); deferErr != nil {
+			if err != nil {
+				err = errors.Wrap(err, errFailedToCloseDb.Error())
+			} else {
+				err = errors.Wrap(deferErr, errFailedToCloseDb.Error())
+			}
+		}
+	}()
+
+	if err := store.ImportInterchange(ctx, interchange, root); err != nil {
+		return errors.Wrap(err, "could not import slashing protection history")
+	}
+	log.WithField("validators", len(interchange.Data)).Infof("Imported slashing protection history from %s", inputFile)
+	return nil
+}
+
+func parseGenesisValidatorsRoot(root string) ([]byte, error) {
+	if root == "" {
+		return nil, errors.New("a genesis validators root is required")
+	}
+	decoded, err := hex.DecodeString(strings.TrimPrefix(root, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode genesis validators root")
+	}
+	if len(decoded) != 32 {
+		return nil, fmt.Errorf("genesis validators root must be 32 bytes, received %d", len(decoded))
+	}
+	return decoded, nil
+}
@@ -0,0 +1,71 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+func TestExportImportSlashingProtection(t *testing.T) {
+	pubKey := [48]byte{1}
+	sourceStore := db.SetupDB(t, [][48]byte{pubKey})
+	slotBits := bitfield.NewBitlist(params.BeaconConfig().SlotsPerEpoch)
+	slotBits.SetBitAt(5, true)
+	if err := sourceStore.SaveProposalHistoryForEpoch(context.Background(), pubKey[:], 1, slotBits); err != nil {
+		t.Fatal(err)
+	}
+	if err := sourceStore.Close(); err != nil {
+		t.Fatalf("Closing source store failed: %v", err)
+	}
+
+	root := fmt.Sprintf("%#x", [32]byte{'r'})
+	file := filepath.Join(testutil.TempDir(), "slashing-protection.json")
+	targetDirectory := filepath.Join(testutil.TempDir(), "interchange-target")
+	t.Cleanup(func() {
+		if err := os.RemoveAll(targetDirectory); err != nil {
+			t.Errorf("Could not remove target directory: %v", err)
+		}
+		if err := os.Remove(file); err != nil {
+			t.Errorf("Could not remove interchange file: %v", err)
+		}
+	})
+
+	if err := ExportSlashingProtection(context.Background(), sourceStore.DatabasePath(), file, root); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if err := ImportSlashingProtection(context.Background(), targetDirectory, file, root); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	targetStore, err := db.GetKVStore(targetDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := targetStore.Close(); err != nil {
+			t.Errorf("Closing target store failed: %v", err)
+		}
+	}()
+	imported, err := targetStore.ProposalHistoryForEpoch(context.Background(), pubKey[:], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !imported.BitAt(5) {
+		t.Error("Expected imported proposal history to mark slot 5 of epoch 1")
+	}
+}
+
+func TestImportSlashingProtection_InvalidGenesisValidatorsRoot(t *testing.T) {
+	err := ImportSlashingProtection(context.Background(), testutil.TempDir(), "unused.json", "0x1234")
+	if err == nil || !strings.Contains(err.Error(), "must be 32 bytes") {
+		t.Errorf("Expected invalid genesis validators root error, received %v", err)
+	}
+}
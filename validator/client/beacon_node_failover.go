@@ -0,0 +1,499 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxHeadSlotLag is how many slots the active beacon node's head may trail the best
+// candidate before the validator fails over to the candidate.
+const maxHeadSlotLag = 2
+
+// healthCheckTimeout is how long a beacon node may take to answer its health queries before it
+// is considered unhealthy, so an unresponsive node cannot hold up the checks of the others.
+var healthCheckTimeout = 2 * time.Second
+
+var (
+	beaconNodeFailoversCount = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "validator",
+		Name:      "beacon_node_failovers_total",
+		Help:      "The number of times the validator switched to a different beacon node.",
+	})
+	beaconNodeHealthyGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_healthy",
+			Help:      "Whether a configured beacon node passed its last health check: 1 healthy, 0 unhealthy.",
+		},
+		[]string{
+			// beacon node endpoint
+			"endpoint",
+		},
+	)
+)
+
+// beaconChainClient is the subset of ethpb.BeaconChainClient used by the validator.
+type beaconChainClient interface {
+	GetChainHead(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.ChainHead, error)
+	GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest, opts ...grpc.CallOption) (*ethpb.ValidatorPerformanceResponse, error)
+	ListIndexedAttestations(ctx context.Context, in *ethpb.ListIndexedAttestationsRequest, opts ...grpc.CallOption) (*ethpb.ListIndexedAttestationsResponse, error)
+	ListBlocks(ctx context.Context, in *ethpb.ListBlocksRequest, opts ...grpc.CallOption) (*ethpb.ListBlocksResponse, error)
+}
+
+// nodeClient is the subset of ethpb.NodeClient used by the validator.
+type nodeClient interface {
+	GetSyncStatus(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.SyncStatus, error)
+}
+
+// beaconNode holds the clients and last known health of a single beacon node endpoint.
+type beaconNode struct {
+	endpoint        string
+	conn            *grpc.ClientConn
+	validatorClient ethpb.BeaconNodeValidatorClient
+	beaconClient    ethpb.BeaconChainClient
+	nodeClient      ethpb.NodeClient
+	healthy         bool
+	syncing         bool
+	headSlot        uint64
+	peerCount       int
+}
+
+// newBeaconNode creates the clients for a beacon node reachable over conn.
+func newBeaconNode(endpoint string, conn *grpc.ClientConn) *beaconNode {
+	return &beaconNode{
+		endpoint:        endpoint,
+		conn:            conn,
+		validatorClient: ethpb.NewBeaconNodeValidatorClient(conn),
+		beaconClient:    ethpb.NewBeaconChainClient(conn),
+		nodeClient:      ethpb.NewNodeClient(conn),
+		healthy:         true,
+	}
+}
+
+// betterThan reports whether node is a more suitable duty provider than other: reachable nodes
+// beat unreachable ones, synced nodes beat syncing ones, then higher head slots and more peers win.
+func (node *beaconNode) betterThan(other *beaconNode) bool {
+	if node.healthy != other.healthy {
+		return node.healthy
+	}
+	if node.syncing != other.syncing {
+		return !node.syncing
+	}
+	if node.headSlot != other.headSlot {
+		return node.headSlot > other.headSlot
+	}
+	return node.peerCount > other.peerCount
+}
+
+// beaconNodeFailover routes the validator's requests to the healthiest of several beacon nodes.
+// Requests that fail because a node is unreachable are retried against the next best node, and
+// a periodic health check moves traffic away from nodes that are syncing or fall behind.
+type beaconNodeFailover struct {
+	nodes    []*beaconNode
+	active   int
+	switched bool
+	lock     sync.RWMutex
+}
+
+// newBeaconNodeFailover creates a failover over the given nodes, starting with the first one.
+func newBeaconNodeFailover(nodes []*beaconNode) *beaconNodeFailover {
+	return &beaconNodeFailover{nodes: nodes}
+}
+
+// current returns the beacon node requests are currently routed to.
+func (f *beaconNodeFailover) current() *beaconNode {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.nodes[f.active]
+}
+
+// consumeSwitched reports whether the active beacon node changed since the last call.
+func (f *beaconNodeFailover) consumeSwitched() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	switched := f.switched
+	f.switched = false
+	return switched
+}
+
+// run checks the health of every beacon node at the given interval until the context is canceled.
+func (f *beaconNodeFailover) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.checkHealth(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth queries the sync status, head slot and peer count of every beacon node and
+// routes requests to the best one. The active node is kept while it is healthy, synced and
+// within maxHeadSlotLag slots of the best node, to avoid flapping between nodes.
+func (f *beaconNodeFailover) checkHealth(ctx context.Context) {
+	for _, node := range f.nodes {
+		healthy, syncing, headSlot, peerCount := queryHealth(ctx, node)
+		f.lock.Lock()
+		node.healthy, node.syncing, node.headSlot, node.peerCount = healthy, syncing, headSlot, peerCount
+		f.lock.Unlock()
+		if healthy {
+			beaconNodeHealthyGaugeVec.WithLabelValues(node.endpoint).Set(1)
+		} else {
+			beaconNodeHealthyGaugeVec.WithLabelValues(node.endpoint).Set(0)
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	best := f.active
+	for i, node := range f.nodes {
+		if node.betterThan(f.nodes[best]) {
+			best = i
+		}
+	}
+	active := f.nodes[f.active]
+	if active.healthy && !active.syncing && active.headSlot+maxHeadSlotLag >= f.nodes[best].headSlot {
+		return
+	}
+	f.switchTo(best)
+}
+
+// queryHealth gathers the health of a single beacon node. A node is unhealthy if any query fails
+// or the queries do not complete within healthCheckTimeout.
+func queryHealth(ctx context.Context, node *beaconNode) (bool, bool, uint64, int) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	syncStatus, err := node.nodeClient.GetSyncStatus(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", node.endpoint).Debug("Could not get beacon node sync status")
+		return false, false, 0, 0
+	}
+	head, err := node.beaconClient.GetChainHead(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", node.endpoint).Debug("Could not get beacon node chain head")
+		return false, false, 0, 0
+	}
+	peers, err := node.nodeClient.ListPeers(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", node.endpoint).Debug("Could not get beacon node peers")
+		return false, false, 0, 0
+	}
+	return true, syncStatus.Syncing, head.HeadSlot, len(peers.Peers)
+}
+
+// switchTo routes requests to the node at index i. Must be called with the lock held.
+func (f *beaconNodeFailover) switchTo(i int) {
+	if i == f.active {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"from": f.nodes[f.active].endpoint,
+		"to":   f.nodes[i].endpoint,
+	}).Warn("Switching to a different beacon node")
+	f.active = i
+	f.switched = true
+	beaconNodeFailoversCount.Inc()
+}
+
+// markUnreachable flags node as unhealthy and, if it is the active node, fails over to the best
+// remaining one.
+func (f *beaconNodeFailover) markUnreachable(node *beaconNode) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	node.healthy = false
+	beaconNodeHealthyGaugeVec.WithLabelValues(node.endpoint).Set(0)
+	if f.nodes[f.active] != node {
+		return
+	}
+	best := -1
+	for i, candidate := range f.nodes {
+		if candidate == node {
+			continue
+		}
+		if best == -1 || candidate.betterThan(f.nodes[best]) {
+			best = i
+		}
+	}
+	if best != -1 {
+		f.switchTo(best)
+	}
+}
+
+// do calls fn with the active beacon node, failing over to the next node whenever the call
+// fails because the node is unreachable. Each node is tried at most once.
+func (f *beaconNodeFailover) do(ctx context.Context, fn func(node *beaconNode) error) error {
+	var err error
+	for attempt := 0; attempt < len(f.nodes); attempt++ {
+		node := f.current()
+		err = fn(node)
+		if err == nil || ctx.Err() != nil || status.Code(err) != codes.Unavailable {
+			return err
+		}
+		log.WithError(err).WithField("endpoint", node.endpoint).Warn("Beacon node unreachable")
+		f.markUnreachable(node)
+	}
+	return err
+}
+
+// close closes the connections to all beacon nodes.
+func (f *beaconNodeFailover) close() error {
+	var err error
+	for _, node := range f.nodes {
+		if node.conn == nil {
+			continue
+		}
+		if closeErr := node.conn.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// failoverValidatorClient is an ethpb.BeaconNodeValidatorClient which routes every call
+// through a beaconNodeFailover. Streams are opened against the node active at call time.
+type failoverValidatorClient struct {
+	failover *beaconNodeFailover
+}
+
+// DomainData --
+func (c *failoverValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest, opts ...grpc.CallOption) (*ethpb.DomainResponse, error) {
+	var resp *ethpb.DomainResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.DomainData(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// GetAttestationData --
+func (c *failoverValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest, opts ...grpc.CallOption) (*ethpb.AttestationData, error) {
+	var resp *ethpb.AttestationData
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.GetAttestationData(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// GetBlock --
+func (c *failoverValidatorClient) GetBlock(ctx context.Context, in *ethpb.BlockRequest, opts ...grpc.CallOption) (*ethpb.BeaconBlock, error) {
+	var resp *ethpb.BeaconBlock
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.GetBlock(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// GetDuties --
+func (c *failoverValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+	var resp *ethpb.DutiesResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.GetDuties(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// MultipleValidatorStatus --
+func (c *failoverValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.MultipleValidatorStatusResponse, error) {
+	var resp *ethpb.MultipleValidatorStatusResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.MultipleValidatorStatus(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ProposeAttestation --
+func (c *failoverValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation, opts ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+	var resp *ethpb.AttestResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.ProposeAttestation(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ProposeBlock --
+func (c *failoverValidatorClient) ProposeBlock(ctx context.Context, in *ethpb.SignedBeaconBlock, opts ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+	var resp *ethpb.ProposeResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.ProposeBlock(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ProposeExit --
+func (c *failoverValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit, opts ...grpc.CallOption) (*ptypes.Empty, error) {
+	var resp *ptypes.Empty
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.ProposeExit(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// StreamDuties --
+func (c *failoverValidatorClient) StreamDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	var resp ethpb.BeaconNodeValidator_StreamDutiesClient
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.StreamDuties(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// SubmitAggregateSelectionProof --
+func (c *failoverValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest, opts ...grpc.CallOption) (*ethpb.AggregateSelectionResponse, error) {
+	var resp *ethpb.AggregateSelectionResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.SubmitAggregateSelectionProof(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// SubmitSignedAggregateSelectionProof --
+func (c *failoverValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest, opts ...grpc.CallOption) (*ethpb.SignedAggregateSubmitResponse, error) {
+	var resp *ethpb.SignedAggregateSubmitResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.SubmitSignedAggregateSelectionProof(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// SubscribeCommitteeSubnets --
+func (c *failoverValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, opts ...grpc.CallOption) (*ptypes.Empty, error) {
+	var resp *ptypes.Empty
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.SubscribeCommitteeSubnets(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ValidatorIndex --
+func (c *failoverValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest, opts ...grpc.CallOption) (*ethpb.ValidatorIndexResponse, error) {
+	var resp *ethpb.ValidatorIndexResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.ValidatorIndex(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ValidatorStatus --
+func (c *failoverValidatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.ValidatorStatusResponse, error) {
+	var resp *ethpb.ValidatorStatusResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.ValidatorStatus(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// WaitForActivation --
+func (c *failoverValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	var resp ethpb.BeaconNodeValidator_WaitForActivationClient
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.WaitForActivation(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// WaitForChainStart --
+func (c *failoverValidatorClient) WaitForChainStart(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error) {
+	var resp ethpb.BeaconNodeValidator_WaitForChainStartClient
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.WaitForChainStart(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// WaitForSynced --
+func (c *failoverValidatorClient) WaitForSynced(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForSyncedClient, error) {
+	var resp ethpb.BeaconNodeValidator_WaitForSyncedClient
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.validatorClient.WaitForSynced(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// failoverBeaconChainClient routes the validator's beacon chain queries through a beaconNodeFailover.
+type failoverBeaconChainClient struct {
+	failover *beaconNodeFailover
+}
+
+// GetChainHead --
+func (c *failoverBeaconChainClient) GetChainHead(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	var resp *ethpb.ChainHead
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.beaconClient.GetChainHead(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// GetValidatorPerformance --
+func (c *failoverBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest, opts ...grpc.CallOption) (*ethpb.ValidatorPerformanceResponse, error) {
+	var resp *ethpb.ValidatorPerformanceResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.beaconClient.GetValidatorPerformance(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ListIndexedAttestations --
+func (c *failoverBeaconChainClient) ListIndexedAttestations(ctx context.Context, in *ethpb.ListIndexedAttestationsRequest, opts ...grpc.CallOption) (*ethpb.ListIndexedAttestationsResponse, error) {
+	var resp *ethpb.ListIndexedAttestationsResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.beaconClient.ListIndexedAttestations(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// ListBlocks --
+func (c *failoverBeaconChainClient) ListBlocks(ctx context.Context, in *ethpb.ListBlocksRequest, opts ...grpc.CallOption) (*ethpb.ListBlocksResponse, error) {
+	var resp *ethpb.ListBlocksResponse
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.beaconClient.ListBlocks(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
+
+// failoverNodeClient routes the validator's node queries through a beaconNodeFailover.
+type failoverNodeClient struct {
+	failover *beaconNodeFailover
+}
+
+// GetSyncStatus --
+func (c *failoverNodeClient) GetSyncStatus(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+	var resp *ethpb.SyncStatus
+	err := c.failover.do(ctx, func(node *beaconNode) (err error) {
+		resp, err = node.nodeClient.GetSyncStatus(ctx, in, opts...)
+		return
+	})
+	return resp, err
+}
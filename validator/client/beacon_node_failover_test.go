@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockBeaconNode struct {
+	validatorClient *mock.MockBeaconNodeValidatorClient
+	beaconClient    *mock.MockBeaconChainClient
+	nodeClient      *mock.MockNodeClient
+}
+
+func setupBeaconNodes(ctrl *gomock.Controller, n int) (*beaconNodeFailover, []*mockBeaconNode) {
+	nodes := make([]*beaconNode, n)
+	mocks := make([]*mockBeaconNode, n)
+	for i := 0; i < n; i++ {
+		mocks[i] = &mockBeaconNode{
+			validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+			beaconClient:    mock.NewMockBeaconChainClient(ctrl),
+			nodeClient:      mock.NewMockNodeClient(ctrl),
+		}
+		nodes[i] = &beaconNode{
+			endpoint:        string(rune('a' + i)),
+			validatorClient: mocks[i].validatorClient,
+			beaconClient:    mocks[i].beaconClient,
+			nodeClient:      mocks[i].nodeClient,
+			healthy:         true,
+		}
+	}
+	return newBeaconNodeFailover(nodes), mocks
+}
+
+func expectHealth(m *mockBeaconNode, syncing bool, headSlot uint64, peers int) {
+	m.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: syncing}, nil)
+	m.beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: headSlot}, nil)
+	m.nodeClient.EXPECT().ListPeers(gomock.Any(), gomock.Any()).Return(&ethpb.Peers{Peers: make([]*ethpb.Peer, peers)}, nil)
+}
+
+func TestFailoverValidatorClient_FailsOverWhenUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	failover, mocks := setupBeaconNodes(ctrl, 2)
+	client := &failoverValidatorClient{failover: failover}
+
+	mocks[0].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	want := &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{{ValidatorIndex: 1}}}
+	mocks[1].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(want, nil)
+
+	resp, err := client.GetDuties(context.Background(), &ethpb.DutiesRequest{})
+	if err != nil {
+		t.Fatalf("Could not get duties: %v", err)
+	}
+	if resp != want {
+		t.Errorf("Expected duties from the second beacon node, received %v", resp)
+	}
+	if failover.current().endpoint != "b" {
+		t.Errorf("Expected active beacon node b, received %s", failover.current().endpoint)
+	}
+	if !failover.consumeSwitched() {
+		t.Error("Expected failover to record a switch")
+	}
+}
+
+func TestFailoverValidatorClient_DoesNotFailOverOnOtherErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	failover, mocks := setupBeaconNodes(ctrl, 2)
+	client := &failoverValidatorClient{failover: failover}
+
+	wantErr := status.Error(codes.InvalidArgument, "bad request")
+	mocks[0].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, wantErr)
+
+	if _, err := client.GetDuties(context.Background(), &ethpb.DutiesRequest{}); err != wantErr {
+		t.Errorf("Expected %v, received %v", wantErr, err)
+	}
+	if failover.current().endpoint != "a" {
+		t.Errorf("Expected active beacon node a, received %s", failover.current().endpoint)
+	}
+}
+
+func TestBeaconNodeFailover_CheckHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(mocks []*mockBeaconNode)
+		active string
+	}{
+		{
+			name: "SyncingActiveNode",
+			setup: func(mocks []*mockBeaconNode) {
+				expectHealth(mocks[0], true, 100, 50)
+				expectHealth(mocks[1], false, 90, 10)
+			},
+			active: "b",
+		},
+		{
+			name: "UnreachableActiveNode",
+			setup: func(mocks []*mockBeaconNode) {
+				mocks[0].nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unreachable"))
+				expectHealth(mocks[1], false, 90, 10)
+			},
+			active: "b",
+		},
+		{
+			name: "ActiveNodeWithinLag",
+			setup: func(mocks []*mockBeaconNode) {
+				expectHealth(mocks[0], false, 100, 10)
+				expectHealth(mocks[1], false, 100+maxHeadSlotLag, 50)
+			},
+			active: "a",
+		},
+		{
+			name: "ActiveNodeBehind",
+			setup: func(mocks []*mockBeaconNode) {
+				expectHealth(mocks[0], false, 100, 10)
+				expectHealth(mocks[1], false, 101+maxHeadSlotLag, 50)
+			},
+			active: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			failover, mocks := setupBeaconNodes(ctrl, 2)
+			tt.setup(mocks)
+			failover.checkHealth(context.Background())
+			if failover.current().endpoint != tt.active {
+				t.Errorf("Expected active beacon node %s, received %s", tt.active, failover.current().endpoint)
+			}
+		})
+	}
+}
+
+func TestBeaconNodeFailover_CheckHealth_TimesOutUnresponsiveNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer func(timeout time.Duration) {
+		healthCheckTimeout = timeout
+	}(healthCheckTimeout)
+	healthCheckTimeout = 10 * time.Millisecond
+	failover, mocks := setupBeaconNodes(ctrl, 2)
+
+	mocks[0].nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *ptypes.Empty, _ ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	expectHealth(mocks[1], false, 90, 10)
+
+	done := make(chan struct{})
+	go func() {
+		failover.checkHealth(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected health check of an unresponsive beacon node to time out")
+	}
+	if failover.current().endpoint != "b" {
+		t.Errorf("Expected active beacon node b, received %s", failover.current().endpoint)
+	}
+}
+
+func TestUpdateDuties_RefreshesAfterFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	failover, mocks := setupBeaconNodes(ctrl, 2)
+	duties := &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:   params.BeaconConfig().SlotsPerEpoch + 1,
+				CommitteeIndex: 1,
+				Committee:      []uint64{0, 1, 2, 3},
+				PublicKey:      []byte("testPubKey_1"),
+			},
+		},
+	}
+	v := validator{
+		keyManager:      testKeyManager,
+		validatorClient: &failoverValidatorClient{failover: failover},
+		beaconNodes:     failover,
+		duties:          duties,
+	}
+
+	expectHealth(mocks[0], true, 0, 0)
+	expectHealth(mocks[1], false, 0, 0)
+	failover.checkHealth(context.Background())
+
+	// The new beacon node must learn about the validator's subnets mid-epoch.
+	mocks[1].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(duties, nil).Times(2)
+	mocks[1].validatorClient.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any()).Return(&ptypes.Empty{}, nil)
+	if err := v.UpdateDuties(context.Background(), params.BeaconConfig().SlotsPerEpoch+1); err != nil {
+		t.Fatalf("Could not update duties: %v", err)
+	}
+}
+
+func TestUpdateDuties_KeepsDutiesWhenRefreshAfterFailoverFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	failover, mocks := setupBeaconNodes(ctrl, 2)
+	duties := &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{{AttesterSlot: params.BeaconConfig().SlotsPerEpoch + 1}},
+	}
+	v := validator{
+		keyManager:      testKeyManager,
+		validatorClient: &failoverValidatorClient{failover: failover},
+		beaconNodes:     failover,
+		duties:          duties,
+	}
+
+	mocks[0].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	mocks[1].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	if err := v.UpdateDuties(context.Background(), params.BeaconConfig().SlotsPerEpoch); err == nil {
+		t.Fatal("Expected error when every beacon node is unreachable at the epoch start")
+	}
+	if v.duties != nil {
+		t.Error("Expected duties to be cleared at the epoch start")
+	}
+
+	v.duties = duties
+	mocks[0].validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, errors.New("not ready"))
+	if err := v.UpdateDuties(context.Background(), params.BeaconConfig().SlotsPerEpoch+2); err != nil {
+		t.Fatalf("Expected refresh failure after failover to be tolerated, received %v", err)
+	}
+	if v.duties != duties {
+		t.Error("Expected existing duties to be kept after a failed refresh")
+	}
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errDoppelganger is returned when another validator client is found signing with our keys.
+var errDoppelganger = errors.New("validator keys are in use by another client, stop it before restarting")
+
+// CheckDoppelganger watches the chain for attestations and blocks from our validators for
+// the configured number of epochs after launch, before anything is signed. Any activity
+// from our keys during that time must come from another validator client using the same
+// keys, and an error is returned so the validator shuts down instead of getting slashed.
+//
+// The epoch of the launch is skipped, as this client may have signed in it before a restart.
+// Signing starts an epoch after the window, once the attestations of its last epoch are included.
+func (v *validator) CheckDoppelganger(ctx context.Context) error {
+	if v.doppelgangerEpochs == 0 {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelganger")
+	defer span.End()
+
+	pubKeys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating keys")
+	}
+	return v.watchForDoppelgangers(ctx, pubKeys, v.NextSlot())
+}
+
+// checkDoppelgangerKeys runs the same check as CheckDoppelganger for the given keys, while
+// the validator runs. It uses its own slot ticker so the slots of the validator are left
+// to the runner.
+func (v *validator) checkDoppelgangerKeys(ctx context.Context, pubKeys [][48]byte) error {
+	if v.doppelgangerEpochs == 0 {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.checkDoppelgangerKeys")
+	defer span.End()
+
+	ticker := slotutil.GetSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	return v.watchForDoppelgangers(ctx, pubKeys, ticker.C())
+}
+
+// watchForDoppelgangers checks every epoch of the detection window for activity from the
+// given keys, once the slots received from nextSlot show the epoch is over.
+func (v *validator) watchForDoppelgangers(ctx context.Context, pubKeys [][48]byte, nextSlot <-chan uint64) error {
+	indices, err := v.validatorIndices(ctx, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not get validator indices")
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	launchEpoch := helpers.SlotToEpoch(slotutil.SlotsSinceGenesis(time.Unix(int64(v.genesisTime), 0)))
+	firstEpoch := launchEpoch + 1
+	lastEpoch := launchEpoch + v.doppelgangerEpochs
+	log.WithFields(logrus.Fields{
+		"epochs":     v.doppelgangerEpochs,
+		"firstEpoch": firstEpoch,
+		"lastEpoch":  lastEpoch,
+	}).Info("Watching the chain for other clients using our keys before signing")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case slot := <-nextSlot:
+			epoch := helpers.SlotToEpoch(slot)
+			// Check every epoch of the detection window once it is over, and the epoch after
+			// it as attestations are included up to an epoch after their target.
+			if !helpers.IsEpochStart(slot) || epoch <= firstEpoch {
+				continue
+			}
+			if err := v.checkDoppelgangerEpoch(ctx, epoch-1, firstEpoch, lastEpoch, indices); err != nil {
+				return err
+			}
+			if epoch > lastEpoch+1 {
+				log.Info("No other client found using our keys, starting to sign")
+				return nil
+			}
+		}
+	}
+}
+
+// checkDoppelgangerEpoch looks for our validators in the attestations and blocks included
+// in the given epoch. Only attestations targeting, and blocks proposed in, an epoch from
+// firstEpoch to lastEpoch are considered.
+func (v *validator) checkDoppelgangerEpoch(ctx context.Context, epoch uint64, firstEpoch uint64, lastEpoch uint64, indices map[uint64][48]byte) error {
+	found := func(index uint64, what string, inEpoch uint64) error {
+		pubKey := indices[index]
+		return errors.Wrapf(errDoppelganger, "found %s of validator %d with public key %#x in epoch %d",
+			what, index, bytesutil.Trunc(pubKey[:]), inEpoch)
+	}
+
+	attReq := &ethpb.ListIndexedAttestationsRequest{
+		QueryFilter: &ethpb.ListIndexedAttestationsRequest_Epoch{Epoch: epoch},
+	}
+	for seen := 0; ; {
+		res, err := v.beaconClient.ListIndexedAttestations(ctx, attReq)
+		if err != nil {
+			return errors.Wrap(err, "could not list indexed attestations")
+		}
+		for _, att := range res.IndexedAttestations {
+			if att.Data == nil || att.Data.Target == nil || att.Data.Target.Epoch < firstEpoch || att.Data.Target.Epoch > lastEpoch {
+				continue
+			}
+			for _, index := range att.AttestingIndices {
+				if _, ok := indices[index]; ok {
+					return found(index, "an attestation", att.Data.Target.Epoch)
+				}
+			}
+		}
+		seen += len(res.IndexedAttestations)
+		if len(res.IndexedAttestations) == 0 || seen >= int(res.TotalSize) || res.NextPageToken == "" {
+			break
+		}
+		attReq.PageToken = res.NextPageToken
+	}
+
+	if epoch < firstEpoch || epoch > lastEpoch {
+		return nil
+	}
+	blockReq := &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Epoch{Epoch: epoch},
+	}
+	for seen := 0; ; {
+		res, err := v.beaconClient.ListBlocks(ctx, blockReq)
+		if err != nil {
+			return errors.Wrap(err, "could not list blocks")
+		}
+		for _, ctr := range res.BlockContainers {
+			if ctr.Block == nil || ctr.Block.Block == nil {
+				continue
+			}
+			if _, ok := indices[ctr.Block.Block.ProposerIndex]; ok {
+				return found(ctr.Block.Block.ProposerIndex, fmt.Sprintf("a block at slot %d", ctr.Block.Block.Slot), epoch)
+			}
+		}
+		seen += len(res.BlockContainers)
+		if len(res.BlockContainers) == 0 || seen >= int(res.TotalSize) || res.NextPageToken == "" {
+			break
+		}
+		blockReq.PageToken = res.NextPageToken
+	}
+	return nil
+}
+
+// validatorIndices maps the indices of the given validators known to the beacon node to
+// their public keys. Keys without an index cannot have signed anything and are skipped.
+func (v *validator) validatorIndices(ctx context.Context, pubKeys [][48]byte) (map[uint64][48]byte, error) {
+	indices := make(map[uint64][48]byte, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		res, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
+		if err != nil {
+			if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+				continue
+			}
+			return nil, err
+		}
+		indices[res.Index] = pubKey
+	}
+	return indices, nil
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckDoppelganger_Disabled(t *testing.T) {
+	v := validator{keyManager: testKeyManager}
+	if err := v.CheckDoppelganger(context.Background()); err != nil {
+		t.Errorf("Expected no error when detection is disabled, received %v", err)
+	}
+}
+
+func TestValidatorIndices_SkipsUnknownKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v := validator{keyManager: testKeyManager, validatorClient: client}
+
+	pubKeys, err := testKeyManager.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.EXPECT().ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKeys[0][:]}).
+		Return(&ethpb.ValidatorIndexResponse{Index: 7}, nil)
+	client.EXPECT().ValidatorIndex(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.NotFound, "not found")).Times(len(pubKeys) - 1)
+
+	indices, err := v.validatorIndices(context.Background(), pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 1 || indices[7] != pubKeys[0] {
+		t.Errorf("Unexpected validator indices %v", indices)
+	}
+}
+
+func TestCheckDoppelgangerEpoch_NoActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	v := validator{beaconClient: client}
+	indices := map[uint64][48]byte{3: {'a'}}
+
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(&ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			{AttestingIndices: []uint64{1, 2}, Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 5}}},
+			// Attestations targeting epochs outside of the window are ignored.
+			{AttestingIndices: []uint64{3}, Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 4}}},
+			{AttestingIndices: []uint64{3}, Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 7}}},
+		},
+		TotalSize: 3,
+	}, nil)
+	client.EXPECT().ListBlocks(gomock.Any(), gomock.Any()).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{
+			{Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 5 * params.BeaconConfig().SlotsPerEpoch, ProposerIndex: 2}}},
+		},
+		TotalSize: 1,
+	}, nil)
+
+	if err := v.checkDoppelgangerEpoch(context.Background(), 5, 5, 6, indices); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestCheckDoppelgangerEpoch_AttestationFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	v := validator{beaconClient: client}
+	indices := map[uint64][48]byte{3: {'a'}}
+
+	first := &ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			{AttestingIndices: []uint64{1}, Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 5}}},
+		},
+		TotalSize:     2,
+		NextPageToken: "1",
+	}
+	second := &ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			{AttestingIndices: []uint64{2, 3}, Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 5}}},
+		},
+		TotalSize: 2,
+	}
+	gomock.InOrder(
+		client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(first, nil),
+		client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(second, nil),
+	)
+
+	err := v.checkDoppelgangerEpoch(context.Background(), 5, 5, 6, indices)
+	if !errors.Is(err, errDoppelganger) {
+		t.Fatalf("Expected %v, received %v", errDoppelganger, err)
+	}
+	if !strings.Contains(err.Error(), "validator 3") {
+		t.Errorf("Expected the error to name the validator, received %v", err)
+	}
+}
+
+func TestCheckDoppelgangerEpoch_BlockFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	v := validator{beaconClient: client}
+	indices := map[uint64][48]byte{3: {'a'}}
+
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(&ethpb.ListIndexedAttestationsResponse{}, nil)
+	client.EXPECT().ListBlocks(gomock.Any(), gomock.Any()).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{
+			{Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 5*params.BeaconConfig().SlotsPerEpoch + 1, ProposerIndex: 3}}},
+		},
+		TotalSize: 1,
+	}, nil)
+
+	if err := v.checkDoppelgangerEpoch(context.Background(), 5, 5, 6, indices); !errors.Is(err, errDoppelganger) {
+		t.Errorf("Expected %v, received %v", errDoppelganger, err)
+	}
+}
+
+func TestCheckDoppelgangerEpoch_AfterWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	v := validator{beaconClient: client}
+	indices := map[uint64][48]byte{3: {'a'}}
+
+	// Attestations targeting the last epoch of the window may be included in the next epoch,
+	// while the blocks of that epoch are not checked.
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), &ethpb.ListIndexedAttestationsRequest{
+		QueryFilter: &ethpb.ListIndexedAttestationsRequest_Epoch{Epoch: 7},
+	}).Return(&ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			{AttestingIndices: []uint64{3}, Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 6}}},
+		},
+		TotalSize: 1,
+	}, nil)
+
+	err := v.checkDoppelgangerEpoch(context.Background(), 7, 5, 6, indices)
+	if !errors.Is(err, errDoppelganger) {
+		t.Fatalf("Expected %v, received %v", errDoppelganger, err)
+	}
+	if !strings.Contains(err.Error(), "in epoch 6") {
+		t.Errorf("Expected the error to name the target epoch, received %v", err)
+	}
+}
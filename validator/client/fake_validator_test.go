@@ -13,6 +13,7 @@ type fakeValidator struct {
 	WaitForChainStartCalled          bool
 	WaitForSyncCalled                bool
 	WaitForSyncedCalled              bool
+	CheckDoppelgangerCalled          bool
 	NextSlotCalled                   bool
 	CanonicalHeadSlotCalled          bool
 	UpdateDutiesCalled               bool
@@ -47,6 +48,11 @@ func (fv *fakeValidator) WaitForActivation(_ context.Context) error {
 	return nil
 }
 
+func (fv *fakeValidator) CheckDoppelganger(_ context.Context) error {
+	fv.CheckDoppelgangerCalled = true
+	return nil
+}
+
 func (fv *fakeValidator) WaitForSync(_ context.Context) error {
 	fv.WaitForSyncCalled = true
 	return nil
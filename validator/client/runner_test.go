@@ -197,3 +197,11 @@ func TestBothProposesAndAttests_NextSlot(t *testing.T) {
 		t.Errorf("ProposeBlock was called with wrong arg. Want=%d, got=%d", slot, v.AttestToBlockHeadArg1)
 	}
 }
+
+func TestCancelledContext_ChecksDoppelganger(t *testing.T) {
+	v := &fakeValidator{}
+	run(cancelledContext(), v)
+	if !v.CheckDoppelgangerCalled {
+		t.Error("Expected CheckDoppelganger() to be called")
+	}
+}
@@ -5,6 +5,7 @@ package client
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/ristretto"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -38,9 +39,10 @@ type ValidatorService struct {
 	ctx                  context.Context
 	cancel               context.CancelFunc
 	validator            Validator
+	db                   *db.Store
 	graffiti             []byte
-	conn                 *grpc.ClientConn
-	endpoint             string
+	beaconNodes          *beaconNodeFailover
+	endpoints            []string
 	withCert             string
 	dataDir              string
 	keyManager           keymanager.KeyManager
@@ -50,11 +52,12 @@ type ValidatorService struct {
 	grpcRetries          uint
 	grpcHeaders          []string
 	protector            slashingprotection.Protector
+	doppelgangerEpochs   uint64
 }
 
 // Config for the validator service.
 type Config struct {
-	Endpoint                   string
+	Endpoint                   string // Comma-separated list of beacon node endpoints.
 	DataDir                    string
 	CertFlag                   string
 	GraffitiFlag               string
@@ -65,6 +68,7 @@ type Config struct {
 	GrpcRetriesFlag            uint
 	GrpcHeadersFlag            string
 	Protector                  slashingprotection.Protector
+	DoppelgangerEpochs         uint64
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -74,7 +78,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 	return &ValidatorService{
 		ctx:                  ctx,
 		cancel:               cancel,
-		endpoint:             cfg.Endpoint,
+		endpoints:            splitEndpoints(cfg.Endpoint),
 		withCert:             cfg.CertFlag,
 		dataDir:              cfg.DataDir,
 		graffiti:             []byte(cfg.GraffitiFlag),
@@ -85,6 +89,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		grpcRetries:          cfg.GrpcRetriesFlag,
 		grpcHeaders:          strings.Split(cfg.GrpcHeadersFlag, ","),
 		protector:            cfg.Protector,
+		doppelgangerEpochs:   cfg.DoppelgangerEpochs,
 	}, nil
 }
 
@@ -101,12 +106,25 @@ func (v *ValidatorService) Start() {
 	if dialOpts == nil {
 		return
 	}
-	conn, err := grpc.DialContext(v.ctx, v.endpoint, dialOpts...)
-	if err != nil {
-		log.Errorf("Could not dial endpoint: %s, %v", v.endpoint, err)
+	if len(v.endpoints) == 0 {
+		log.Error("No beacon node endpoints provided")
+		return
+	}
+	nodes := make([]*beaconNode, 0, len(v.endpoints))
+	for _, endpoint := range v.endpoints {
+		conn, err := grpc.DialContext(v.ctx, endpoint, dialOpts...)
+		if err != nil {
+			log.Errorf("Could not dial endpoint: %s, %v", endpoint, err)
+			continue
+		}
+		nodes = append(nodes, newBeaconNode(endpoint, conn))
+	}
+	if len(nodes) == 0 {
+		log.Error("Could not dial any beacon node endpoint")
 		return
 	}
-	log.Debug("Successfully started gRPC connection")
+	v.beaconNodes = newBeaconNodeFailover(nodes)
+	log.WithField("endpoints", v.endpoints).Debug("Successfully started gRPC connection")
 
 	pubkeys, err := v.keyManager.FetchValidatingKeys()
 	if err != nil {
@@ -119,8 +137,24 @@ func (v *ValidatorService) Start() {
 		log.Errorf("Could not initialize db: %v", err)
 		return
 	}
+	if managed, ok := v.keyManager.(keymanager.Managed); ok {
+		// Reload the keys disabled and added while previously running, so the keys added then are
+		// validated with, and checked for doppelgangers, like the others.
+		if err := managed.Restore(v.ctx, valDB); err != nil {
+			log.Errorf("Could not restore managed keys: %v", err)
+			return
+		}
+		pubkeys, err = v.keyManager.FetchValidatingKeys()
+		if err != nil {
+			log.Errorf("Could not get validating keys: %v", err)
+			return
+		}
+		if err := valDB.InitializePublicKeys(v.ctx, pubkeys); err != nil {
+			log.Errorf("Could not initialize db: %v", err)
+			return
+		}
+	}
 
-	v.conn = conn
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1920, // number of keys to track.
 		MaxCost:     192,  // maximum cost of cache, 1 item = 1 cost.
@@ -136,11 +170,11 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
-	v.validator = &validator{
+	val := &validator{
 		db:                             valDB,
-		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
-		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
-		node:                           ethpb.NewNodeClient(v.conn),
+		validatorClient:                nodes[0].validatorClient,
+		beaconClient:                   nodes[0].beaconClient,
+		node:                           nodes[0].nodeClient,
 		keyManager:                     v.keyManager,
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
@@ -150,7 +184,19 @@ func (v *ValidatorService) Start() {
 		domainDataCache:                cache,
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
 		protector:                      v.protector,
+		doppelgangerEpochs:             v.doppelgangerEpochs,
+	}
+	if len(nodes) > 1 {
+		// Route every request through the failover and keep checking the health of all nodes.
+		val.validatorClient = &failoverValidatorClient{failover: v.beaconNodes}
+		val.beaconClient = &failoverBeaconChainClient{failover: v.beaconNodes}
+		val.node = &failoverNodeClient{failover: v.beaconNodes}
+		val.beaconNodes = v.beaconNodes
+		v.beaconNodes.checkHealth(v.ctx)
+		go v.beaconNodes.run(v.ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
 	}
+	v.validator = val
+	v.db = valDB
 	go run(v.ctx, v.validator)
 }
 
@@ -158,8 +204,8 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
-	if v.conn != nil {
-		return v.conn.Close()
+	if v.beaconNodes != nil {
+		return v.beaconNodes.close()
 	}
 	return nil
 }
@@ -168,12 +214,48 @@ func (v *ValidatorService) Stop() error {
 //
 // WIP - not done.
 func (v *ValidatorService) Status() error {
-	if v.conn == nil {
+	if v.beaconNodes == nil {
 		return errors.New("no connection to beacon RPC")
 	}
 	return nil
 }
 
+// Duties returns the duties of the current epoch fetched by the validator, or nil if they
+// were not fetched yet.
+func (v *ValidatorService) Duties() []*ethpb.DutiesResponse_Duty {
+	val, ok := v.validator.(*validator)
+	if !ok {
+		return nil
+	}
+	return val.currentDuties()
+}
+
+// ValidatorDB returns the database of the validator, or nil if the service did not start.
+func (v *ValidatorService) ValidatorDB() *db.Store {
+	return v.db
+}
+
+// CheckDoppelgangerKeys watches the chain for other clients using the given keys, as is done
+// for all keys when the validator starts. It is used for keys added while the validator runs.
+func (v *ValidatorService) CheckDoppelgangerKeys(ctx context.Context, pubKeys [][48]byte) error {
+	val, ok := v.validator.(*validator)
+	if !ok {
+		return errors.New("validator not started")
+	}
+	return val.checkDoppelgangerKeys(ctx, pubKeys)
+}
+
+// splitEndpoints parses a comma-separated list of beacon node endpoints.
+func splitEndpoints(endpoints string) []string {
+	var result []string
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			result = append(result, endpoint)
+		}
+	}
+	return result
+}
+
 // signObject signs a generic object, with protection if available.
 func (v *validator) signObject(pubKey [48]byte, object interface{}, domain []byte) (*bls.Signature, error) {
 	if protectingKeymanager, supported := v.keyManager.(keymanager.ProtectingKeyManager); supported {
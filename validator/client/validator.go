@@ -48,10 +48,12 @@ type validator struct {
 	ticker                             *slotutil.SlotTicker
 	db                                 *db.Store
 	duties                             *ethpb.DutiesResponse
+	dutiesLock                         sync.RWMutex
 	validatorClient                    ethpb.BeaconNodeValidatorClient
-	beaconClient                       ethpb.BeaconChainClient
+	beaconClient                       beaconChainClient
 	graffiti                           []byte
-	node                               ethpb.NodeClient
+	node                               nodeClient
+	beaconNodes                        *beaconNodeFailover
 	keyManager                         keymanager.KeyManager
 	prevBalance                        map[[48]byte]uint64
 	logValidatorBalances               bool
@@ -65,6 +67,7 @@ type validator struct {
 	attesterHistoryByPubKey            map[[48]byte]*slashpb.AttestationHistory
 	attesterHistoryByPubKeyLock        sync.RWMutex
 	protector                          slashingprotection.Protector
+	doppelgangerEpochs                 uint64
 }
 
 var validatorStatusesGaugeVec = promauto.NewGaugeVec(
@@ -315,8 +318,10 @@ func (v *validator) SlotDeadline(slot uint64) time.Time {
 // list of upcoming assignments needs to be updated. For example, at the
 // beginning of a new epoch.
 func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
-	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil {
-		// Do nothing if not epoch start AND assignments already exist.
+	switchedNode := v.beaconNodes != nil && v.beaconNodes.consumeSwitched()
+	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil && !switchedNode {
+		// Do nothing if not epoch start AND assignments already exist, unless the validator
+		// failed over to a different beacon node which must be told about our subnets.
 		return nil
 	}
 	// Set deadline to end of epoch.
@@ -337,12 +342,21 @@ func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
 	// If duties is nil it means we have had no prior duties and just started up.
 	resp, err := v.validatorClient.GetDuties(ctx, req)
 	if err != nil {
+		if switchedNode && v.duties != nil && slot%params.BeaconConfig().SlotsPerEpoch != 0 {
+			// Mid-epoch the duties we already hold are still current, keep performing them.
+			log.WithError(err).Warn("Could not refresh duties after switching beacon node")
+			return nil
+		}
+		v.dutiesLock.Lock()
 		v.duties = nil // Clear assignments so we know to retry the request.
+		v.dutiesLock.Unlock()
 		log.Error(err)
 		return err
 	}
 
+	v.dutiesLock.Lock()
 	v.duties = resp
+	v.dutiesLock.Unlock()
 	v.logDuties(slot, v.duties.Duties)
 	subscribeSlots := make([]uint64, 0, len(validatingKeys))
 	subscribeCommitteeIDs := make([]uint64, 0, len(validatingKeys))
@@ -413,6 +427,16 @@ func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
 	return err
 }
 
+// currentDuties returns the duties of the current epoch, or nil if they were not fetched yet.
+func (v *validator) currentDuties() []*ethpb.DutiesResponse_Duty {
+	v.dutiesLock.RLock()
+	defer v.dutiesLock.RUnlock()
+	if v.duties == nil {
+		return nil
+	}
+	return v.duties.Duties
+}
+
 // RolesAt slot returns the validator roles at the given slot. Returns nil if the
 // validator is known to not have a roles at the at slot. Returns UNKNOWN if the
 // validator assignments are unknown. Otherwise returns a valid validatorRole map.
@@ -4,16 +4,21 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	lru "github.com/hashicorp/golang-lru"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	kmtesting "github.com/prysmaticlabs/prysm/validator/keymanager/testing"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -342,3 +347,65 @@ func TestProposeBlock_BroadcastsBlock_WithGraffiti(t *testing.T) {
 		t.Errorf("Block was broadcast with the wrong graffiti field, wanted \"%v\", got \"%v\"", string(validator.graffiti), string(sentBlock.Block.Body.Graffiti))
 	}
 }
+
+func TestProposeBlock_RemoteHTTPKeyManager(t *testing.T) {
+	validator, m, finish := setup(t)
+	defer finish()
+
+	signer, err := kmtesting.NewRemoteSigner([]*bls.SecretKey{validatorKey.SecretKey})
+	if err != nil {
+		t.Fatalf("Could not start remote signer: %v", err)
+	}
+	defer func() {
+		if err := signer.Close(); err != nil {
+			t.Errorf("Could not close remote signer: %v", err)
+		}
+	}()
+	km, _, err := keymanager.NewRemoteHTTPWallet(signer.KeymanagerOpts(time.Second))
+	if err != nil {
+		t.Fatalf("Could not create key manager: %v", err)
+	}
+	validator.keyManager = km
+
+	randaoDomain := make([]byte, 32)
+	copy(randaoDomain, params.BeaconConfig().DomainRandao[:])
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: randaoDomain}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetBlock(
+		gomock.Any(), // ctx
+		gomock.Any(),
+	).Return(&ethpb.BeaconBlock{
+		Slot:       1,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		Body:       &ethpb.BeaconBlockBody{},
+	}, nil /*err*/)
+
+	proposerDomain := make([]byte, 32)
+	copy(proposerDomain, params.BeaconConfig().DomainBeaconProposer[:])
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: proposerDomain}, nil /*err*/)
+
+	var sentBlock *ethpb.SignedBeaconBlock
+	m.validatorClient.EXPECT().ProposeBlock(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.SignedBeaconBlock{}),
+	).DoAndReturn(func(_ context.Context, blk *ethpb.SignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+		sentBlock = blk
+		return &ethpb.ProposeResponse{}, nil
+	})
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+
+	if sentBlock == nil {
+		t.Fatal("Expected block to be proposed")
+	}
+	if err := helpers.VerifyBlockSigningRoot(sentBlock.Block, validatorPubKey[:], sentBlock.Signature, proposerDomain); err != nil {
+		t.Errorf("Could not verify remotely signed block: %v", err)
+	}
+}
@@ -82,6 +82,8 @@ func NewKVStore(dirPath string, pubKeys [][48]byte) (*Store, error) {
 			tx,
 			historicProposalsBucket,
 			historicAttestationsBucket,
+			disabledKeysBucket,
+			addedKeysBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -18,8 +18,15 @@ type ValidatorDB interface {
 	ProposalHistoryForEpoch(ctx context.Context, publicKey []byte, epoch uint64) (bitfield.Bitlist, error)
 	SaveProposalHistoryForEpoch(ctx context.Context, publicKey []byte, epoch uint64, history bitfield.Bitlist) error
 	DeleteProposalHistory(ctx context.Context, publicKey []byte) error
+	LatestProposalSlot(ctx context.Context, publicKey []byte) (uint64, bool, error)
+	InitializePublicKeys(ctx context.Context, pubKeys [][48]byte) error
 	// Attester protection related methods.
 	AttestationHistoryForPubKeys(ctx context.Context, publicKeys [][48]byte) (map[[48]byte]*slashpb.AttestationHistory, error)
 	SaveAttestationHistoryForPubKeys(ctx context.Context, historyByPubKey map[[48]byte]*slashpb.AttestationHistory) error
 	DeleteAttestationHistory(ctx context.Context, publicKey []byte) error
+	// Managed keys related methods.
+	DisabledPublicKeys(ctx context.Context) ([][48]byte, error)
+	SaveKeyDisabled(ctx context.Context, pubKey [48]byte, disabled bool) error
+	AddedKeystores(ctx context.Context) ([][]byte, error)
+	SaveAddedKeystore(ctx context.Context, pubKey [48]byte, keystore []byte) error
 }
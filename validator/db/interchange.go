@@ -0,0 +1,343 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/wealdtech/go-bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// InterchangeFormatVersion is the version of the slashing protection interchange
+// format (EIP-3076) produced and accepted by this package.
+const InterchangeFormatVersion = "5"
+
+// Interchange is the client-neutral slashing protection interchange document. It lists,
+// for every validator public key, the slots of all signed blocks and the source/target
+// epochs of all signed attestations. All numbers are encoded as decimal strings and all
+// byte values as 0x-prefixed hex strings, as specified by EIP-3076:
+//
+//	{
+//	  "metadata": {
+//	    "interchange_format_version": "5",
+//	    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+//	  },
+//	  "data": [
+//	    {
+//	      "pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+//	      "signed_blocks": [{"slot": "81952"}],
+//	      "signed_attestations": [{"source_epoch": "2290", "target_epoch": "3007"}]
+//	    }
+//	  ]
+//	}
+type Interchange struct {
+	Metadata *InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata identifies the format version and the chain an interchange file belongs to.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData holds the signing history of a single validator public key.
+type InterchangeData struct {
+	PubKey             string                    `json:"pubkey"`
+	SignedBlocks       []*InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a block signed by a validator, identified by its slot.
+type InterchangeBlock struct {
+	Slot string `json:"slot"`
+}
+
+// InterchangeAttestation is an attestation signed by a validator, identified by its source and target epochs.
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+}
+
+// ExportInterchange builds an interchange document from the attestation and proposal history
+// of every public key stored in the database, tagged with the given genesis validators root.
+func (db *Store) ExportInterchange(ctx context.Context, genesisValidatorsRoot []byte) (*Interchange, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.ExportInterchange")
+	defer span.End()
+
+	if len(genesisValidatorsRoot) != 32 {
+		return nil, fmt.Errorf("genesis validators root must be 32 bytes, received %d", len(genesisValidatorsRoot))
+	}
+	dataByKey := make(map[string]*InterchangeData)
+	entryFor := func(pubKey []byte) *InterchangeData {
+		key := fmt.Sprintf("%#x", pubKey)
+		if _, ok := dataByKey[key]; !ok {
+			dataByKey[key] = &InterchangeData{
+				PubKey:             key,
+				SignedBlocks:       []*InterchangeBlock{},
+				SignedAttestations: []*InterchangeAttestation{},
+			}
+		}
+		return dataByKey[key]
+	}
+
+	err := db.view(func(tx *bolt.Tx) error {
+		proposalsBucket := tx.Bucket(historicProposalsBucket)
+		if err := proposalsBucket.ForEach(func(pubKey, _ []byte) error {
+			valBucket := proposalsBucket.Bucket(pubKey)
+			if valBucket == nil {
+				return nil
+			}
+			entry := entryFor(pubKey)
+			return valBucket.ForEach(func(epochKey, slotBits []byte) error {
+				if len(slotBits) == 0 {
+					return nil
+				}
+				epoch := binary.LittleEndian.Uint64(epochKey)
+				bits := bitfield.Bitlist(slotBits)
+				for i := uint64(0); i < bits.Len(); i++ {
+					if bits.BitAt(i) {
+						slot := epoch*params.BeaconConfig().SlotsPerEpoch + i
+						entry.SignedBlocks = append(entry.SignedBlocks, &InterchangeBlock{
+							Slot: strconv.FormatUint(slot, 10),
+						})
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return errors.Wrap(err, "could not export proposal history")
+		}
+
+		attestationsBucket := tx.Bucket(historicAttestationsBucket)
+		return attestationsBucket.ForEach(func(pubKey, enc []byte) error {
+			history, err := unmarshalAttestationHistory(enc)
+			if err != nil {
+				return err
+			}
+			entry := entryFor(pubKey)
+			for _, att := range signedAttestationsFromHistory(history) {
+				entry.SignedAttestations = append(entry.SignedAttestations, &InterchangeAttestation{
+					SourceEpoch: strconv.FormatUint(att[0], 10),
+					TargetEpoch: strconv.FormatUint(att[1], 10),
+				})
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(dataByKey))
+	for key := range dataByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	data := make([]*InterchangeData, 0, len(keys))
+	for _, key := range keys {
+		data = append(data, dataByKey[key])
+	}
+	return &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", genesisValidatorsRoot),
+		},
+		Data: data,
+	}, nil
+}
+
+// ImportInterchange merges an interchange document into the database. The document must belong
+// to the chain identified by the given genesis validators root. Imported history never relaxes
+// the existing protection: signed block slots are unioned with the stored proposal history and
+// signed attestations are added to the stored attestation history. Votes with different source
+// epochs for the same target epoch cannot both be kept, so the import is refused when it holds any.
+// All changes are written in a single transaction.
+func (db *Store) ImportInterchange(ctx context.Context, interchange *Interchange, genesisValidatorsRoot []byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.ImportInterchange")
+	defer span.End()
+
+	if interchange == nil || interchange.Metadata == nil {
+		return errors.New("interchange file is missing metadata")
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf(
+			"unsupported interchange format version %q, expected %q",
+			interchange.Metadata.InterchangeFormatVersion,
+			InterchangeFormatVersion,
+		)
+	}
+	fileRoot, err := decodeHexString(interchange.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not decode genesis validators root")
+	}
+	if !bytes.Equal(fileRoot, genesisValidatorsRoot) {
+		return fmt.Errorf(
+			"interchange genesis validators root %#x does not match expected %#x",
+			fileRoot,
+			genesisValidatorsRoot,
+		)
+	}
+
+	return db.update(func(tx *bolt.Tx) error {
+		proposalsBucket := tx.Bucket(historicProposalsBucket)
+		attestationsBucket := tx.Bucket(historicAttestationsBucket)
+		for _, entry := range interchange.Data {
+			pubKey, err := decodeHexString(entry.PubKey)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode public key %s", entry.PubKey)
+			}
+			if len(pubKey) != 48 {
+				return fmt.Errorf("public key %s must be 48 bytes, received %d", entry.PubKey, len(pubKey))
+			}
+			if err := importSignedBlocks(proposalsBucket, pubKey, entry.SignedBlocks); err != nil {
+				return err
+			}
+			if err := importSignedAttestations(attestationsBucket, pubKey, entry.SignedAttestations); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func importSignedBlocks(proposalsBucket *bolt.Bucket, pubKey []byte, blocks []*InterchangeBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	valBucket, err := proposalsBucket.CreateBucketIfNotExists(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to create proposal history bucket")
+	}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	newestEpoch := uint64(0)
+	for _, block := range blocks {
+		slot, err := strconv.ParseUint(block.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse signed block slot %q for public key %#x", block.Slot, pubKey)
+		}
+		epoch := slot / slotsPerEpoch
+		slotBits := bitfield.NewBitlist(slotsPerEpoch)
+		if enc := valBucket.Get(bytesutil.Bytes8(epoch)); len(enc) != 0 {
+			copy(slotBits, enc)
+		}
+		slotBits.SetBitAt(slot%slotsPerEpoch, true)
+		if err := valBucket.Put(bytesutil.Bytes8(epoch), slotBits); err != nil {
+			return err
+		}
+		if epoch > newestEpoch {
+			newestEpoch = epoch
+		}
+	}
+	return pruneProposalHistory(valBucket, newestEpoch)
+}
+
+func importSignedAttestations(attestationsBucket *bolt.Bucket, pubKey []byte, atts []*InterchangeAttestation) error {
+	if len(atts) == 0 {
+		return nil
+	}
+	history := &slashpb.AttestationHistory{
+		TargetToSource: map[uint64]uint64{0: params.BeaconConfig().FarFutureEpoch},
+	}
+	if enc := attestationsBucket.Get(pubKey); enc != nil {
+		var err error
+		history, err = unmarshalAttestationHistory(enc)
+		if err != nil {
+			return err
+		}
+	}
+	// Apply attestations in ascending target order so the history window only ever moves forward.
+	parsed := make([][2]uint64, 0, len(atts))
+	for _, att := range atts {
+		source, err := strconv.ParseUint(att.SourceEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse source epoch %q for public key %#x", att.SourceEpoch, pubKey)
+		}
+		target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse target epoch %q for public key %#x", att.TargetEpoch, pubKey)
+		}
+		if source > target {
+			return fmt.Errorf("signed attestation for public key %#x has source %d after target %d", pubKey, source, target)
+		}
+		parsed = append(parsed, [2]uint64{source, target})
+	}
+	sort.Slice(parsed, func(i, j int) bool {
+		return parsed[i][1] < parsed[j][1]
+	})
+	for _, att := range parsed {
+		if err := mergeAttestationIntoHistory(history, att[0], att[1]); err != nil {
+			return errors.Wrapf(err, "could not import signed attestations for public key %#x", pubKey)
+		}
+	}
+	enc, err := proto.Marshal(history)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode attestation history")
+	}
+	return attestationsBucket.Put(pubKey, enc)
+}
+
+// mergeAttestationIntoHistory records a (source, target) vote in the history. Votes older than the
+// history window are dropped as they are no longer checked. The history holds a single source per
+// target epoch, so a vote with another source than the one already recorded for its target cannot
+// be kept without losing the protection of one of them, and is refused.
+func mergeAttestationIntoHistory(history *slashpb.AttestationHistory, source uint64, target uint64) error {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	farFuture := params.BeaconConfig().FarFutureEpoch
+
+	if int(target) <= int(history.LatestEpochWritten)-int(wsPeriod) {
+		return nil
+	}
+	if target > history.LatestEpochWritten {
+		maxToWrite := history.LatestEpochWritten + wsPeriod
+		for i := history.LatestEpochWritten + 1; i < target && i <= maxToWrite; i++ {
+			history.TargetToSource[i%wsPeriod] = farFuture
+		}
+		history.LatestEpochWritten = target
+		history.TargetToSource[target%wsPeriod] = source
+		return nil
+	}
+	existing, ok := history.TargetToSource[target%wsPeriod]
+	if ok && existing != farFuture && existing != source {
+		return fmt.Errorf("conflicting source epochs %d and %d for target epoch %d", existing, source, target)
+	}
+	history.TargetToSource[target%wsPeriod] = source
+	return nil
+}
+
+// signedAttestationsFromHistory lists the (source, target) votes still held in the history
+// window, ordered by target epoch.
+func signedAttestationsFromHistory(history *slashpb.AttestationHistory) [][2]uint64 {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	farFuture := params.BeaconConfig().FarFutureEpoch
+
+	start := uint64(0)
+	if history.LatestEpochWritten >= wsPeriod {
+		start = history.LatestEpochWritten - wsPeriod + 1
+	}
+	var atts [][2]uint64
+	for target := start; target <= history.LatestEpochWritten; target++ {
+		source, ok := history.TargetToSource[target%wsPeriod]
+		if !ok || source == farFuture {
+			continue
+		}
+		atts = append(atts, [2]uint64{source, target})
+	}
+	return atts
+}
+
+func decodeHexString(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
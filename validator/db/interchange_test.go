@@ -0,0 +1,215 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+var testGenesisValidatorsRoot = []byte("genesis-validators-root-32-bytes")
+
+func TestExportImportInterchange_RoundTrip(t *testing.T) {
+	pubKey := [48]byte{1}
+	source := SetupDB(t, [][48]byte{pubKey})
+	ctx := context.Background()
+
+	slotBits := bitfield.NewBitlist(params.BeaconConfig().SlotsPerEpoch)
+	slotBits.SetBitAt(3, true)
+	if err := source.SaveProposalHistoryForEpoch(ctx, pubKey[:], 2, slotBits); err != nil {
+		t.Fatal(err)
+	}
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{0: farFuture, 1: 0, 2: farFuture, 3: 1},
+		LatestEpochWritten: 3,
+	}
+	if err := source.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}); err != nil {
+		t.Fatal(err)
+	}
+
+	interchange, err := source.ExportInterchange(ctx, testGenesisValidatorsRoot)
+	if err != nil {
+		t.Fatalf("Could not export interchange: %v", err)
+	}
+	if len(interchange.Data) != 1 {
+		t.Fatalf("Expected 1 public key in interchange, received %d", len(interchange.Data))
+	}
+	data := interchange.Data[0]
+	if data.PubKey != fmt.Sprintf("%#x", pubKey) {
+		t.Errorf("Unexpected public key %s", data.PubKey)
+	}
+	wantSlot := fmt.Sprintf("%d", 2*params.BeaconConfig().SlotsPerEpoch+3)
+	if len(data.SignedBlocks) != 1 || data.SignedBlocks[0].Slot != wantSlot {
+		t.Errorf("Expected signed block at slot %s, received %v", wantSlot, data.SignedBlocks)
+	}
+	if len(data.SignedAttestations) != 2 {
+		t.Fatalf("Expected 2 signed attestations, received %d", len(data.SignedAttestations))
+	}
+	if data.SignedAttestations[1].SourceEpoch != "1" || data.SignedAttestations[1].TargetEpoch != "3" {
+		t.Errorf("Unexpected signed attestation %v", data.SignedAttestations[1])
+	}
+
+	target := SetupDB(t, [][48]byte{})
+	if err := target.ImportInterchange(ctx, interchange, testGenesisValidatorsRoot); err != nil {
+		t.Fatalf("Could not import interchange: %v", err)
+	}
+	importedBits, err := target.ProposalHistoryForEpoch(ctx, pubKey[:], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !importedBits.BitAt(3) {
+		t.Error("Expected imported proposal history to mark slot 3 of epoch 2")
+	}
+	imported, err := target.AttestationHistoryForPubKeys(ctx, [][48]byte{pubKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported[pubKey].LatestEpochWritten != 3 {
+		t.Errorf("Expected latest epoch written 3, received %d", imported[pubKey].LatestEpochWritten)
+	}
+	if imported[pubKey].TargetToSource[1] != 0 || imported[pubKey].TargetToSource[3] != 1 {
+		t.Errorf("Unexpected imported attestation history %v", imported[pubKey].TargetToSource)
+	}
+	if imported[pubKey].TargetToSource[2] != farFuture {
+		t.Errorf("Expected target epoch 2 to be unattested, received %d", imported[pubKey].TargetToSource[2])
+	}
+}
+
+func TestImportInterchange_MergesHistory(t *testing.T) {
+	pubKey := [48]byte{2}
+	db := SetupDB(t, [][48]byte{pubKey})
+	ctx := context.Background()
+
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{0: farFuture, 1: farFuture, 2: farFuture, 3: 2, 4: farFuture, 5: 4},
+		LatestEpochWritten: 5,
+	}
+	if err := db.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}); err != nil {
+		t.Fatal(err)
+	}
+
+	interchange := &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", testGenesisValidatorsRoot),
+		},
+		Data: []*InterchangeData{
+			{
+				PubKey: fmt.Sprintf("%#x", pubKey),
+				SignedAttestations: []*InterchangeAttestation{
+					// Votes already in the history are kept.
+					{SourceEpoch: "2", TargetEpoch: "3"},
+					{SourceEpoch: "4", TargetEpoch: "5"},
+					// New target fills an unattested epoch.
+					{SourceEpoch: "0", TargetEpoch: "2"},
+				},
+			},
+		},
+	}
+	if err := db.ImportInterchange(ctx, interchange, testGenesisValidatorsRoot); err != nil {
+		t.Fatalf("Could not import interchange: %v", err)
+	}
+	merged, err := db.AttestationHistoryForPubKeys(ctx, [][48]byte{pubKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[uint64]uint64{2: 0, 3: 2, 5: 4}
+	for target, source := range want {
+		if merged[pubKey].TargetToSource[target] != source {
+			t.Errorf("Expected source %d for target %d, received %d", source, target, merged[pubKey].TargetToSource[target])
+		}
+	}
+	if merged[pubKey].LatestEpochWritten != 5 {
+		t.Errorf("Expected latest epoch written 5, received %d", merged[pubKey].LatestEpochWritten)
+	}
+}
+
+func TestImportInterchange_ConflictingSources(t *testing.T) {
+	pubKey := [48]byte{3}
+	db := SetupDB(t, [][48]byte{pubKey})
+	ctx := context.Background()
+
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{0: farFuture, 1: farFuture, 2: farFuture, 3: 2},
+		LatestEpochWritten: 3,
+	}
+	if err := db.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		atts []*InterchangeAttestation
+	}{
+		{
+			name: "conflicts with the stored history",
+			atts: []*InterchangeAttestation{{SourceEpoch: "1", TargetEpoch: "3"}},
+		},
+		{
+			name: "conflicts within the interchange",
+			atts: []*InterchangeAttestation{
+				{SourceEpoch: "4", TargetEpoch: "6"},
+				{SourceEpoch: "5", TargetEpoch: "6"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interchange := &Interchange{
+				Metadata: &InterchangeMetadata{
+					InterchangeFormatVersion: InterchangeFormatVersion,
+					GenesisValidatorsRoot:    fmt.Sprintf("%#x", testGenesisValidatorsRoot),
+				},
+				Data: []*InterchangeData{
+					{PubKey: fmt.Sprintf("%#x", pubKey), SignedAttestations: tt.atts},
+				},
+			}
+			err := db.ImportInterchange(ctx, interchange, testGenesisValidatorsRoot)
+			if err == nil || !strings.Contains(err.Error(), "conflicting source epochs") {
+				t.Errorf("Expected conflicting sources error, received %v", err)
+			}
+			stored, err := db.AttestationHistoryForPubKeys(ctx, [][48]byte{pubKey})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored[pubKey].LatestEpochWritten != 3 || stored[pubKey].TargetToSource[3] != 2 {
+				t.Errorf("Expected the history to be left unchanged, received %v", stored[pubKey])
+			}
+		})
+	}
+}
+
+func TestImportInterchange_GenesisValidatorsRootMismatch(t *testing.T) {
+	db := SetupDB(t, [][48]byte{})
+	interchange := &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", [32]byte{1}),
+		},
+	}
+	err := db.ImportInterchange(context.Background(), interchange, testGenesisValidatorsRoot)
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected genesis validators root mismatch error, received %v", err)
+	}
+}
+
+func TestImportInterchange_UnsupportedVersion(t *testing.T) {
+	db := SetupDB(t, [][48]byte{})
+	interchange := &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: "1",
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", testGenesisValidatorsRoot),
+		},
+	}
+	err := db.ImportInterchange(context.Background(), interchange, testGenesisValidatorsRoot)
+	if err == nil || !strings.Contains(err.Error(), "unsupported interchange format version") {
+		t.Errorf("Expected unsupported version error, received %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// DisabledPublicKeys returns the public keys disabled through the management API.
+func (db *Store) DisabledPublicKeys(ctx context.Context) ([][48]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.DisabledPublicKeys")
+	defer span.End()
+
+	var pubKeys [][48]byte
+	err := db.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(disabledKeysBucket).ForEach(func(k, _ []byte) error {
+			var pubKey [48]byte
+			copy(pubKey[:], k)
+			pubKeys = append(pubKeys, pubKey)
+			return nil
+		})
+	})
+	return pubKeys, err
+}
+
+// SaveKeyDisabled records whether the given public key is disabled.
+func (db *Store) SaveKeyDisabled(ctx context.Context, pubKey [48]byte, disabled bool) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.SaveKeyDisabled")
+	defer span.End()
+
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(disabledKeysBucket)
+		if !disabled {
+			return bucket.Delete(pubKey[:])
+		}
+		return bucket.Put(pubKey[:], []byte{1})
+	})
+}
+
+// AddedKeystores returns the encrypted keystores of the secret keys added through the management API.
+func (db *Store) AddedKeystores(ctx context.Context) ([][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.AddedKeystores")
+	defer span.End()
+
+	var keystores [][]byte
+	err := db.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(addedKeysBucket).ForEach(func(_, v []byte) error {
+			keystores = append(keystores, append([]byte{}, v...))
+			return nil
+		})
+	})
+	return keystores, err
+}
+
+// SaveAddedKeystore stores the encrypted keystore of a secret key added through the management API,
+// by its public key. The secret key itself is never stored.
+func (db *Store) SaveAddedKeystore(ctx context.Context, pubKey [48]byte, keystore []byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.SaveAddedKeystore")
+	defer span.End()
+
+	return db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(addedKeysBucket).Put(pubKey[:], keystore)
+	})
+}
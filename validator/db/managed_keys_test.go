@@ -0,0 +1,46 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestStore_DisabledPublicKeys(t *testing.T) {
+	ctx := context.Background()
+	db := SetupDB(t, [][48]byte{})
+
+	if err := db.SaveKeyDisabled(ctx, [48]byte{1}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveKeyDisabled(ctx, [48]byte{2}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveKeyDisabled(ctx, [48]byte{1}, false); err != nil {
+		t.Fatal(err)
+	}
+	pubKeys, err := db.DisabledPublicKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubKeys) != 1 || pubKeys[0] != [48]byte{2} {
+		t.Errorf("Expected only the disabled key, received %v", pubKeys)
+	}
+}
+
+func TestStore_AddedKeystores(t *testing.T) {
+	ctx := context.Background()
+	db := SetupDB(t, [][48]byte{})
+
+	keystore := []byte(`{"crypto":{}}`)
+	if err := db.SaveAddedKeystore(ctx, [48]byte{1}, keystore); err != nil {
+		t.Fatal(err)
+	}
+	keystores, err := db.AddedKeystores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keystores) != 1 || !bytes.Equal(keystores[0], keystore) {
+		t.Errorf("Unexpected added keystores %v", keystores)
+	}
+}
@@ -75,6 +75,49 @@ func (db *Store) DeleteProposalHistory(ctx context.Context, pubkey []byte) error
 	})
 }
 
+// LatestProposalSlot returns the slot of the latest proposal in the proposal history of the given
+// validator public key. The boolean is false if there is no proposal in the history of the validator.
+func (db *Store) LatestProposalSlot(ctx context.Context, publicKey []byte) (uint64, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.LatestProposalSlot")
+	defer span.End()
+
+	var latest uint64
+	var found bool
+	err := db.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historicProposalsBucket)
+		valBucket := bucket.Bucket(publicKey)
+		if valBucket == nil {
+			return fmt.Errorf("validator history empty for public key %#x", publicKey)
+		}
+		// Epochs are little endian encoded, so the keys are not sorted by epoch.
+		return valBucket.ForEach(func(k, v []byte) error {
+			epoch := binary.LittleEndian.Uint64(k)
+			slotBits := bitfield.Bitlist(v)
+			for i := slotBits.Len(); i > 0; i-- {
+				if !slotBits.BitAt(i - 1) {
+					continue
+				}
+				slot := epoch*params.BeaconConfig().SlotsPerEpoch + i - 1
+				if !found || slot > latest {
+					latest = slot
+					found = true
+				}
+				break
+			}
+			return nil
+		})
+	})
+	return latest, found, err
+}
+
+// InitializePublicKeys initializes the proposal history of the given validator public keys, so
+// keys added while the validator runs are protected like the ones it started with.
+func (db *Store) InitializePublicKeys(ctx context.Context, pubKeys [][48]byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.InitializePublicKeys")
+	defer span.End()
+	return db.initializeSubBuckets(pubKeys)
+}
+
 func pruneProposalHistory(valBucket *bolt.Bucket, newestEpoch uint64) error {
 	c := valBucket.Cursor()
 	for k, _ := c.First(); k != nil; k, _ = c.First() {
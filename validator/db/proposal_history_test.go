@@ -268,3 +268,42 @@ func TestDeleteProposalHistory_OK(t *testing.T) {
 		t.Fatalf("Unexpected error, received %v", err)
 	}
 }
+
+func TestLatestProposalSlot(t *testing.T) {
+	pubkey := [48]byte{4}
+	db := SetupDB(t, [][48]byte{pubkey})
+	ctx := context.Background()
+
+	if _, found, err := db.LatestProposalSlot(ctx, pubkey[:]); err != nil || found {
+		t.Fatalf("Expected no proposal in an empty history, received found %v and error %v", found, err)
+	}
+
+	// Bit 2 of epoch 256 is set, its key sorts before epoch 2 once little endian encoded.
+	if err := db.SaveProposalHistoryForEpoch(ctx, pubkey[:], 2, bitfield.Bitlist{0x05, 0x00, 0x00, 0x00, 0x01}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveProposalHistoryForEpoch(ctx, pubkey[:], 256, bitfield.Bitlist{0x04, 0x00, 0x00, 0x00, 0x01}); err != nil {
+		t.Fatal(err)
+	}
+	slot, found, err := db.LatestProposalSlot(ctx, pubkey[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 256*params.BeaconConfig().SlotsPerEpoch + 2
+	if !found || slot != want {
+		t.Errorf("Wanted latest proposal slot %d, received %d (found %v)", want, slot, found)
+	}
+}
+
+func TestInitializePublicKeys(t *testing.T) {
+	pubkey := [48]byte{5}
+	db := SetupDB(t, [][48]byte{})
+	ctx := context.Background()
+
+	if err := db.InitializePublicKeys(ctx, [][48]byte{pubkey}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ProposalHistoryForEpoch(ctx, pubkey[:], 0); err != nil {
+		t.Errorf("Expected the proposal history of the key to be initialized: %v", err)
+	}
+}
@@ -5,4 +5,8 @@ var (
 	historicProposalsBucket = []byte("proposal-history-bucket")
 	// Validator slashing protection from slashable attestations.
 	historicAttestationsBucket = []byte("attestation-history-bucket")
+	// Keys disabled through the management API.
+	disabledKeysBucket = []byte("disabled-keys-bucket")
+	// Secret keys added through the management API.
+	addedKeysBucket = []byte("added-keys-bucket")
 )
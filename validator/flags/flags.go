@@ -14,10 +14,10 @@ var (
 			"of validating keys may wish to disable granular prometheus metrics as it increases " +
 			"the data cardinality.",
 	}
-	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
+	// BeaconRPCProviderFlag defines the beacon node RPC endpoints.
 	BeaconRPCProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Usage: "Beacon node RPC provider endpoint. Accepts a comma-separated list of endpoints, in which case the validator routes duties to the healthiest beacon node and fails over when it becomes unavailable",
 		Value: "localhost:4000",
 	}
 	// CertFlag defines a flag for the node's TLS certificate.
@@ -41,6 +41,14 @@ var (
 		Name:  "disable-rewards-penalties-logging",
 		Usage: "Disable reward/penalty logging during cluster deployment",
 	}
+	// DoppelgangerDetectionEpochsFlag defines the number of epochs to watch for another validator
+	// client using our keys before signing anything.
+	DoppelgangerDetectionEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-detection-epochs",
+		Usage: "Number of epochs to watch the chain for attestations and blocks from our own keys " +
+			"before signing, shutting down if any are found. Disabled if 0",
+		Value: 0,
+	}
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
 		Name:  "graffiti",
@@ -61,7 +69,7 @@ var (
 	// KeyManager specifies the key manager to use.
 	KeyManager = &cli.StringFlag{
 		Name:  "keymanager",
-		Usage: "The keymanger to use (unencrypted, interop, keystore, wallet)",
+		Usage: "The keymanger to use (unencrypted, interop, keystore, wallet, remote, remote-http)",
 		Value: "",
 	}
 	// KeyManagerOpts specifies the key manager options.
@@ -81,11 +89,38 @@ var (
 		Usage: "Port used to listening and respond metrics for prometheus.",
 		Value: 8081,
 	}
+	// EnableManagementAPIFlag enables the local management API of the validator client.
+	EnableManagementAPIFlag = &cli.BoolFlag{
+		Name:  "enable-management-api",
+		Usage: "Enables the local HTTP API to list, enable, disable and add validator keys at runtime. Added keys are stored encrypted with the --password value",
+	}
+	// ManagementAPIHostFlag defines the host on which the management API listens.
+	ManagementAPIHostFlag = &cli.StringFlag{
+		Name:  "management-api-host",
+		Usage: "Host on which the management API listens",
+		Value: "127.0.0.1",
+	}
+	// ManagementAPIPortFlag defines the port on which the management API listens.
+	ManagementAPIPortFlag = &cli.IntFlag{
+		Name:  "management-api-port",
+		Usage: "Port on which the management API listens",
+		Value: 7500,
+	}
 	// PasswordFlag defines the password value for storing and retrieving validator private keys from the keystore.
 	PasswordFlag = &cli.StringFlag{
 		Name:  "password",
 		Usage: "String value of the password for your validator private keys",
 	}
+	// SlashingProtectionFileFlag defines the path of a slashing protection interchange JSON file to import or export.
+	SlashingProtectionFileFlag = &cli.StringFlag{
+		Name:  "slashing-protection-file",
+		Usage: "Path to a slashing protection interchange (EIP-3076) JSON file to import or export",
+	}
+	// GenesisValidatorsRootFlag defines the genesis validators root of the chain a slashing protection file belongs to.
+	GenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "genesis-validators-root",
+		Usage: "Hex encoded genesis validators root of the chain, used to tag and validate slashing protection files",
+	}
 	// SourceDirectories defines the locations of the source validator databases while managing validators.
 	SourceDirectories = &cli.StringFlag{
 		Name:  "source-dirs",
@@ -0,0 +1,307 @@
+package keymanager
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// ErrKeyExists is returned whenever a key is added to a key manager which already holds it.
+var ErrKeyExists = errors.New("key already exists")
+
+// ErrCannotAddKey is returned whenever a key is added to a key manager which does not hold its keys locally.
+var ErrCannotAddKey = errors.New("cannot add keys to a key manager signing remotely")
+
+// KeyStateDB persists the keys disabled and added while the validator runs, so they are kept
+// across restarts. Added keys are persisted as EIP-2335 keystores.
+type KeyStateDB interface {
+	DisabledPublicKeys(ctx context.Context) ([][48]byte, error)
+	SaveKeyDisabled(ctx context.Context, pubKey [48]byte, disabled bool) error
+	AddedKeystores(ctx context.Context) ([][]byte, error)
+	SaveAddedKeystore(ctx context.Context, pubKey [48]byte, keystore []byte) error
+}
+
+// Managed is a key manager whose keys can be disabled, enabled and added while the validator runs.
+type Managed interface {
+	KeyManager
+	// FetchAllKeys fetches every key of the key manager, including the disabled ones.
+	FetchAllKeys() ([][48]byte, error)
+	// IsEnabled returns true if the key is used to validate with.
+	IsEnabled(pubKey [48]byte) bool
+	// Enable starts validating with a disabled key.
+	Enable(pubKey [48]byte) error
+	// Disable stops validating with a key. Signing attempts with a disabled key are denied.
+	Disable(pubKey [48]byte) error
+	// AddKey adds a secret key to validate with. The key is pending, and is not used to validate
+	// with until it is activated.
+	AddKey(sk *bls.SecretKey) error
+	// ActivateKey starts validating with a pending key, once no other client was found using it.
+	ActivateKey(pubKey [48]byte) error
+	// Restore reloads the keys disabled and added in previous runs from the db, and persists the
+	// later changes to it.
+	Restore(ctx context.Context, db KeyStateDB) error
+}
+
+// NewManaged wraps a key manager so its keys can be managed at runtime. The keys added at runtime
+// are encrypted with the given passphrase when persisted. The slashing protection of the wrapped
+// key manager is kept if it provides one.
+func NewManaged(km KeyManager, passphrase string) Managed {
+	m := &managed{
+		km:         km,
+		passphrase: passphrase,
+		disabled:   make(map[[48]byte]bool),
+		added:      make(map[[48]byte]*bls.SecretKey),
+		pending:    make(map[[48]byte]bool),
+	}
+	if pkm, ok := km.(ProtectingKeyManager); ok {
+		return &protectingManaged{managed: m, pkm: pkm}
+	}
+	return m
+}
+
+type managed struct {
+	km         KeyManager
+	db         KeyStateDB
+	passphrase string
+	lock       sync.RWMutex
+	disabled   map[[48]byte]bool
+	added      map[[48]byte]*bls.SecretKey
+	pending    map[[48]byte]bool
+}
+
+// FetchValidatingKeys fetches the list of enabled public keys that should be used to validate with.
+func (m *managed) FetchValidatingKeys() ([][48]byte, error) {
+	keys, err := m.FetchAllKeys()
+	if err != nil {
+		return nil, err
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	enabled := make([][48]byte, 0, len(keys))
+	for _, key := range keys {
+		if !m.disabled[key] && !m.pending[key] {
+			enabled = append(enabled, key)
+		}
+	}
+	return enabled, nil
+}
+
+// FetchAllKeys fetches every key of the key manager, including the disabled ones.
+func (m *managed) FetchAllKeys() ([][48]byte, error) {
+	keys, err := m.km.FetchValidatingKeys()
+	if err != nil {
+		return nil, err
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for key := range m.added {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// IsEnabled returns true if the key is used to validate with.
+func (m *managed) IsEnabled(pubKey [48]byte) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return !m.disabled[pubKey] && !m.pending[pubKey]
+}
+
+// Enable starts validating with a disabled key.
+func (m *managed) Enable(pubKey [48]byte) error {
+	if err := m.checkKnown(pubKey); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.db != nil {
+		if err := m.db.SaveKeyDisabled(context.Background(), pubKey, false); err != nil {
+			return err
+		}
+	}
+	delete(m.disabled, pubKey)
+	return nil
+}
+
+// Disable stops validating with a key. Signing attempts with a disabled key are denied.
+func (m *managed) Disable(pubKey [48]byte) error {
+	if err := m.checkKnown(pubKey); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.db != nil {
+		if err := m.db.SaveKeyDisabled(context.Background(), pubKey, true); err != nil {
+			return err
+		}
+	}
+	m.disabled[pubKey] = true
+	return nil
+}
+
+// AddKey adds a secret key to validate with. The key is pending, and is not used to validate
+// with until it is activated.
+func (m *managed) AddKey(sk *bls.SecretKey) error {
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	if err := m.checkKnown(pubKey); err == nil {
+		return ErrKeyExists
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.db != nil {
+		keystore, err := m.encryptKey(sk)
+		if err != nil {
+			return err
+		}
+		if err := m.db.SaveAddedKeystore(context.Background(), pubKey, keystore); err != nil {
+			return err
+		}
+	}
+	m.added[pubKey] = sk
+	m.pending[pubKey] = true
+	return nil
+}
+
+// ActivateKey starts validating with a pending key, once no other client was found using it.
+func (m *managed) ActivateKey(pubKey [48]byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.added[pubKey]; !ok {
+		return ErrNoSuchKey
+	}
+	delete(m.pending, pubKey)
+	return nil
+}
+
+// Restore reloads the keys disabled and added in previous runs from the db, and persists the
+// later changes to it. Keys added in previous runs are not pending, as they are checked for
+// other clients using them when the validator starts.
+func (m *managed) Restore(ctx context.Context, db KeyStateDB) error {
+	disabled, err := db.DisabledPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	keystores, err := db.AddedKeystores(ctx)
+	if err != nil {
+		return err
+	}
+	keys, err := m.km.FetchValidatingKeys()
+	if err != nil {
+		return err
+	}
+	known := make(map[[48]byte]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, pubKey := range disabled {
+		m.disabled[pubKey] = true
+	}
+	for _, keystore := range keystores {
+		sk, err := m.decryptKey(keystore)
+		if err != nil {
+			return err
+		}
+		pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+		if !known[pubKey] {
+			m.added[pubKey] = sk
+		}
+	}
+	m.db = db
+	return nil
+}
+
+// Sign signs a message for the validator to broadcast, unless the key is disabled or pending.
+func (m *managed) Sign(pubKey [48]byte, root [32]byte) (*bls.Signature, error) {
+	m.lock.RLock()
+	if m.disabled[pubKey] || m.pending[pubKey] {
+		m.lock.RUnlock()
+		return nil, ErrDenied
+	}
+	sk, added := m.added[pubKey]
+	m.lock.RUnlock()
+	if added {
+		return sk.Sign(root[:]), nil
+	}
+	return m.km.Sign(pubKey, root)
+}
+
+// encryptKey encrypts a secret key with the passphrase of the key manager, returning the JSON
+// encoded crypto section of its EIP-2335 keystore.
+func (m *managed) encryptKey(sk *bls.SecretKey) ([]byte, error) {
+	keystore, err := keystorev4.New().Encrypt(sk.Marshal(), m.passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt added key")
+	}
+	return json.Marshal(keystore)
+}
+
+// decryptKey decrypts a secret key encrypted by encryptKey.
+func (m *managed) decryptKey(enc []byte) (*bls.SecretKey, error) {
+	keystore := make(map[string]interface{})
+	if err := json.Unmarshal(enc, &keystore); err != nil {
+		return nil, errors.Wrap(err, "could not decode keystore of added key")
+	}
+	skBytes, err := keystorev4.New().Decrypt(keystore, m.passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt keystore of added key")
+	}
+	return bls.SecretKeyFromBytes(skBytes)
+}
+
+// checkKnown returns ErrNoSuchKey if the key manager does not hold the key.
+func (m *managed) checkKnown(pubKey [48]byte) error {
+	keys, err := m.FetchAllKeys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key == pubKey {
+			return nil
+		}
+	}
+	return ErrNoSuchKey
+}
+
+// protectingManaged is a managed key manager passing through the slashing protection of the
+// key manager it wraps.
+type protectingManaged struct {
+	*managed
+	pkm ProtectingKeyManager
+}
+
+// AddKey is not supported, the keys of a protecting key manager are held remotely.
+func (m *protectingManaged) AddKey(_ *bls.SecretKey) error {
+	return ErrCannotAddKey
+}
+
+// SignGeneric signs a generic root, unless the key is disabled.
+func (m *protectingManaged) SignGeneric(pubKey [48]byte, root [32]byte, domain [32]byte) (*bls.Signature, error) {
+	if !m.IsEnabled(pubKey) {
+		return nil, ErrDenied
+	}
+	return m.pkm.SignGeneric(pubKey, root, domain)
+}
+
+// SignProposal signs a block proposal for the validator to broadcast, unless the key is disabled.
+func (m *protectingManaged) SignProposal(pubKey [48]byte, domain [32]byte, data *ethpb.BeaconBlockHeader) (*bls.Signature, error) {
+	if !m.IsEnabled(pubKey) {
+		return nil, ErrDenied
+	}
+	return m.pkm.SignProposal(pubKey, domain, data)
+}
+
+// SignAttestation signs an attestation for the validator to broadcast, unless the key is disabled.
+func (m *protectingManaged) SignAttestation(pubKey [48]byte, domain [32]byte, data *ethpb.AttestationData) (*bls.Signature, error) {
+	if !m.IsEnabled(pubKey) {
+		return nil, ErrDenied
+	}
+	return m.pkm.SignAttestation(pubKey, domain, data)
+}
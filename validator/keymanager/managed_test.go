@@ -0,0 +1,172 @@
+package keymanager_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+func TestManaged_DisableEnable(t *testing.T) {
+	sk := bls.RandKey()
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	km := keymanager.NewManaged(keymanager.NewDirect([]*bls.SecretKey{sk}), "password")
+
+	if err := km.Disable(pubKey); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("Expected no validating key, received %d", len(keys))
+	}
+	keys, err = km.FetchAllKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != pubKey {
+		t.Errorf("Expected the disabled key to be listed, received %v", keys)
+	}
+	if _, err := km.Sign(pubKey, [32]byte{}); err != keymanager.ErrDenied {
+		t.Errorf("Expected signing with a disabled key to be denied, received %v", err)
+	}
+
+	if err := km.Enable(pubKey); err != nil {
+		t.Fatal(err)
+	}
+	if !km.IsEnabled(pubKey) {
+		t.Error("Expected the key to be enabled")
+	}
+	if _, err := km.Sign(pubKey, [32]byte{}); err != nil {
+		t.Errorf("Could not sign with an enabled key: %v", err)
+	}
+
+	if err := km.Disable([48]byte{1}); err != keymanager.ErrNoSuchKey {
+		t.Errorf("Expected %v when disabling an unknown key, received %v", keymanager.ErrNoSuchKey, err)
+	}
+}
+
+func TestManaged_AddKey(t *testing.T) {
+	km := keymanager.NewManaged(keymanager.NewDirect(nil), "password")
+	sk := bls.RandKey()
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+
+	if err := km.AddKey(sk); err != nil {
+		t.Fatal(err)
+	}
+	if err := km.AddKey(sk); err != keymanager.ErrKeyExists {
+		t.Errorf("Expected %v when adding a key twice, received %v", keymanager.ErrKeyExists, err)
+	}
+	keys, err := km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("Expected the added key to be pending, received %v", keys)
+	}
+	if _, err := km.Sign(pubKey, [32]byte{}); err != keymanager.ErrDenied {
+		t.Errorf("Expected signing with a pending key to be denied, received %v", err)
+	}
+
+	if err := km.ActivateKey(pubKey); err != nil {
+		t.Fatal(err)
+	}
+	keys, err = km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != pubKey {
+		t.Errorf("Expected the activated key to validate with, received %v", keys)
+	}
+	root := [32]byte{'a'}
+	sig, err := km.Sign(pubKey, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.Verify(sk.PublicKey(), root[:]) {
+		t.Error("Expected a valid signature from the added key")
+	}
+}
+
+func TestManaged_Restore(t *testing.T) {
+	ctx := context.Background()
+	sk := bls.RandKey()
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	added := bls.RandKey()
+	addedPubKey := bytesutil.ToBytes48(added.PublicKey().Marshal())
+	db := &mockKeyStateDB{disabled: make(map[[48]byte]bool), added: make(map[[48]byte][]byte)}
+
+	km := keymanager.NewManaged(keymanager.NewDirect([]*bls.SecretKey{sk}), "password")
+	if err := km.Restore(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := km.Disable(pubKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := km.AddKey(added); err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(db.added[addedPubKey], added.Marshal()) {
+		t.Error("Expected the added key to be saved encrypted")
+	}
+	wrongPassword := keymanager.NewManaged(keymanager.NewDirect([]*bls.SecretKey{sk}), "wrong")
+	if err := wrongPassword.Restore(ctx, db); err == nil {
+		t.Error("Expected the added key not to be restored with a wrong password")
+	}
+
+	restarted := keymanager.NewManaged(keymanager.NewDirect([]*bls.SecretKey{sk}), "password")
+	if err := restarted.Restore(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if restarted.IsEnabled(pubKey) {
+		t.Error("Expected the disabled key to stay disabled")
+	}
+	keys, err := restarted.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != addedPubKey {
+		t.Errorf("Expected only the added key to validate with, received %v", keys)
+	}
+}
+
+type mockKeyStateDB struct {
+	disabled map[[48]byte]bool
+	added    map[[48]byte][]byte
+}
+
+func (m *mockKeyStateDB) DisabledPublicKeys(_ context.Context) ([][48]byte, error) {
+	keys := make([][48]byte, 0, len(m.disabled))
+	for key := range m.disabled {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *mockKeyStateDB) SaveKeyDisabled(_ context.Context, pubKey [48]byte, disabled bool) error {
+	if disabled {
+		m.disabled[pubKey] = true
+	} else {
+		delete(m.disabled, pubKey)
+	}
+	return nil
+}
+
+func (m *mockKeyStateDB) AddedKeystores(_ context.Context) ([][]byte, error) {
+	keys := make([][]byte, 0, len(m.added))
+	for _, key := range m.added {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *mockKeyStateDB) SaveAddedKeystore(_ context.Context, pubKey [48]byte, keystore []byte) error {
+	m.added[pubKey] = keystore
+	return nil
+}
@@ -0,0 +1,369 @@
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Paths served by an HTTP remote signer, relative to its base URL.
+const (
+	RemoteHTTPKeysPath        = "/api/v1/keys"
+	RemoteHTTPBlockPath       = "/api/v1/sign/block"
+	RemoteHTTPAttestationPath = "/api/v1/sign/attestation"
+	RemoteHTTPAggregatePath   = "/api/v1/sign/aggregate"
+	RemoteHTTPRandaoPath      = "/api/v1/sign/randao"
+	RemoteHTTPExitPath        = "/api/v1/sign/exit"
+)
+
+// defaultRemoteHTTPTimeout is used when no per-request timeout is configured.
+const defaultRemoteHTTPTimeout = 5 * time.Second
+
+// DeniedError is returned when an HTTP remote signer refuses to sign, typically because the
+// request would be slashable. It unwraps to ErrDenied.
+type DeniedError struct {
+	Reason string
+}
+
+// Error returns the denial reason given by the signer.
+func (e *DeniedError) Error() string {
+	if e.Reason == "" {
+		return ErrDenied.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrDenied.Error(), e.Reason)
+}
+
+// Unwrap allows errors.Is(err, ErrDenied) to match signer denials.
+func (e *DeniedError) Unwrap() error {
+	return ErrDenied
+}
+
+// RemoteHTTPKeysResponse lists the public keys an HTTP remote signer can sign for.
+type RemoteHTTPKeysResponse struct {
+	PublicKeys []string `json:"public_keys"`
+}
+
+// RemoteHTTPSignRequest is the body posted to every signing endpoint. Block and Attestation are
+// only set on their respective endpoints; all other endpoints receive the hash tree root of the
+// object to sign in ObjectRoot, which the signer combines with Domain into the signing root.
+type RemoteHTTPSignRequest struct {
+	PublicKey   string                     `json:"public_key"`
+	Domain      string                     `json:"domain"`
+	ObjectRoot  string                     `json:"object_root,omitempty"`
+	Block       *RemoteHTTPBlockHeader     `json:"block,omitempty"`
+	Attestation *RemoteHTTPAttestationData `json:"attestation,omitempty"`
+}
+
+// RemoteHTTPBlockHeader is the JSON representation of a beacon block header.
+type RemoteHTTPBlockHeader struct {
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+// RemoteHTTPAttestationData is the JSON representation of attestation data.
+type RemoteHTTPAttestationData struct {
+	Slot            uint64                `json:"slot"`
+	CommitteeIndex  uint64                `json:"committee_index"`
+	BeaconBlockRoot string                `json:"beacon_block_root"`
+	Source          *RemoteHTTPCheckpoint `json:"source"`
+	Target          *RemoteHTTPCheckpoint `json:"target"`
+}
+
+// RemoteHTTPCheckpoint is the JSON representation of a checkpoint.
+type RemoteHTTPCheckpoint struct {
+	Epoch uint64 `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// RemoteHTTPSignResponse is returned by a signing endpoint on success.
+type RemoteHTTPSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// RemoteHTTPErrorResponse is returned by a signing endpoint on failure. A 403 status code
+// denotes a denial.
+type RemoteHTTPErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteHTTP is a key manager that signs through an HTTP JSON remote signer.
+type RemoteHTTP struct {
+	baseURL string
+	client  *http.Client
+	timeout time.Duration
+	keys    map[[48]byte]bool
+}
+
+type remoteHTTPOpts struct {
+	URL          string                 `json:"url"`
+	TimeoutMs    uint64                 `json:"timeout_ms"`
+	Certificates *remoteCertificateOpts `json:"certificates"`
+}
+
+var remoteHTTPOptsHelp = `The remote-http key manager signs through an HTTP JSON remote signer over mutual TLS.  The options are:
+  - url This is the base URL of the remote signer.
+  - timeout_ms This is the timeout for each request to the signer, in milliseconds.
+    If not supplied it defaults to 5000.
+  - certificates This provides paths to certificates:
+    - ca_cert This is the path to the server's certificate authority certificate file
+    - client_cert This is the path to the client's certificate file
+    - client_key This is the path to the client's key file
+
+An sample keymanager options file (with annotations; these should be removed if
+using this as a template) is:
+
+  {
+    "url":        "https://signer.example.com:9000", // Connect to the signer at signer.example.com on port 9000
+    "timeout_ms": 2000,                              // Give up on a signing request after 2 seconds
+    "certificates": {
+      "ca_cert": "/home/eth2/certs/ca.crt"         // Certificate file for the CA that signed the server's certificate
+      "client_cert": "/home/eth2/certs/client.crt" // Certificate file for this client
+      "client_key": "/home/eth2/certs/client.key"  // Key file for this client
+    }
+  }`
+
+// NewRemoteHTTPWallet creates a key manager populated with the keys served by an HTTP remote signer.
+func NewRemoteHTTPWallet(input string) (KeyManager, string, error) {
+	opts := &remoteHTTPOpts{}
+	if err := json.Unmarshal([]byte(input), opts); err != nil {
+		return nil, remoteHTTPOptsHelp, err
+	}
+	if opts.URL == "" {
+		return nil, remoteHTTPOptsHelp, errors.New("remote signer URL is required")
+	}
+
+	// Load the client certificates.
+	if opts.Certificates == nil {
+		return nil, remoteHTTPOptsHelp, errors.New("certificates are required")
+	}
+	if opts.Certificates.ClientCert == "" {
+		return nil, remoteHTTPOptsHelp, errors.New("client certificate is required")
+	}
+	if opts.Certificates.ClientKey == "" {
+		return nil, remoteHTTPOptsHelp, errors.New("client key is required")
+	}
+	clientPair, err := tls.LoadX509KeyPair(opts.Certificates.ClientCert, opts.Certificates.ClientKey)
+	if err != nil {
+		return nil, remoteHTTPOptsHelp, errors.Wrap(err, "failed to obtain client's certificate and/or key")
+	}
+
+	// Load the CA for the server certificate if present, otherwise fall back to the system pool.
+	var cp *x509.CertPool
+	if opts.Certificates.CACert != "" {
+		serverCA, err := ioutil.ReadFile(opts.Certificates.CACert)
+		if err != nil {
+			return nil, remoteHTTPOptsHelp, errors.Wrap(err, "failed to obtain server's CA certificate")
+		}
+		cp = x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(serverCA) {
+			return nil, remoteHTTPOptsHelp, errors.New("failed to add server's CA certificate to pool")
+		}
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates: []tls.Certificate{clientPair},
+				RootCAs:      cp,
+			},
+		},
+	}
+	timeout := time.Duration(opts.TimeoutMs) * time.Millisecond
+	km, err := NewRemoteHTTP(opts.URL, client, timeout)
+	if err != nil {
+		return nil, remoteHTTPOptsHelp, err
+	}
+	return km, remoteHTTPOptsHelp, nil
+}
+
+// NewRemoteHTTP creates a key manager for the HTTP remote signer at baseURL, using the given
+// client for all requests. A zero timeout uses the default per-request timeout.
+func NewRemoteHTTP(baseURL string, client *http.Client, timeout time.Duration) (*RemoteHTTP, error) {
+	if timeout == 0 {
+		timeout = defaultRemoteHTTPTimeout
+	}
+	km := &RemoteHTTP{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		timeout: timeout,
+	}
+	if err := km.RefreshValidatingKeys(); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch keys from remote signer")
+	}
+	return km, nil
+}
+
+// FetchValidatingKeys fetches the list of public keys that should be used to validate with.
+func (km *RemoteHTTP) FetchValidatingKeys() ([][48]byte, error) {
+	res := make([][48]byte, 0, len(km.keys))
+	for key := range km.keys {
+		res = append(res, key)
+	}
+	return res, nil
+}
+
+// RefreshValidatingKeys refreshes the list of validating keys from the remote signer.
+func (km *RemoteHTTP) RefreshValidatingKeys() error {
+	ctx, cancel := context.WithTimeout(context.Background(), km.timeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, km.baseURL+RemoteHTTPKeysPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := km.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d when listing keys", resp.StatusCode)
+	}
+	keysResp := &RemoteHTTPKeysResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(keysResp); err != nil {
+		return errors.Wrap(err, "could not decode keys response")
+	}
+	keys := make(map[[48]byte]bool, len(keysResp.PublicKeys))
+	for _, key := range keysResp.PublicKeys {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(pubKey) != 48 {
+			log.WithField("pubKey", key).Warn("Received invalid public key from server; ignoring")
+			continue
+		}
+		keys[bytesutil.ToBytes48(pubKey)] = true
+	}
+	km.keys = keys
+	return nil
+}
+
+// Sign without protection is not supported by remote keymanagers.
+func (km *RemoteHTTP) Sign(pubKey [48]byte, root [32]byte) (*bls.Signature, error) {
+	return nil, errors.New("remote keymanager does not support unprotected signing")
+}
+
+// SignGeneric signs a generic message for the validator to broadcast. The endpoint is chosen from
+// the domain type, so only randao reveals, aggregation selection proofs, aggregates and exits can be signed.
+func (km *RemoteHTTP) SignGeneric(pubKey [48]byte, root [32]byte, domain [32]byte) (*bls.Signature, error) {
+	var path string
+	domainType := bytesutil.ToBytes4(domain[:4])
+	switch domainType {
+	case params.BeaconConfig().DomainRandao:
+		path = RemoteHTTPRandaoPath
+	case params.BeaconConfig().DomainSelectionProof, params.BeaconConfig().DomainAggregateAndProof:
+		path = RemoteHTTPAggregatePath
+	case params.BeaconConfig().DomainVoluntaryExit:
+		path = RemoteHTTPExitPath
+	default:
+		return nil, fmt.Errorf("remote signer does not support generic signing for domain type %#x", domainType)
+	}
+	return km.sign(path, pubKey, &RemoteHTTPSignRequest{
+		Domain:     fmt.Sprintf("%#x", domain),
+		ObjectRoot: fmt.Sprintf("%#x", root),
+	})
+}
+
+// SignProposal signs a block proposal for the validator to broadcast.
+func (km *RemoteHTTP) SignProposal(pubKey [48]byte, domain [32]byte, data *ethpb.BeaconBlockHeader) (*bls.Signature, error) {
+	return km.sign(RemoteHTTPBlockPath, pubKey, &RemoteHTTPSignRequest{
+		Domain: fmt.Sprintf("%#x", domain),
+		Block: &RemoteHTTPBlockHeader{
+			Slot:          data.Slot,
+			ProposerIndex: data.ProposerIndex,
+			ParentRoot:    fmt.Sprintf("%#x", data.ParentRoot),
+			StateRoot:     fmt.Sprintf("%#x", data.StateRoot),
+			BodyRoot:      fmt.Sprintf("%#x", data.BodyRoot),
+		},
+	})
+}
+
+// SignAttestation signs an attestation for the validator to broadcast.
+func (km *RemoteHTTP) SignAttestation(pubKey [48]byte, domain [32]byte, data *ethpb.AttestationData) (*bls.Signature, error) {
+	return km.sign(RemoteHTTPAttestationPath, pubKey, &RemoteHTTPSignRequest{
+		Domain: fmt.Sprintf("%#x", domain),
+		Attestation: &RemoteHTTPAttestationData{
+			Slot:            data.Slot,
+			CommitteeIndex:  data.CommitteeIndex,
+			BeaconBlockRoot: fmt.Sprintf("%#x", data.BeaconBlockRoot),
+			Source: &RemoteHTTPCheckpoint{
+				Epoch: data.Source.Epoch,
+				Root:  fmt.Sprintf("%#x", data.Source.Root),
+			},
+			Target: &RemoteHTTPCheckpoint{
+				Epoch: data.Target.Epoch,
+				Root:  fmt.Sprintf("%#x", data.Target.Root),
+			},
+		},
+	})
+}
+
+// sign posts a signing request to the given endpoint and decodes the resulting signature.
+func (km *RemoteHTTP) sign(path string, pubKey [48]byte, signReq *RemoteHTTPSignRequest) (*bls.Signature, error) {
+	if !km.keys[pubKey] {
+		return nil, ErrNoSuchKey
+	}
+	signReq.PublicKey = fmt.Sprintf("%#x", pubKey)
+	body, err := json.Marshal(signReq)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), km.timeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, km.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := km.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reach remote signer")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		errResp := &RemoteHTTPErrorResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(errResp); err != nil {
+			log.WithError(err).Debug("Could not decode denial response")
+		}
+		return nil, &DeniedError{Reason: errResp.Error}
+	default:
+		log.WithField("status", resp.StatusCode).WithField("path", path).Debug("Remote signer failed to sign")
+		return nil, ErrCannotSign
+	}
+
+	signResp := &RemoteHTTPSignResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(signResp); err != nil {
+		return nil, errors.Wrap(err, "could not decode signing response")
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(signResp.Signature, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature")
+	}
+	return bls.SignatureFromBytes(sig)
+}
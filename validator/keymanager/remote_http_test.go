@@ -0,0 +1,161 @@
+package keymanager_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	kmtesting "github.com/prysmaticlabs/prysm/validator/keymanager/testing"
+)
+
+func setupRemoteHTTP(t *testing.T, timeout time.Duration) (*bls.SecretKey, *kmtesting.RemoteSigner, keymanager.ProtectingKeyManager) {
+	sk := bls.RandKey()
+	signer, err := kmtesting.NewRemoteSigner([]*bls.SecretKey{sk})
+	if err != nil {
+		t.Fatalf("Could not start remote signer: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := signer.Close(); err != nil {
+			t.Errorf("Could not close remote signer: %v", err)
+		}
+	})
+	km, _, err := keymanager.NewRemoteHTTPWallet(signer.KeymanagerOpts(timeout))
+	if err != nil {
+		t.Fatalf("Could not create key manager: %v", err)
+	}
+	return sk, signer, km.(keymanager.ProtectingKeyManager)
+}
+
+func TestNewRemoteHTTPWallet_InvalidOpts(t *testing.T) {
+	tests := []struct {
+		name string
+		opts string
+		err  string
+	}{
+		{
+			name: "NoURL",
+			opts: `{}`,
+			err:  "remote signer URL is required",
+		},
+		{
+			name: "NoCertificates",
+			opts: `{"url":"https://localhost:9000"}`,
+			err:  "certificates are required",
+		},
+		{
+			name: "NoClientKey",
+			opts: `{"url":"https://localhost:9000","certificates":{"client_cert":"client.crt"}}`,
+			err:  "client key is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := keymanager.NewRemoteHTTPWallet(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error %q, received %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRemoteHTTP_FetchValidatingKeys(t *testing.T) {
+	sk, _, km := setupRemoteHTTP(t, time.Second)
+	keys, err := km.(keymanager.KeyManager).FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != bytesutil.ToBytes48(sk.PublicKey().Marshal()) {
+		t.Errorf("Unexpected validating keys %v", keys)
+	}
+}
+
+func TestRemoteHTTP_SignProposal(t *testing.T) {
+	sk, _, km := setupRemoteHTTP(t, time.Second)
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	domain := [32]byte{1}
+	header := &ethpb.BeaconBlockHeader{
+		Slot:       5,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   make([]byte, 32),
+	}
+	sig, err := km.SignProposal(pubKey, domain, header)
+	if err != nil {
+		t.Fatalf("Could not sign proposal: %v", err)
+	}
+	root, err := helpers.ComputeSigningRoot(header, domain[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.Verify(sk.PublicKey(), root[:]) {
+		t.Error("Could not verify proposal signature")
+	}
+
+	// A different block for the same slot is a double proposal.
+	header.StateRoot = bytesutil.PadTo([]byte{'a'}, 32)
+	_, err = km.SignProposal(pubKey, domain, header)
+	if !errors.Is(err, keymanager.ErrDenied) {
+		t.Errorf("Expected denial, received %v", err)
+	}
+}
+
+func TestRemoteHTTP_SignAttestation_SurroundVote(t *testing.T) {
+	sk, _, km := setupRemoteHTTP(t, time.Second)
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 3, Root: make([]byte, 32)},
+	}
+	if _, err := km.SignAttestation(pubKey, [32]byte{}, data); err != nil {
+		t.Fatalf("Could not sign attestation: %v", err)
+	}
+
+	data.Source.Epoch = 1
+	data.Target.Epoch = 4
+	_, err := km.SignAttestation(pubKey, [32]byte{}, data)
+	denied := &keymanager.DeniedError{}
+	if !errors.As(err, &denied) || !strings.Contains(denied.Reason, "surround") {
+		t.Errorf("Expected surround vote denial, received %v", err)
+	}
+}
+
+func TestRemoteHTTP_SignGeneric(t *testing.T) {
+	sk, _, km := setupRemoteHTTP(t, time.Second)
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+
+	randaoDomain := [32]byte{}
+	copy(randaoDomain[:], params.BeaconConfig().DomainRandao[:])
+	if _, err := km.SignGeneric(pubKey, [32]byte{'r'}, randaoDomain); err != nil {
+		t.Errorf("Could not sign randao reveal: %v", err)
+	}
+
+	attesterDomain := [32]byte{}
+	copy(attesterDomain[:], params.BeaconConfig().DomainBeaconAttester[:])
+	if _, err := km.SignGeneric(pubKey, [32]byte{'a'}, attesterDomain); err == nil {
+		t.Error("Expected generic signing of an attester domain to be rejected")
+	}
+
+	if _, err := km.SignGeneric([48]byte{'x'}, [32]byte{}, randaoDomain); err != keymanager.ErrNoSuchKey {
+		t.Errorf("Expected %v, received %v", keymanager.ErrNoSuchKey, err)
+	}
+}
+
+func TestRemoteHTTP_Timeout(t *testing.T) {
+	sk, signer, km := setupRemoteHTTP(t, 50*time.Millisecond)
+	signer.SetDelay(200 * time.Millisecond)
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+
+	randaoDomain := [32]byte{}
+	copy(randaoDomain[:], params.BeaconConfig().DomainRandao[:])
+	if _, err := km.SignGeneric(pubKey, [32]byte{'r'}, randaoDomain); err == nil {
+		t.Error("Expected request to time out")
+	}
+}
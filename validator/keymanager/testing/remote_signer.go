@@ -0,0 +1,360 @@
+// Package testing includes an in-process stand-in for an HTTP remote signer,
+// useful for exercising the remote-http key manager and the validator client
+// end to end without an external signing service.
+package testing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "remote-signer")
+
+type signedAttestation struct {
+	source      uint64
+	target      uint64
+	signingRoot [32]byte
+}
+
+// RemoteSigner is an HTTP remote signer served over mutual TLS from an in-process test server.
+// It refuses to sign double proposals, double votes and surround votes.
+type RemoteSigner struct {
+	server         *httptest.Server
+	certDir        string
+	caCertFile     string
+	clientCertFile string
+	clientKeyFile  string
+	secretKeys     map[[48]byte]*bls.SecretKey
+	lock           sync.Mutex
+	signedBlocks   map[[48]byte]map[uint64][32]byte
+	signedAtts     map[[48]byte][]*signedAttestation
+	delay          time.Duration
+}
+
+// NewRemoteSigner starts a remote signer holding the given secret keys. The certificate
+// authority, server and client certificates are generated on the fly.
+func NewRemoteSigner(sks []*bls.SecretKey) (*RemoteSigner, error) {
+	certDir, err := ioutil.TempDir("", "remote-signer")
+	if err != nil {
+		return nil, err
+	}
+	rs := &RemoteSigner{
+		certDir:      certDir,
+		secretKeys:   make(map[[48]byte]*bls.SecretKey, len(sks)),
+		signedBlocks: make(map[[48]byte]map[uint64][32]byte),
+		signedAtts:   make(map[[48]byte][]*signedAttestation),
+	}
+	for _, sk := range sks {
+		rs.secretKeys[bytesutil.ToBytes48(sk.PublicKey().Marshal())] = sk
+	}
+
+	serverCert, clientCAs, err := rs.generateCertificates()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate certificates")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(keymanager.RemoteHTTPKeysPath, rs.handleKeys)
+	mux.HandleFunc(keymanager.RemoteHTTPBlockPath, rs.handleBlock)
+	mux.HandleFunc(keymanager.RemoteHTTPAttestationPath, rs.handleAttestation)
+	mux.HandleFunc(keymanager.RemoteHTTPAggregatePath, rs.handleGeneric)
+	mux.HandleFunc(keymanager.RemoteHTTPRandaoPath, rs.handleGeneric)
+	mux.HandleFunc(keymanager.RemoteHTTPExitPath, rs.handleGeneric)
+
+	rs.server = httptest.NewUnstartedServer(mux)
+	rs.server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	rs.server.StartTLS()
+	return rs, nil
+}
+
+// URL returns the base URL of the signer.
+func (rs *RemoteSigner) URL() string {
+	return rs.server.URL
+}
+
+// KeymanagerOpts returns remote-http key manager options that connect to this signer.
+func (rs *RemoteSigner) KeymanagerOpts(timeout time.Duration) string {
+	return fmt.Sprintf(
+		`{"url":%q,"timeout_ms":%d,"certificates":{"ca_cert":%q,"client_cert":%q,"client_key":%q}}`,
+		rs.server.URL,
+		timeout.Milliseconds(),
+		rs.caCertFile,
+		rs.clientCertFile,
+		rs.clientKeyFile,
+	)
+}
+
+// SetDelay delays the answer to every subsequent signing request, to exercise client timeouts.
+func (rs *RemoteSigner) SetDelay(delay time.Duration) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	rs.delay = delay
+}
+
+// Close shuts down the signer and removes its certificates.
+func (rs *RemoteSigner) Close() error {
+	rs.server.Close()
+	return os.RemoveAll(rs.certDir)
+}
+
+func (rs *RemoteSigner) handleKeys(w http.ResponseWriter, r *http.Request) {
+	resp := &keymanager.RemoteHTTPKeysResponse{PublicKeys: make([]string, 0, len(rs.secretKeys))}
+	for pubKey := range rs.secretKeys {
+		resp.PublicKeys = append(resp.PublicKeys, fmt.Sprintf("%#x", pubKey))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (rs *RemoteSigner) handleBlock(w http.ResponseWriter, r *http.Request) {
+	req, sk, ok := rs.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Block == nil {
+		writeError(w, http.StatusBadRequest, "missing block")
+		return
+	}
+	header := &ethpb.BeaconBlockHeader{
+		Slot:          req.Block.Slot,
+		ProposerIndex: req.Block.ProposerIndex,
+		ParentRoot:    decodeHex(req.Block.ParentRoot),
+		StateRoot:     decodeHex(req.Block.StateRoot),
+		BodyRoot:      decodeHex(req.Block.BodyRoot),
+	}
+	root, err := helpers.ComputeSigningRoot(header, decodeHex(req.Domain))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pubKey := bytesutil.ToBytes48(decodeHex(req.PublicKey))
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	if rs.signedBlocks[pubKey] == nil {
+		rs.signedBlocks[pubKey] = make(map[uint64][32]byte)
+	}
+	if existing, ok := rs.signedBlocks[pubKey][header.Slot]; ok && existing != root {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("double proposal at slot %d", header.Slot))
+		return
+	}
+	rs.signedBlocks[pubKey][header.Slot] = root
+	writeJSON(w, http.StatusOK, &keymanager.RemoteHTTPSignResponse{Signature: fmt.Sprintf("%#x", sk.Sign(root[:]).Marshal())})
+}
+
+func (rs *RemoteSigner) handleAttestation(w http.ResponseWriter, r *http.Request) {
+	req, sk, ok := rs.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Attestation == nil || req.Attestation.Source == nil || req.Attestation.Target == nil {
+		writeError(w, http.StatusBadRequest, "missing attestation")
+		return
+	}
+	data := &ethpb.AttestationData{
+		Slot:            req.Attestation.Slot,
+		CommitteeIndex:  req.Attestation.CommitteeIndex,
+		BeaconBlockRoot: decodeHex(req.Attestation.BeaconBlockRoot),
+		Source: &ethpb.Checkpoint{
+			Epoch: req.Attestation.Source.Epoch,
+			Root:  decodeHex(req.Attestation.Source.Root),
+		},
+		Target: &ethpb.Checkpoint{
+			Epoch: req.Attestation.Target.Epoch,
+			Root:  decodeHex(req.Attestation.Target.Root),
+		},
+	}
+	root, err := helpers.ComputeSigningRoot(data, decodeHex(req.Domain))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pubKey := bytesutil.ToBytes48(decodeHex(req.PublicKey))
+	source, target := data.Source.Epoch, data.Target.Epoch
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	for _, prev := range rs.signedAtts[pubKey] {
+		if prev.target == target && prev.signingRoot != root {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("double vote for target epoch %d", target))
+			return
+		}
+		if (prev.source < source && target < prev.target) || (source < prev.source && prev.target < target) {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("surround vote with source %d and target %d", prev.source, prev.target))
+			return
+		}
+	}
+	rs.signedAtts[pubKey] = append(rs.signedAtts[pubKey], &signedAttestation{source: source, target: target, signingRoot: root})
+	writeJSON(w, http.StatusOK, &keymanager.RemoteHTTPSignResponse{Signature: fmt.Sprintf("%#x", sk.Sign(root[:]).Marshal())})
+}
+
+func (rs *RemoteSigner) handleGeneric(w http.ResponseWriter, r *http.Request) {
+	req, sk, ok := rs.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	root, err := ssz.HashTreeRoot(&p2ppb.SigningRoot{
+		ObjectRoot: decodeHex(req.ObjectRoot),
+		Domain:     decodeHex(req.Domain),
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &keymanager.RemoteHTTPSignResponse{Signature: fmt.Sprintf("%#x", sk.Sign(root[:]).Marshal())})
+}
+
+// decodeRequest decodes a signing request and looks up the key it is for, writing an error
+// response and returning false if either fails.
+func (rs *RemoteSigner) decodeRequest(w http.ResponseWriter, r *http.Request) (*keymanager.RemoteHTTPSignRequest, *bls.SecretKey, bool) {
+	rs.lock.Lock()
+	delay := rs.delay
+	rs.lock.Unlock()
+	time.Sleep(delay)
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "signing requests must be posted")
+		return nil, nil, false
+	}
+	req := &keymanager.RemoteHTTPSignRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, nil, false
+	}
+	sk, ok := rs.secretKeys[bytesutil.ToBytes48(decodeHex(req.PublicKey))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown public key")
+		return nil, nil, false
+	}
+	return req, sk, true
+}
+
+// generateCertificates creates a certificate authority which issues both the server certificate and
+// the client certificate, and writes the files the key manager needs to the certificate directory.
+func (rs *RemoteSigner) generateCertificates() (tls.Certificate, *x509.CertPool, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Remote signer CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	issue := func(serial int64, usage x509.ExtKeyUsage) ([]byte, *ecdsa.PrivateKey, error) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback},
+			DNSNames:     []string{"localhost"},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		return der, key, err
+	}
+
+	serverDER, serverKey, err := issue(2, x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	clientDER, clientKey, err := issue(3, x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	clientKeyDER, err := x509.MarshalECPrivateKey(clientKey)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	rs.caCertFile = filepath.Join(rs.certDir, "ca.crt")
+	rs.clientCertFile = filepath.Join(rs.certDir, "client.crt")
+	rs.clientKeyFile = filepath.Join(rs.certDir, "client.key")
+	files := map[string]*pem.Block{
+		rs.caCertFile:     {Type: "CERTIFICATE", Bytes: caDER},
+		rs.clientCertFile: {Type: "CERTIFICATE", Bytes: clientDER},
+		rs.clientKeyFile:  {Type: "EC PRIVATE KEY", Bytes: clientKeyDER},
+	}
+	for path, block := range files {
+		if err := ioutil.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+			return tls.Certificate{}, nil, err
+		}
+	}
+
+	serverCert := tls.Certificate{
+		Certificate: [][]byte{serverDER},
+		PrivateKey:  serverKey,
+	}
+	return serverCert, pool, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("Could not write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, &keymanager.RemoteHTTPErrorResponse{Error: msg})
+}
+
+func decodeHex(s string) []byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil
+	}
+	return b
+}
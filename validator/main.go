@@ -52,8 +52,11 @@ var appFlags = []cli.Flag{
 	flags.SourceDirectories,
 	flags.SourceDirectory,
 	flags.TargetDirectory,
+	flags.SlashingProtectionFileFlag,
+	flags.GenesisValidatorsRootFlag,
 	flags.PasswordFlag,
 	flags.DisablePenaltyRewardLogFlag,
+	flags.DoppelgangerDetectionEpochsFlag,
 	flags.UnencryptedKeysFlag,
 	flags.InteropStartIndex,
 	flags.InteropNumValidators,
@@ -62,6 +65,9 @@ var appFlags = []cli.Flag{
 	flags.KeyManager,
 	flags.KeyManagerOpts,
 	flags.DisableAccountMetricsFlag,
+	flags.EnableManagementAPIFlag,
+	flags.ManagementAPIHostFlag,
+	flags.ManagementAPIPortFlag,
 	flags.MonitoringPortFlag,
 	flags.SlasherRPCProviderFlag,
 	flags.SlasherCertFlag,
@@ -271,6 +277,54 @@ contract in order to activate the validator client`,
 				},
 			},
 		},
+		{
+			Name:     "slashing-protection",
+			Category: "slashing-protection",
+			Usage:    "moves the validator's slashing protection history between machines using the EIP-3076 interchange format",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "export",
+					Description: "exports the attestation and proposal history of the validator database to an interchange JSON file",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.SlashingProtectionFileFlag,
+						flags.GenesisValidatorsRootFlag,
+					},
+					Action: func(cliCtx *cli.Context) error {
+						dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+						file := cliCtx.String(flags.SlashingProtectionFileFlag.Name)
+						root := cliCtx.String(flags.GenesisValidatorsRootFlag.Name)
+
+						if err := accounts.ExportSlashingProtection(context.Background(), dataDir, file, root); err != nil {
+							log.WithError(err).Error("Exporting slashing protection history failed")
+							return err
+						}
+						return nil
+					},
+				},
+				{
+					Name: "import",
+					Description: `imports an interchange JSON file into the validator database, merging it with any existing
+history so that the most conservative protection is kept, and refuses conflicting votes`,
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.SlashingProtectionFileFlag,
+						flags.GenesisValidatorsRootFlag,
+					},
+					Action: func(cliCtx *cli.Context) error {
+						dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+						file := cliCtx.String(flags.SlashingProtectionFileFlag.Name)
+						root := cliCtx.String(flags.GenesisValidatorsRootFlag.Name)
+
+						if err := accounts.ImportSlashingProtection(context.Background(), dataDir, file, root); err != nil {
+							log.WithError(err).Error("Importing slashing protection history failed")
+							return err
+						}
+						return nil
+					},
+				},
+			},
+		},
 	}
 
 	app.Flags = appFlags
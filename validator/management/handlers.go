@@ -0,0 +1,242 @@
+package management
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+// keyResponse describes a key of the validator client.
+type keyResponse struct {
+	PublicKey        string               `json:"public_key"`
+	Enabled          bool                 `json:"enabled"`
+	Status           string               `json:"status"`
+	ValidatorIndex   *uint64              `json:"validator_index,omitempty"`
+	Duties           *dutiesResponse      `json:"duties,omitempty"`
+	LastAttestation  *attestationResponse `json:"last_attestation,omitempty"`
+	LastProposalSlot *uint64              `json:"last_proposal_slot,omitempty"`
+}
+
+// dutiesResponse describes the upcoming duties of a key.
+type dutiesResponse struct {
+	AttesterSlot   uint64   `json:"attester_slot"`
+	CommitteeIndex uint64   `json:"committee_index"`
+	ProposerSlots  []uint64 `json:"proposer_slots"`
+}
+
+// attestationResponse describes the last attestation signed by a key.
+type attestationResponse struct {
+	SourceEpoch uint64 `json:"source_epoch"`
+	TargetEpoch uint64 `json:"target_epoch"`
+}
+
+// addKeyRequest holds the hex encoded secret key to add to the validator client.
+type addKeyRequest struct {
+	SecretKey string `json:"secret_key"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handleKeys lists the keys of the validator client on GET, and adds a key on POST.
+func (s *Service) handleKeys(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listKeys(w, r)
+	case http.MethodPost:
+		s.addKey(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// handleKey enables or disables a key on POST /v1/keys/{pubkey}/enable and /v1/keys/{pubkey}/disable.
+func (s *Service) handleKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/keys/"), "/")
+	if len(parts) != 2 {
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	pubKey, err := decodePublicKey(parts[0])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	switch parts[1] {
+	case "enable":
+		err = s.keyManager.Enable(pubKey)
+	case "disable":
+		err = s.keyManager.Disable(pubKey)
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	if err == keymanager.ErrNoSuchKey {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.WithField("pubKey", parts[0]).Infof("Key %sd", parts[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Service) listKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	keys, err := s.keyManager.FetchAllKeys()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, errors.Wrap(err, "could not fetch keys"))
+		return
+	}
+	duties := make(map[[48]byte]*ethpb.DutiesResponse_Duty)
+	for _, duty := range s.validator.Duties() {
+		duties[bytesutil.ToBytes48(duty.PublicKey)] = duty
+	}
+
+	resp := make([]*keyResponse, len(keys))
+	for i, key := range keys {
+		resp[i] = &keyResponse{
+			PublicKey: hex.EncodeToString(key[:]),
+			Enabled:   s.keyManager.IsEnabled(key),
+			Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS.String(),
+		}
+		if duty, ok := duties[key]; ok {
+			index := duty.ValidatorIndex
+			resp[i].Status = duty.Status.String()
+			resp[i].ValidatorIndex = &index
+			resp[i].Duties = &dutiesResponse{
+				AttesterSlot:   duty.AttesterSlot,
+				CommitteeIndex: duty.CommitteeIndex,
+				ProposerSlots:  duty.ProposerSlots,
+			}
+		}
+	}
+
+	valDB := s.validator.ValidatorDB()
+	if valDB != nil {
+		histories, err := valDB.AttestationHistoryForPubKeys(ctx, keys)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, errors.Wrap(err, "could not get attestation history"))
+			return
+		}
+		wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+		for i, key := range keys {
+			history, ok := histories[key]
+			if !ok {
+				continue
+			}
+			target := history.LatestEpochWritten
+			source, ok := history.TargetToSource[target%wsPeriod]
+			if ok && source != params.BeaconConfig().FarFutureEpoch {
+				resp[i].LastAttestation = &attestationResponse{SourceEpoch: source, TargetEpoch: target}
+			}
+			slot, found, err := valDB.LatestProposalSlot(ctx, key[:])
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, errors.Wrap(err, "could not get proposal history"))
+				return
+			}
+			if found {
+				resp[i].LastProposalSlot = &slot
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Service) addKey(w http.ResponseWriter, r *http.Request) {
+	req := &addKeyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode request"))
+		return
+	}
+	skBytes, err := hex.DecodeString(strings.TrimPrefix(req.SecretKey, "0x"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode secret key"))
+		return
+	}
+	sk, err := bls.SecretKeyFromBytes(skBytes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid secret key"))
+		return
+	}
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+
+	// The slashing protection of the key must exist before the key can sign.
+	if valDB := s.validator.ValidatorDB(); valDB != nil {
+		if err := valDB.InitializePublicKeys(r.Context(), [][48]byte{pubKey}); err != nil {
+			writeError(w, http.StatusInternalServerError, errors.Wrap(err, "could not initialize slashing protection"))
+			return
+		}
+	}
+	switch err := s.keyManager.AddKey(sk); err {
+	case nil:
+	case keymanager.ErrKeyExists:
+		writeError(w, http.StatusConflict, err)
+		return
+	case keymanager.ErrCannotAddKey:
+		writeError(w, http.StatusBadRequest, err)
+		return
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.WithField("pubKey", hex.EncodeToString(pubKey[:])).Info("Key added, checking for other clients using it before signing")
+	go s.activateKey(pubKey)
+	writeJSON(w, http.StatusCreated, &keyResponse{
+		PublicKey: hex.EncodeToString(pubKey[:]),
+		Enabled:   false,
+		Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS.String(),
+	})
+}
+
+// activateKey starts validating with an added key once no other client was found using it.
+func (s *Service) activateKey(pubKey [48]byte) {
+	logger := log.WithField("pubKey", hex.EncodeToString(pubKey[:]))
+	if err := s.validator.CheckDoppelgangerKeys(s.ctx, [][48]byte{pubKey}); err != nil {
+		logger.WithError(err).Error("Not validating with the added key")
+		return
+	}
+	if err := s.keyManager.ActivateKey(pubKey); err != nil {
+		logger.WithError(err).Error("Could not activate the added key")
+		return
+	}
+	logger.Info("Validating with the added key")
+}
+
+func decodePublicKey(s string) ([48]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return [48]byte{}, errors.Wrap(err, "could not decode public key")
+	}
+	if len(b) != 48 {
+		return [48]byte{}, errors.Errorf("public key must be 48 bytes, received %d", len(b))
+	}
+	return bytesutil.ToBytes48(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("Could not write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, &errorResponse{Error: err.Error()})
+}
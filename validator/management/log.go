@@ -0,0 +1,5 @@
+package management
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "management")
@@ -0,0 +1,146 @@
+// Package management serves a local HTTP API to inspect the keys of the validator client,
+// and to enable, disable or add keys while the validator runs.
+package management
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/sirupsen/logrus"
+)
+
+// tokenLength is the number of random bytes of the authentication token.
+const tokenLength = 32
+
+// ValidatorFetcher gives access to the state of the running validator client.
+type ValidatorFetcher interface {
+	Duties() []*ethpb.DutiesResponse_Duty
+	ValidatorDB() *db.Store
+	CheckDoppelgangerKeys(ctx context.Context, pubKeys [][48]byte) error
+}
+
+// Service serves the management API of the validator client. Every request must be
+// authenticated by the bearer token written to the token file when the service starts.
+type Service struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	host       string
+	port       int
+	tokenFile  string
+	keyManager keymanager.Managed
+	validator  ValidatorFetcher
+	token      string
+	server     *http.Server
+	err        error
+}
+
+// Config options for the management service.
+type Config struct {
+	Host       string
+	Port       int
+	TokenFile  string
+	KeyManager keymanager.Managed
+	Validator  ValidatorFetcher
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:        ctx,
+		cancel:     cancel,
+		host:       cfg.Host,
+		port:       cfg.Port,
+		tokenFile:  cfg.TokenFile,
+		keyManager: cfg.KeyManager,
+		validator:  cfg.Validator,
+	}
+}
+
+// Start the management API server.
+func (s *Service) Start() {
+	token, err := generateToken()
+	if err != nil {
+		s.err = errors.Wrap(err, "could not generate authentication token")
+		log.WithError(s.err).Error("Could not start management API")
+		return
+	}
+	if err := ioutil.WriteFile(s.tokenFile, []byte(token), 0600); err != nil {
+		s.err = errors.Wrap(err, "could not write authentication token")
+		log.WithError(s.err).Error("Could not start management API")
+		return
+	}
+	s.token = token
+
+	address := fmt.Sprintf("%s:%d", s.host, s.port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		s.err = errors.Wrapf(err, "could not listen to address %s", address)
+		log.WithError(s.err).Error("Could not start management API")
+		return
+	}
+	s.server = &http.Server{Handler: s.handler()}
+	log.WithFields(logrus.Fields{
+		"address":   address,
+		"tokenFile": s.tokenFile,
+	}).Info("Management API listening")
+	go func() {
+		if err := s.server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			s.err = err
+			log.WithError(err).Error("Management API failed")
+		}
+	}()
+}
+
+// Stop the management API server.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Shutdown(s.ctx)
+}
+
+// Status returns the error which stopped the management API, if any.
+func (s *Service) Status() error {
+	return s.err
+}
+
+// handler returns the authenticated routes of the management API.
+func (s *Service) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/keys", s.handleKeys)
+	mux.HandleFunc("/v1/keys/", s.handleKey)
+	return s.authenticate(mux)
+}
+
+// authenticate rejects the requests which do not hold the authentication token.
+func (s *Service) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("invalid authentication token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
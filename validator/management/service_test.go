@@ -0,0 +1,210 @@
+package management
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+type mockValidator struct {
+	duties          []*ethpb.DutiesResponse_Duty
+	db              *db.Store
+	doppelgangerErr error
+}
+
+func (m *mockValidator) Duties() []*ethpb.DutiesResponse_Duty {
+	return m.duties
+}
+
+func (m *mockValidator) ValidatorDB() *db.Store {
+	return m.db
+}
+
+func (m *mockValidator) CheckDoppelgangerKeys(_ context.Context, _ [][48]byte) error {
+	return m.doppelgangerErr
+}
+
+func setupService(t *testing.T, sks []*bls.SecretKey, duties []*ethpb.DutiesResponse_Duty) *Service {
+	pubKeys := make([][48]byte, len(sks))
+	for i, sk := range sks {
+		pubKeys[i] = bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	}
+	valDB := db.SetupDB(t, pubKeys)
+	km := keymanager.NewManaged(keymanager.NewDirect(sks), "password")
+	if err := km.Restore(context.Background(), valDB); err != nil {
+		t.Fatal(err)
+	}
+	return &Service{
+		ctx:        context.Background(),
+		token:      "secret",
+		keyManager: km,
+		validator:  &mockValidator{duties: duties, db: valDB},
+	}
+}
+
+func waitForValidatingKeys(t *testing.T, km keymanager.Managed, count int) [][48]byte {
+	for i := 0; ; i++ {
+		keys, err := km.FetchValidatingKeys()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(keys) == count || i == 100 {
+			return keys
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func serve(s *Service, method string, path string, body []byte, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestService_Unauthenticated(t *testing.T) {
+	s := setupService(t, []*bls.SecretKey{bls.RandKey()}, nil)
+	rec := serve(s, http.MethodGet, "/v1/keys", nil, "wrong")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, received %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestService_ListKeys(t *testing.T) {
+	sk := bls.RandKey()
+	pubKey := sk.PublicKey().Marshal()
+	duties := []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      pubKey,
+			ValidatorIndex: 3,
+			AttesterSlot:   10,
+			CommitteeIndex: 2,
+			ProposerSlots:  []uint64{12},
+			Status:         ethpb.ValidatorStatus_ACTIVE,
+		},
+	}
+	s := setupService(t, []*bls.SecretKey{sk}, duties)
+
+	rec := serve(s, http.MethodGet, "/v1/keys", nil, s.token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, received %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var keys []*keyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &keys); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("Expected 1 key, received %d", len(keys))
+	}
+	key := keys[0]
+	if key.PublicKey != hex.EncodeToString(pubKey) || !key.Enabled {
+		t.Errorf("Unexpected key %+v", key)
+	}
+	if key.Status != ethpb.ValidatorStatus_ACTIVE.String() {
+		t.Errorf("Expected status %s, received %s", ethpb.ValidatorStatus_ACTIVE, key.Status)
+	}
+	if key.ValidatorIndex == nil || *key.ValidatorIndex != 3 {
+		t.Errorf("Unexpected validator index %v", key.ValidatorIndex)
+	}
+	if key.Duties == nil || key.Duties.AttesterSlot != 10 || key.Duties.CommitteeIndex != 2 {
+		t.Errorf("Unexpected duties %+v", key.Duties)
+	}
+	if key.LastAttestation != nil || key.LastProposalSlot != nil {
+		t.Errorf("Expected no signed attestation nor proposal, received %+v", key)
+	}
+}
+
+func TestService_DisableEnableKey(t *testing.T) {
+	sk := bls.RandKey()
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	s := setupService(t, []*bls.SecretKey{sk}, nil)
+	path := "/v1/keys/" + hex.EncodeToString(pubKey[:])
+
+	rec := serve(s, http.MethodPost, path+"/disable", nil, s.token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, received %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if s.keyManager.IsEnabled(pubKey) {
+		t.Error("Expected key to be disabled")
+	}
+
+	rec = serve(s, http.MethodPost, path+"/enable", nil, s.token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, received %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if !s.keyManager.IsEnabled(pubKey) {
+		t.Error("Expected key to be enabled")
+	}
+
+	unknown := bls.RandKey().PublicKey().Marshal()
+	rec = serve(s, http.MethodPost, "/v1/keys/"+hex.EncodeToString(unknown)+"/disable", nil, s.token)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, received %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestService_AddKey(t *testing.T) {
+	s := setupService(t, []*bls.SecretKey{bls.RandKey()}, nil)
+	sk := bls.RandKey()
+	body, err := json.Marshal(&addKeyRequest{SecretKey: hex.EncodeToString(sk.Marshal())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(s, http.MethodPost, "/v1/keys", body, s.token)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, received %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	keys := waitForValidatingKeys(t, s.keyManager, 2)
+	if len(keys) != 2 || keys[1] != bytesutil.ToBytes48(sk.PublicKey().Marshal()) {
+		t.Errorf("Expected added key to be validating, received %v", keys)
+	}
+	saved, err := s.validator.ValidatorDB().AddedKeystores(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 || bytes.Contains(saved[0], sk.Marshal()) {
+		t.Errorf("Expected added key to be saved encrypted, received %d keys", len(saved))
+	}
+
+	rec = serve(s, http.MethodPost, "/v1/keys", body, s.token)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, received %d", http.StatusConflict, rec.Code)
+	}
+}
+
+func TestService_AddKey_Doppelganger(t *testing.T) {
+	s := setupService(t, []*bls.SecretKey{bls.RandKey()}, nil)
+	s.validator.(*mockValidator).doppelgangerErr = errors.New("found another client")
+	sk := bls.RandKey()
+	body, err := json.Marshal(&addKeyRequest{SecretKey: hex.EncodeToString(sk.Marshal())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(s, http.MethodPost, "/v1/keys", body, s.token)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, received %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	time.Sleep(100 * time.Millisecond)
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	if s.keyManager.IsEnabled(pubKey) {
+		t.Error("Expected the key used by another client not to be validating")
+	}
+	if _, err := s.keyManager.Sign(pubKey, [32]byte{}); err != keymanager.ErrDenied {
+		t.Errorf("Expected signing with the key to be denied, received %v", err)
+	}
+}
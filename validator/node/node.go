@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -26,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/flags"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/management"
 	slashing_protection "github.com/prysmaticlabs/prysm/validator/slashing-protection"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -33,6 +35,10 @@ import (
 
 var log = logrus.WithField("prefix", "node")
 
+// managementTokenFile is the name of the file, in the data directory, holding the token
+// authenticating requests to the management API.
+const managementTokenFile = "management-api-token"
+
 // ValidatorClient defines an instance of a sharding validator that manages
 // the entire lifecycle of services attached to it participating in
 // Ethereum Serenity.
@@ -120,9 +126,23 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 			return nil, err
 		}
 	}
+	var managedKeys keymanager.Managed
+	if cliCtx.Bool(flags.EnableManagementAPIFlag.Name) {
+		passphrase := cliCtx.String(flags.PasswordFlag.Name)
+		if passphrase == "" {
+			return nil, errors.New("a password is required to encrypt the keys added through the management API")
+		}
+		managedKeys = keymanager.NewManaged(keyManager, passphrase)
+		keyManager = managedKeys
+	}
 	if err := ValidatorClient.registerClientService(keyManager); err != nil {
 		return nil, err
 	}
+	if managedKeys != nil {
+		if err := ValidatorClient.registerManagementService(managedKeys); err != nil {
+			return nil, err
+		}
+	}
 
 	return ValidatorClient, nil
 }
@@ -207,6 +227,7 @@ func (s *ValidatorClient) registerClientService(keyManager keymanager.KeyManager
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcHeadersFlag:            s.cliCtx.String(flags.GrpcHeadersFlag.Name),
 		Protector:                  protector,
+		DoppelgangerEpochs:         s.cliCtx.Uint64(flags.DoppelgangerDetectionEpochsFlag.Name),
 	})
 
 	if err != nil {
@@ -214,6 +235,23 @@ func (s *ValidatorClient) registerClientService(keyManager keymanager.KeyManager
 	}
 	return s.services.RegisterService(v)
 }
+
+func (s *ValidatorClient) registerManagementService(keyManager keymanager.Managed) error {
+	var vs *client.ValidatorService
+	if err := s.services.FetchService(&vs); err != nil {
+		return err
+	}
+	dataDir := s.cliCtx.String(cmd.DataDirFlag.Name)
+	service := management.NewService(context.Background(), &management.Config{
+		Host:       s.cliCtx.String(flags.ManagementAPIHostFlag.Name),
+		Port:       s.cliCtx.Int(flags.ManagementAPIPortFlag.Name),
+		TokenFile:  filepath.Join(dataDir, managementTokenFile),
+		KeyManager: keyManager,
+		Validator:  vs,
+	})
+	return s.services.RegisterService(service)
+}
+
 func (s *ValidatorClient) registerSlasherClientService() error {
 	endpoint := s.cliCtx.String(flags.SlasherRPCProviderFlag.Name)
 	if endpoint == "" {
@@ -291,6 +329,8 @@ func selectKeyManager(ctx *cli.Context) (keymanager.KeyManager, error) {
 		km, help, err = keymanager.NewWallet(opts)
 	case "remote":
 		km, help, err = keymanager.NewRemoteWallet(opts)
+	case "remote-http":
+		km, help, err = keymanager.NewRemoteHTTPWallet(opts)
 	default:
 		return nil, fmt.Errorf("unknown keymanager %q", manager)
 	}
@@ -11,7 +11,10 @@ import (
 	"google.golang.org/grpc"
 )
 
+// mockSlasher implements the slashing checks of the slasher client, the other methods are not
+// used by the slashing protection service.
 type mockSlasher struct {
+	slashpb.SlasherClient
 	slashAttestation bool
 	slashBlock       bool
 }
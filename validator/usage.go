@@ -81,6 +81,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.KeystorePathFlag,
 			flags.PasswordFlag,
 			flags.DisablePenaltyRewardLogFlag,
+			flags.DoppelgangerDetectionEpochsFlag,
 			flags.UnencryptedKeysFlag,
 			flags.GraffitiFlag,
 			flags.GrpcRetriesFlag,
@@ -90,7 +91,12 @@ var appHelpFlagGroups = []flagGroup{
 			flags.SourceDirectories,
 			flags.SourceDirectory,
 			flags.TargetDirectory,
+			flags.SlashingProtectionFileFlag,
+			flags.GenesisValidatorsRootFlag,
 			flags.DisableAccountMetricsFlag,
+			flags.EnableManagementAPIFlag,
+			flags.ManagementAPIHostFlag,
+			flags.ManagementAPIPortFlag,
 		},
 	},
 	{